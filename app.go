@@ -21,6 +21,12 @@ type App struct {
 	Notification *NotificationService
 	Export       *ExportService
 	Photo        *PhotoService
+	Statement    *StatementService
+	Calendar     *CalendarService
+	Group        *GroupService
+	Flock        *FlockService
+	Buyer        *BuyerService
+	Cooperative  *CooperativeService
 }
 
 // NewApp creates a new App application struct
@@ -37,8 +43,14 @@ func NewApp() *App {
 	backup := NewBackupService()
 	weather := NewWeatherService()
 	notification := NewNotificationService()
-	export := NewExportService()
 	photo := NewPhotoService()
+	export := NewExportService(livestock, crops, inventory, feed, health, financial, breeding, photo, dashboard)
+	statement := NewStatementService()
+	calendar := NewCalendarService(notification)
+	group := NewGroupService(livestock)
+	flock := NewFlockService()
+	buyer := NewBuyerService()
+	cooperative := NewCooperativeService()
 
 	return &App{
 		Livestock:    livestock,
@@ -55,6 +67,12 @@ func NewApp() *App {
 		Notification: notification,
 		Export:       export,
 		Photo:        photo,
+		Statement:    statement,
+		Calendar:     calendar,
+		Group:        group,
+		Flock:        flock,
+		Buyer:        buyer,
+		Cooperative:  cooperative,
 	}
 }
 
@@ -64,14 +82,20 @@ func (a *App) startup(ctx context.Context) {
 	a.Backup.SetContext(ctx)               // Set context for file dialogs
 	a.Export.SetContext(ctx)               // Set context for file dialogs
 	a.Photo.SetContext(ctx)                // Set context for file dialogs
+	a.Statement.SetContext(ctx)            // Set context for file dialogs
+	a.Calendar.SetContext(ctx)             // Set context for file dialogs
+	a.Buyer.SetContext(ctx)                // Set context for file dialogs
 	a.Notification.SetContext(ctx)         // Set context for desktop notifications
 	a.Notification.StartBackgroundWorker() // Start background poller
 	if err := InitDatabase(); err != nil {
 		println("Database initialization error:", err.Error())
 	}
+	a.Calendar.StartFeedIfEnabled()    // Resume the reminders calendar feed
+	a.Livestock.StartLifeStageWorker() // Move animals on to their next life stage
 }
 
 // shutdown is called when the app is closing
 func (a *App) shutdown(ctx context.Context) {
+	a.Calendar.Shutdown()
 	CloseDatabase()
 }
@@ -178,6 +178,16 @@ func (s *BackupService) GetDatabaseInfo() (*BackupInfo, error) {
 	}, nil
 }
 
+// snapshotDatabase writes a consistent copy of the open database to dst with
+// VACUUM INTO, which includes changes still held in the write-ahead log
+func snapshotDatabase(dst string) error {
+	if err := os.Remove(dst); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	_, err := db.Exec(`VACUUM INTO ?`, dst)
+	return err
+}
+
 // copyFile copies a file from src to dst
 func copyFile(src, dst string) error {
 	in, err := os.Open(src)
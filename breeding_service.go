@@ -2,6 +2,9 @@ package main
 
 import (
 	"database/sql"
+	"fmt"
+	"math"
+	"sort"
 	"time"
 )
 
@@ -153,11 +156,13 @@ func (s *BreedingService) GetBreedingHistoryForAnimal(animalID int64) ([]Breedin
 
 // AddBreedingRecord adds a new breeding record
 func (s *BreedingService) AddBreedingRecord(record BreedingRecord) (int64, error) {
-	// Calculate expected due date if not provided (280 days for cows)
+	// Calculate expected due date if not provided from the dam's gestation length
 	if record.ExpectedDueDate == "" && record.BreedingDate != "" {
 		breedingDate, err := time.Parse("2006-01-02", record.BreedingDate)
 		if err == nil {
-			record.ExpectedDueDate = breedingDate.AddDate(0, 0, 280).Format("2006-01-02")
+			var species sql.NullString
+			_ = db.QueryRow(`SELECT species FROM animals WHERE id = ?`, record.FemaleID).Scan(&species)
+			record.ExpectedDueDate = breedingDate.AddDate(0, 0, gestationDays(species.String)).Format("2006-01-02")
 		}
 	}
 
@@ -204,6 +209,7 @@ func (s *BreedingService) GetPregnantAnimals() ([]BreedingRecord, error) {
 		LEFT JOIN animals m ON br.male_id = m.id
 		LEFT JOIN animals o ON br.offspring_id = o.id
 		WHERE br.pregnancy_status IN ('pending', 'confirmed')
+		AND f.status = 'active'
 		ORDER BY br.expected_due_date ASC
 	`)
 	if err != nil {
@@ -273,3 +279,328 @@ func (s *BreedingService) UpdatePregnancyStatus(id int64, status string) error {
 	_, err := db.Exec(`UPDATE breeding_records SET pregnancy_status = ? WHERE id = ?`, status, id)
 	return err
 }
+
+// Pedigree walks are limited so a data entry loop (an animal recorded as its
+// own ancestor) cannot run forever
+const maxPedigreeGenerations = 15
+
+// Thresholds for judging inbreeding: 6.25% is the coefficient of a calf from
+// first cousins, 3.125% from second cousins
+const (
+	inbreedingCautionPercent = 3.125
+	inbreedingAvoidPercent   = 6.25
+)
+
+// pedigreeAnimal holds the fields needed to walk the herd's family tree
+type pedigreeAnimal struct {
+	id          int64
+	name        string
+	tagNumber   string
+	gender      string
+	breed       string
+	dateOfBirth string
+	motherID    int64
+	fatherID    int64
+}
+
+// pedigree is the family tree of the whole herd, loaded in one query
+type pedigree struct {
+	animals    map[int64]*pedigreeAnimal
+	children   map[int64][]int64
+	inbreeding map[int64]float64
+	inProgress map[int64]bool
+}
+
+func loadPedigree() (*pedigree, error) {
+	rows, err := db.Query(`
+		SELECT id, name, tag_number, gender, breed, date_of_birth, mother_id, father_id
+		FROM animals
+		ORDER BY date_of_birth, id
+	`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	p := &pedigree{
+		animals:    make(map[int64]*pedigreeAnimal),
+		children:   make(map[int64][]int64),
+		inbreeding: make(map[int64]float64),
+		inProgress: make(map[int64]bool),
+	}
+	for rows.Next() {
+		var a pedigreeAnimal
+		var tagNumber, gender, breed, dateOfBirth sql.NullString
+		var motherID, fatherID sql.NullInt64
+		if err := rows.Scan(&a.id, &a.name, &tagNumber, &gender, &breed, &dateOfBirth, &motherID, &fatherID); err != nil {
+			return nil, err
+		}
+		a.tagNumber = tagNumber.String
+		a.gender = gender.String
+		a.breed = breed.String
+		a.dateOfBirth = dateOfBirth.String
+		a.motherID = motherID.Int64
+		a.fatherID = fatherID.Int64
+		p.animals[a.id] = &a
+	}
+	for _, a := range p.animals {
+		for _, parent := range []int64{a.motherID, a.fatherID} {
+			if parent > 0 {
+				p.children[parent] = append(p.children[parent], a.id)
+			}
+		}
+	}
+	for parent := range p.children {
+		kids := p.children[parent]
+		sort.Slice(kids, func(i, j int) bool {
+			a, b := p.animals[kids[i]], p.animals[kids[j]]
+			if a.dateOfBirth != b.dateOfBirth {
+				return a.dateOfBirth < b.dateOfBirth
+			}
+			return a.id < b.id
+		})
+	}
+	return p, nil
+}
+
+func (p *pedigree) node(a *pedigreeAnimal, generation int) PedigreeNode {
+	return PedigreeNode{
+		AnimalID:    a.id,
+		Name:        a.name,
+		TagNumber:   a.tagNumber,
+		Gender:      a.gender,
+		Breed:       a.breed,
+		DateOfBirth: a.dateOfBirth,
+		Generation:  generation,
+	}
+}
+
+func (p *pedigree) ancestors(id int64, generation, generations int) *PedigreeNode {
+	a, ok := p.animals[id]
+	if !ok {
+		return nil
+	}
+	n := p.node(a, generation)
+	if generation < generations {
+		n.Mother = p.ancestors(a.motherID, generation+1, generations)
+		n.Father = p.ancestors(a.fatherID, generation+1, generations)
+	}
+	return &n
+}
+
+func (p *pedigree) descendants(id int64, generation int) PedigreeNode {
+	n := p.node(p.animals[id], generation)
+	if generation < maxPedigreeGenerations {
+		for _, child := range p.children[id] {
+			n.Children = append(n.Children, p.descendants(child, generation+1))
+		}
+	}
+	return n
+}
+
+// paths returns every upward path from id through its known ancestors. Each
+// path starts at id and ends at the ancestor it reaches, so id itself is
+// reached by the single-animal path.
+func (p *pedigree) paths(id int64) [][]int64 {
+	var result [][]int64
+	var walk func(path []int64)
+	walk = func(path []int64) {
+		result = append(result, path)
+		if len(path) > maxPedigreeGenerations {
+			return
+		}
+		a := p.animals[path[len(path)-1]]
+		for _, parent := range []int64{a.motherID, a.fatherID} {
+			if _, ok := p.animals[parent]; ok {
+				next := make([]int64, len(path), len(path)+1)
+				copy(next, path)
+				walk(append(next, parent))
+			}
+		}
+	}
+	if _, ok := p.animals[id]; ok {
+		walk([]int64{id})
+	}
+	return result
+}
+
+// coefficient computes Wright's coefficient of inbreeding for a calf of sire
+// and dam by path counting: F = sum over common ancestors A and every pair of
+// paths joining through A with no other animal in common of
+// (1/2)^(n1+n2+1) * (1 + F_A), where n1 and n2 are the generations from the
+// sire and the dam up to A.
+func (p *pedigree) coefficient(sireID, damID int64) (float64, []CommonAncestor) {
+	sirePaths := p.paths(sireID)
+	damPaths := p.paths(damID)
+	if len(sirePaths) == 0 || len(damPaths) == 0 {
+		return 0, []CommonAncestor{}
+	}
+
+	byAncestor := make(map[int64][][]int64)
+	for _, path := range damPaths {
+		end := path[len(path)-1]
+		byAncestor[end] = append(byAncestor[end], path)
+	}
+
+	contributions := make(map[int64]*CommonAncestor)
+	total := 0.0
+	for _, sirePath := range sirePaths {
+		ancestor := sirePath[len(sirePath)-1]
+		for _, damPath := range byAncestor[ancestor] {
+			if !disjointBelow(sirePath, damPath) {
+				continue
+			}
+			n1, n2 := len(sirePath)-1, len(damPath)-1
+			value := math.Pow(0.5, float64(n1+n2+1)) * (1 + p.inbreedingOf(ancestor))
+			total += value
+
+			c, ok := contributions[ancestor]
+			if !ok {
+				c = &CommonAncestor{AnimalID: ancestor, Name: p.animals[ancestor].name}
+				contributions[ancestor] = c
+			}
+			c.Paths++
+			c.Contribution += value
+		}
+	}
+
+	common := make([]CommonAncestor, 0, len(contributions))
+	for _, c := range contributions {
+		common = append(common, *c)
+	}
+	sort.Slice(common, func(i, j int) bool { return common[i].Contribution > common[j].Contribution })
+	return total, common
+}
+
+// disjointBelow reports whether two paths meeting at the same ancestor share
+// no other animal
+func disjointBelow(a, b []int64) bool {
+	seen := make(map[int64]bool, len(a))
+	for _, id := range a[:len(a)-1] {
+		seen[id] = true
+	}
+	for _, id := range b[:len(b)-1] {
+		if seen[id] {
+			return false
+		}
+	}
+	return true
+}
+
+// inbreedingOf returns the coefficient of a herd animal from its parents
+func (p *pedigree) inbreedingOf(id int64) float64 {
+	if f, ok := p.inbreeding[id]; ok {
+		return f
+	}
+	a, ok := p.animals[id]
+	if !ok || a.motherID == 0 || a.fatherID == 0 || p.inProgress[id] {
+		return 0
+	}
+	p.inProgress[id] = true
+	f, _ := p.coefficient(a.fatherID, a.motherID)
+	delete(p.inProgress, id)
+	p.inbreeding[id] = f
+	return f
+}
+
+func inbreedingResult(f float64, common []CommonAncestor) *InbreedingResult {
+	percent := math.Round(f*10000) / 100
+	assessment := "acceptable"
+	switch {
+	case percent >= inbreedingAvoidPercent:
+		assessment = "avoid"
+	case percent >= inbreedingCautionPercent:
+		assessment = "caution"
+	}
+	for i := range common {
+		common[i].Contribution = math.Round(common[i].Contribution*10000) / 10000
+	}
+	return &InbreedingResult{
+		Coefficient:     math.Round(f*10000) / 10000,
+		Percent:         percent,
+		CommonAncestors: common,
+		Assessment:      assessment,
+	}
+}
+
+// GetPedigree returns an animal's ancestors up to the given number of
+// generations (3 when not set)
+func (s *BreedingService) GetPedigree(animalID int64, generations int) (*PedigreeNode, error) {
+	if generations <= 0 {
+		generations = 3
+	}
+	if generations > maxPedigreeGenerations {
+		generations = maxPedigreeGenerations
+	}
+	p, err := loadPedigree()
+	if err != nil {
+		return nil, err
+	}
+	tree := p.ancestors(animalID, 0, generations)
+	if tree == nil {
+		return nil, fmt.Errorf("animal not found")
+	}
+	return tree, nil
+}
+
+// GetDescendants returns the full tree of an animal's offspring, their
+// offspring and so on
+func (s *BreedingService) GetDescendants(animalID int64) (*PedigreeNode, error) {
+	p, err := loadPedigree()
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := p.animals[animalID]; !ok {
+		return nil, fmt.Errorf("animal not found")
+	}
+	tree := p.descendants(animalID, 0)
+	return &tree, nil
+}
+
+// GetInbreedingCoefficient returns Wright's coefficient of inbreeding for an
+// animal from its recorded ancestry. Unknown parents count as unrelated.
+func (s *BreedingService) GetInbreedingCoefficient(animalID int64) (*InbreedingResult, error) {
+	p, err := loadPedigree()
+	if err != nil {
+		return nil, err
+	}
+	a, ok := p.animals[animalID]
+	if !ok {
+		return nil, fmt.Errorf("animal not found")
+	}
+
+	f, common := 0.0, []CommonAncestor{}
+	if a.motherID > 0 && a.fatherID > 0 {
+		f, common = p.coefficient(a.fatherID, a.motherID)
+	}
+	result := inbreedingResult(f, common)
+	result.AnimalID = animalID
+	result.DamID = a.motherID
+	result.SireID = a.fatherID
+	return result, nil
+}
+
+// GetProspectiveInbreeding returns the coefficient of inbreeding the calf of
+// a proposed cow and bull pairing would have
+func (s *BreedingService) GetProspectiveInbreeding(cowID, bullID int64) (*InbreedingResult, error) {
+	p, err := loadPedigree()
+	if err != nil {
+		return nil, err
+	}
+	cow, ok := p.animals[cowID]
+	if !ok {
+		return nil, fmt.Errorf("cow not found")
+	}
+	bull, ok := p.animals[bullID]
+	if !ok {
+		return nil, fmt.Errorf("bull not found")
+	}
+	if cow.gender != "female" || bull.gender != "male" {
+		return nil, fmt.Errorf("a pairing needs a female and a male")
+	}
+
+	result := inbreedingResult(p.coefficient(bullID, cowID))
+	result.DamID = cowID
+	result.SireID = bullID
+	return result, nil
+}
@@ -0,0 +1,106 @@
+package main
+
+import (
+	"math"
+	"testing"
+)
+
+// testPedigree builds a herd from id: {mother, father} pairs, 0 for unknown
+func testPedigree(parents map[int64][2]int64) *pedigree {
+	p := &pedigree{
+		animals:    make(map[int64]*pedigreeAnimal),
+		children:   make(map[int64][]int64),
+		inbreeding: make(map[int64]float64),
+		inProgress: make(map[int64]bool),
+	}
+	for id, mf := range parents {
+		p.animals[id] = &pedigreeAnimal{id: id, motherID: mf[0], fatherID: mf[1]}
+	}
+	return p
+}
+
+func TestInbreedingCoefficient(t *testing.T) {
+	tests := []struct {
+		name      string
+		parents   map[int64][2]int64
+		sire, dam int64
+		want      float64
+		wantPaths map[int64]int
+	}{
+		{
+			name:    "unrelated",
+			parents: map[int64][2]int64{1: {}, 2: {}},
+			sire:    1, dam: 2,
+			want: 0, wantPaths: map[int64]int{},
+		},
+		{
+			name:    "unknown parents",
+			parents: map[int64][2]int64{1: {0, 3}, 2: {}, 3: {}},
+			sire:    1, dam: 2,
+			want: 0, wantPaths: map[int64]int{},
+		},
+		{
+			name:    "half siblings",
+			parents: map[int64][2]int64{1: {3, 5}, 2: {4, 5}, 3: {}, 4: {}, 5: {}},
+			sire:    1, dam: 2,
+			want: 0.125, wantPaths: map[int64]int{5: 1},
+		},
+		{
+			name:    "full siblings",
+			parents: map[int64][2]int64{1: {3, 4}, 2: {3, 4}, 3: {}, 4: {}},
+			sire:    1, dam: 2,
+			want: 0.25, wantPaths: map[int64]int{3: 1, 4: 1},
+		},
+		{
+			name:    "sire on his daughter",
+			parents: map[int64][2]int64{1: {}, 2: {3, 1}, 3: {}},
+			sire:    1, dam: 2,
+			want: 0.25, wantPaths: map[int64]int{1: 1},
+		},
+		{
+			name: "first cousins",
+			parents: map[int64][2]int64{
+				1: {3, 7}, 2: {4, 8},
+				3: {5, 6}, 4: {5, 6},
+				5: {}, 6: {}, 7: {}, 8: {},
+			},
+			sire: 1, dam: 2,
+			want: 0.0625, wantPaths: map[int64]int{5: 1, 6: 1},
+		},
+		{
+			name: "half siblings by an inbred sire",
+			parents: map[int64][2]int64{
+				1: {3, 5}, 2: {4, 5}, 3: {}, 4: {},
+				5: {6, 7}, 6: {8, 9}, 7: {8, 9}, 8: {}, 9: {},
+			},
+			sire: 1, dam: 2,
+			want: 0.15625, wantPaths: map[int64]int{5: 1},
+		},
+		{
+			name: "ancestor reached on both sides of the dam",
+			parents: map[int64][2]int64{
+				1: {3, 5}, 2: {4, 6},
+				3: {}, 4: {5, 7}, 6: {5, 8},
+				5: {}, 7: {}, 8: {},
+			},
+			sire: 1, dam: 2,
+			want: 0.125, wantPaths: map[int64]int{5: 2},
+		},
+	}
+	for _, tt := range tests {
+		p := testPedigree(tt.parents)
+		f, common := p.coefficient(tt.sire, tt.dam)
+		if math.Abs(f-tt.want) > 1e-9 {
+			t.Errorf("%s: F = %v, want %v", tt.name, f, tt.want)
+		}
+		if len(common) != len(tt.wantPaths) {
+			t.Errorf("%s: %d common ancestors, want %d", tt.name, len(common), len(tt.wantPaths))
+			continue
+		}
+		for _, c := range common {
+			if c.Paths != tt.wantPaths[c.AnimalID] {
+				t.Errorf("%s: ancestor %d joins %d paths, want %d", tt.name, c.AnimalID, c.Paths, tt.wantPaths[c.AnimalID])
+			}
+		}
+	}
+}
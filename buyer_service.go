@@ -0,0 +1,776 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+// BuyerService handles milk buyer accounts: payments against their sales,
+// running balances, monthly statements and the receivables ageing report.
+// Sales post their income to finances when recorded, so payments only settle
+// the buyer's account and do not create transactions of their own.
+type BuyerService struct {
+	ctx context.Context
+}
+
+// NewBuyerService creates a new BuyerService
+func NewBuyerService() *BuyerService {
+	return &BuyerService{}
+}
+
+// SetContext sets the Wails runtime context
+func (s *BuyerService) SetContext(ctx context.Context) {
+	s.ctx = ctx
+}
+
+// Amounts within half a cent are treated as settled
+const balanceTolerance = 0.005
+
+// paymentMethods are the ways a buyer can pay
+var paymentMethods = []string{"cash", "mpesa", "bank", "cheque"}
+
+// GetPaymentMethods returns the ways a buyer can pay
+func (s *BuyerService) GetPaymentMethods() []string {
+	return paymentMethods
+}
+
+// GetBuyers returns all buyers with their balances, active buyers first
+func (s *BuyerService) GetBuyers() ([]Buyer, error) {
+	rows, err := db.Query(`
+		SELECT b.id, b.name, b.phone, b.email, b.address, b.agreed_price, b.active, b.notes, b.created_at,
+			   COALESCE((SELECT SUM(total_amount) FROM milk_sales WHERE buyer_id = b.id), 0),
+			   COALESCE((SELECT SUM(amount) FROM buyer_payments WHERE buyer_id = b.id), 0)
+		FROM buyers b
+		ORDER BY b.active DESC, b.name
+	`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	buyers := []Buyer{}
+	for rows.Next() {
+		var b Buyer
+		var phone, email, address, notes sql.NullString
+		var agreedPrice sql.NullFloat64
+		err := rows.Scan(&b.ID, &b.Name, &phone, &email, &address, &agreedPrice, &b.Active, &notes, &b.CreatedAt,
+			&b.TotalSales, &b.TotalPayments)
+		if err != nil {
+			return nil, err
+		}
+		b.Phone = phone.String
+		b.Email = email.String
+		b.Address = address.String
+		b.AgreedPrice = agreedPrice.Float64
+		b.Notes = notes.String
+		b.Balance = roundMoney(b.TotalSales - b.TotalPayments)
+		buyers = append(buyers, b)
+	}
+	return buyers, nil
+}
+
+// GetBuyer returns a single buyer by ID
+func (s *BuyerService) GetBuyer(id int64) (*Buyer, error) {
+	buyers, err := s.GetBuyers()
+	if err != nil {
+		return nil, err
+	}
+	for _, b := range buyers {
+		if b.ID == id {
+			return &b, nil
+		}
+	}
+	return nil, fmt.Errorf("buyer not found")
+}
+
+// AddBuyer adds a buyer. Earlier sales recorded under the same name are moved
+// onto the new account, and those marked paid get a matching payment so the
+// balance starts out right.
+func (s *BuyerService) AddBuyer(buyer Buyer) (int64, error) {
+	if err := validateBuyer(&buyer); err != nil {
+		return 0, err
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		return 0, err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	result, err := tx.Exec(`
+		INSERT INTO buyers (name, phone, email, address, agreed_price, active, notes)
+		VALUES (?, ?, ?, ?, ?, 1, ?)
+	`, buyer.Name, buyer.Phone, buyer.Email, buyer.Address, buyer.AgreedPrice, buyer.Notes)
+	if err != nil {
+		return 0, buyerError(err, buyer.Name)
+	}
+	id, err := result.LastInsertId()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get last insert id: %w", err)
+	}
+
+	if _, err := tx.Exec(`
+		INSERT INTO buyer_payments (buyer_id, date, amount, method, milk_sale_id, notes)
+		SELECT ?, date, total_amount, 'cash', id, 'Marked paid before the buyer account was opened'
+		FROM milk_sales WHERE buyer_id IS NULL AND is_paid = 1 AND LOWER(TRIM(buyer_name)) = LOWER(?)
+	`, id, buyer.Name); err != nil {
+		return 0, err
+	}
+	if _, err := tx.Exec(`
+		UPDATE milk_sales SET buyer_id = ? WHERE buyer_id IS NULL AND LOWER(TRIM(buyer_name)) = LOWER(?)
+	`, id, buyer.Name); err != nil {
+		return 0, err
+	}
+	if err := applyBuyerPayments(tx, id); err != nil {
+		return 0, err
+	}
+	if err := tx.Commit(); err != nil {
+		return 0, err
+	}
+	return id, nil
+}
+
+// UpdateBuyer updates a buyer's details, renaming their sales to match
+func (s *BuyerService) UpdateBuyer(buyer Buyer) error {
+	if err := validateBuyer(&buyer); err != nil {
+		return err
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	if _, err := tx.Exec(`
+		UPDATE buyers SET name = ?, phone = ?, email = ?, address = ?, agreed_price = ?, active = ?, notes = ?
+		WHERE id = ?
+	`, buyer.Name, buyer.Phone, buyer.Email, buyer.Address, buyer.AgreedPrice, buyer.Active, buyer.Notes, buyer.ID); err != nil {
+		return buyerError(err, buyer.Name)
+	}
+	if _, err := tx.Exec(`UPDATE milk_sales SET buyer_name = ? WHERE buyer_id = ?`, buyer.Name, buyer.ID); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// DeleteBuyer deletes a buyer with no sales or payments. Buyers with history
+// should be deactivated instead.
+func (s *BuyerService) DeleteBuyer(id int64) error {
+	var count int
+	err := db.QueryRow(`
+		SELECT (SELECT COUNT(*) FROM milk_sales WHERE buyer_id = ?) + (SELECT COUNT(*) FROM buyer_payments WHERE buyer_id = ?)
+	`, id, id).Scan(&count)
+	if err != nil {
+		return err
+	}
+	if count > 0 {
+		return fmt.Errorf("buyer has sales or payments; deactivate them instead")
+	}
+	_, err = db.Exec(`DELETE FROM buyers WHERE id = ?`, id)
+	return err
+}
+
+func validateBuyer(buyer *Buyer) error {
+	buyer.Name = strings.TrimSpace(buyer.Name)
+	if buyer.Name == "" {
+		return fmt.Errorf("buyer name is required")
+	}
+	if buyer.AgreedPrice < 0 {
+		return fmt.Errorf("agreed price cannot be negative")
+	}
+	return nil
+}
+
+// buyerError explains a duplicate buyer name
+func buyerError(err error, name string) error {
+	if strings.Contains(err.Error(), "UNIQUE") {
+		return fmt.Errorf("a buyer named %s already exists", name)
+	}
+	return err
+}
+
+// GetBuyerPayments returns a buyer's payments within a date range, newest
+// first. A buyerID of 0 returns payments from all buyers.
+func (s *BuyerService) GetBuyerPayments(buyerID int64, startDate, endDate string) ([]BuyerPayment, error) {
+	query := `
+		SELECT p.id, p.buyer_id, b.name, p.date, p.amount, p.method, p.reference, p.milk_sale_id, p.notes, p.created_at,
+			   COALESCE((SELECT SUM(amount) FROM payment_allocations WHERE payment_id = p.id), 0)
+		FROM buyer_payments p
+		JOIN buyers b ON p.buyer_id = b.id
+		WHERE 1=1
+	`
+	args := []interface{}{}
+	if buyerID > 0 {
+		query += " AND p.buyer_id = ?"
+		args = append(args, buyerID)
+	}
+	query, rangeArgs := appendDateRange(query, "p.date", startDate, endDate)
+	args = append(args, rangeArgs...)
+	query += " ORDER BY p.date DESC, p.id DESC"
+
+	rows, err := db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	payments := []BuyerPayment{}
+	for rows.Next() {
+		var p BuyerPayment
+		var method, reference, notes sql.NullString
+		var saleID sql.NullInt64
+		err := rows.Scan(&p.ID, &p.BuyerID, &p.BuyerName, &p.Date, &p.Amount, &method, &reference, &saleID, &notes, &p.CreatedAt, &p.Allocated)
+		if err != nil {
+			return nil, err
+		}
+		p.Method = firstNonEmpty(method.String, "cash")
+		p.Reference = reference.String
+		p.Notes = notes.String
+		if saleID.Valid {
+			p.MilkSaleID = &saleID.Int64
+		}
+		payments = append(payments, p)
+	}
+	return payments, nil
+}
+
+// AddBuyerPayment records a payment from a buyer and applies it to their sales
+func (s *BuyerService) AddBuyerPayment(payment BuyerPayment) (int64, error) {
+	if err := validateBuyerPayment(&payment); err != nil {
+		return 0, err
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		return 0, err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	id, err := insertBuyerPayment(tx, payment)
+	if err != nil {
+		return 0, err
+	}
+	if err := applyBuyerPayments(tx, payment.BuyerID); err != nil {
+		return 0, err
+	}
+	if err := tx.Commit(); err != nil {
+		return 0, err
+	}
+	return id, nil
+}
+
+// UpdateBuyerPayment updates a payment and reapplies the buyer's payments
+func (s *BuyerService) UpdateBuyerPayment(payment BuyerPayment) error {
+	if err := validateBuyerPayment(&payment); err != nil {
+		return err
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	var previousBuyer int64
+	if err := tx.QueryRow(`SELECT buyer_id FROM buyer_payments WHERE id = ?`, payment.ID).Scan(&previousBuyer); err != nil {
+		return fmt.Errorf("payment not found: %w", err)
+	}
+	if _, err := tx.Exec(`
+		UPDATE buyer_payments SET buyer_id = ?, date = ?, amount = ?, method = ?, reference = ?, milk_sale_id = ?, notes = ?
+		WHERE id = ?
+	`, payment.BuyerID, payment.Date, payment.Amount, payment.Method, payment.Reference, payment.MilkSaleID, payment.Notes, payment.ID); err != nil {
+		return err
+	}
+	for _, buyerID := range []int64{previousBuyer, payment.BuyerID} {
+		if err := applyBuyerPayments(tx, buyerID); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+// DeleteBuyerPayment deletes a payment, leaving the sales it paid outstanding again
+func (s *BuyerService) DeleteBuyerPayment(id int64) error {
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	var buyerID int64
+	if err := tx.QueryRow(`SELECT buyer_id FROM buyer_payments WHERE id = ?`, id).Scan(&buyerID); err != nil {
+		return fmt.Errorf("payment not found: %w", err)
+	}
+	if _, err := tx.Exec(`DELETE FROM payment_allocations WHERE payment_id = ?`, id); err != nil {
+		return err
+	}
+	if _, err := tx.Exec(`DELETE FROM buyer_payments WHERE id = ?`, id); err != nil {
+		return err
+	}
+	if err := applyBuyerPayments(tx, buyerID); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func validateBuyerPayment(payment *BuyerPayment) error {
+	if payment.BuyerID <= 0 {
+		return fmt.Errorf("choose the buyer who paid")
+	}
+	if payment.Amount <= 0 {
+		return fmt.Errorf("payment amount must be greater than zero")
+	}
+	if _, err := time.Parse("2006-01-02", payment.Date); err != nil {
+		return fmt.Errorf("invalid payment date %q", payment.Date)
+	}
+	payment.Method = firstNonEmpty(payment.Method, "cash")
+	if !slices.Contains(paymentMethods, payment.Method) {
+		return fmt.Errorf("unknown payment method %q", payment.Method)
+	}
+	if payment.MilkSaleID != nil {
+		var buyerID sql.NullInt64
+		if err := db.QueryRow(`SELECT buyer_id FROM milk_sales WHERE id = ?`, *payment.MilkSaleID).Scan(&buyerID); err != nil {
+			return fmt.Errorf("sale not found: %w", err)
+		}
+		if buyerID.Int64 != payment.BuyerID {
+			return fmt.Errorf("the sale was not made to this buyer")
+		}
+	}
+	return nil
+}
+
+// insertBuyerPayment stores a payment without applying it
+func insertBuyerPayment(tx *sql.Tx, payment BuyerPayment) (int64, error) {
+	result, err := tx.Exec(`
+		INSERT INTO buyer_payments (buyer_id, date, amount, method, reference, milk_sale_id, notes)
+		VALUES (?, ?, ?, ?, ?, ?, ?)
+	`, payment.BuyerID, payment.Date, payment.Amount, firstNonEmpty(payment.Method, "cash"), payment.Reference, payment.MilkSaleID, payment.Notes)
+	if err != nil {
+		return 0, err
+	}
+	id, err := result.LastInsertId()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get last insert id: %w", err)
+	}
+	return id, nil
+}
+
+// resolveSaleBuyer names a sale after its buyer account and prices it at the
+// buyer's agreed price when no price is given
+func resolveSaleBuyer(sale *MilkSale) error {
+	if sale.BuyerID == nil || *sale.BuyerID <= 0 {
+		sale.BuyerID = nil
+		return nil
+	}
+	var name string
+	var agreedPrice sql.NullFloat64
+	if err := db.QueryRow(`SELECT name, agreed_price FROM buyers WHERE id = ?`, *sale.BuyerID).Scan(&name, &agreedPrice); err != nil {
+		return fmt.Errorf("buyer not found: %w", err)
+	}
+	sale.BuyerName = name
+	if sale.PricePerLiter <= 0 {
+		sale.PricePerLiter = agreedPrice.Float64
+	}
+	return nil
+}
+
+// paySaleOnDelivery records a payment for a new buyer sale paid when the milk
+// was delivered, then applies the buyer's payments
+func paySaleOnDelivery(tx *sql.Tx, buyerID, saleID int64, date string, amount float64, paid bool) error {
+	if paid && amount > 0 {
+		payment := BuyerPayment{BuyerID: buyerID, Date: date, Amount: amount, Method: "cash", MilkSaleID: &saleID, Notes: "Paid on delivery"}
+		if _, err := insertBuyerPayment(tx, payment); err != nil {
+			return err
+		}
+	}
+	return applyBuyerPayments(tx, buyerID)
+}
+
+// detachSalePayments removes a sale's allocations and unlinks the payments
+// made for it, before the sale is deleted or moved to another buyer
+func detachSalePayments(tx *sql.Tx, saleID int64) error {
+	if _, err := tx.Exec(`DELETE FROM payment_allocations WHERE milk_sale_id = ?`, saleID); err != nil {
+		return err
+	}
+	_, err := tx.Exec(`UPDATE buyer_payments SET milk_sale_id = NULL WHERE milk_sale_id = ?`, saleID)
+	return err
+}
+
+// applyBuyerPayments rebuilds the allocations of a buyer's payments to their
+// sales and updates which sales are paid. Payments are applied in date order,
+// each first to the sale it was made for and then to the oldest unpaid sales.
+// Rebuilding keeps balances right when sales or payments are edited.
+func applyBuyerPayments(tx *sql.Tx, buyerID int64) error {
+	if _, err := tx.Exec(`
+		DELETE FROM payment_allocations WHERE payment_id IN (SELECT id FROM buyer_payments WHERE buyer_id = ?)
+	`, buyerID); err != nil {
+		return err
+	}
+
+	type openSale struct {
+		id          int64
+		outstanding float64
+	}
+	var sales []*openSale
+	byID := map[int64]*openSale{}
+	rows, err := tx.Query(`SELECT id, total_amount FROM milk_sales WHERE buyer_id = ? ORDER BY date, id`, buyerID)
+	if err != nil {
+		return err
+	}
+	for rows.Next() {
+		sale := &openSale{}
+		if err := rows.Scan(&sale.id, &sale.outstanding); err != nil {
+			rows.Close()
+			return err
+		}
+		sales = append(sales, sale)
+		byID[sale.id] = sale
+	}
+	rows.Close()
+
+	type payment struct {
+		id     int64
+		amount float64
+		saleID sql.NullInt64
+	}
+	var payments []payment
+	rows, err = tx.Query(`SELECT id, amount, milk_sale_id FROM buyer_payments WHERE buyer_id = ? ORDER BY date, id`, buyerID)
+	if err != nil {
+		return err
+	}
+	for rows.Next() {
+		var p payment
+		if err := rows.Scan(&p.id, &p.amount, &p.saleID); err != nil {
+			rows.Close()
+			return err
+		}
+		payments = append(payments, p)
+	}
+	rows.Close()
+
+	allocate := func(p *payment, sale *openSale) error {
+		amount := math.Min(p.amount, sale.outstanding)
+		if amount < balanceTolerance {
+			return nil
+		}
+		if _, err := tx.Exec(`INSERT INTO payment_allocations (payment_id, milk_sale_id, amount) VALUES (?, ?, ?)`,
+			p.id, sale.id, roundMoney(amount)); err != nil {
+			return err
+		}
+		p.amount -= amount
+		sale.outstanding -= amount
+		return nil
+	}
+	for i := range payments {
+		p := &payments[i]
+		if sale, ok := byID[p.saleID.Int64]; ok && p.saleID.Valid {
+			if err := allocate(p, sale); err != nil {
+				return err
+			}
+		}
+		for _, sale := range sales {
+			if p.amount < balanceTolerance {
+				break
+			}
+			if err := allocate(p, sale); err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, sale := range sales {
+		if _, err := tx.Exec(`UPDATE milk_sales SET is_paid = ? WHERE id = ?`, sale.outstanding < balanceTolerance, sale.id); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// buyerBalance returns what a buyer owed at the end of a date, or in total
+// when the date is empty
+func buyerBalance(buyerID int64, endDate string) (float64, error) {
+	var balance float64
+	err := db.QueryRow(`
+		SELECT COALESCE((SELECT SUM(total_amount) FROM milk_sales WHERE buyer_id = ? AND (? = '' OR date <= ?)), 0)
+			 - COALESCE((SELECT SUM(amount) FROM buyer_payments WHERE buyer_id = ? AND (? = '' OR date <= ?)), 0)
+	`, buyerID, endDate, endDate, buyerID, endDate, endDate).Scan(&balance)
+	return roundMoney(balance), err
+}
+
+// GetBuyerStatement returns a buyer's sales and payments for a month with
+// the opening, running and closing balances
+func (s *BuyerService) GetBuyerStatement(buyerID int64, month string) (*BuyerStatement, error) {
+	if month == "" {
+		month = time.Now().Format("2006-01")
+	}
+	monthStart, err := time.Parse("2006-01", month)
+	if err != nil {
+		return nil, fmt.Errorf("invalid month %q: expected YYYY-MM", month)
+	}
+	buyer, err := s.GetBuyer(buyerID)
+	if err != nil {
+		return nil, err
+	}
+
+	statement := &BuyerStatement{
+		Buyer:     *buyer,
+		Month:     month,
+		StartDate: monthStart.Format("2006-01-02"),
+		EndDate:   monthStart.AddDate(0, 1, -1).Format("2006-01-02"),
+		Lines:     []BuyerStatementLine{},
+	}
+	statement.OpeningBalance, err = buyerBalance(buyerID, monthStart.AddDate(0, 0, -1).Format("2006-01-02"))
+	if err != nil {
+		return nil, err
+	}
+
+	// Sales come before payments made on the same day
+	rows, err := db.Query(`
+		SELECT date, 'sale', id, liters, price_per_liter + COALESCE(quality_adjustment, 0), total_amount, '', '' FROM milk_sales
+		WHERE buyer_id = ? AND date >= ? AND date <= ?
+		UNION ALL
+		SELECT date, 'payment', id, 0, 0, amount, COALESCE(method, 'cash'), COALESCE(reference, '') FROM buyer_payments
+		WHERE buyer_id = ? AND date >= ? AND date <= ?
+		ORDER BY 1, 2 DESC, 3
+	`, buyerID, statement.StartDate, statement.EndDate, buyerID, statement.StartDate, statement.EndDate)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	balance := statement.OpeningBalance
+	for rows.Next() {
+		var line BuyerStatementLine
+		var id int64
+		var liters, price, amount float64
+		var method string
+		if err := rows.Scan(&line.Date, &line.Type, &id, &liters, &price, &amount, &method, &line.Reference); err != nil {
+			return nil, err
+		}
+		if line.Type == "sale" {
+			line.Description = fmt.Sprintf("Milk %.1f L @ %.2f", liters, price)
+			line.Reference = fmt.Sprintf("Sale #%d", id)
+			line.Debit = amount
+			balance += amount
+			statement.TotalSales += amount
+			statement.TotalLiters += liters
+		} else {
+			line.Description = "Payment - " + method
+			line.Credit = amount
+			balance -= amount
+			statement.TotalPayments += amount
+		}
+		line.Balance = roundMoney(balance)
+		statement.Lines = append(statement.Lines, line)
+	}
+	statement.ClosingBalance = roundMoney(balance)
+	return statement, rows.Err()
+}
+
+// ExportBuyerStatementPDF saves a buyer's monthly statement as a printable PDF
+func (s *BuyerService) ExportBuyerStatementPDF(buyerID int64, month string) (*ExportResult, error) {
+	if s.ctx == nil {
+		return nil, fmt.Errorf("context not set")
+	}
+	statement, err := s.GetBuyerStatement(buyerID, month)
+	if err != nil {
+		return nil, err
+	}
+
+	savePath, err := runtime.SaveFileDialog(s.ctx, runtime.SaveDialogOptions{
+		Title:           "Save Buyer Statement",
+		DefaultFilename: fmt.Sprintf("statement-%s-%s.pdf", safeFileName(statement.Buyer.Name), statement.Month),
+		Filters: []runtime.FileFilter{
+			{DisplayName: "PDF Documents", Pattern: "*.pdf"},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if savePath == "" {
+		return nil, nil
+	}
+
+	doc := buildBuyerStatementPDF(statement)
+	if err := doc.Save(savePath); err != nil {
+		return nil, fmt.Errorf("failed to write statement: %w", err)
+	}
+	return &ExportResult{Path: savePath, Records: len(statement.Lines)}, nil
+}
+
+// buildBuyerStatementPDF lays out a buyer statement
+func buildBuyerStatementPDF(st *BuyerStatement) *pdfDocument {
+	monthStart, _ := time.Parse("2006-01", st.Month)
+	monthName := monthStart.Format("January 2006")
+
+	doc := newPDFDocument(fmt.Sprintf("Statement - %s - %s", st.Buyer.Name, monthName))
+	doc.Title("Statement of Account", fmt.Sprintf("%s  |  Generated %s", monthName, time.Now().Format("2 Jan 2006 15:04")))
+
+	details := [][2]string{{"Buyer", st.Buyer.Name}}
+	if st.Buyer.Phone != "" {
+		details = append(details, [2]string{"Phone", st.Buyer.Phone})
+	}
+	if st.Buyer.Email != "" {
+		details = append(details, [2]string{"Email", st.Buyer.Email})
+	}
+	if st.Buyer.Address != "" {
+		details = append(details, [2]string{"Address", st.Buyer.Address})
+	}
+	details = append(details, [2]string{"Period", st.StartDate + " to " + st.EndDate})
+	doc.KeyValues(details)
+
+	doc.Heading("Transactions")
+	rows := [][]string{{st.StartDate, "Opening balance", "", "", "", fmt.Sprintf("%.2f", st.OpeningBalance)}}
+	for _, l := range st.Lines {
+		debit, credit := "", ""
+		if l.Debit > 0 {
+			debit = fmt.Sprintf("%.2f", l.Debit)
+		}
+		if l.Credit > 0 {
+			credit = fmt.Sprintf("%.2f", l.Credit)
+		}
+		rows = append(rows, []string{l.Date, l.Description, l.Reference, debit, credit, fmt.Sprintf("%.2f", l.Balance)})
+	}
+	doc.Table([]string{"Date", "Description", "Reference", "Sales (KES)", "Payments (KES)", "Balance (KES)"}, []float64{2, 4, 2.5, 2, 2, 2}, rows)
+
+	doc.Heading("Summary")
+	closing := fmt.Sprintf("KES %.2f", st.ClosingBalance)
+	if st.ClosingBalance < 0 {
+		closing = fmt.Sprintf("KES %.2f in credit", -st.ClosingBalance)
+	}
+	doc.KeyValues([][2]string{
+		{"Opening balance", fmt.Sprintf("KES %.2f", st.OpeningBalance)},
+		{"Milk supplied", fmt.Sprintf("%.1f L", st.TotalLiters)},
+		{"Sales", fmt.Sprintf("KES %.2f", st.TotalSales)},
+		{"Payments received", fmt.Sprintf("KES %.2f", st.TotalPayments)},
+		{"Balance due", closing},
+	})
+	return doc
+}
+
+// GetAgeingReport splits what each buyer owes by how long the unpaid sales
+// have been outstanding as of a date, which defaults to today
+func (s *BuyerService) GetAgeingReport(asOf string) (*AgeingReport, error) {
+	if asOf == "" {
+		asOf = time.Now().Format("2006-01-02")
+	}
+	if _, err := time.Parse("2006-01-02", asOf); err != nil {
+		return nil, fmt.Errorf("invalid date %q", asOf)
+	}
+
+	// Allocations only count payments received by the date
+	rows, err := db.Query(`
+		SELECT b.id, b.name, ms.date, ms.total_amount - COALESCE((
+			SELECT SUM(pa.amount) FROM payment_allocations pa
+			JOIN buyer_payments p ON pa.payment_id = p.id
+			WHERE pa.milk_sale_id = ms.id AND p.date <= ?
+		), 0)
+		FROM milk_sales ms
+		JOIN buyers b ON ms.buyer_id = b.id
+		WHERE ms.date <= ?
+		ORDER BY b.name, ms.date
+	`, asOf, asOf)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	report := &AgeingReport{AsOf: asOf, Buyers: []BuyerAgeing{}}
+	byBuyer := map[int64]*BuyerAgeing{}
+	var order []int64
+	for rows.Next() {
+		var id int64
+		var name, date string
+		var outstanding float64
+		if err := rows.Scan(&id, &name, &date, &outstanding); err != nil {
+			return nil, err
+		}
+		if outstanding < balanceTolerance {
+			continue
+		}
+		a := byBuyer[id]
+		if a == nil {
+			a = &BuyerAgeing{BuyerID: id, BuyerName: name, OldestSale: date}
+			byBuyer[id] = a
+			order = append(order, id)
+		}
+		switch age := daysBetween(date, asOf); {
+		case age <= 30:
+			a.Current += outstanding
+		case age <= 60:
+			a.Days31To60 += outstanding
+		case age <= 90:
+			a.Days61To90 += outstanding
+		default:
+			a.Over90 += outstanding
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	// Payments not applied to sales made by the date are credit on the account
+	credits, err := db.Query(`
+		SELECT b.id, b.name, SUM(p.amount - COALESCE((
+			SELECT SUM(pa.amount) FROM payment_allocations pa
+			JOIN milk_sales ms ON pa.milk_sale_id = ms.id
+			WHERE pa.payment_id = p.id AND ms.date <= ?
+		), 0))
+		FROM buyer_payments p
+		JOIN buyers b ON p.buyer_id = b.id
+		WHERE p.date <= ?
+		GROUP BY b.id
+	`, asOf, asOf)
+	if err != nil {
+		return nil, err
+	}
+	defer credits.Close()
+	for credits.Next() {
+		var id int64
+		var name string
+		var credit float64
+		if err := credits.Scan(&id, &name, &credit); err != nil {
+			return nil, err
+		}
+		if credit < balanceTolerance {
+			continue
+		}
+		a := byBuyer[id]
+		if a == nil {
+			a = &BuyerAgeing{BuyerID: id, BuyerName: name}
+			byBuyer[id] = a
+			order = append(order, id)
+		}
+		a.Credit = credit
+	}
+
+	t := &report.Totals
+	t.BuyerName = "Total"
+	for _, id := range order {
+		a := byBuyer[id]
+		a.Current, a.Days31To60, a.Days61To90, a.Over90 = roundMoney(a.Current), roundMoney(a.Days31To60), roundMoney(a.Days61To90), roundMoney(a.Over90)
+		a.Credit = roundMoney(a.Credit)
+		a.Outstanding = roundMoney(a.Current + a.Days31To60 + a.Days61To90 + a.Over90 - a.Credit)
+		t.Current += a.Current
+		t.Days31To60 += a.Days31To60
+		t.Days61To90 += a.Days61To90
+		t.Over90 += a.Over90
+		t.Credit += a.Credit
+		t.Outstanding += a.Outstanding
+		report.Buyers = append(report.Buyers, *a)
+	}
+	return report, credits.Err()
+}
+
+// roundMoney rounds an amount to cents
+func roundMoney(amount float64) float64 {
+	return math.Round(amount*100) / 100
+}
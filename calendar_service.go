@@ -0,0 +1,351 @@
+package main
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+// CalendarService publishes farm reminders as iCalendar (ICS) data, either as
+// an exported file or as a feed served on the local network that phone and
+// desktop calendars can subscribe to.
+type CalendarService struct {
+	ctx          context.Context
+	notification *NotificationService
+	mu           sync.Mutex
+	server       *http.Server
+	port         int
+}
+
+// CalendarFeedStatus describes the local calendar feed
+type CalendarFeedStatus struct {
+	Running   bool   `json:"running"`
+	Enabled   bool   `json:"enabled"` // start automatically with the app
+	Port      int    `json:"port"`
+	URL       string `json:"url"`       // http URL on the local network
+	WebcalURL string `json:"webcalUrl"` // same feed with the webcal:// scheme for phones
+}
+
+// Settings keys and defaults for the calendar feed
+const (
+	calendarFeedEnabledSetting = "calendar_feed_enabled"
+	calendarFeedPortSetting    = "calendar_feed_port"
+	calendarFeedTokenSetting   = "calendar_feed_token"
+	calendarFeedDefaultPort    = 8765
+)
+
+// Calendars receive reminders for a longer horizon than the in-app list
+const calendarHorizonDays = 365
+
+// NewCalendarService creates a new CalendarService
+func NewCalendarService(n *NotificationService) *CalendarService {
+	return &CalendarService{notification: n}
+}
+
+// SetContext sets the context for the service
+func (s *CalendarService) SetContext(ctx context.Context) {
+	s.ctx = ctx
+}
+
+// BuildRemindersICS renders upcoming reminders as an iCalendar document. Each
+// reminder keeps the same UID between exports, so re-importing or refreshing a
+// subscription updates events instead of duplicating them.
+func (s *CalendarService) BuildRemindersICS() (string, int, error) {
+	reminders, err := s.notification.GetRemindersWithin(calendarHorizonDays)
+	if err != nil {
+		return "", 0, err
+	}
+
+	var b strings.Builder
+	stamp := time.Now().UTC().Format("20060102T150405Z")
+
+	icsLine(&b, "BEGIN:VCALENDAR")
+	icsLine(&b, "VERSION:2.0")
+	icsLine(&b, "PRODID:-//Farmland//Farm Reminders//EN")
+	icsLine(&b, "CALSCALE:GREGORIAN")
+	icsLine(&b, "METHOD:PUBLISH")
+	icsLine(&b, "X-WR-CALNAME:Farm Reminders")
+	icsLine(&b, "REFRESH-INTERVAL;VALUE=DURATION:PT1H")
+	icsLine(&b, "X-PUBLISHED-TTL:PT1H")
+
+	count := 0
+	for _, r := range reminders {
+		due, err := time.Parse("2006-01-02", r.DueDate)
+		if err != nil {
+			continue
+		}
+
+		icsLine(&b, "BEGIN:VEVENT")
+		icsLine(&b, "UID:"+reminderUID(r))
+		icsLine(&b, "DTSTAMP:"+stamp)
+		icsLine(&b, "DTSTART;VALUE=DATE:"+due.Format("20060102"))
+		icsLine(&b, "DTEND;VALUE=DATE:"+due.AddDate(0, 0, 1).Format("20060102"))
+		icsLine(&b, "SUMMARY:"+icsEscape(r.Title+": "+r.EntityName))
+		icsLine(&b, "DESCRIPTION:"+icsEscape(r.Description))
+		icsLine(&b, "CATEGORIES:"+icsEscape(r.Type))
+		icsLine(&b, "TRANSP:TRANSPARENT")
+		icsLine(&b, "BEGIN:VALARM")
+		icsLine(&b, "ACTION:DISPLAY")
+		icsLine(&b, "DESCRIPTION:"+icsEscape(r.Title))
+		icsLine(&b, "TRIGGER:-PT12H")
+		icsLine(&b, "END:VALARM")
+		icsLine(&b, "END:VEVENT")
+		count++
+	}
+
+	icsLine(&b, "END:VCALENDAR")
+	return b.String(), count, nil
+}
+
+// ExportRemindersICS saves upcoming reminders to an .ics file
+func (s *CalendarService) ExportRemindersICS() (*ExportResult, error) {
+	if s.ctx == nil {
+		return nil, fmt.Errorf("context not set")
+	}
+
+	savePath, err := runtime.SaveFileDialog(s.ctx, runtime.SaveDialogOptions{
+		Title:           "Export Reminders Calendar",
+		DefaultFilename: fmt.Sprintf("farm-reminders-%s.ics", time.Now().Format("2006-01-02")),
+		Filters: []runtime.FileFilter{
+			{DisplayName: "iCalendar Files", Pattern: "*.ics"},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if savePath == "" {
+		return nil, nil
+	}
+
+	ics, count, err := s.BuildRemindersICS()
+	if err != nil {
+		return nil, err
+	}
+	if err := os.WriteFile(savePath, []byte(ics), 0644); err != nil {
+		return nil, err
+	}
+
+	return &ExportResult{Path: savePath, Records: count}, nil
+}
+
+// StartCalendarFeed serves the reminders calendar on the local network and
+// remembers to start it again the next time the app opens
+func (s *CalendarService) StartCalendarFeed(port int) (*CalendarFeedStatus, error) {
+	if port <= 0 {
+		port = calendarFeedDefaultPort
+	}
+	if err := s.startServer(port); err != nil {
+		return nil, err
+	}
+	_ = setSetting(calendarFeedEnabledSetting, "true")
+	_ = setSetting(calendarFeedPortSetting, strconv.Itoa(port))
+	return s.GetCalendarFeedStatus()
+}
+
+// StopCalendarFeed stops serving the calendar feed and disables it on startup
+func (s *CalendarService) StopCalendarFeed() error {
+	s.stopServer()
+	return setSetting(calendarFeedEnabledSetting, "false")
+}
+
+// StartFeedIfEnabled starts the calendar feed when it was left enabled
+func (s *CalendarService) StartFeedIfEnabled() {
+	if getSetting(calendarFeedEnabledSetting, "false") != "true" {
+		return
+	}
+	port, err := strconv.Atoi(getSetting(calendarFeedPortSetting, ""))
+	if err != nil || port <= 0 {
+		port = calendarFeedDefaultPort
+	}
+	if err := s.startServer(port); err != nil {
+		fmt.Printf("Calendar feed failed to start: %v\n", err)
+	}
+}
+
+// Shutdown stops the feed server without changing the saved setting
+func (s *CalendarService) Shutdown() {
+	s.stopServer()
+}
+
+// GetCalendarFeedStatus returns whether the feed is running and its subscription URL
+func (s *CalendarService) GetCalendarFeedStatus() (*CalendarFeedStatus, error) {
+	token, err := s.feedToken()
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	running := s.server != nil
+	port := s.port
+	s.mu.Unlock()
+
+	if !running {
+		port, err = strconv.Atoi(getSetting(calendarFeedPortSetting, ""))
+		if err != nil || port <= 0 {
+			port = calendarFeedDefaultPort
+		}
+	}
+
+	address := fmt.Sprintf("%s:%d/%s/farm-reminders.ics", localNetworkIP(), port, token)
+	return &CalendarFeedStatus{
+		Running:   running,
+		Enabled:   getSetting(calendarFeedEnabledSetting, "false") == "true",
+		Port:      port,
+		URL:       "http://" + address,
+		WebcalURL: "webcal://" + address,
+	}, nil
+}
+
+// RegenerateCalendarFeedToken replaces the secret part of the feed URL, so
+// existing subscriptions stop receiving updates
+func (s *CalendarService) RegenerateCalendarFeedToken() (*CalendarFeedStatus, error) {
+	token, err := newFeedToken()
+	if err != nil {
+		return nil, err
+	}
+	if err := setSetting(calendarFeedTokenSetting, token); err != nil {
+		return nil, err
+	}
+	return s.GetCalendarFeedStatus()
+}
+
+func (s *CalendarService) startServer(port int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.server != nil {
+		if s.port == port {
+			return nil
+		}
+		_ = s.server.Close()
+		s.server = nil
+	}
+
+	// Listen on all interfaces so phones on the same network can subscribe;
+	// the random token in the path keeps the feed private.
+	listener, err := net.Listen("tcp", fmt.Sprintf(":%d", port))
+	if err != nil {
+		return fmt.Errorf("failed to start calendar feed on port %d: %w", port, err)
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", s.serveFeed)
+	server := &http.Server{Handler: mux, ReadHeaderTimeout: 10 * time.Second}
+
+	go func() {
+		if err := server.Serve(listener); err != nil && err != http.ErrServerClosed {
+			fmt.Printf("Calendar feed stopped: %v\n", err)
+		}
+	}()
+
+	s.server = server
+	s.port = port
+	return nil
+}
+
+func (s *CalendarService) stopServer() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.server != nil {
+		_ = s.server.Close()
+		s.server = nil
+	}
+}
+
+// serveFeed answers GET /<token>/farm-reminders.ics
+func (s *CalendarService) serveFeed(w http.ResponseWriter, r *http.Request) {
+	token, err := s.feedToken()
+	if err != nil || r.URL.Path != "/"+token+"/farm-reminders.ics" {
+		http.NotFound(w, r)
+		return
+	}
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	ics, _, err := s.BuildRemindersICS()
+	if err != nil {
+		http.Error(w, "failed to build calendar", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/calendar; charset=utf-8")
+	w.Header().Set("Content-Disposition", `inline; filename="farm-reminders.ics"`)
+	w.Header().Set("Cache-Control", "no-cache")
+	_, _ = w.Write([]byte(ics))
+}
+
+// feedToken returns the secret feed token, creating one on first use
+func (s *CalendarService) feedToken() (string, error) {
+	if token := getSetting(calendarFeedTokenSetting, ""); token != "" {
+		return token, nil
+	}
+	token, err := newFeedToken()
+	if err != nil {
+		return "", err
+	}
+	return token, setSetting(calendarFeedTokenSetting, token)
+}
+
+func newFeedToken() (string, error) {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf), nil
+}
+
+// reminderUID returns a UID that stays the same for a reminder across exports.
+// Reminder IDs are the ids of the underlying vet or breeding records, so the
+// type is included to keep them unique.
+func reminderUID(r Reminder) string {
+	return fmt.Sprintf("%s-%d@farmland", r.Type, r.ID)
+}
+
+// localNetworkIP returns the first non-loopback IPv4 address, or localhost
+func localNetworkIP() string {
+	addrs, err := net.InterfaceAddrs()
+	if err != nil {
+		return "localhost"
+	}
+	for _, addr := range addrs {
+		if ipNet, ok := addr.(*net.IPNet); ok && !ipNet.IP.IsLoopback() && ipNet.IP.To4() != nil {
+			return ipNet.IP.String()
+		}
+	}
+	return "localhost"
+}
+
+// icsLine writes a content line, folding it at 75 octets as RFC 5545 requires
+func icsLine(b *strings.Builder, line string) {
+	limit := 75
+	for len(line) > limit {
+		cut := limit
+		// Do not split a multi-byte UTF-8 character
+		for cut > 0 && line[cut]&0xC0 == 0x80 {
+			cut--
+		}
+		b.WriteString(line[:cut])
+		b.WriteString("\r\n ")
+		line = line[cut:]
+		limit = 74 // continuation lines start with a space
+	}
+	b.WriteString(line)
+	b.WriteString("\r\n")
+}
+
+// icsEscape escapes text values for iCalendar
+func icsEscape(s string) string {
+	return strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`).Replace(s)
+}
@@ -0,0 +1,680 @@
+package main
+
+import (
+	"database/sql"
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+	"time"
+)
+
+// CooperativeService handles milk delivered to dairy cooperatives: the daily
+// delivery notes, the monthly payout statements with their deductions, and the
+// reconciliation of the two against the farm's own figures.
+type CooperativeService struct{}
+
+// NewCooperativeService creates a new CooperativeService
+func NewCooperativeService() *CooperativeService {
+	return &CooperativeService{}
+}
+
+// Differences up to half a liter a day are put down to measuring and not flagged
+const coopShortfallTolerance = 0.5
+
+// coopDeductionCategories are what a cooperative deducts from a payout
+var coopDeductionCategories = []string{"transport", "shares", "loan", "inputs", "other"}
+
+// GetCoopDeductionCategories returns what a cooperative can deduct from a payout
+func (s *CooperativeService) GetCoopDeductionCategories() []string {
+	return coopDeductionCategories
+}
+
+// GetCooperatives returns all cooperatives, active ones first
+func (s *CooperativeService) GetCooperatives() ([]Cooperative, error) {
+	rows, err := db.Query(`
+		SELECT id, name, member_number, price_per_liter, active, notes, created_at
+		FROM cooperatives
+		ORDER BY active DESC, name
+	`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	coops := []Cooperative{}
+	for rows.Next() {
+		var c Cooperative
+		var memberNumber, notes sql.NullString
+		var price sql.NullFloat64
+		if err := rows.Scan(&c.ID, &c.Name, &memberNumber, &price, &c.Active, &notes, &c.CreatedAt); err != nil {
+			return nil, err
+		}
+		c.MemberNumber = memberNumber.String
+		c.PricePerLiter = price.Float64
+		c.Notes = notes.String
+		coops = append(coops, c)
+	}
+	return coops, nil
+}
+
+// GetCooperative returns a single cooperative by ID
+func (s *CooperativeService) GetCooperative(id int64) (*Cooperative, error) {
+	coops, err := s.GetCooperatives()
+	if err != nil {
+		return nil, err
+	}
+	for _, c := range coops {
+		if c.ID == id {
+			return &c, nil
+		}
+	}
+	return nil, fmt.Errorf("cooperative not found")
+}
+
+// AddCooperative adds a cooperative
+func (s *CooperativeService) AddCooperative(coop Cooperative) (int64, error) {
+	if err := validateCooperative(&coop); err != nil {
+		return 0, err
+	}
+	result, err := db.Exec(`
+		INSERT INTO cooperatives (name, member_number, price_per_liter, active, notes)
+		VALUES (?, ?, ?, 1, ?)
+	`, coop.Name, coop.MemberNumber, coop.PricePerLiter, coop.Notes)
+	if err != nil {
+		return 0, cooperativeError(err, coop.Name)
+	}
+	return result.LastInsertId()
+}
+
+// UpdateCooperative updates a cooperative's details
+func (s *CooperativeService) UpdateCooperative(coop Cooperative) error {
+	if err := validateCooperative(&coop); err != nil {
+		return err
+	}
+	_, err := db.Exec(`
+		UPDATE cooperatives SET name = ?, member_number = ?, price_per_liter = ?, active = ?, notes = ?
+		WHERE id = ?
+	`, coop.Name, coop.MemberNumber, coop.PricePerLiter, coop.Active, coop.Notes, coop.ID)
+	if err != nil {
+		return cooperativeError(err, coop.Name)
+	}
+	return nil
+}
+
+// DeleteCooperative deletes a cooperative with no deliveries or payouts.
+// Cooperatives with history should be deactivated instead.
+func (s *CooperativeService) DeleteCooperative(id int64) error {
+	var count int
+	err := db.QueryRow(`
+		SELECT (SELECT COUNT(*) FROM coop_deliveries WHERE cooperative_id = ?) + (SELECT COUNT(*) FROM coop_payouts WHERE cooperative_id = ?)
+	`, id, id).Scan(&count)
+	if err != nil {
+		return err
+	}
+	if count > 0 {
+		return fmt.Errorf("cooperative has deliveries or payouts; deactivate it instead")
+	}
+	_, err = db.Exec(`DELETE FROM cooperatives WHERE id = ?`, id)
+	return err
+}
+
+func validateCooperative(coop *Cooperative) error {
+	coop.Name = strings.TrimSpace(coop.Name)
+	if coop.Name == "" {
+		return fmt.Errorf("cooperative name is required")
+	}
+	if coop.PricePerLiter < 0 {
+		return fmt.Errorf("price per liter cannot be negative")
+	}
+	return nil
+}
+
+// cooperativeError explains a duplicate cooperative name
+func cooperativeError(err error, name string) error {
+	if strings.Contains(err.Error(), "UNIQUE") {
+		return fmt.Errorf("a cooperative named %s already exists", name)
+	}
+	return err
+}
+
+// GetCoopDeliveries returns deliveries within a date range, newest first. A
+// cooperativeID of 0 returns deliveries to all cooperatives.
+func (s *CooperativeService) GetCoopDeliveries(cooperativeID int64, startDate, endDate string) ([]CoopDelivery, error) {
+	query := `
+		SELECT d.id, d.cooperative_id, c.name, d.date, d.liters, d.coop_liters, d.delivery_note, d.notes, d.created_at
+		FROM coop_deliveries d
+		JOIN cooperatives c ON d.cooperative_id = c.id
+		WHERE 1=1
+	`
+	args := []interface{}{}
+	if cooperativeID > 0 {
+		query += " AND d.cooperative_id = ?"
+		args = append(args, cooperativeID)
+	}
+	query, rangeArgs := appendDateRange(query, "d.date", startDate, endDate)
+	args = append(args, rangeArgs...)
+	query += " ORDER BY d.date DESC, d.id DESC"
+
+	rows, err := db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	deliveries := []CoopDelivery{}
+	for rows.Next() {
+		var d CoopDelivery
+		var coopLiters sql.NullFloat64
+		var deliveryNote, notes sql.NullString
+		err := rows.Scan(&d.ID, &d.CooperativeID, &d.CooperativeName, &d.Date, &d.Liters, &coopLiters, &deliveryNote, &notes, &d.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+		if coopLiters.Valid {
+			d.CoopLiters = &coopLiters.Float64
+		}
+		d.DeliveryNote = deliveryNote.String
+		d.Notes = notes.String
+		deliveries = append(deliveries, d)
+	}
+	return deliveries, nil
+}
+
+// AddCoopDelivery records milk delivered to a cooperative
+func (s *CooperativeService) AddCoopDelivery(delivery CoopDelivery) (int64, error) {
+	if err := validateCoopDelivery(&delivery); err != nil {
+		return 0, err
+	}
+	result, err := db.Exec(`
+		INSERT INTO coop_deliveries (cooperative_id, date, liters, coop_liters, delivery_note, notes)
+		VALUES (?, ?, ?, ?, ?, ?)
+	`, delivery.CooperativeID, delivery.Date, delivery.Liters, delivery.CoopLiters, delivery.DeliveryNote, delivery.Notes)
+	if err != nil {
+		return 0, err
+	}
+	return result.LastInsertId()
+}
+
+// UpdateCoopDelivery updates a delivery, typically to add the liters the
+// cooperative measured
+func (s *CooperativeService) UpdateCoopDelivery(delivery CoopDelivery) error {
+	if err := validateCoopDelivery(&delivery); err != nil {
+		return err
+	}
+	_, err := db.Exec(`
+		UPDATE coop_deliveries SET cooperative_id = ?, date = ?, liters = ?, coop_liters = ?, delivery_note = ?, notes = ?
+		WHERE id = ?
+	`, delivery.CooperativeID, delivery.Date, delivery.Liters, delivery.CoopLiters, delivery.DeliveryNote, delivery.Notes, delivery.ID)
+	return err
+}
+
+// DeleteCoopDelivery deletes a delivery
+func (s *CooperativeService) DeleteCoopDelivery(id int64) error {
+	_, err := db.Exec(`DELETE FROM coop_deliveries WHERE id = ?`, id)
+	return err
+}
+
+func validateCoopDelivery(delivery *CoopDelivery) error {
+	if delivery.CooperativeID <= 0 {
+		return fmt.Errorf("cooperative is required")
+	}
+	if delivery.Date == "" {
+		delivery.Date = time.Now().Format("2006-01-02")
+	}
+	if delivery.Liters <= 0 {
+		return fmt.Errorf("liters delivered must be greater than zero")
+	}
+	if delivery.CoopLiters != nil && *delivery.CoopLiters < 0 {
+		return fmt.Errorf("the cooperative's liters cannot be negative")
+	}
+	delivery.DeliveryNote = strings.TrimSpace(delivery.DeliveryNote)
+	return nil
+}
+
+// GetCoopPayouts returns payouts with their deductions, newest month first. A
+// cooperativeID of 0 returns payouts from all cooperatives.
+func (s *CooperativeService) GetCoopPayouts(cooperativeID int64) ([]CoopPayout, error) {
+	return coopPayouts(cooperativeID, "")
+}
+
+// GetCoopPayout returns a single payout with its deductions
+func (s *CooperativeService) GetCoopPayout(id int64) (*CoopPayout, error) {
+	payouts, err := coopPayouts(0, "")
+	if err != nil {
+		return nil, err
+	}
+	for _, p := range payouts {
+		if p.ID == id {
+			return &p, nil
+		}
+	}
+	return nil, fmt.Errorf("payout not found")
+}
+
+// coopPayouts loads payouts, optionally of one cooperative and month
+func coopPayouts(cooperativeID int64, month string) ([]CoopPayout, error) {
+	query := `
+		SELECT p.id, p.cooperative_id, c.name, p.month, p.payout_date, p.liters, p.price_per_liter, p.gross_amount,
+			   p.reference, p.transaction_id, p.notes, p.created_at
+		FROM coop_payouts p
+		JOIN cooperatives c ON p.cooperative_id = c.id
+		WHERE 1=1
+	`
+	args := []interface{}{}
+	if cooperativeID > 0 {
+		query += " AND p.cooperative_id = ?"
+		args = append(args, cooperativeID)
+	}
+	if month != "" {
+		query += " AND p.month = ?"
+		args = append(args, month)
+	}
+	query += " ORDER BY p.month DESC, c.name"
+
+	rows, err := db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	payouts := []CoopPayout{}
+	byID := map[int64]int{}
+	for rows.Next() {
+		var p CoopPayout
+		var reference, notes sql.NullString
+		var transactionID sql.NullInt64
+		err := rows.Scan(&p.ID, &p.CooperativeID, &p.CooperativeName, &p.Month, &p.PayoutDate, &p.Liters, &p.PricePerLiter,
+			&p.GrossAmount, &reference, &transactionID, &notes, &p.CreatedAt)
+		if err != nil {
+			rows.Close()
+			return nil, err
+		}
+		p.Reference = reference.String
+		p.Notes = notes.String
+		if transactionID.Valid {
+			p.TransactionID = &transactionID.Int64
+		}
+		p.Deductions = []CoopDeduction{}
+		byID[p.ID] = len(payouts)
+		payouts = append(payouts, p)
+	}
+	rows.Close()
+	if len(payouts) == 0 {
+		return payouts, nil
+	}
+
+	rows, err = db.Query(`SELECT id, payout_id, category, description, amount FROM coop_deductions ORDER BY id`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var d CoopDeduction
+		var description sql.NullString
+		if err := rows.Scan(&d.ID, &d.PayoutID, &d.Category, &description, &d.Amount); err != nil {
+			return nil, err
+		}
+		d.Description = description.String
+		if i, ok := byID[d.PayoutID]; ok {
+			payouts[i].Deductions = append(payouts[i].Deductions, d)
+			payouts[i].TotalDeductions += d.Amount
+		}
+	}
+	for i := range payouts {
+		p := &payouts[i]
+		p.TotalDeductions = roundMoney(p.TotalDeductions)
+		p.NetAmount = roundMoney(p.GrossAmount - p.TotalDeductions)
+	}
+	return payouts, rows.Err()
+}
+
+// AddCoopPayout records a cooperative's payout statement for a month and
+// posts the net payout to finances
+func (s *CooperativeService) AddCoopPayout(payout CoopPayout) (int64, error) {
+	coop, err := s.validateCoopPayout(&payout)
+	if err != nil {
+		return 0, err
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		return 0, err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	result, err := tx.Exec(`
+		INSERT INTO coop_payouts (cooperative_id, month, payout_date, liters, price_per_liter, gross_amount, reference, notes)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+	`, payout.CooperativeID, payout.Month, payout.PayoutDate, payout.Liters, payout.PricePerLiter, payout.GrossAmount,
+		payout.Reference, payout.Notes)
+	if err != nil {
+		if strings.Contains(err.Error(), "UNIQUE") {
+			return 0, fmt.Errorf("a payout from %s for %s is already recorded", coop.Name, payout.Month)
+		}
+		return 0, err
+	}
+	payout.ID, err = result.LastInsertId()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get last insert id: %w", err)
+	}
+
+	if err := saveCoopDeductions(tx, payout); err != nil {
+		return 0, err
+	}
+	if err := postCoopPayout(tx, payout, coop.Name, sql.NullInt64{}); err != nil {
+		return 0, err
+	}
+	if err := tx.Commit(); err != nil {
+		return 0, err
+	}
+	return payout.ID, nil
+}
+
+// UpdateCoopPayout updates a payout and its deductions, and the finance
+// transaction posted for it
+func (s *CooperativeService) UpdateCoopPayout(payout CoopPayout) error {
+	coop, err := s.validateCoopPayout(&payout)
+	if err != nil {
+		return err
+	}
+	var transactionID sql.NullInt64
+	if err := db.QueryRow(`SELECT transaction_id FROM coop_payouts WHERE id = ?`, payout.ID).Scan(&transactionID); err != nil {
+		return fmt.Errorf("payout not found: %w", err)
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	_, err = tx.Exec(`
+		UPDATE coop_payouts SET cooperative_id = ?, month = ?, payout_date = ?, liters = ?, price_per_liter = ?, gross_amount = ?,
+			reference = ?, notes = ?
+		WHERE id = ?
+	`, payout.CooperativeID, payout.Month, payout.PayoutDate, payout.Liters, payout.PricePerLiter, payout.GrossAmount,
+		payout.Reference, payout.Notes, payout.ID)
+	if err != nil {
+		if strings.Contains(err.Error(), "UNIQUE") {
+			return fmt.Errorf("a payout from %s for %s is already recorded", coop.Name, payout.Month)
+		}
+		return err
+	}
+	if _, err := tx.Exec(`DELETE FROM coop_deductions WHERE payout_id = ?`, payout.ID); err != nil {
+		return err
+	}
+	if err := saveCoopDeductions(tx, payout); err != nil {
+		return err
+	}
+	if err := postCoopPayout(tx, payout, coop.Name, transactionID); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// DeleteCoopPayout deletes a payout, its deductions and its finance
+// transaction. A payout whose transaction has been reconciled against the bank
+// cannot be deleted until it is unlinked.
+func (s *CooperativeService) DeleteCoopPayout(id int64) error {
+	var transactionID sql.NullInt64
+	if err := db.QueryRow(`SELECT transaction_id FROM coop_payouts WHERE id = ?`, id).Scan(&transactionID); err != nil {
+		return fmt.Errorf("payout not found: %w", err)
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	if transactionID.Valid {
+		if err := checkTransactionRemovable(tx, transactionID.Int64); err != nil {
+			return fmt.Errorf("cannot delete payout: %w", err)
+		}
+	}
+	if _, err := tx.Exec(`DELETE FROM coop_deductions WHERE payout_id = ?`, id); err != nil {
+		return err
+	}
+	if _, err := tx.Exec(`DELETE FROM coop_payouts WHERE id = ?`, id); err != nil {
+		return err
+	}
+	if transactionID.Valid {
+		if _, err := tx.Exec(`DELETE FROM transactions WHERE id = ?`, transactionID.Int64); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+// validateCoopPayout checks a payout and fills in the cooperative's usual
+// price and the gross amount when they are not given
+func (s *CooperativeService) validateCoopPayout(payout *CoopPayout) (*Cooperative, error) {
+	coop, err := s.GetCooperative(payout.CooperativeID)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := time.Parse("2006-01", payout.Month); err != nil {
+		return nil, fmt.Errorf("invalid month %q: expected YYYY-MM", payout.Month)
+	}
+	if payout.PayoutDate == "" {
+		payout.PayoutDate = time.Now().Format("2006-01-02")
+	}
+	if payout.Liters < 0 || payout.PricePerLiter < 0 || payout.GrossAmount < 0 {
+		return nil, fmt.Errorf("liters, price and gross amount cannot be negative")
+	}
+	if payout.PricePerLiter == 0 {
+		payout.PricePerLiter = coop.PricePerLiter
+	}
+	if payout.GrossAmount == 0 {
+		payout.GrossAmount = roundMoney(payout.Liters * payout.PricePerLiter)
+	}
+
+	payout.TotalDeductions = 0
+	for i := range payout.Deductions {
+		d := &payout.Deductions[i]
+		d.Description = strings.TrimSpace(d.Description)
+		if d.Category == "" {
+			d.Category = "other"
+		}
+		if !slices.Contains(coopDeductionCategories, d.Category) {
+			return nil, fmt.Errorf("unknown deduction category %q", d.Category)
+		}
+		if d.Amount <= 0 {
+			return nil, fmt.Errorf("deduction amounts must be greater than zero")
+		}
+		payout.TotalDeductions += d.Amount
+	}
+	payout.TotalDeductions = roundMoney(payout.TotalDeductions)
+	payout.NetAmount = roundMoney(payout.GrossAmount - payout.TotalDeductions)
+	return coop, nil
+}
+
+func saveCoopDeductions(tx *sql.Tx, payout CoopPayout) error {
+	for _, d := range payout.Deductions {
+		if _, err := tx.Exec(`
+			INSERT INTO coop_deductions (payout_id, category, description, amount) VALUES (?, ?, ?, ?)
+		`, payout.ID, d.Category, d.Description, d.Amount); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// postCoopPayout posts the net payout to finances as milk sales income,
+// updating the transaction posted earlier if there is one. The amount and date
+// of a transaction that has cleared or been reconciled cannot change. A payout
+// swallowed by its deductions has nothing to post, so its earlier transaction
+// is removed unless it has been reconciled.
+func postCoopPayout(tx *sql.Tx, payout CoopPayout, coopName string, transactionID sql.NullInt64) error {
+	if payout.NetAmount <= 0 {
+		if !transactionID.Valid {
+			return nil
+		}
+		if err := checkTransactionRemovable(tx, transactionID.Int64); err != nil {
+			return fmt.Errorf("payout leaves nothing to post after deductions: %w", err)
+		}
+		if _, err := tx.Exec(`UPDATE coop_payouts SET transaction_id = NULL WHERE id = ?`, payout.ID); err != nil {
+			return err
+		}
+		_, err := tx.Exec(`DELETE FROM transactions WHERE id = ?`, transactionID.Int64)
+		return err
+	}
+
+	month, _ := time.Parse("2006-01", payout.Month)
+	description := fmt.Sprintf("%s milk payout for %s", coopName, month.Format("January 2006"))
+	if payout.TotalDeductions > 0 {
+		description += fmt.Sprintf(" (gross %.2f less deductions %.2f)", payout.GrossAmount, payout.TotalDeductions)
+	}
+	if transactionID.Valid {
+		var postedDate string
+		var amount float64
+		if err := tx.QueryRow(`SELECT date, amount FROM transactions WHERE id = ?`, transactionID.Int64).Scan(&postedDate, &amount); err != nil {
+			return err
+		}
+		if postedDate != payout.PayoutDate || roundMoney(amount) != payout.NetAmount {
+			if err := checkTransactionRemovable(tx, transactionID.Int64); err != nil {
+				return fmt.Errorf("cannot change the posted payout: %w", err)
+			}
+		}
+		_, err := tx.Exec(`UPDATE transactions SET date = ?, description = ?, amount = ? WHERE id = ?`,
+			payout.PayoutDate, description, payout.NetAmount, transactionID.Int64)
+		return err
+	}
+
+	result, err := tx.Exec(`
+		INSERT INTO transactions (date, type, category, description, amount, payment_method, related_entity)
+		VALUES (?, 'income', 'milk_sales', ?, ?, 'automatic', ?)
+	`, payout.PayoutDate, description, payout.NetAmount, fmt.Sprintf("coop_payout:%d", payout.ID))
+	if err != nil {
+		return err
+	}
+	id, err := result.LastInsertId()
+	if err != nil {
+		return fmt.Errorf("failed to get last insert id: %w", err)
+	}
+	_, err = tx.Exec(`UPDATE coop_payouts SET transaction_id = ? WHERE id = ?`, id, payout.ID)
+	return err
+}
+
+// GetCoopReconciliation compares a month of deliveries to a cooperative with
+// the liters it measured and the payout it made. Days where the cooperative
+// recorded less than was delivered are flagged.
+func (s *CooperativeService) GetCoopReconciliation(cooperativeID int64, month string) (*CoopReconciliation, error) {
+	if month == "" {
+		month = time.Now().Format("2006-01")
+	}
+	monthStart, err := time.Parse("2006-01", month)
+	if err != nil {
+		return nil, fmt.Errorf("invalid month %q: expected YYYY-MM", month)
+	}
+	coop, err := s.GetCooperative(cooperativeID)
+	if err != nil {
+		return nil, err
+	}
+
+	r := &CoopReconciliation{
+		CooperativeID:   coop.ID,
+		CooperativeName: coop.Name,
+		Month:           month,
+		StartDate:       monthStart.Format("2006-01-02"),
+		EndDate:         monthStart.AddDate(0, 1, -1).Format("2006-01-02"),
+		Days:            []CoopReconciliationDay{},
+		Issues:          []string{},
+	}
+	deliveries, err := s.GetCoopDeliveries(cooperativeID, r.StartDate, r.EndDate)
+	if err != nil {
+		return nil, err
+	}
+
+	// Deliveries come newest first; build the days oldest first. Only
+	// deliveries the cooperative has measured count towards a shortfall.
+	measuredLiters := map[string]float64{}
+	for i := len(deliveries) - 1; i >= 0; i-- {
+		d := deliveries[i]
+		if len(r.Days) == 0 || r.Days[len(r.Days)-1].Date != d.Date {
+			r.Days = append(r.Days, CoopReconciliationDay{Date: d.Date, Measured: true})
+		}
+		day := &r.Days[len(r.Days)-1]
+		day.Liters += d.Liters
+		if d.CoopLiters != nil {
+			day.CoopLiters += *d.CoopLiters
+			measuredLiters[d.Date] += d.Liters
+		} else {
+			day.Measured = false
+		}
+		if d.DeliveryNote != "" {
+			if day.DeliveryNotes != "" {
+				day.DeliveryNotes += ", "
+			}
+			day.DeliveryNotes += d.DeliveryNote
+		}
+	}
+
+	expectedLiters := 0.0
+	for i := range r.Days {
+		day := &r.Days[i]
+		day.Liters = math.Round(day.Liters*10) / 10
+		day.CoopLiters = math.Round(day.CoopLiters*10) / 10
+		day.Shortfall = math.Max(0, math.Round((measuredLiters[day.Date]-day.CoopLiters)*10)/10)
+		day.Flagged = day.Shortfall > coopShortfallTolerance
+		r.DeliveredLiters += day.Liters
+		r.CoopLiters += day.CoopLiters
+		// The cooperative pays on its own figures, or on ours where it has none yet
+		expectedLiters += day.CoopLiters + day.Liters - measuredLiters[day.Date]
+		if day.Flagged {
+			r.FlaggedDays++
+			r.ShortfallLiters += day.Shortfall
+		}
+		if !day.Measured {
+			r.UnmeasuredDays++
+		}
+	}
+	r.DeliveredLiters = math.Round(r.DeliveredLiters*10) / 10
+	r.CoopLiters = math.Round(r.CoopLiters*10) / 10
+	r.ShortfallLiters = math.Round(r.ShortfallLiters*10) / 10
+
+	if r.FlaggedDays > 0 {
+		r.Issues = append(r.Issues, fmt.Sprintf("On %d %s the cooperative recorded %.1f L less than was delivered",
+			r.FlaggedDays, pluralDays(r.FlaggedDays), r.ShortfallLiters))
+	}
+	if r.UnmeasuredDays > 0 {
+		r.Issues = append(r.Issues, fmt.Sprintf("%d %s without the cooperative's measured liters", r.UnmeasuredDays, pluralDays(r.UnmeasuredDays)))
+	}
+
+	price := coop.PricePerLiter
+	payouts, err := coopPayouts(cooperativeID, month)
+	if err != nil {
+		return nil, err
+	}
+	if len(payouts) == 0 {
+		if len(r.Days) > 0 {
+			r.Issues = append(r.Issues, fmt.Sprintf("No payout recorded for %s", monthStart.Format("January 2006")))
+		}
+	} else {
+		p := payouts[0]
+		r.Payout = &p
+		price = p.PricePerLiter
+		if unpaid := expectedLiters - p.Liters; unpaid > coopShortfallTolerance {
+			r.UnpaidLiters = math.Round(unpaid*10) / 10
+			r.Issues = append(r.Issues, fmt.Sprintf("Paid for %.1f L, %.1f L less than the %.1f L delivered and measured",
+				p.Liters, r.UnpaidLiters, expectedLiters))
+		}
+		if expected := roundMoney(p.Liters * p.PricePerLiter); math.Abs(expected-p.GrossAmount) >= 1 {
+			r.Issues = append(r.Issues, fmt.Sprintf("Gross payout of %.2f differs from %.1f L at %.2f per liter (%.2f)",
+				p.GrossAmount, p.Liters, p.PricePerLiter, expected))
+		}
+		if p.NetAmount <= 0 {
+			r.Issues = append(r.Issues, fmt.Sprintf("Deductions of %.2f take the whole gross payout of %.2f; nothing was posted to finances",
+				p.TotalDeductions, p.GrossAmount))
+		}
+	}
+	r.ShortfallValue = roundMoney((r.ShortfallLiters + r.UnpaidLiters) * price)
+	return r, nil
+}
+
+// pluralDays returns "day" or "days" to go with a count
+func pluralDays(n int) string {
+	if n == 1 {
+		return "day"
+	}
+	return "days"
+}
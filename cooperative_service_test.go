@@ -0,0 +1,79 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCoopPayoutReconciledGuard(t *testing.T) {
+	setupTestDatabase(t)
+	coops := NewCooperativeService()
+
+	coopID, err := coops.AddCooperative(Cooperative{Name: "Githunguri Dairy", PricePerLiter: 50, Active: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	addPayout := func(month string, cleared bool) CoopPayout {
+		payout := CoopPayout{CooperativeID: coopID, Month: month, PayoutDate: month + "-28", Liters: 100}
+		id, err := coops.AddCoopPayout(payout)
+		if err != nil {
+			t.Fatal(err)
+		}
+		saved, err := coops.GetCoopPayout(id)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if cleared {
+			if _, err := db.Exec(`UPDATE transactions SET reconciliation_status = 'cleared', cleared_date = ? WHERE id = ?`, saved.PayoutDate, *saved.TransactionID); err != nil {
+				t.Fatal(err)
+			}
+		}
+		return *saved
+	}
+	swallowed := func(p CoopPayout) CoopPayout {
+		p.Deductions = []CoopDeduction{{Category: "loan", Amount: p.GrossAmount}}
+		return p
+	}
+	deducted := func(p CoopPayout) CoopPayout {
+		p.Deductions = []CoopDeduction{{Category: "loan", Amount: 1000}}
+		return p
+	}
+
+	// wantAmount is the transaction's amount after a successful change, 0 when removed
+	tests := []struct {
+		name       string
+		payout     CoopPayout
+		change     func(CoopPayout) error
+		wantAmount float64
+		wantErr    string
+	}{
+		{"delete an uncleared payout", addPayout("2024-01", false), func(p CoopPayout) error { return coops.DeleteCoopPayout(p.ID) }, 0, ""},
+		{"delete a cleared payout", addPayout("2024-02", true), func(p CoopPayout) error { return coops.DeleteCoopPayout(p.ID) }, 0, "has cleared the bank"},
+		{"deduct an uncleared payout to nothing", addPayout("2024-03", false), func(p CoopPayout) error { return coops.UpdateCoopPayout(swallowed(p)) }, 0, ""},
+		{"deduct a cleared payout to nothing", addPayout("2024-04", true), func(p CoopPayout) error { return coops.UpdateCoopPayout(swallowed(p)) }, 0, "has cleared the bank"},
+		{"edit an uncleared payout", addPayout("2024-05", false), func(p CoopPayout) error { return coops.UpdateCoopPayout(deducted(p)) }, 4000, ""},
+		{"edit a cleared payout", addPayout("2024-06", true), func(p CoopPayout) error { return coops.UpdateCoopPayout(deducted(p)) }, 0, "has cleared the bank"},
+	}
+	for _, tt := range tests {
+		err := tt.change(tt.payout)
+		var amount float64
+		if err := db.QueryRow(`SELECT COALESCE(SUM(amount), 0) FROM transactions WHERE id = ?`, *tt.payout.TransactionID).Scan(&amount); err != nil {
+			t.Fatal(err)
+		}
+		if tt.wantErr == "" {
+			if err != nil {
+				t.Errorf("%s: unexpected error %v", tt.name, err)
+			}
+			if amount != tt.wantAmount {
+				t.Errorf("%s: transaction amount = %v, want %v", tt.name, amount, tt.wantAmount)
+			}
+			continue
+		}
+		if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+			t.Errorf("%s: error = %v, want %q", tt.name, err, tt.wantErr)
+		}
+		if amount != tt.payout.NetAmount {
+			t.Errorf("%s: reconciled transaction amount = %v, want it kept at %v", tt.name, amount, tt.payout.NetAmount)
+		}
+	}
+}
@@ -28,26 +28,33 @@ func (s *DashboardService) GetDashboardStats() (*DashboardStats, error) {
 		_ = err // Log error or continue
 	}
 
-	// Active dairy cows
-	if err := db.QueryRow(`SELECT COUNT(*) FROM animals WHERE status = 'active' AND gender = 'female' AND type IN ('cow', 'heifer')`).Scan(&stats.ActiveCows); err != nil {
+	// Active milking animals of every milked species
+	milking, milkingArgs := milkingAnimalsClause("")
+	if err := db.QueryRow(`SELECT COUNT(*) FROM animals WHERE status = 'active' AND gender = 'female' AND `+milking, milkingArgs...).Scan(&stats.ActiveCows); err != nil {
 		_ = err // Log error or continue
 	}
 
-	// Today's milk
-	today := time.Now().Format("2006-01-02")
-	var todayMilk sql.NullFloat64
-	if err := db.QueryRow(`SELECT SUM(total_liters) FROM milk_records WHERE date = ?`, today).Scan(&todayMilk); err != nil {
-		_ = err // Log error or continue
+	// Today's milk, in total and per milking session
+	if todayMilk, err := s.livestock.GetTodayMilkTotal(); err == nil {
+		stats.TodayMilkLiters = todayMilk
+	}
+	if bySession, err := s.livestock.GetTodayMilkBySession(); err == nil {
+		stats.TodayMilkBySession = bySession
 	}
-	stats.TodayMilkLiters = todayMilk.Float64
 
 	// Month's milk
 	startOfMonth := time.Now().Format("2006-01") + "-01"
-	var monthMilk sql.NullFloat64
-	if err := db.QueryRow(`SELECT SUM(total_liters) FROM milk_records WHERE date >= ?`, startOfMonth).Scan(&monthMilk); err != nil {
-		_ = err // Log error or continue
+	if monthMilk, err := milkTotal(startOfMonth, ""); err == nil {
+		stats.MonthMilkLiters = monthMilk
+	}
+
+	// Milk discarded this month, mostly during drug withdrawal periods
+	if discarded, err := discardedMilkTotal(startOfMonth, ""); err == nil {
+		stats.MonthDiscardedMilkLiters = discarded
+	}
+	if withdrawals, err := withdrawalsOn(time.Now().Format("2006-01-02")); err == nil {
+		stats.AnimalsUnderWithdrawal = len(withdrawals)
 	}
-	stats.MonthMilkLiters = monthMilk.Float64
 
 	// Active fields
 	if err := db.QueryRow(`SELECT COUNT(*) FROM fields WHERE status IN ('planted', 'growing', 'ready_harvest')`).Scan(&stats.ActiveFields); err != nil {
@@ -140,79 +147,98 @@ func (s *DashboardService) GetRecentActivity() ([]RecentActivity, error) {
 	return activities, nil
 }
 
-// GetMilkProductionChart returns milk production data for specified timeframe
+// GetLactationComparison returns the current lactation of each milking cow for side-by-side comparison
+func (s *DashboardService) GetLactationComparison() ([]Lactation, error) {
+	return s.livestock.GetCurrentLactations()
+}
+
+// GetMilkProductionChart returns milk production data for specified timeframe.
+// Each point carries the total and the liters of each milking session.
 func (s *DashboardService) GetMilkProductionChart(timeframe string) ([]map[string]interface{}, error) {
-	var query string
+	return s.milkProductionChart(timeframe, time.Now().Local())
+}
+
+// milkProductionChart returns the production chart for the timeframe ending
+// on the given day. The monthly report draws its trend from it too.
+func (s *DashboardService) milkProductionChart(timeframe string, end time.Time) ([]map[string]interface{}, error) {
+	period, since := "mr.date", end.AddDate(0, 0, -6)
 	switch timeframe {
 	case "month":
-		query = `SELECT date, SUM(total_liters) as total FROM milk_records WHERE date >= date('now', 'localtime', '-29 days') GROUP BY date ORDER BY date`
+		since = end.AddDate(0, 0, -29)
 	case "year":
-		query = `SELECT strftime('%Y-%m', date) as period, SUM(total_liters) as total FROM milk_records WHERE date >= date('now', 'localtime', '-11 months', 'start of month') GROUP BY period ORDER BY period`
-	default: // week
-		query = `SELECT date, SUM(total_liters) as total FROM milk_records WHERE date >= date('now', 'localtime', '-6 days') GROUP BY date ORDER BY date`
-	}
-
-	rows, err := db.Query(query)
+		period, since = "strftime('%Y-%m', mr.date)", time.Date(end.Year(), end.Month()-11, 1, 0, 0, 0, 0, end.Location())
+	}
+
+	rows, err := db.Query(`
+		SELECT `+period+` AS period, ms.name, SUM(mrs.liters) AS total
+		FROM milk_record_sessions mrs
+		JOIN milk_records mr ON mrs.milk_record_id = mr.id
+		JOIN milking_sessions ms ON mrs.session_id = ms.id
+		WHERE mr.date >= ? AND mr.date <= ?
+		GROUP BY period, ms.id
+		ORDER BY period, ms.sort_order
+	`, since.Format("2006-01-02"), end.Format("2006-01-02"))
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
 
 	var data []map[string]interface{}
+	points := make(map[string]map[string]interface{})
 	for rows.Next() {
-		var label string
+		var label, session string
 		var total float64
-		if err := rows.Scan(&label, &total); err != nil {
+		if err := rows.Scan(&label, &session, &total); err != nil {
 			_ = err // Log or continue
+			continue
 		}
-		data = append(data, map[string]interface{}{"date": label, "liters": total})
+		point, ok := points[label]
+		if !ok {
+			point = map[string]interface{}{"date": label, "liters": 0.0, "sessions": map[string]float64{}}
+			points[label] = point
+			data = append(data, point)
+		}
+		point["liters"] = point["liters"].(float64) + total
+		point["sessions"].(map[string]float64)[session] = total
 	}
 
-	return s.fillChartGaps(data, timeframe), nil
+	return s.fillChartGaps(data, timeframe, end), nil
 }
 
-// fillChartGaps ensures that the returned data has an entry for every day/month in the range
-func (s *DashboardService) fillChartGaps(data []map[string]interface{}, timeframe string) []map[string]interface{} {
-	now := time.Now().Local()
+// fillChartGaps ensures that the returned data has an entry for every day/month in the range ending on end
+func (s *DashboardService) fillChartGaps(data []map[string]interface{}, timeframe string, end time.Time) []map[string]interface{} {
 	var result []map[string]interface{}
-	dataMap := make(map[string]float64)
+	dataMap := make(map[string]map[string]interface{})
 	for _, d := range data {
-		dataMap[d["date"].(string)] = d["liters"].(float64)
+		dataMap[d["date"].(string)] = d
+	}
+	point := func(key string) map[string]interface{} {
+		if p, ok := dataMap[key]; ok {
+			return p
+		}
+		return map[string]interface{}{"date": key, "liters": 0.0, "sessions": map[string]float64{}}
 	}
 
 	switch timeframe {
 	case "year":
 		// Show last 12 months
+		// Step from the first of the month so the 31st does not skip a short month
+		first := time.Date(end.Year(), end.Month(), 1, 0, 0, 0, 0, end.Location())
 		for i := 11; i >= 0; i-- {
-			d := now.AddDate(0, -i, 0)
-			period := d.Format("2006-01")
-			val := 0.0
-			if v, ok := dataMap[period]; ok {
-				val = v
-			}
-			result = append(result, map[string]interface{}{"date": period, "liters": val})
+			d := first.AddDate(0, -i, 0)
+			result = append(result, point(d.Format("2006-01")))
 		}
 	case "month":
 		// Show last 30 days
 		for i := 29; i >= 0; i-- {
-			d := now.AddDate(0, 0, -i)
-			date := d.Format("2006-01-02")
-			val := 0.0
-			if v, ok := dataMap[date]; ok {
-				val = v
-			}
-			result = append(result, map[string]interface{}{"date": date, "liters": val})
+			d := end.AddDate(0, 0, -i)
+			result = append(result, point(d.Format("2006-01-02")))
 		}
 	default: // week
 		// Show last 7 days
 		for i := 6; i >= 0; i-- {
-			d := now.AddDate(0, 0, -i)
-			date := d.Format("2006-01-02")
-			val := 0.0
-			if v, ok := dataMap[date]; ok {
-				val = v
-			}
-			result = append(result, map[string]interface{}{"date": date, "liters": val})
+			d := end.AddDate(0, 0, -i)
+			result = append(result, point(d.Format("2006-01-02")))
 		}
 	}
 
@@ -2,6 +2,7 @@ package main
 
 import (
 	"database/sql"
+	"fmt"
 	"log"
 	"os"
 	"path/filepath"
@@ -43,6 +44,10 @@ func InitDatabase() error {
 		log.Printf("Warning: Could not insert default feed types: %v", err)
 	}
 
+	if err := migrateMilkSessions(); err != nil {
+		log.Printf("Warning: Could not migrate milking sessions: %v", err)
+	}
+
 	return nil
 }
 
@@ -67,13 +72,29 @@ func createTables() error {
 			id INTEGER PRIMARY KEY AUTOINCREMENT,
 			animal_id INTEGER NOT NULL,
 			date TEXT NOT NULL,
-			morning_liters REAL DEFAULT 0,
-			evening_liters REAL DEFAULT 0,
+			morning_liters REAL DEFAULT 0, -- legacy, copied to milk_record_sessions
+			evening_liters REAL DEFAULT 0, -- legacy, copied to milk_record_sessions
 			total_liters REAL DEFAULT 0,
 			notes TEXT,
 			created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
 			FOREIGN KEY (animal_id) REFERENCES animals(id)
 		)`,
+		`CREATE TABLE IF NOT EXISTS milking_sessions (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			name TEXT NOT NULL UNIQUE,
+			sort_order INTEGER DEFAULT 0,
+			active INTEGER DEFAULT 1,
+			created_at DATETIME DEFAULT CURRENT_TIMESTAMP
+		)`,
+		`CREATE TABLE IF NOT EXISTS milk_record_sessions (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			milk_record_id INTEGER NOT NULL,
+			session_id INTEGER NOT NULL,
+			liters REAL DEFAULT 0,
+			UNIQUE (milk_record_id, session_id),
+			FOREIGN KEY (milk_record_id) REFERENCES milk_records(id),
+			FOREIGN KEY (session_id) REFERENCES milking_sessions(id)
+		)`,
 		`CREATE TABLE IF NOT EXISTS milk_sales (
 			id INTEGER PRIMARY KEY AUTOINCREMENT,
 			date TEXT NOT NULL,
@@ -199,6 +220,205 @@ func createTables() error {
 			notes TEXT,
 			created_at DATETIME DEFAULT CURRENT_TIMESTAMP
 		)`,
+		`CREATE TABLE IF NOT EXISTS animal_disposals (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			animal_id INTEGER NOT NULL UNIQUE,
+			disposal_type TEXT NOT NULL,
+			date TEXT NOT NULL,
+			buyer_name TEXT,
+			price REAL DEFAULT 0,
+			cause_of_death TEXT,
+			cull_reason TEXT,
+			transaction_id INTEGER,
+			notes TEXT,
+			created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+			FOREIGN KEY (animal_id) REFERENCES animals(id),
+			FOREIGN KEY (transaction_id) REFERENCES transactions(id)
+		)`,
+		`CREATE TABLE IF NOT EXISTS weight_records (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			animal_id INTEGER NOT NULL,
+			date TEXT NOT NULL,
+			weight_kg REAL NOT NULL,
+			method TEXT DEFAULT 'weigh_band',
+			notes TEXT,
+			created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+			FOREIGN KEY (animal_id) REFERENCES animals(id)
+		)`,
+		`CREATE TABLE IF NOT EXISTS milk_quality_tests (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			animal_id INTEGER,
+			date TEXT NOT NULL,
+			butterfat_pct REAL DEFAULT 0,
+			protein_pct REAL DEFAULT 0,
+			scc INTEGER DEFAULT 0,
+			lactometer REAL DEFAULT 0,
+			notes TEXT,
+			created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+			FOREIGN KEY (animal_id) REFERENCES animals(id)
+		)`,
+		`CREATE TABLE IF NOT EXISTS animal_groups (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			name TEXT NOT NULL UNIQUE COLLATE NOCASE,
+			kind TEXT DEFAULT 'group',
+			description TEXT,
+			active INTEGER DEFAULT 1,
+			created_at DATETIME DEFAULT CURRENT_TIMESTAMP
+		)`,
+		`CREATE TABLE IF NOT EXISTS animal_movements (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			animal_id INTEGER NOT NULL,
+			date TEXT NOT NULL,
+			from_group_id INTEGER,
+			to_group_id INTEGER,
+			reason TEXT,
+			created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+			FOREIGN KEY (animal_id) REFERENCES animals(id),
+			FOREIGN KEY (from_group_id) REFERENCES animal_groups(id),
+			FOREIGN KEY (to_group_id) REFERENCES animal_groups(id)
+		)`,
+		`CREATE TABLE IF NOT EXISTS flocks (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			name TEXT NOT NULL,
+			species TEXT DEFAULT 'poultry',
+			purpose TEXT DEFAULT 'layers',
+			breed TEXT,
+			placed_date TEXT NOT NULL,
+			initial_count INTEGER NOT NULL,
+			status TEXT DEFAULT 'active',
+			notes TEXT,
+			created_at DATETIME DEFAULT CURRENT_TIMESTAMP
+		)`,
+		`CREATE TABLE IF NOT EXISTS flock_records (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			flock_id INTEGER NOT NULL,
+			date TEXT NOT NULL,
+			eggs INTEGER DEFAULT 0,
+			broken_eggs INTEGER DEFAULT 0,
+			mortality INTEGER DEFAULT 0,
+			culled INTEGER DEFAULT 0,
+			sold INTEGER DEFAULT 0,
+			feed_kg REAL DEFAULT 0,
+			notes TEXT,
+			created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+			FOREIGN KEY (flock_id) REFERENCES flocks(id),
+			UNIQUE(flock_id, date)
+		)`,
+		`CREATE TABLE IF NOT EXISTS animal_type_changes (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			animal_id INTEGER NOT NULL,
+			date TEXT NOT NULL,
+			from_type TEXT NOT NULL,
+			to_type TEXT NOT NULL,
+			reason TEXT,
+			automatic INTEGER DEFAULT 0,
+			created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+			FOREIGN KEY (animal_id) REFERENCES animals(id)
+		)`,
+		`CREATE TABLE IF NOT EXISTS buyers (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			name TEXT NOT NULL UNIQUE,
+			phone TEXT,
+			email TEXT,
+			address TEXT,
+			agreed_price REAL DEFAULT 0,
+			active INTEGER DEFAULT 1,
+			notes TEXT,
+			created_at DATETIME DEFAULT CURRENT_TIMESTAMP
+		)`,
+		`CREATE TABLE IF NOT EXISTS buyer_payments (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			buyer_id INTEGER NOT NULL,
+			date TEXT NOT NULL,
+			amount REAL NOT NULL,
+			method TEXT DEFAULT 'cash',
+			reference TEXT,
+			milk_sale_id INTEGER,
+			notes TEXT,
+			created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+			FOREIGN KEY (buyer_id) REFERENCES buyers(id),
+			FOREIGN KEY (milk_sale_id) REFERENCES milk_sales(id)
+		)`,
+		`CREATE TABLE IF NOT EXISTS payment_allocations (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			payment_id INTEGER NOT NULL,
+			milk_sale_id INTEGER NOT NULL,
+			amount REAL NOT NULL,
+			FOREIGN KEY (payment_id) REFERENCES buyer_payments(id),
+			FOREIGN KEY (milk_sale_id) REFERENCES milk_sales(id)
+		)`,
+		`CREATE TABLE IF NOT EXISTS cooperatives (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			name TEXT NOT NULL UNIQUE,
+			member_number TEXT,
+			price_per_liter REAL DEFAULT 0,
+			active INTEGER DEFAULT 1,
+			notes TEXT,
+			created_at DATETIME DEFAULT CURRENT_TIMESTAMP
+		)`,
+		`CREATE TABLE IF NOT EXISTS coop_deliveries (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			cooperative_id INTEGER NOT NULL,
+			date TEXT NOT NULL,
+			liters REAL NOT NULL,
+			coop_liters REAL,
+			delivery_note TEXT,
+			notes TEXT,
+			created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+			FOREIGN KEY (cooperative_id) REFERENCES cooperatives(id)
+		)`,
+		`CREATE TABLE IF NOT EXISTS coop_payouts (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			cooperative_id INTEGER NOT NULL,
+			month TEXT NOT NULL,
+			payout_date TEXT NOT NULL,
+			liters REAL NOT NULL,
+			price_per_liter REAL NOT NULL,
+			gross_amount REAL NOT NULL,
+			reference TEXT,
+			transaction_id INTEGER,
+			notes TEXT,
+			created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+			UNIQUE (cooperative_id, month),
+			FOREIGN KEY (cooperative_id) REFERENCES cooperatives(id),
+			FOREIGN KEY (transaction_id) REFERENCES transactions(id)
+		)`,
+		`CREATE TABLE IF NOT EXISTS coop_deductions (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			payout_id INTEGER NOT NULL,
+			category TEXT NOT NULL,
+			description TEXT,
+			amount REAL NOT NULL,
+			FOREIGN KEY (payout_id) REFERENCES coop_payouts(id)
+		)`,
+		`CREATE TABLE IF NOT EXISTS milk_usages (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			date TEXT NOT NULL,
+			use_type TEXT NOT NULL,
+			liters REAL NOT NULL,
+			notes TEXT,
+			created_at DATETIME DEFAULT CURRENT_TIMESTAMP
+		)`,
+		`CREATE TABLE IF NOT EXISTS statement_lines (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			source TEXT NOT NULL,
+			reference TEXT NOT NULL,
+			date TEXT NOT NULL,
+			description TEXT,
+			counterparty TEXT,
+			amount REAL NOT NULL,
+			direction TEXT NOT NULL,
+			status TEXT DEFAULT 'review',
+			transaction_id INTEGER REFERENCES transactions(id),
+			milk_sale_id INTEGER REFERENCES milk_sales(id),
+			created_at DATETIME DEFAULT CURRENT_TIMESTAMP
+		)`,
+		`CREATE TABLE IF NOT EXISTS statement_splits (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			line_id INTEGER NOT NULL REFERENCES statement_lines(id),
+			transaction_id INTEGER NOT NULL REFERENCES transactions(id),
+			amount REAL NOT NULL
+		)`,
 		`CREATE TABLE IF NOT EXISTS settings (
 			key TEXT PRIMARY KEY,
 			value TEXT NOT NULL,
@@ -220,6 +440,7 @@ func createTables() error {
 		`CREATE INDEX IF NOT EXISTS idx_milk_records_date ON milk_records(date)`,
 		`CREATE INDEX IF NOT EXISTS idx_milk_records_animal ON milk_records(animal_id)`,
 		`CREATE UNIQUE INDEX IF NOT EXISTS idx_milk_records_animal_date ON milk_records(animal_id, date)`,
+		`CREATE INDEX IF NOT EXISTS idx_milk_record_sessions_session ON milk_record_sessions(session_id)`,
 		`CREATE INDEX IF NOT EXISTS idx_milk_sales_date ON milk_sales(date)`,
 		`CREATE INDEX IF NOT EXISTS idx_crop_records_field ON crop_records(field_id)`,
 		`CREATE INDEX IF NOT EXISTS idx_vet_records_animal ON vet_records(animal_id)`,
@@ -230,6 +451,23 @@ func createTables() error {
 		`CREATE INDEX IF NOT EXISTS idx_breeding_female ON breeding_records(female_id)`,
 		`CREATE INDEX IF NOT EXISTS idx_breeding_status ON breeding_records(pregnancy_status)`,
 		`CREATE INDEX IF NOT EXISTS idx_photos_entity ON photos(entity_type, entity_id)`,
+		`CREATE INDEX IF NOT EXISTS idx_animal_disposals_date ON animal_disposals(date)`,
+		`CREATE INDEX IF NOT EXISTS idx_weight_records_animal ON weight_records(animal_id, date)`,
+		`CREATE INDEX IF NOT EXISTS idx_milk_quality_animal ON milk_quality_tests(animal_id, date)`,
+		`CREATE INDEX IF NOT EXISTS idx_animals_group ON animals(group_id)`,
+		`CREATE INDEX IF NOT EXISTS idx_animal_movements_animal ON animal_movements(animal_id, date)`,
+		`CREATE INDEX IF NOT EXISTS idx_feed_records_group ON feed_records(group_id)`,
+		`CREATE INDEX IF NOT EXISTS idx_flock_records_date ON flock_records(date)`,
+		`CREATE INDEX IF NOT EXISTS idx_animal_type_changes_animal ON animal_type_changes(animal_id, date)`,
+		`CREATE INDEX IF NOT EXISTS idx_milk_sales_buyer ON milk_sales(buyer_id, date)`,
+		`CREATE INDEX IF NOT EXISTS idx_buyer_payments_buyer ON buyer_payments(buyer_id, date)`,
+		`CREATE INDEX IF NOT EXISTS idx_payment_allocations_sale ON payment_allocations(milk_sale_id)`,
+		`CREATE INDEX IF NOT EXISTS idx_coop_deliveries_date ON coop_deliveries(cooperative_id, date)`,
+		`CREATE INDEX IF NOT EXISTS idx_coop_deductions_payout ON coop_deductions(payout_id)`,
+		`CREATE INDEX IF NOT EXISTS idx_milk_usages_date ON milk_usages(date)`,
+		`CREATE UNIQUE INDEX IF NOT EXISTS idx_statement_lines_reference ON statement_lines(source, reference)`,
+		`CREATE INDEX IF NOT EXISTS idx_statement_lines_status ON statement_lines(status)`,
+		`CREATE INDEX IF NOT EXISTS idx_statement_splits_line ON statement_splits(line_id)`,
 	}
 
 	for _, idx := range indexes {
@@ -247,6 +485,26 @@ func runMigrations() {
 		`ALTER TABLE animals ADD COLUMN mother_id INTEGER REFERENCES animals(id)`,
 		`ALTER TABLE animals ADD COLUMN father_id INTEGER REFERENCES animals(id)`,
 		`ALTER TABLE feed_records ADD COLUMN unit TEXT DEFAULT 'kg'`,
+		`ALTER TABLE transactions ADD COLUMN reconciliation_status TEXT DEFAULT 'uncleared'`,
+		`ALTER TABLE transactions ADD COLUMN cleared_date TEXT`,
+		`ALTER TABLE animals ADD COLUMN acquisition_type TEXT DEFAULT 'born'`,
+		`ALTER TABLE animals ADD COLUMN seller TEXT`,
+		`ALTER TABLE animals ADD COLUMN purchase_date TEXT`,
+		`ALTER TABLE animals ADD COLUMN purchase_price REAL DEFAULT 0`,
+		`ALTER TABLE animals ADD COLUMN transport_cost REAL DEFAULT 0`,
+		`ALTER TABLE animals ADD COLUMN quarantine_days INTEGER DEFAULT 0`,
+		`ALTER TABLE animals ADD COLUMN quarantine_end_date TEXT`,
+		`ALTER TABLE milk_sales ADD COLUMN butterfat_pct REAL DEFAULT 0`,
+		`ALTER TABLE milk_sales ADD COLUMN protein_pct REAL DEFAULT 0`,
+		`ALTER TABLE milk_sales ADD COLUMN scc INTEGER DEFAULT 0`,
+		`ALTER TABLE milk_sales ADD COLUMN quality_adjustment REAL DEFAULT 0`,
+		`ALTER TABLE animals ADD COLUMN group_id INTEGER REFERENCES animal_groups(id)`,
+		`ALTER TABLE feed_records ADD COLUMN group_id INTEGER REFERENCES animal_groups(id)`,
+		`ALTER TABLE animals ADD COLUMN species TEXT DEFAULT 'cattle'`,
+		`ALTER TABLE vet_records ADD COLUMN milk_withdrawal_days INTEGER DEFAULT 0`,
+		`ALTER TABLE vet_records ADD COLUMN meat_withdrawal_days INTEGER DEFAULT 0`,
+		`ALTER TABLE milk_records ADD COLUMN discarded INTEGER DEFAULT 0`,
+		`ALTER TABLE milk_sales ADD COLUMN buyer_id INTEGER REFERENCES buyers(id)`,
 	}
 
 	for _, m := range migrations {
@@ -298,6 +556,110 @@ func insertDefaultFeedTypes() error {
 	return nil
 }
 
+// milkSessionsMigratedSetting marks the move of the legacy milk columns into
+// milk_record_sessions as done. Datasets exported before milking sessions
+// existed do not carry it, so importing one migrates its milk again.
+const milkSessionsMigratedSetting = "milk_sessions_migrated"
+
+// migrateMilkSessions creates the default Morning and Evening sessions and
+// copies milk recorded in the old morning_liters and evening_liters columns
+// into the sessions of the same name, creating a session that has been renamed
+// or removed since. The old columns are left as they are; the settings flag
+// keeps the copy from running twice.
+func migrateMilkSessions() error {
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	if err := migrateMilkSessionsTx(tx); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// migrateMilkSessionsTx runs the milk session migration inside tx, so a
+// dataset import and its migration succeed or fail together
+func migrateMilkSessionsTx(tx *sql.Tx) error {
+	var done string
+	err := tx.QueryRow(`SELECT value FROM settings WHERE key = ?`, milkSessionsMigratedSetting).Scan(&done)
+	if err != nil && err != sql.ErrNoRows {
+		return err
+	}
+	if done == "done" {
+		return nil
+	}
+
+	var count int
+	if err := tx.QueryRow(`SELECT COUNT(*) FROM milking_sessions`).Scan(&count); err != nil {
+		return err
+	}
+	if count == 0 {
+		for i, name := range []string{"Morning", "Evening"} {
+			if _, err := tx.Exec(`INSERT INTO milking_sessions (name, sort_order, active) VALUES (?, ?, 1)`, name, i+1); err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, legacy := range []struct{ column, session string }{
+		{"morning_liters", "Morning"},
+		{"evening_liters", "Evening"},
+	} {
+		var pending int
+		if err := tx.QueryRow(fmt.Sprintf(`SELECT COUNT(*) FROM milk_records WHERE %s > 0`, legacy.column)).Scan(&pending); err != nil {
+			return err
+		}
+		if pending == 0 {
+			continue
+		}
+
+		var sessionID int64
+		err := tx.QueryRow(`SELECT id FROM milking_sessions WHERE name = ? COLLATE NOCASE ORDER BY id LIMIT 1`, legacy.session).Scan(&sessionID)
+		if err == sql.ErrNoRows {
+			result, err := tx.Exec(`
+				INSERT INTO milking_sessions (name, sort_order, active)
+				VALUES (?, (SELECT COALESCE(MAX(sort_order), 0) + 1 FROM milking_sessions), 1)
+			`, legacy.session)
+			if err != nil {
+				return err
+			}
+			if sessionID, err = result.LastInsertId(); err != nil {
+				return err
+			}
+		} else if err != nil {
+			return err
+		}
+
+		if _, err := tx.Exec(fmt.Sprintf(`
+			INSERT INTO milk_record_sessions (milk_record_id, session_id, liters)
+			SELECT id, ?, %[1]s FROM milk_records WHERE %[1]s > 0
+			ON CONFLICT (milk_record_id, session_id) DO NOTHING
+		`, legacy.column), sessionID); err != nil {
+			return err
+		}
+	}
+
+	_, err = tx.Exec(`INSERT OR REPLACE INTO settings (key, value, updated_at) VALUES (?, 'done', CURRENT_TIMESTAMP)`, milkSessionsMigratedSetting)
+	return err
+}
+
+// getSetting returns a value from the settings table, or def if it is not set
+func getSetting(key, def string) string {
+	var value string
+	if err := db.QueryRow(`SELECT value FROM settings WHERE key = ?`, key).Scan(&value); err != nil {
+		return def
+	}
+	return value
+}
+
+// setSetting stores a value in the settings table
+func setSetting(key, value string) error {
+	_, err := db.Exec(`INSERT OR REPLACE INTO settings (key, value, updated_at) VALUES (?, ?, CURRENT_TIMESTAMP)`, key, value)
+	return err
+}
+
 // GetDB returns the database connection
 func GetDB() *sql.DB {
 	return db
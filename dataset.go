@@ -0,0 +1,295 @@
+package main
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// The farm dataset is a versioned JSON interchange format holding every row of
+// every farm table. It is written by WriteFarmDataset and read back by
+// ReadFarmDataset/ImportFarmDataset, and is documented in docs/dataset-format.md.
+//
+// Rows are stored as column-name to value objects so that exports stay
+// readable by other tools and so that adding a column does not require a new
+// format version. Only removing or redefining columns bumps the version.
+const (
+	datasetFormat        = "farmland-dataset"
+	datasetFormatVersion = 1
+)
+
+// datasetTables lists the exported tables in dependency order: referenced
+// tables come before the tables that point at them, so imports can be
+// replayed front to back and deletes back to front.
+var datasetTables = []string{
+	"settings",
+	"animal_groups",
+	"animals",
+	"fields",
+	"inventory_items",
+	"feed_types",
+	"milking_sessions",
+	"buyers",
+	"milk_records",
+	"milk_record_sessions",
+	"milk_sales",
+	"milk_usages",
+	"crop_records",
+	"feed_records",
+	"vet_records",
+	"breeding_records",
+	"transactions",
+	"photos",
+	"animal_disposals",
+	"weight_records",
+	"milk_quality_tests",
+	"animal_movements",
+	"flocks",
+	"flock_records",
+	"animal_type_changes",
+	"buyer_payments",
+	"payment_allocations",
+	"cooperatives",
+	"coop_deliveries",
+	"coop_payouts",
+	"coop_deductions",
+	"statement_lines",
+	"statement_splits",
+}
+
+// FarmDataset is the top-level document of the JSON interchange format
+type FarmDataset struct {
+	Format        string                              `json:"format"`
+	FormatVersion int                                 `json:"formatVersion"`
+	AppVersion    string                              `json:"appVersion"`
+	ExportedAt    string                              `json:"exportedAt"` // RFC3339
+	Tables        map[string][]map[string]interface{} `json:"tables"`
+}
+
+// DatasetImportResult summarises an import
+type DatasetImportResult struct {
+	Tables  map[string]int `json:"tables"`  // rows imported per table
+	Records int            `json:"records"` // total rows imported
+	Skipped []string       `json:"skipped"` // tables in the file this version does not know
+}
+
+// ExportFarmDataset reads every dataset table from the database
+func ExportFarmDataset() (*FarmDataset, error) {
+	ds := &FarmDataset{
+		Format:        datasetFormat,
+		FormatVersion: datasetFormatVersion,
+		AppVersion:    Version,
+		ExportedAt:    time.Now().Format(time.RFC3339),
+		Tables:        make(map[string][]map[string]interface{}),
+	}
+
+	for _, table := range datasetTables {
+		rows, err := db.Query(fmt.Sprintf(`SELECT * FROM %s ORDER BY rowid`, table))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", table, err)
+		}
+
+		columns, err := rows.Columns()
+		if err != nil {
+			rows.Close()
+			return nil, err
+		}
+
+		records := []map[string]interface{}{}
+		for rows.Next() {
+			values := make([]interface{}, len(columns))
+			ptrs := make([]interface{}, len(columns))
+			for i := range values {
+				ptrs[i] = &values[i]
+			}
+			if err := rows.Scan(ptrs...); err != nil {
+				rows.Close()
+				return nil, fmt.Errorf("failed to read %s: %w", table, err)
+			}
+
+			record := make(map[string]interface{}, len(columns))
+			for i, col := range columns {
+				record[col] = datasetValue(values[i])
+			}
+			records = append(records, record)
+		}
+		rows.Close()
+		if err := rows.Err(); err != nil {
+			return nil, err
+		}
+		ds.Tables[table] = records
+	}
+
+	return ds, nil
+}
+
+// WriteFarmDataset writes the full farm dataset as indented JSON
+func WriteFarmDataset(w io.Writer) (int, error) {
+	ds, err := ExportFarmDataset()
+	if err != nil {
+		return 0, err
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(ds); err != nil {
+		return 0, err
+	}
+
+	count := 0
+	for _, rows := range ds.Tables {
+		count += len(rows)
+	}
+	return count, nil
+}
+
+// ReadFarmDataset decodes and validates a dataset document
+func ReadFarmDataset(r io.Reader) (*FarmDataset, error) {
+	dec := json.NewDecoder(r)
+	dec.UseNumber()
+
+	var ds FarmDataset
+	if err := dec.Decode(&ds); err != nil {
+		return nil, fmt.Errorf("invalid dataset file: %w", err)
+	}
+	if ds.Format != datasetFormat {
+		return nil, fmt.Errorf("not a farmland dataset (format %q)", ds.Format)
+	}
+	if ds.FormatVersion < 1 || ds.FormatVersion > datasetFormatVersion {
+		return nil, fmt.Errorf("unsupported dataset version %d (this version reads up to %d)", ds.FormatVersion, datasetFormatVersion)
+	}
+	return &ds, nil
+}
+
+// ImportFarmDataset replaces the contents of every dataset table with the
+// rows in ds. Columns missing from the file keep their database defaults and
+// columns unknown to this version are ignored. The import runs in a single
+// transaction, so a failure leaves the existing data untouched.
+func ImportFarmDataset(ds *FarmDataset) (*DatasetImportResult, error) {
+	result := &DatasetImportResult{Tables: make(map[string]int), Skipped: []string{}}
+
+	known := make(map[string]bool)
+	for _, table := range datasetTables {
+		known[table] = true
+	}
+	for table := range ds.Tables {
+		if !known[table] {
+			result.Skipped = append(result.Skipped, table)
+		}
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	for i := len(datasetTables) - 1; i >= 0; i-- {
+		if _, err := tx.Exec(fmt.Sprintf(`DELETE FROM %s`, datasetTables[i])); err != nil {
+			return nil, fmt.Errorf("failed to clear %s: %w", datasetTables[i], err)
+		}
+	}
+
+	for _, table := range datasetTables {
+		rows := ds.Tables[table]
+		if len(rows) == 0 {
+			continue
+		}
+
+		columns, err := tableColumns(tx, table)
+		if err != nil {
+			return nil, err
+		}
+
+		for n, row := range rows {
+			var cols, marks []string
+			var args []interface{}
+			for _, col := range columns {
+				value, ok := row[col]
+				if !ok {
+					continue
+				}
+				cols = append(cols, col)
+				marks = append(marks, "?")
+				args = append(args, datasetArg(value))
+			}
+			if len(cols) == 0 {
+				continue
+			}
+			query := fmt.Sprintf(`INSERT INTO %s (%s) VALUES (%s)`, table, strings.Join(cols, ", "), strings.Join(marks, ", "))
+			if _, err := tx.Exec(query, args...); err != nil {
+				return nil, fmt.Errorf("failed to import %s row %d: %w", table, n+1, err)
+			}
+			result.Tables[table]++
+			result.Records++
+		}
+	}
+
+	// Datasets from before milking sessions carry milk in the old columns
+	if err := migrateMilkSessionsTx(tx); err != nil {
+		return nil, fmt.Errorf("failed to migrate milk sessions: %w", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// tableColumns returns the column names of a table in the current schema
+func tableColumns(tx *sql.Tx, table string) ([]string, error) {
+	rows, err := tx.Query(fmt.Sprintf(`SELECT name FROM pragma_table_info('%s')`, table))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var columns []string
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, err
+		}
+		columns = append(columns, name)
+	}
+	return columns, rows.Err()
+}
+
+// datasetValue converts a scanned database value into its JSON representation
+func datasetValue(v interface{}) interface{} {
+	switch val := v.(type) {
+	case []byte:
+		return string(val)
+	case time.Time:
+		return val.UTC().Format("2006-01-02 15:04:05")
+	default:
+		return val
+	}
+}
+
+// datasetArg converts a decoded JSON value into a database argument
+func datasetArg(v interface{}) interface{} {
+	switch val := v.(type) {
+	case json.Number:
+		if i, err := val.Int64(); err == nil {
+			return i
+		}
+		f, _ := val.Float64()
+		return f
+	case bool:
+		if val {
+			return 1
+		}
+		return 0
+	case map[string]interface{}, []interface{}:
+		data, _ := json.Marshal(val)
+		return string(data)
+	default:
+		return val
+	}
+}
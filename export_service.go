@@ -1,11 +1,16 @@
 package main
 
 import (
+	"archive/zip"
 	"context"
+	"database/sql"
 	"encoding/csv"
+	"encoding/json"
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/wailsapp/wails/v2/pkg/runtime"
@@ -13,12 +18,21 @@ import (
 
 // ExportService handles data export operations
 type ExportService struct {
-	ctx context.Context
+	ctx       context.Context
+	livestock *LivestockService
+	crops     *CropsService
+	inventory *InventoryService
+	feed      *FeedService
+	health    *HealthService
+	financial *FinancialService
+	breeding  *BreedingService
+	photo     *PhotoService
+	dashboard *DashboardService
 }
 
 // NewExportService creates a new ExportService
-func NewExportService() *ExportService {
-	return &ExportService{}
+func NewExportService(l *LivestockService, c *CropsService, i *InventoryService, fe *FeedService, h *HealthService, f *FinancialService, b *BreedingService, p *PhotoService, d *DashboardService) *ExportService {
+	return &ExportService{livestock: l, crops: c, inventory: i, feed: fe, health: h, financial: f, breeding: b, photo: p, dashboard: d}
 }
 
 // SetContext sets the Wails runtime context
@@ -55,28 +69,14 @@ func (s *ExportService) ExportMilkRecordsCSV(startDate, endDate string) (*Export
 		return nil, nil
 	}
 
-	query := `
-		SELECT mr.id, a.name, mr.date, mr.morning_liters, mr.evening_liters, mr.total_liters, mr.notes
-		FROM milk_records mr
-		JOIN animals a ON mr.animal_id = a.id
-		WHERE 1=1
-	`
-	args := []interface{}{}
-	if startDate != "" {
-		query += " AND mr.date >= ?"
-		args = append(args, startDate)
-	}
-	if endDate != "" {
-		query += " AND mr.date <= ?"
-		args = append(args, endDate)
+	records, err := s.livestock.GetMilkRecords(0, startDate, endDate)
+	if err != nil {
+		return nil, err
 	}
-	query += " ORDER BY mr.date DESC, a.name"
-
-	rows, err := db.Query(query, args...)
+	sessions, err := s.milkSessionColumns(records)
 	if err != nil {
 		return nil, err
 	}
-	defer rows.Close()
 
 	file, err := os.Create(savePath)
 	if err != nil {
@@ -87,28 +87,22 @@ func (s *ExportService) ExportMilkRecordsCSV(startDate, endDate string) (*Export
 	writer := csv.NewWriter(file)
 	defer writer.Flush()
 
-	header := []string{"ID", "Animal", "Date", "Morning (L)", "Evening (L)", "Total (L)", "Notes"}
+	header := []string{"ID", "Animal", "Date"}
+	for _, session := range sessions {
+		header = append(header, session.Name+" (L)")
+	}
+	header = append(header, "Total (L)", "Notes")
 	if err := writer.Write(header); err != nil {
 		return nil, err
 	}
 
 	count := 0
-	for rows.Next() {
-		var id int64
-		var animalName, date, notes interface{}
-		var morning, evening, total float64
-		if err := rows.Scan(&id, &animalName, &date, &morning, &evening, &total, &notes); err != nil {
-			continue
-		}
-		record := []string{
-			fmt.Sprintf("%d", id),
-			toString(animalName),
-			toString(date),
-			fmt.Sprintf("%.2f", morning),
-			fmt.Sprintf("%.2f", evening),
-			fmt.Sprintf("%.2f", total),
-			toString(notes),
+	for _, r := range records {
+		record := []string{fmt.Sprintf("%d", r.ID), r.AnimalName, r.Date}
+		for _, session := range sessions {
+			record = append(record, fmt.Sprintf("%.2f", sessionLiters(r, session.ID)))
 		}
+		record = append(record, fmt.Sprintf("%.2f", r.TotalLiters), r.Notes)
 		if err := writer.Write(record); err != nil {
 			continue
 		}
@@ -118,6 +112,39 @@ func (s *ExportService) ExportMilkRecordsCSV(startDate, endDate string) (*Export
 	return &ExportResult{Path: savePath, Records: count}, nil
 }
 
+// milkSessionColumns returns the sessions to show as milk columns: the active
+// sessions and any inactive session that has milk among the records
+func (s *ExportService) milkSessionColumns(records []MilkRecord) ([]MilkingSession, error) {
+	all, err := s.livestock.GetMilkingSessions()
+	if err != nil {
+		return nil, err
+	}
+	used := make(map[int64]bool)
+	for _, r := range records {
+		for _, y := range r.Sessions {
+			used[y.SessionID] = true
+		}
+	}
+
+	var sessions []MilkingSession
+	for _, session := range all {
+		if session.Active || used[session.ID] {
+			sessions = append(sessions, session)
+		}
+	}
+	return sessions, nil
+}
+
+// sessionLiters returns the liters of one session of a milk record
+func sessionLiters(r MilkRecord, sessionID int64) float64 {
+	for _, y := range r.Sessions {
+		if y.SessionID == sessionID {
+			return y.Liters
+		}
+	}
+	return 0
+}
+
 // ExportFinancesCSV exports financial transactions to CSV
 func (s *ExportService) ExportFinancesCSV(startDate, endDate string) (*ExportResult, error) {
 	if s.ctx == nil {
@@ -227,6 +254,7 @@ func (s *ExportService) ExportAnimalsCSV() (*ExportResult, error) {
 		SELECT 
 			a.id, a.tag_number, a.name, a.type, a.breed, a.date_of_birth, a.gender,
 			m.name as mother_name, f.name as father_name, a.status, a.notes, a.created_at,
+			a.acquisition_type, a.seller, a.purchase_date, COALESCE(a.purchase_price, 0),
 			(SELECT COUNT(*) FROM milk_records WHERE animal_id = a.id) as milk_count,
 			(SELECT COALESCE(SUM(total_liters), 0) FROM milk_records WHERE animal_id = a.id) as milk_total,
 			(SELECT MAX(date) FROM vet_records WHERE animal_id = a.id) as last_vet,
@@ -254,7 +282,7 @@ func (s *ExportService) ExportAnimalsCSV() (*ExportResult, error) {
 	// Write rich header
 	header := []string{
 		"System ID", "Tag Number", "Name", "Type", "Breed", "Gender", "Date of Birth", "Status",
-		"Mother", "Father", "Milk Records Count", "Total Production (Liters)",
+		"Mother", "Father", "Acquisition", "Seller", "Purchase Date", "Purchase Price (KES)", "Milk Records Count", "Total Production (Liters)",
 		"Last Vet Visit", "Total Vet Cost (KES)", "Notes", "Registry Date",
 	}
 	if err := writer.Write(header); err != nil {
@@ -265,6 +293,8 @@ func (s *ExportService) ExportAnimalsCSV() (*ExportResult, error) {
 	for rows.Next() {
 		var id int64
 		var tagNumber, name, animalType, breed, dob, gender, motherName, fatherName, status, notes, createdAt interface{}
+		var acquisitionType, seller, purchaseDate interface{}
+		var purchasePrice float64
 		var milkCount int
 		var milkTotal float64
 		var lastVet interface{}
@@ -273,6 +303,7 @@ func (s *ExportService) ExportAnimalsCSV() (*ExportResult, error) {
 		err := rows.Scan(
 			&id, &tagNumber, &name, &animalType, &breed, &dob, &gender,
 			&motherName, &fatherName, &status, &notes, &createdAt,
+			&acquisitionType, &seller, &purchaseDate, &purchasePrice,
 			&milkCount, &milkTotal, &lastVet, &vetTotal,
 		)
 		if err != nil {
@@ -290,6 +321,10 @@ func (s *ExportService) ExportAnimalsCSV() (*ExportResult, error) {
 			toString(status),
 			toString(motherName),
 			toString(fatherName),
+			firstNonEmpty(toString(acquisitionType), "born"),
+			toString(seller),
+			toString(purchaseDate),
+			fmt.Sprintf("%.2f", purchasePrice),
 			fmt.Sprintf("%d", milkCount),
 			fmt.Sprintf("%.2f", milkTotal),
 			toString(lastVet),
@@ -306,10 +341,1247 @@ func (s *ExportService) ExportAnimalsCSV() (*ExportResult, error) {
 	return &ExportResult{Path: savePath, Records: count}, nil
 }
 
-// GetExportDirectory returns the default export directory
-func (s *ExportService) GetExportDirectory() string {
-	home, _ := os.UserHomeDir()
-	return filepath.Join(home, "Documents")
+// ExportCropRecordsCSV exports field planting and harvest records to CSV
+func (s *ExportService) ExportCropRecordsCSV(startDate, endDate string) (*ExportResult, error) {
+	savePath, err := s.chooseCSVPath("Export Crop Records to CSV", "farmland-crop-records")
+	if err != nil || savePath == "" {
+		return nil, err
+	}
+
+	query := `
+		SELECT cr.id, f.name, f.size_acres, cr.crop_type, cr.variety, cr.planting_date, cr.expected_harvest, cr.actual_harvest,
+			   cr.seed_cost, cr.fertilizer_cost, cr.labor_cost, cr.yield_kg, cr.yield_value, cr.status, cr.notes
+		FROM crop_records cr
+		JOIN fields f ON cr.field_id = f.id
+		WHERE 1=1
+	`
+	query, args := appendDateRange(query, "cr.planting_date", startDate, endDate)
+	query += " ORDER BY cr.planting_date DESC, f.name"
+
+	rows, err := db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	header := []string{"ID", "Field", "Field Size (Acres)", "Crop", "Variety", "Planting Date", "Expected Harvest", "Actual Harvest",
+		"Seed Cost (KES)", "Fertilizer Cost (KES)", "Labor Cost (KES)", "Total Cost (KES)", "Yield (kg)", "Yield Value (KES)", "Status", "Notes"}
+
+	var records [][]string
+	for rows.Next() {
+		var id int64
+		var fieldName, cropType, variety, plantingDate, expectedHarvest, actualHarvest, status, notes interface{}
+		var sizeAcres sql.NullFloat64
+		var seedCost, fertilizerCost, laborCost, yieldKg, yieldValue float64
+		if err := rows.Scan(&id, &fieldName, &sizeAcres, &cropType, &variety, &plantingDate, &expectedHarvest, &actualHarvest,
+			&seedCost, &fertilizerCost, &laborCost, &yieldKg, &yieldValue, &status, &notes); err != nil {
+			continue
+		}
+		records = append(records, []string{
+			fmt.Sprintf("%d", id),
+			toString(fieldName),
+			fmt.Sprintf("%.2f", sizeAcres.Float64),
+			toString(cropType),
+			toString(variety),
+			toString(plantingDate),
+			toString(expectedHarvest),
+			toString(actualHarvest),
+			fmt.Sprintf("%.2f", seedCost),
+			fmt.Sprintf("%.2f", fertilizerCost),
+			fmt.Sprintf("%.2f", laborCost),
+			fmt.Sprintf("%.2f", seedCost+fertilizerCost+laborCost),
+			fmt.Sprintf("%.2f", yieldKg),
+			fmt.Sprintf("%.2f", yieldValue),
+			toString(status),
+			toString(notes),
+		})
+	}
+
+	return writeCSVFile(savePath, header, records)
+}
+
+// ExportInventoryCSV exports inventory items to CSV, filtered by when the item was last updated
+func (s *ExportService) ExportInventoryCSV(startDate, endDate string) (*ExportResult, error) {
+	savePath, err := s.chooseCSVPath("Export Inventory to CSV", "farmland-inventory")
+	if err != nil || savePath == "" {
+		return nil, err
+	}
+
+	query := `
+		SELECT id, name, category, quantity, unit, minimum_stock, cost_per_unit, supplier, notes, updated_at
+		FROM inventory_items
+		WHERE 1=1
+	`
+	query, args := appendDateRange(query, "date(updated_at)", startDate, endDate)
+	query += " ORDER BY category, name"
+
+	rows, err := db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	header := []string{"ID", "Name", "Category", "Quantity", "Unit", "Minimum Stock", "Cost per Unit (KES)", "Stock Value (KES)",
+		"Low Stock", "Supplier", "Notes", "Last Updated"}
+
+	var records [][]string
+	for rows.Next() {
+		var id int64
+		var name, category, unit, supplier, notes, updatedAt interface{}
+		var quantity, minimumStock, costPerUnit float64
+		if err := rows.Scan(&id, &name, &category, &quantity, &unit, &minimumStock, &costPerUnit, &supplier, &notes, &updatedAt); err != nil {
+			continue
+		}
+		lowStock := "No"
+		if quantity < minimumStock {
+			lowStock = "Yes"
+		}
+		records = append(records, []string{
+			fmt.Sprintf("%d", id),
+			toString(name),
+			toString(category),
+			fmt.Sprintf("%.2f", quantity),
+			toString(unit),
+			fmt.Sprintf("%.2f", minimumStock),
+			fmt.Sprintf("%.2f", costPerUnit),
+			fmt.Sprintf("%.2f", quantity*costPerUnit),
+			lowStock,
+			toString(supplier),
+			toString(notes),
+			toString(updatedAt),
+		})
+	}
+
+	return writeCSVFile(savePath, header, records)
+}
+
+// ExportFeedRecordsCSV exports feeding records to CSV
+func (s *ExportService) ExportFeedRecordsCSV(startDate, endDate string) (*ExportResult, error) {
+	savePath, err := s.chooseCSVPath("Export Feed Records to CSV", "farmland-feed-records")
+	if err != nil || savePath == "" {
+		return nil, err
+	}
+
+	query := `
+		SELECT fr.id, fr.date, ft.name, ft.category, fr.quantity_kg, fr.unit, ft.cost_per_kg, g.name, fr.animal_count, fr.feeding_time, fr.notes
+		FROM feed_records fr
+		JOIN feed_types ft ON fr.feed_type_id = ft.id
+		LEFT JOIN animal_groups g ON fr.group_id = g.id
+		WHERE 1=1
+	`
+	query, args := appendDateRange(query, "fr.date", startDate, endDate)
+	query += " ORDER BY fr.date DESC, fr.feeding_time"
+
+	rows, err := db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	header := []string{"ID", "Date", "Feed Type", "Feed Category", "Quantity", "Unit", "Cost per kg (KES)", "Group", "Animals Fed", "Feeding Time", "Notes"}
+
+	var records [][]string
+	for rows.Next() {
+		var id int64
+		var date, feedType, feedCategory, unit, groupName, feedingTime, notes interface{}
+		var quantity, costPerKg float64
+		var animalCount int
+		if err := rows.Scan(&id, &date, &feedType, &feedCategory, &quantity, &unit, &costPerKg, &groupName, &animalCount, &feedingTime, &notes); err != nil {
+			continue
+		}
+		unitStr := toString(unit)
+		if unitStr == "" {
+			unitStr = "kg"
+		}
+		records = append(records, []string{
+			fmt.Sprintf("%d", id),
+			toString(date),
+			toString(feedType),
+			toString(feedCategory),
+			fmt.Sprintf("%.2f", quantity),
+			unitStr,
+			fmt.Sprintf("%.2f", costPerKg),
+			toString(groupName),
+			fmt.Sprintf("%d", animalCount),
+			toString(feedingTime),
+			toString(notes),
+		})
+	}
+
+	return writeCSVFile(savePath, header, records)
+}
+
+// ExportVetRecordsCSV exports health and veterinary records to CSV
+func (s *ExportService) ExportVetRecordsCSV(startDate, endDate string) (*ExportResult, error) {
+	savePath, err := s.chooseCSVPath("Export Vet Records to CSV", "farmland-vet-records")
+	if err != nil || savePath == "" {
+		return nil, err
+	}
+
+	query := `
+		SELECT vr.id, vr.date, a.tag_number, a.name, vr.record_type, vr.description, vr.diagnosis, vr.treatment,
+			   vr.medicine, vr.dosage, vr.vet_name, vr.cost, vr.next_due_date, vr.notes
+		FROM vet_records vr
+		JOIN animals a ON vr.animal_id = a.id
+		WHERE 1=1
+	`
+	query, args := appendDateRange(query, "vr.date", startDate, endDate)
+	query += " ORDER BY vr.date DESC, a.name"
+
+	rows, err := db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	header := []string{"ID", "Date", "Tag Number", "Animal", "Type", "Description", "Diagnosis", "Treatment",
+		"Medicine", "Dosage", "Vet", "Cost (KES)", "Next Due", "Notes"}
+
+	var records [][]string
+	for rows.Next() {
+		var id int64
+		var date, tagNumber, animalName, recordType, description, diagnosis, treatment, medicine, dosage, vetName, nextDue, notes interface{}
+		var cost float64
+		if err := rows.Scan(&id, &date, &tagNumber, &animalName, &recordType, &description, &diagnosis, &treatment,
+			&medicine, &dosage, &vetName, &cost, &nextDue, &notes); err != nil {
+			continue
+		}
+		records = append(records, []string{
+			fmt.Sprintf("%d", id),
+			toString(date),
+			toString(tagNumber),
+			toString(animalName),
+			toString(recordType),
+			toString(description),
+			toString(diagnosis),
+			toString(treatment),
+			toString(medicine),
+			toString(dosage),
+			toString(vetName),
+			fmt.Sprintf("%.2f", cost),
+			toString(nextDue),
+			toString(notes),
+		})
+	}
+
+	return writeCSVFile(savePath, header, records)
+}
+
+// ExportBreedingRecordsCSV exports breeding and pregnancy records to CSV
+func (s *ExportService) ExportBreedingRecordsCSV(startDate, endDate string) (*ExportResult, error) {
+	savePath, err := s.chooseCSVPath("Export Breeding Records to CSV", "farmland-breeding-records")
+	if err != nil || savePath == "" {
+		return nil, err
+	}
+
+	query := `
+		SELECT br.id, br.breeding_date, f.tag_number, f.name, m.name, br.breeding_method, br.sire_source,
+			   br.expected_due_date, br.actual_birth_date, o.name, br.pregnancy_status, br.notes
+		FROM breeding_records br
+		LEFT JOIN animals f ON br.female_id = f.id
+		LEFT JOIN animals m ON br.male_id = m.id
+		LEFT JOIN animals o ON br.offspring_id = o.id
+		WHERE 1=1
+	`
+	query, args := appendDateRange(query, "br.breeding_date", startDate, endDate)
+	query += " ORDER BY br.breeding_date DESC"
+
+	rows, err := db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	header := []string{"ID", "Breeding Date", "Female Tag", "Female", "Male", "Method", "Sire Source",
+		"Expected Due", "Actual Birth", "Offspring", "Status", "Notes"}
+
+	var records [][]string
+	for rows.Next() {
+		var id int64
+		var breedingDate, femaleTag, femaleName, maleName, method, sireSource, expectedDue, actualBirth, offspringName, status, notes interface{}
+		if err := rows.Scan(&id, &breedingDate, &femaleTag, &femaleName, &maleName, &method, &sireSource,
+			&expectedDue, &actualBirth, &offspringName, &status, &notes); err != nil {
+			continue
+		}
+		records = append(records, []string{
+			fmt.Sprintf("%d", id),
+			toString(breedingDate),
+			toString(femaleTag),
+			toString(femaleName),
+			toString(maleName),
+			toString(method),
+			toString(sireSource),
+			toString(expectedDue),
+			toString(actualBirth),
+			toString(offspringName),
+			toString(status),
+			toString(notes),
+		})
+	}
+
+	return writeCSVFile(savePath, header, records)
+}
+
+// chooseCSVPath opens the save dialog for a CSV export. An empty path means the user cancelled.
+func (s *ExportService) chooseCSVPath(title, filenamePrefix string) (string, error) {
+	if s.ctx == nil {
+		return "", fmt.Errorf("context not set")
+	}
+
+	timestamp := time.Now().Format("2006-01-02")
+	filename := fmt.Sprintf("%s-%s.csv", filenamePrefix, timestamp)
+
+	return runtime.SaveFileDialog(s.ctx, runtime.SaveDialogOptions{
+		Title:           title,
+		DefaultFilename: filename,
+		Filters: []runtime.FileFilter{
+			{DisplayName: "CSV Files", Pattern: "*.csv"},
+		},
+	})
+}
+
+// writeCSVFile writes a header and records to a CSV file
+func writeCSVFile(path string, header []string, records [][]string) (*ExportResult, error) {
+	file, err := os.Create(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	writer := csv.NewWriter(file)
+	if err := writer.Write(header); err != nil {
+		return nil, err
+	}
+	if err := writer.WriteAll(records); err != nil {
+		return nil, err
+	}
+
+	return &ExportResult{Path: path, Records: len(records)}, nil
+}
+
+// appendDateRange adds optional start/end date conditions on column to a query
+func appendDateRange(query, column, startDate, endDate string) (string, []interface{}) {
+	args := []interface{}{}
+	if startDate != "" {
+		query += fmt.Sprintf(" AND %s >= ?", column)
+		args = append(args, startDate)
+	}
+	if endDate != "" {
+		query += fmt.Sprintf(" AND %s <= ?", column)
+		args = append(args, endDate)
+	}
+	return query, args
+}
+
+// ExportFarmWorkbookXLSX exports the whole farm to a single Excel workbook with one sheet per module
+func (s *ExportService) ExportFarmWorkbookXLSX(startDate, endDate string) (*ExportResult, error) {
+	if s.ctx == nil {
+		return nil, fmt.Errorf("context not set")
+	}
+
+	timestamp := time.Now().Format("2006-01-02")
+	filename := fmt.Sprintf("farmland-workbook-%s.xlsx", timestamp)
+
+	savePath, err := runtime.SaveFileDialog(s.ctx, runtime.SaveDialogOptions{
+		Title:           "Export Farm Workbook to Excel",
+		DefaultFilename: filename,
+		Filters: []runtime.FileFilter{
+			{DisplayName: "Excel Workbook", Pattern: "*.xlsx"},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if savePath == "" {
+		return nil, nil
+	}
+
+	wb, count, err := s.buildFarmWorkbook(startDate, endDate)
+	if err != nil {
+		return nil, err
+	}
+	if err := wb.Save(savePath); err != nil {
+		return nil, fmt.Errorf("failed to write workbook: %w", err)
+	}
+
+	return &ExportResult{Path: savePath, Records: count}, nil
+}
+
+// buildFarmWorkbook assembles the workbook sheets and returns the number of data rows written
+func (s *ExportService) buildFarmWorkbook(startDate, endDate string) (*xlsxWorkbook, int, error) {
+	wb := newXLSXWorkbook()
+
+	// Summary sheet
+	summary, err := s.financial.GetFinancialSummary(startDate, endDate)
+	if err != nil {
+		return nil, 0, err
+	}
+	period := "All records"
+	if startDate != "" || endDate != "" {
+		period = fmt.Sprintf("%s to %s", startDate, endDate)
+	}
+	sheet := wb.AddSheet("Summary", "Item", "Category", "Amount (KES)")
+	sheet.AddRow(xlsxString("Period"), xlsxString(period))
+	sheet.AddRow(xlsxString("Generated"), xlsxDate(time.Now().Format("2006-01-02")))
+	sheet.AddRow(xlsxString("Total Income"), xlsxString(""), xlsxNumber(summary.TotalIncome))
+	sheet.AddRow(xlsxString("Total Expenses"), xlsxString(""), xlsxNumber(summary.TotalExpenses))
+	sheet.AddRow(xlsxString("Net Profit"), xlsxString(""), xlsxNumber(summary.NetProfit))
+	for _, cat := range sortedKeys(summary.IncomeByCategory) {
+		sheet.AddRow(xlsxString("Income"), xlsxString(cat), xlsxNumber(summary.IncomeByCategory[cat]))
+	}
+	for _, cat := range sortedKeys(summary.ExpenseByCategory) {
+		sheet.AddRow(xlsxString("Expense"), xlsxString(cat), xlsxNumber(summary.ExpenseByCategory[cat]))
+	}
+
+	// Animals
+	animals, err := s.livestock.GetAllAnimals()
+	if err != nil {
+		return nil, 0, err
+	}
+	sheet = wb.AddSheet("Animals", "ID", "Tag Number", "Name", "Species", "Type", "Breed", "Gender", "Date of Birth", "Status", "Mother", "Father", "Group", "Notes")
+	for _, a := range animals {
+		sheet.AddRow(xlsxInt(a.ID), xlsxString(a.TagNumber), xlsxString(a.Name), xlsxString(a.Species), xlsxString(a.Type), xlsxString(a.Breed),
+			xlsxString(a.Gender), xlsxDate(a.DateOfBirth), xlsxString(a.Status), xlsxString(a.MotherName), xlsxString(a.FatherName),
+			xlsxString(a.GroupName), xlsxString(a.Notes))
+	}
+
+	// Milk records
+	milkRecords, err := s.livestock.GetMilkRecords(0, startDate, endDate)
+	if err != nil {
+		return nil, 0, err
+	}
+	sessions, err := s.milkSessionColumns(milkRecords)
+	if err != nil {
+		return nil, 0, err
+	}
+	headers := []string{"ID", "Date", "Animal"}
+	for _, session := range sessions {
+		headers = append(headers, session.Name+" (L)")
+	}
+	sheet = wb.AddSheet("Milk Records", append(headers, "Total (L)", "Discarded", "Notes")...)
+	for _, r := range milkRecords {
+		cells := []xlsxCell{xlsxInt(r.ID), xlsxDate(r.Date), xlsxString(r.AnimalName)}
+		for _, session := range sessions {
+			cells = append(cells, xlsxNumber(sessionLiters(r, session.ID)))
+		}
+		discarded := "No"
+		if r.Discarded {
+			discarded = "Yes"
+		}
+		sheet.AddRow(append(cells, xlsxNumber(r.TotalLiters), xlsxString(discarded), xlsxString(r.Notes))...)
+	}
+
+	// Milk sales
+	sales, err := s.livestock.GetMilkSales(startDate, endDate)
+	if err != nil {
+		return nil, 0, err
+	}
+	sheet = wb.AddSheet("Milk Sales", "ID", "Date", "Buyer", "Liters", "Price per Liter (KES)", "Quality Adj. (KES/L)", "Total (KES)", "Paid", "Amount Paid (KES)", "Notes")
+	for _, sale := range sales {
+		paid := "No"
+		if sale.IsPaid {
+			paid = "Yes"
+		}
+		sheet.AddRow(xlsxInt(sale.ID), xlsxDate(sale.Date), xlsxString(sale.BuyerName), xlsxNumber(sale.Liters),
+			xlsxNumber(sale.PricePerLiter), xlsxNumber(sale.QualityAdjustment), xlsxNumber(sale.TotalAmount), xlsxString(paid), xlsxNumber(sale.AmountPaid), xlsxString(sale.Notes))
+	}
+
+	// Crops
+	crops, err := s.crops.GetCropRecords(0)
+	if err != nil {
+		return nil, 0, err
+	}
+	sheet = wb.AddSheet("Crops", "ID", "Field", "Crop", "Variety", "Planting Date", "Expected Harvest", "Actual Harvest",
+		"Seed Cost (KES)", "Fertilizer Cost (KES)", "Labor Cost (KES)", "Yield (kg)", "Yield Value (KES)", "Status", "Notes")
+	for _, c := range crops {
+		if !inDateRange(c.PlantingDate, startDate, endDate) {
+			continue
+		}
+		sheet.AddRow(xlsxInt(c.ID), xlsxString(c.FieldName), xlsxString(c.CropType), xlsxString(c.Variety),
+			xlsxDate(c.PlantingDate), xlsxDate(c.ExpectedHarvest), xlsxDate(c.ActualHarvest),
+			xlsxNumber(c.SeedCost), xlsxNumber(c.FertilizerCost), xlsxNumber(c.LaborCost),
+			xlsxNumber(c.YieldKg), xlsxNumber(c.YieldValue), xlsxString(c.Status), xlsxString(c.Notes))
+	}
+
+	// Inventory is a current snapshot, so it is not date filtered
+	items, err := s.inventory.GetAllInventory()
+	if err != nil {
+		return nil, 0, err
+	}
+	sheet = wb.AddSheet("Inventory", "ID", "Name", "Category", "Quantity", "Unit", "Minimum Stock", "Cost per Unit (KES)", "Stock Value (KES)", "Supplier", "Notes")
+	for _, item := range items {
+		sheet.AddRow(xlsxInt(item.ID), xlsxString(item.Name), xlsxString(item.Category), xlsxNumber(item.Quantity),
+			xlsxString(item.Unit), xlsxNumber(item.MinimumStock), xlsxNumber(item.CostPerUnit),
+			xlsxNumber(item.Quantity*item.CostPerUnit), xlsxString(item.Supplier), xlsxString(item.Notes))
+	}
+
+	// Feed
+	feedRecords, err := s.feed.GetFeedRecords(startDate, endDate)
+	if err != nil {
+		return nil, 0, err
+	}
+	sheet = wb.AddSheet("Feed", "ID", "Date", "Feed Type", "Quantity", "Unit", "Group", "Animals Fed", "Feeding Time", "Notes")
+	for _, r := range feedRecords {
+		sheet.AddRow(xlsxInt(r.ID), xlsxDate(r.Date), xlsxString(r.FeedTypeName), xlsxNumber(r.QuantityKg),
+			xlsxString(r.Unit), xlsxString(r.GroupName), xlsxInt(int64(r.AnimalCount)), xlsxString(r.FeedingTime), xlsxString(r.Notes))
+	}
+
+	// Vet records
+	vetRecords, err := s.health.GetVetRecords(0)
+	if err != nil {
+		return nil, 0, err
+	}
+	sheet = wb.AddSheet("Vet Records", "ID", "Date", "Animal", "Type", "Description", "Diagnosis", "Treatment",
+		"Medicine", "Dosage", "Vet", "Cost (KES)", "Next Due", "Milk Withheld Until", "Meat Withheld Until", "Notes")
+	for _, r := range vetRecords {
+		if !inDateRange(r.Date, startDate, endDate) {
+			continue
+		}
+		sheet.AddRow(xlsxInt(r.ID), xlsxDate(r.Date), xlsxString(r.AnimalName), xlsxString(r.RecordType),
+			xlsxString(r.Description), xlsxString(r.Diagnosis), xlsxString(r.Treatment), xlsxString(r.Medicine),
+			xlsxString(r.Dosage), xlsxString(r.VetName), xlsxNumber(r.Cost), xlsxDate(r.NextDueDate),
+			xlsxDate(r.MilkWithheldUntil), xlsxDate(r.MeatWithheldUntil), xlsxString(r.Notes))
+	}
+
+	// Breeding
+	breedingRecords, err := s.breeding.GetAllBreedingRecords()
+	if err != nil {
+		return nil, 0, err
+	}
+	sheet = wb.AddSheet("Breeding", "ID", "Breeding Date", "Female", "Male", "Method", "Sire Source",
+		"Expected Due", "Actual Birth", "Offspring", "Status", "Notes")
+	for _, r := range breedingRecords {
+		if !inDateRange(r.BreedingDate, startDate, endDate) {
+			continue
+		}
+		sheet.AddRow(xlsxInt(r.ID), xlsxDate(r.BreedingDate), xlsxString(r.FemaleName), xlsxString(r.MaleName),
+			xlsxString(r.BreedingMethod), xlsxString(r.SireSource), xlsxDate(r.ExpectedDueDate), xlsxDate(r.ActualBirthDate),
+			xlsxString(r.OffspringName), xlsxString(r.PregnancyStatus), xlsxString(r.Notes))
+	}
+
+	// Transactions
+	transactions, err := s.financial.GetTransactions(startDate, endDate, "", "")
+	if err != nil {
+		return nil, 0, err
+	}
+	sheet = wb.AddSheet("Transactions", "ID", "Date", "Type", "Category", "Description", "Amount (KES)", "Payment Method", "Related To", "Notes")
+	for _, t := range transactions {
+		sheet.AddRow(xlsxInt(t.ID), xlsxDate(t.Date), xlsxString(t.Type), xlsxString(t.Category), xlsxString(t.Description),
+			xlsxNumber(t.Amount), xlsxString(t.PaymentMethod), xlsxString(t.RelatedEntity), xlsxString(t.Notes))
+	}
+
+	count := 0
+	for _, sh := range wb.sheets[1:] {
+		count += sh.Len()
+	}
+	return wb, count, nil
+}
+
+// MonthlyReportOptions selects the month and sections of the monthly farm report
+type MonthlyReportOptions struct {
+	Month           string `json:"month"` // YYYY-MM format
+	IncludeMilk     bool   `json:"includeMilk"`
+	IncludeFinance  bool   `json:"includeFinance"`
+	IncludeVet      bool   `json:"includeVet"`
+	IncludeBreeding bool   `json:"includeBreeding"`
+	IncludeLowStock bool   `json:"includeLowStock"`
+	IncludeCrops    bool   `json:"includeCrops"`
+}
+
+// ExportMonthlyReportPDF generates the printable monthly farm report
+func (s *ExportService) ExportMonthlyReportPDF(options MonthlyReportOptions) (*ExportResult, error) {
+	if s.ctx == nil {
+		return nil, fmt.Errorf("context not set")
+	}
+
+	if options.Month == "" {
+		options.Month = time.Now().Format("2006-01")
+	}
+	monthStart, err := time.Parse("2006-01", options.Month)
+	if err != nil {
+		return nil, fmt.Errorf("invalid month %q: expected YYYY-MM", options.Month)
+	}
+
+	filename := fmt.Sprintf("farmland-monthly-report-%s.pdf", options.Month)
+
+	savePath, err := runtime.SaveFileDialog(s.ctx, runtime.SaveDialogOptions{
+		Title:           "Save Monthly Farm Report",
+		DefaultFilename: filename,
+		Filters: []runtime.FileFilter{
+			{DisplayName: "PDF Documents", Pattern: "*.pdf"},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if savePath == "" {
+		return nil, nil
+	}
+
+	doc, count, err := s.buildMonthlyReport(monthStart, options)
+	if err != nil {
+		return nil, err
+	}
+	if err := doc.Save(savePath); err != nil {
+		return nil, fmt.Errorf("failed to write report: %w", err)
+	}
+
+	return &ExportResult{Path: savePath, Records: count}, nil
+}
+
+// buildMonthlyReport lays out the selected report sections and returns the number of table rows written
+func (s *ExportService) buildMonthlyReport(monthStart time.Time, options MonthlyReportOptions) (*pdfDocument, int, error) {
+	startDate := monthStart.Format("2006-01-02")
+	endDate := monthStart.AddDate(0, 1, -1).Format("2006-01-02")
+	monthName := monthStart.Format("January 2006")
+
+	doc := newPDFDocument("Farmland Monthly Report - " + monthName)
+	doc.Title("Monthly Farm Report", fmt.Sprintf("%s  |  Generated %s", monthName, time.Now().Format("2 Jan 2006 15:04")))
+	count := 0
+
+	if options.IncludeMilk {
+		records, err := s.livestock.GetMilkRecords(0, startDate, endDate)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		type cowTotal struct {
+			name  string
+			days  int
+			total float64
+		}
+		totals := map[int64]*cowTotal{}
+		daily := map[string]float64{}
+		var order []int64
+		monthTotal := 0.0
+		for _, r := range records {
+			t, ok := totals[r.AnimalID]
+			if !ok {
+				t = &cowTotal{name: r.AnimalName}
+				totals[r.AnimalID] = t
+				order = append(order, r.AnimalID)
+			}
+			t.days++
+			t.total += r.TotalLiters
+			daily[r.Date] += r.TotalLiters
+			monthTotal += r.TotalLiters
+		}
+		sort.Slice(order, func(i, j int) bool { return totals[order[i]].total > totals[order[j]].total })
+
+		doc.Heading("Milk Production")
+		doc.KeyValues([][2]string{
+			{"Total production", fmt.Sprintf("%.1f L", monthTotal)},
+			{"Cows recorded", fmt.Sprintf("%d", len(order))},
+		})
+
+		var rows [][]string
+		for _, id := range order {
+			t := totals[id]
+			rows = append(rows, []string{t.name, fmt.Sprintf("%d", t.days), fmt.Sprintf("%.1f", t.total), fmt.Sprintf("%.1f", t.total/float64(t.days))})
+		}
+		doc.Subheading("Production per cow")
+		doc.Table([]string{"Cow", "Days Recorded", "Total (L)", "Average per Day (L)"}, []float64{3, 2, 2, 2}, rows)
+		count += len(rows)
+
+		var labels []string
+		var values []float64
+		for d := monthStart; d.Month() == monthStart.Month(); d = d.AddDate(0, 0, 1) {
+			labels = append(labels, d.Format("2"))
+			values = append(values, daily[d.Format("2006-01-02")])
+		}
+		doc.Subheading("Daily production (L)")
+		doc.BarChart(labels, values, "L")
+
+		trend, err := s.dashboard.milkProductionChart("year", monthStart.AddDate(0, 1, -1))
+		if err != nil {
+			return nil, 0, err
+		}
+		labels, values = nil, nil
+		for _, point := range trend {
+			month, _ := time.Parse("2006-01", point["date"].(string))
+			labels = append(labels, month.Format("Jan 06"))
+			values = append(values, point["liters"].(float64))
+		}
+		doc.Subheading("12-month production trend (L)")
+		doc.BarChart(labels, values, "L")
+	}
+
+	if options.IncludeFinance {
+		summary, err := s.financial.GetFinancialSummary(startDate, endDate)
+		if err != nil {
+			return nil, 0, err
+		}
+		doc.Heading("Financial Summary")
+		doc.KeyValues([][2]string{
+			{"Total income", fmt.Sprintf("KES %.2f", summary.TotalIncome)},
+			{"Total expenses", fmt.Sprintf("KES %.2f", summary.TotalExpenses)},
+			{"Net profit", fmt.Sprintf("KES %.2f", summary.NetProfit)},
+		})
+		var rows [][]string
+		for _, cat := range sortedKeys(summary.IncomeByCategory) {
+			rows = append(rows, []string{"Income", cat, fmt.Sprintf("%.2f", summary.IncomeByCategory[cat])})
+		}
+		for _, cat := range sortedKeys(summary.ExpenseByCategory) {
+			rows = append(rows, []string{"Expense", cat, fmt.Sprintf("%.2f", summary.ExpenseByCategory[cat])})
+		}
+		doc.Table([]string{"Type", "Category", "Amount (KES)"}, []float64{2, 4, 3}, rows)
+		count += len(rows)
+	}
+
+	if options.IncludeVet {
+		records, err := s.health.GetVetRecords(0)
+		if err != nil {
+			return nil, 0, err
+		}
+		var rows [][]string
+		totalCost := 0.0
+		for _, r := range records {
+			if !inDateRange(r.Date, startDate, endDate) {
+				continue
+			}
+			totalCost += r.Cost
+			rows = append(rows, []string{r.Date, r.AnimalName, r.RecordType, firstNonEmpty(r.Diagnosis, r.Description), r.Medicine, fmt.Sprintf("%.2f", r.Cost)})
+		}
+		doc.Heading("Veterinary Activity")
+		doc.KeyValues([][2]string{
+			{"Vet records", fmt.Sprintf("%d", len(rows))},
+			{"Total vet cost", fmt.Sprintf("KES %.2f", totalCost)},
+		})
+		doc.Table([]string{"Date", "Animal", "Type", "Details", "Medicine", "Cost (KES)"}, []float64{2, 2, 2, 3, 2.5, 1.5}, rows)
+		count += len(rows)
+	}
+
+	if options.IncludeBreeding {
+		pregnant, err := s.breeding.GetPregnantAnimals()
+		if err != nil {
+			return nil, 0, err
+		}
+		var rows [][]string
+		for _, r := range pregnant {
+			sire := firstNonEmpty(r.MaleName, r.SireSource)
+			rows = append(rows, []string{r.FemaleName, r.BreedingDate, sire, r.PregnancyStatus, r.ExpectedDueDate})
+		}
+		doc.Heading("Breeding Status")
+		doc.Table([]string{"Cow", "Bred On", "Sire", "Status", "Expected Due"}, []float64{3, 2, 3, 2, 2}, rows)
+		count += len(rows)
+	}
+
+	if options.IncludeLowStock {
+		items, err := s.inventory.GetLowStockItems()
+		if err != nil {
+			return nil, 0, err
+		}
+		var rows [][]string
+		for _, i := range items {
+			rows = append(rows, []string{i.Name, i.Category, fmt.Sprintf("%.1f %s", i.Quantity, i.Unit), fmt.Sprintf("%.1f %s", i.MinimumStock, i.Unit), i.Supplier})
+		}
+		doc.Heading("Low Stock")
+		doc.Table([]string{"Item", "Category", "In Stock", "Minimum", "Supplier"}, []float64{3, 2, 2, 2, 3}, rows)
+		count += len(rows)
+	}
+
+	if options.IncludeCrops {
+		fields, err := s.crops.GetAllFields()
+		if err != nil {
+			return nil, 0, err
+		}
+		var rows [][]string
+		for _, f := range fields {
+			rows = append(rows, []string{f.Name, fmt.Sprintf("%.1f", f.SizeAcres), f.CurrentCrop, f.Status})
+		}
+		doc.Heading("Crop Status")
+		doc.Table([]string{"Field", "Acres", "Current Crop", "Status"}, []float64{3, 1.5, 3, 2}, rows)
+		count += len(rows)
+	}
+
+	return doc, count, nil
+}
+
+// AnimalPassport collects everything recorded about one animal: identity,
+// parents and offspring, health and breeding history, milk production and photos
+type AnimalPassport struct {
+	GeneratedAt     string            `json:"generatedAt"` // RFC3339
+	Animal          Animal            `json:"animal"`
+	Mother          *Animal           `json:"mother"`
+	Father          *Animal           `json:"father"`
+	Offspring       []Animal          `json:"offspring"`
+	VetRecords      []VetRecord       `json:"vetRecords"`
+	BreedingHistory []BreedingRecord  `json:"breedingHistory"`
+	Milk            AnimalMilkSummary `json:"milk"`
+	Photos          []Photo           `json:"photos"`
+}
+
+// AnimalMilkSummary summarises an animal's recorded milk production
+type AnimalMilkSummary struct {
+	Records       int                `json:"records"`
+	FirstDate     string             `json:"firstDate"`
+	LastDate      string             `json:"lastDate"`
+	TotalLiters   float64            `json:"totalLiters"`
+	AveragePerDay float64            `json:"averagePerDay"`
+	BestDay       string             `json:"bestDay"`
+	BestDayLiters float64            `json:"bestDayLiters"`
+	Last30Days    float64            `json:"last30Days"`
+	Monthly       []MonthlyMilkTotal `json:"monthly"` // last 12 months with records, oldest first
+}
+
+// MonthlyMilkTotal is the milk recorded in one month
+type MonthlyMilkTotal struct {
+	Month  string  `json:"month"` // YYYY-MM
+	Liters float64 `json:"liters"`
+}
+
+// GetAnimalPassport gathers the passport data for an animal
+func (s *ExportService) GetAnimalPassport(animalID int64) (*AnimalPassport, error) {
+	animal, err := s.livestock.GetAnimal(animalID)
+	if err != nil {
+		return nil, fmt.Errorf("animal not found: %w", err)
+	}
+
+	passport := &AnimalPassport{
+		GeneratedAt: time.Now().Format(time.RFC3339),
+		Animal:      *animal,
+		Offspring:   []Animal{},
+		Photos:      []Photo{},
+	}
+
+	if animal.MotherID != nil {
+		if mother, err := s.livestock.GetAnimal(*animal.MotherID); err == nil {
+			passport.Mother = mother
+		}
+	}
+	if animal.FatherID != nil {
+		if father, err := s.livestock.GetAnimal(*animal.FatherID); err == nil {
+			passport.Father = father
+		}
+	}
+
+	if offspring, err := s.livestock.GetOffspring(animalID); err == nil && offspring != nil {
+		passport.Offspring = offspring
+	}
+
+	passport.VetRecords, err = s.health.GetVetRecords(animalID)
+	if err != nil {
+		return nil, err
+	}
+	if passport.VetRecords == nil {
+		passport.VetRecords = []VetRecord{}
+	}
+
+	passport.BreedingHistory, err = s.breeding.GetBreedingHistoryForAnimal(animalID)
+	if err != nil {
+		return nil, err
+	}
+	if passport.BreedingHistory == nil {
+		passport.BreedingHistory = []BreedingRecord{}
+	}
+
+	records, err := s.livestock.GetMilkRecords(animalID, "", "")
+	if err != nil {
+		return nil, err
+	}
+	passport.Milk = summariseMilk(records)
+
+	if photos, err := s.photo.GetPhotos("animal", animalID); err == nil && photos != nil {
+		passport.Photos = photos
+	}
+
+	return passport, nil
+}
+
+// summariseMilk totals milk records (newest first, as returned by GetMilkRecords)
+func summariseMilk(records []MilkRecord) AnimalMilkSummary {
+	summary := AnimalMilkSummary{Monthly: []MonthlyMilkTotal{}}
+	if len(records) == 0 {
+		return summary
+	}
+
+	cutoff := time.Now().AddDate(0, 0, -30).Format("2006-01-02")
+	monthly := map[string]float64{}
+	for _, r := range records {
+		summary.Records++
+		summary.TotalLiters += r.TotalLiters
+		if r.Date >= cutoff {
+			summary.Last30Days += r.TotalLiters
+		}
+		if r.TotalLiters > summary.BestDayLiters {
+			summary.BestDay = r.Date
+			summary.BestDayLiters = r.TotalLiters
+		}
+		if len(r.Date) >= 7 {
+			monthly[r.Date[:7]] += r.TotalLiters
+		}
+	}
+	summary.LastDate = records[0].Date
+	summary.FirstDate = records[len(records)-1].Date
+	summary.AveragePerDay = summary.TotalLiters / float64(summary.Records)
+
+	months := sortedKeys(monthly)
+	if len(months) > 12 {
+		months = months[len(months)-12:]
+	}
+	for _, m := range months {
+		summary.Monthly = append(summary.Monthly, MonthlyMilkTotal{Month: m, Liters: monthly[m]})
+	}
+	return summary
+}
+
+// ExportAnimalPassportPDF saves a printable health passport for an animal
+func (s *ExportService) ExportAnimalPassportPDF(animalID int64) (*ExportResult, error) {
+	if s.ctx == nil {
+		return nil, fmt.Errorf("context not set")
+	}
+
+	passport, err := s.GetAnimalPassport(animalID)
+	if err != nil {
+		return nil, err
+	}
+
+	savePath, err := runtime.SaveFileDialog(s.ctx, runtime.SaveDialogOptions{
+		Title:           "Save Animal Passport",
+		DefaultFilename: fmt.Sprintf("passport-%s-%s.pdf", passportFileName(passport.Animal), time.Now().Format("2006-01-02")),
+		Filters: []runtime.FileFilter{
+			{DisplayName: "PDF Documents", Pattern: "*.pdf"},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if savePath == "" {
+		return nil, nil
+	}
+
+	doc, count := buildPassportPDF(passport)
+	if err := doc.Save(savePath); err != nil {
+		return nil, fmt.Errorf("failed to write passport: %w", err)
+	}
+
+	return &ExportResult{Path: savePath, Records: count}, nil
+}
+
+// ExportAnimalPassportPackage saves the passport as a zip holding passport.json
+// and the animal's photos, for import into other systems
+func (s *ExportService) ExportAnimalPassportPackage(animalID int64) (*ExportResult, error) {
+	if s.ctx == nil {
+		return nil, fmt.Errorf("context not set")
+	}
+
+	passport, err := s.GetAnimalPassport(animalID)
+	if err != nil {
+		return nil, err
+	}
+
+	savePath, err := runtime.SaveFileDialog(s.ctx, runtime.SaveDialogOptions{
+		Title:           "Save Animal Passport Package",
+		DefaultFilename: fmt.Sprintf("passport-%s-%s.zip", passportFileName(passport.Animal), time.Now().Format("2006-01-02")),
+		Filters: []runtime.FileFilter{
+			{DisplayName: "Zip Archives", Pattern: "*.zip"},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if savePath == "" {
+		return nil, nil
+	}
+
+	if err := writePassportPackage(savePath, passport); err != nil {
+		return nil, fmt.Errorf("failed to write passport package: %w", err)
+	}
+
+	count := len(passport.VetRecords) + len(passport.BreedingHistory) + passport.Milk.Records
+	return &ExportResult{Path: savePath, Records: count}, nil
+}
+
+// writePassportPackage writes passport.json plus a photos/ folder. Photo paths
+// in the JSON are rewritten to their location inside the archive; photos whose
+// files are missing are left out.
+func writePassportPackage(path string, passport *AnimalPassport) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	zw := zip.NewWriter(file)
+
+	packaged := *passport
+	packaged.Photos = []Photo{}
+	for _, p := range passport.Photos {
+		data, err := os.ReadFile(p.Path)
+		if err != nil {
+			continue
+		}
+		name := "photos/" + p.Filename
+		w, err := zw.Create(name)
+		if err != nil {
+			return err
+		}
+		if _, err := w.Write(data); err != nil {
+			return err
+		}
+		p.Path = name
+		packaged.Photos = append(packaged.Photos, p)
+	}
+
+	w, err := zw.Create("passport.json")
+	if err != nil {
+		return err
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(packaged); err != nil {
+		return err
+	}
+
+	return zw.Close()
+}
+
+// buildPassportPDF lays out the passport and returns the number of table rows written
+func buildPassportPDF(p *AnimalPassport) (*pdfDocument, int) {
+	a := p.Animal
+	name := a.Name
+	if a.TagNumber != "" {
+		name = fmt.Sprintf("%s (%s)", a.Name, a.TagNumber)
+	}
+
+	doc := newPDFDocument("Animal Passport - " + name)
+	doc.Title("Animal Health Passport", fmt.Sprintf("%s  |  Generated %s", name, time.Now().Format("2 Jan 2006 15:04")))
+	count := 0
+
+	doc.Heading("Identity")
+	doc.KeyValues([][2]string{
+		{"Name", a.Name},
+		{"Tag number", a.TagNumber},
+		{"Species", a.Species},
+		{"Type", a.Type},
+		{"Breed", a.Breed},
+		{"Gender", a.Gender},
+		{"Date of birth", a.DateOfBirth},
+		{"Status", a.Status},
+	})
+	if a.Notes != "" {
+		doc.Paragraph(a.Notes)
+	}
+
+	var photos [][]byte
+	for _, photo := range p.Photos {
+		if data, err := os.ReadFile(photo.Path); err == nil {
+			photos = append(photos, data)
+		}
+	}
+	if len(photos) > 0 {
+		doc.Subheading("Photos")
+		doc.Images(photos, 140)
+	}
+
+	doc.Heading("Pedigree")
+	parent := func(animal *Animal, fallback string) string {
+		if animal == nil {
+			return firstNonEmpty(fallback, "Unknown")
+		}
+		return strings.TrimSpace(fmt.Sprintf("%s %s  %s", animal.Name, passportTag(animal.TagNumber), animal.Breed))
+	}
+	doc.KeyValues([][2]string{
+		{"Mother (dam)", parent(p.Mother, a.MotherName)},
+		{"Father (sire)", parent(p.Father, a.FatherName)},
+	})
+	if len(p.Offspring) > 0 {
+		var rows [][]string
+		for _, o := range p.Offspring {
+			rows = append(rows, []string{o.Name, o.TagNumber, o.Gender, o.DateOfBirth, o.Status})
+		}
+		doc.Subheading("Offspring")
+		doc.Table([]string{"Name", "Tag", "Gender", "Born", "Status"}, []float64{3, 2, 1.5, 2, 1.5}, rows)
+		count += len(rows)
+	}
+
+	doc.Heading("Vaccinations and Treatments")
+	var rows [][]string
+	for _, r := range p.VetRecords {
+		medicine := strings.TrimSpace(r.Medicine + " " + r.Dosage)
+		rows = append(rows, []string{r.Date, r.RecordType, firstNonEmpty(r.Diagnosis, r.Description, r.Treatment), medicine, r.VetName, r.NextDueDate})
+	}
+	doc.Table([]string{"Date", "Type", "Details", "Medicine", "Vet", "Next Due"}, []float64{2, 2, 3.5, 2.5, 2, 2}, rows)
+	count += len(rows)
+
+	doc.Heading("Breeding History")
+	rows = nil
+	for _, r := range p.BreedingHistory {
+		partner := firstNonEmpty(r.MaleName, r.SireSource)
+		if r.FemaleID != a.ID {
+			partner = r.FemaleName
+		}
+		rows = append(rows, []string{r.BreedingDate, r.BreedingMethod, partner, r.PregnancyStatus, firstNonEmpty(r.ActualBirthDate, r.ExpectedDueDate), r.OffspringName})
+	}
+	doc.Table([]string{"Bred On", "Method", "Partner", "Status", "Birth / Due", "Offspring"}, []float64{2, 2.5, 2.5, 2, 2, 2}, rows)
+	count += len(rows)
+
+	if p.Milk.Records > 0 {
+		m := p.Milk
+		doc.Heading("Milk Production")
+		doc.KeyValues([][2]string{
+			{"Recorded days", fmt.Sprintf("%d (%s to %s)", m.Records, m.FirstDate, m.LastDate)},
+			{"Total production", fmt.Sprintf("%.1f L", m.TotalLiters)},
+			{"Average per day", fmt.Sprintf("%.1f L", m.AveragePerDay)},
+			{"Best day", fmt.Sprintf("%.1f L on %s", m.BestDayLiters, m.BestDay)},
+			{"Last 30 days", fmt.Sprintf("%.1f L", m.Last30Days)},
+		})
+		var labels []string
+		var values []float64
+		for _, month := range m.Monthly {
+			label := month.Month
+			if t, err := time.Parse("2006-01", month.Month); err == nil {
+				label = t.Format("Jan 06")
+			}
+			labels = append(labels, label)
+			values = append(values, month.Liters)
+		}
+		doc.Subheading("Monthly production (L)")
+		doc.BarChart(labels, values, "L")
+	}
+
+	return doc, count
+}
+
+func passportTag(tag string) string {
+	if tag == "" {
+		return ""
+	}
+	return "(" + tag + ")"
+}
+
+// passportFileName returns a filesystem-safe name for an animal
+func passportFileName(a Animal) string {
+	return safeFileName(firstNonEmpty(a.TagNumber, a.Name, fmt.Sprintf("animal-%d", a.ID)))
+}
+
+// safeFileName lowercases a name and replaces anything but letters, digits
+// and dashes with dashes
+func safeFileName(name string) string {
+	return strings.Map(func(r rune) rune {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') || r == '-' {
+			return r
+		}
+		return '-'
+	}, strings.ToLower(name))
+}
+
+// ExportDatasetJSON exports every farm table to the versioned JSON dataset format
+func (s *ExportService) ExportDatasetJSON() (*ExportResult, error) {
+	if s.ctx == nil {
+		return nil, fmt.Errorf("context not set")
+	}
+
+	timestamp := time.Now().Format("2006-01-02")
+	filename := fmt.Sprintf("farmland-dataset-%s.json", timestamp)
+
+	savePath, err := runtime.SaveFileDialog(s.ctx, runtime.SaveDialogOptions{
+		Title:           "Export Farm Dataset (JSON)",
+		DefaultFilename: filename,
+		Filters: []runtime.FileFilter{
+			{DisplayName: "JSON Files", Pattern: "*.json"},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if savePath == "" {
+		return nil, nil
+	}
+
+	file, err := os.Create(savePath)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	count, err := WriteFarmDataset(file)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ExportResult{Path: savePath, Records: count}, nil
+}
+
+// ImportDatasetJSON replaces all farm data with the contents of a JSON dataset file.
+// A copy of the current database is kept next to it as farmland.db.pre-import.
+func (s *ExportService) ImportDatasetJSON() (*DatasetImportResult, error) {
+	if s.ctx == nil {
+		return nil, fmt.Errorf("context not set")
+	}
+
+	openPath, err := runtime.OpenFileDialog(s.ctx, runtime.OpenDialogOptions{
+		Title: "Select Farm Dataset to Import",
+		Filters: []runtime.FileFilter{
+			{DisplayName: "JSON Files", Pattern: "*.json"},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if openPath == "" {
+		return nil, nil
+	}
+
+	file, err := os.Open(openPath)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	ds, err := ReadFarmDataset(file)
+	if err != nil {
+		return nil, err
+	}
+
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get home directory: %w", err)
+	}
+	dbPath := filepath.Join(homeDir, ".farmland", "farmland.db")
+	if err := snapshotDatabase(dbPath + ".pre-import"); err != nil {
+		return nil, fmt.Errorf("failed to save a copy of the current database: %w", err)
+	}
+
+	return ImportFarmDataset(ds)
+}
+
+// GetExportDirectory returns the default export directory
+func (s *ExportService) GetExportDirectory() string {
+	home, _ := os.UserHomeDir()
+	return filepath.Join(home, "Documents")
+}
+
+// inDateRange reports whether a YYYY-MM-DD date falls within the optional range
+func inDateRange(date, startDate, endDate string) bool {
+	if startDate != "" && date < startDate {
+		return false
+	}
+	if endDate != "" && date > endDate {
+		return false
+	}
+	return true
+}
+
+// sortedKeys returns the keys of a category map in alphabetical order
+func sortedKeys(m map[string]float64) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// firstNonEmpty returns the first non-empty string
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
 }
 
 // toString converts interface to string safely
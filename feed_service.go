@@ -68,10 +68,18 @@ func (s *FeedService) DeleteFeedType(id int64) error {
 
 // GetFeedRecords returns feed records within a date range
 func (s *FeedService) GetFeedRecords(startDate, endDate string) ([]FeedRecord, error) {
+	return feedRecords(startDate, endDate, 0)
+}
+
+// feedRecords returns feed records within a date range, limited to the
+// feedings that targeted groupID when it is not 0
+func feedRecords(startDate, endDate string, groupID int64) ([]FeedRecord, error) {
 	query := `
-		SELECT fr.id, fr.date, fr.feed_type_id, ft.name, fr.quantity_kg, fr.unit, fr.animal_count, fr.feeding_time, fr.notes, fr.created_at
+		SELECT fr.id, fr.date, fr.feed_type_id, ft.name, fr.quantity_kg, fr.unit, fr.animal_count, fr.feeding_time, fr.notes, fr.created_at,
+			   fr.group_id, g.name
 		FROM feed_records fr
 		JOIN feed_types ft ON fr.feed_type_id = ft.id
+		LEFT JOIN animal_groups g ON fr.group_id = g.id
 		WHERE 1=1
 	`
 	args := []interface{}{}
@@ -84,6 +92,10 @@ func (s *FeedService) GetFeedRecords(startDate, endDate string) ([]FeedRecord, e
 		query += " AND fr.date <= ?"
 		args = append(args, endDate)
 	}
+	if groupID != 0 {
+		query += " AND fr.group_id = ?"
+		args = append(args, groupID)
+	}
 	query += " ORDER BY fr.date DESC, fr.feeding_time"
 
 	rows, err := db.Query(query, args...)
@@ -95,8 +107,10 @@ func (s *FeedService) GetFeedRecords(startDate, endDate string) ([]FeedRecord, e
 	var records []FeedRecord
 	for rows.Next() {
 		var r FeedRecord
-		var feedingTime, notes, unit sql.NullString
-		err := rows.Scan(&r.ID, &r.Date, &r.FeedTypeID, &r.FeedTypeName, &r.QuantityKg, &unit, &r.AnimalCount, &feedingTime, &notes, &r.CreatedAt)
+		var feedingTime, notes, unit, groupName sql.NullString
+		var group sql.NullInt64
+		err := rows.Scan(&r.ID, &r.Date, &r.FeedTypeID, &r.FeedTypeName, &r.QuantityKg, &unit, &r.AnimalCount, &feedingTime, &notes, &r.CreatedAt,
+			&group, &groupName)
 		if err != nil {
 			return nil, err
 		}
@@ -106,17 +120,25 @@ func (s *FeedService) GetFeedRecords(startDate, endDate string) ([]FeedRecord, e
 		if r.Unit == "" {
 			r.Unit = "kg" // Default for old records
 		}
+		if group.Valid {
+			r.GroupID = &group.Int64
+		}
+		r.GroupName = groupName.String
 		records = append(records, r)
 	}
 	return records, nil
 }
 
-// AddFeedRecord adds a new feed record
+// AddFeedRecord adds a new feed record. A feeding that targets a group
+// without an animal count takes the group's current head count.
 func (s *FeedService) AddFeedRecord(record FeedRecord) (int64, error) {
+	if err := resolveFeedGroup(&record); err != nil {
+		return 0, err
+	}
 	result, err := db.Exec(`
-		INSERT INTO feed_records (date, feed_type_id, quantity_kg, unit, animal_count, feeding_time, notes)
-		VALUES (?, ?, ?, ?, ?, ?, ?)
-	`, record.Date, record.FeedTypeID, record.QuantityKg, record.Unit, record.AnimalCount, record.FeedingTime, record.Notes)
+		INSERT INTO feed_records (date, feed_type_id, quantity_kg, unit, animal_count, feeding_time, notes, group_id)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+	`, record.Date, record.FeedTypeID, record.QuantityKg, record.Unit, record.AnimalCount, record.FeedingTime, record.Notes, record.GroupID)
 	if err != nil {
 		return 0, err
 	}
@@ -125,13 +147,34 @@ func (s *FeedService) AddFeedRecord(record FeedRecord) (int64, error) {
 
 // UpdateFeedRecord updates an existing feed record
 func (s *FeedService) UpdateFeedRecord(record FeedRecord) error {
+	if err := resolveFeedGroup(&record); err != nil {
+		return err
+	}
 	_, err := db.Exec(`
-		UPDATE feed_records SET date = ?, feed_type_id = ?, quantity_kg = ?, unit = ?, animal_count = ?, feeding_time = ?, notes = ?
+		UPDATE feed_records SET date = ?, feed_type_id = ?, quantity_kg = ?, unit = ?, animal_count = ?, feeding_time = ?, notes = ?, group_id = ?
 		WHERE id = ?
-	`, record.Date, record.FeedTypeID, record.QuantityKg, record.Unit, record.AnimalCount, record.FeedingTime, record.Notes, record.ID)
+	`, record.Date, record.FeedTypeID, record.QuantityKg, record.Unit, record.AnimalCount, record.FeedingTime, record.Notes, record.GroupID, record.ID)
 	return err
 }
 
+// resolveFeedGroup clears an empty group and fills the animal count of a
+// group feeding from the group's head count
+func resolveFeedGroup(record *FeedRecord) error {
+	if record.GroupID == nil || *record.GroupID == 0 {
+		record.GroupID = nil
+		return nil
+	}
+	if record.AnimalCount > 0 {
+		return nil
+	}
+	count, err := groupHeadCount(*record.GroupID)
+	if err != nil {
+		return err
+	}
+	record.AnimalCount = count
+	return nil
+}
+
 // DeleteFeedRecord deletes a feed record
 func (s *FeedService) DeleteFeedRecord(id int64) error {
 	_, err := db.Exec(`DELETE FROM feed_records WHERE id = ?`, id)
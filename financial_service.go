@@ -2,6 +2,7 @@ package main
 
 import (
 	"database/sql"
+	"fmt"
 	"time"
 )
 
@@ -15,7 +16,7 @@ func NewFinancialService() *FinancialService {
 
 // GetTransactions returns transactions with optional filters
 func (s *FinancialService) GetTransactions(startDate, endDate, transactionType, category string) ([]Transaction, error) {
-	query := `SELECT id, date, type, category, description, amount, payment_method, related_entity, notes, created_at FROM transactions WHERE 1=1`
+	query := `SELECT id, date, type, category, description, amount, payment_method, related_entity, notes, reconciliation_status, cleared_date, created_at FROM transactions WHERE 1=1`
 	args := []interface{}{}
 	if startDate != "" {
 		query += " AND date >= ?"
@@ -44,8 +45,9 @@ func (s *FinancialService) GetTransactions(startDate, endDate, transactionType,
 	var transactions []Transaction
 	for rows.Next() {
 		var t Transaction
-		var description, paymentMethod, relatedEntity, notes sql.NullString
-		err := rows.Scan(&t.ID, &t.Date, &t.Type, &t.Category, &description, &t.Amount, &paymentMethod, &relatedEntity, &notes, &t.CreatedAt)
+		var description, paymentMethod, relatedEntity, notes, reconciliationStatus, clearedDate sql.NullString
+		err := rows.Scan(&t.ID, &t.Date, &t.Type, &t.Category, &description, &t.Amount, &paymentMethod, &relatedEntity, &notes,
+			&reconciliationStatus, &clearedDate, &t.CreatedAt)
 		if err != nil {
 			return nil, err
 		}
@@ -53,6 +55,11 @@ func (s *FinancialService) GetTransactions(startDate, endDate, transactionType,
 		t.PaymentMethod = paymentMethod.String
 		t.RelatedEntity = relatedEntity.String
 		t.Notes = notes.String
+		t.ReconciliationStatus = reconciliationStatus.String
+		if t.ReconciliationStatus == "" {
+			t.ReconciliationStatus = "uncleared"
+		}
+		t.ClearedDate = clearedDate.String
 		transactions = append(transactions, t)
 	}
 	return transactions, nil
@@ -68,16 +75,62 @@ func (s *FinancialService) AddTransaction(transaction Transaction) (int64, error
 	return result.LastInsertId()
 }
 
-// UpdateTransaction updates an existing transaction
+// UpdateTransaction updates an existing transaction. The date, type and
+// amount of a transaction that has cleared or been reconciled against a bank
+// statement cannot change.
 func (s *FinancialService) UpdateTransaction(transaction Transaction) error {
-	_, err := db.Exec(`UPDATE transactions SET date = ?, type = ?, category = ?, description = ?, amount = ?, payment_method = ?, related_entity = ?, notes = ? WHERE id = ?`,
-		transaction.Date, transaction.Type, transaction.Category, transaction.Description, transaction.Amount, transaction.PaymentMethod, transaction.RelatedEntity, transaction.Notes, transaction.ID)
-	return err
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	var date, txnType string
+	var amount float64
+	if err := tx.QueryRow(`SELECT date, type, amount FROM transactions WHERE id = ?`, transaction.ID).Scan(&date, &txnType, &amount); err != nil {
+		return fmt.Errorf("transaction not found: %w", err)
+	}
+	if date != transaction.Date || txnType != transaction.Type || amount != transaction.Amount {
+		if err := checkTransactionRemovable(tx, transaction.ID); err != nil {
+			return fmt.Errorf("cannot change transaction: %w", err)
+		}
+	}
+
+	if _, err := tx.Exec(`UPDATE transactions SET date = ?, type = ?, category = ?, description = ?, amount = ?, payment_method = ?, related_entity = ?, notes = ? WHERE id = ?`,
+		transaction.Date, transaction.Type, transaction.Category, transaction.Description, transaction.Amount, transaction.PaymentMethod, transaction.RelatedEntity, transaction.Notes, transaction.ID); err != nil {
+		return err
+	}
+	return tx.Commit()
 }
 
-// DeleteTransaction deletes a transaction
+// DeleteTransaction deletes a transaction that has not cleared or been
+// reconciled against a bank statement
 func (s *FinancialService) DeleteTransaction(id int64) error {
-	_, err := db.Exec(`DELETE FROM transactions WHERE id = ?`, id)
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	if err := checkTransactionRemovable(tx, id); err != nil {
+		return fmt.Errorf("cannot delete transaction: %w", err)
+	}
+	if _, err := tx.Exec(`DELETE FROM transactions WHERE id = ?`, id); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// SetTransactionCleared marks a transaction as cleared (seen on a statement) or uncleared
+func (s *FinancialService) SetTransactionCleared(id int64, cleared bool, clearedDate string) error {
+	if !cleared {
+		_, err := db.Exec(`UPDATE transactions SET reconciliation_status = 'uncleared', cleared_date = NULL WHERE id = ?`, id)
+		return err
+	}
+	if clearedDate == "" {
+		clearedDate = time.Now().Format("2006-01-02")
+	}
+	_, err := db.Exec(`UPDATE transactions SET reconciliation_status = 'cleared', cleared_date = ? WHERE id = ?`, clearedDate, id)
 	return err
 }
 
@@ -172,7 +225,7 @@ func (s *FinancialService) GetIncomeCategories() []string {
 
 // GetExpenseCategories returns available expense categories
 func (s *FinancialService) GetExpenseCategories() []string {
-	return []string{"feed", "veterinary", "labor", "equipment", "seeds", "fertilizer", "fuel", "maintenance", "transport", "utilities", "other_expense"}
+	return []string{"feed", "veterinary", "livestock_purchase", "labor", "equipment", "seeds", "fertilizer", "fuel", "maintenance", "transport", "utilities", "other_expense"}
 }
 
 // addTransactionInternal is a helper for other services to record transactions
@@ -0,0 +1,75 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestTransactionReconciledGuard(t *testing.T) {
+	setupTestDatabase(t)
+	finance := &FinancialService{}
+
+	add := func(status string, linked bool) Transaction {
+		txn := Transaction{Date: "2024-03-01", Type: "income", Category: "milk_sales", Description: "Milk", Amount: 500, PaymentMethod: "mpesa"}
+		id, err := finance.AddTransaction(txn)
+		if err != nil {
+			t.Fatal(err)
+		}
+		txn.ID = id
+		if _, err := db.Exec(`UPDATE transactions SET reconciliation_status = ? WHERE id = ?`, status, id); err != nil {
+			t.Fatal(err)
+		}
+		if linked {
+			if _, err := db.Exec(`
+				INSERT INTO statement_lines (source, reference, date, amount, direction, status, transaction_id)
+				VALUES ('mpesa', ?, '2024-03-01', 500, 'in', 'matched', ?)
+			`, fmt.Sprintf("R%d", id), id); err != nil {
+				t.Fatal(err)
+			}
+		}
+		return txn
+	}
+	reamount := func(txn Transaction) error {
+		txn.Amount = 450
+		return finance.UpdateTransaction(txn)
+	}
+	recategorize := func(txn Transaction) error {
+		txn.Category, txn.Notes = "other", "moved"
+		return finance.UpdateTransaction(txn)
+	}
+	remove := func(txn Transaction) error { return finance.DeleteTransaction(txn.ID) }
+
+	tests := []struct {
+		name    string
+		txn     Transaction
+		change  func(Transaction) error
+		wantErr string
+	}{
+		{"edit an uncleared amount", add("uncleared", false), reamount, ""},
+		{"edit a cleared amount", add("cleared", false), reamount, "has cleared the bank"},
+		{"edit a matched amount", add("cleared", true), reamount, "reconciled against a bank statement"},
+		{"recategorize a matched transaction", add("cleared", true), recategorize, ""},
+		{"delete an uncleared transaction", add("uncleared", false), remove, ""},
+		{"delete a cleared transaction", add("cleared", false), remove, "has cleared the bank"},
+		{"delete a matched transaction", add("cleared", true), remove, "reconciled against a bank statement"},
+	}
+	for _, tt := range tests {
+		err := tt.change(tt.txn)
+		if tt.wantErr == "" {
+			if err != nil {
+				t.Errorf("%s: unexpected error %v", tt.name, err)
+			}
+			continue
+		}
+		if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+			t.Errorf("%s: error = %v, want %q", tt.name, err, tt.wantErr)
+		}
+		var amount float64
+		if err := db.QueryRow(`SELECT amount FROM transactions WHERE id = ?`, tt.txn.ID).Scan(&amount); err != nil {
+			t.Errorf("%s: transaction is gone: %v", tt.name, err)
+		} else if amount != 500 {
+			t.Errorf("%s: amount = %v, want it kept at 500", tt.name, amount)
+		}
+	}
+}
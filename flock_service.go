@@ -0,0 +1,305 @@
+package main
+
+import (
+	"database/sql"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+)
+
+// FlockService handles poultry flocks, which are recorded as a unit with
+// daily egg and mortality counts instead of as individually tagged animals
+type FlockService struct{}
+
+// NewFlockService creates a new FlockService
+func NewFlockService() *FlockService {
+	return &FlockService{}
+}
+
+// flockLayingWindowDays is the period the laying rate on the flock list covers
+const flockLayingWindowDays = 7
+
+// GetFlocks returns all flocks with their current bird count and recent laying rate
+func (s *FlockService) GetFlocks() ([]Flock, error) {
+	rows, err := db.Query(`
+		SELECT id, name, species, purpose, breed, placed_date, initial_count, status, notes, created_at
+		FROM flocks
+		ORDER BY status = 'active' DESC, placed_date DESC
+	`)
+	if err != nil {
+		return nil, err
+	}
+
+	flocks := []Flock{}
+	for rows.Next() {
+		var f Flock
+		var species, purpose, breed, status, notes sql.NullString
+		err := rows.Scan(&f.ID, &f.Name, &species, &purpose, &breed, &f.PlacedDate, &f.InitialCount, &status, &notes, &f.CreatedAt)
+		if err != nil {
+			rows.Close()
+			return nil, err
+		}
+		f.Species = firstNonEmpty(species.String, "poultry")
+		f.Purpose = firstNonEmpty(purpose.String, "layers")
+		f.Breed = breed.String
+		f.Status = firstNonEmpty(status.String, "active")
+		f.Notes = notes.String
+		flocks = append(flocks, f)
+	}
+	rows.Close()
+
+	end := time.Now().Format("2006-01-02")
+	start := time.Now().AddDate(0, 0, -(flockLayingWindowDays - 1)).Format("2006-01-02")
+	for i := range flocks {
+		records, err := s.GetFlockRecords(flocks[i].ID, "", "")
+		if err != nil {
+			return nil, err
+		}
+		stats := flockPeriodStats(flocks[i], records, start, end)
+		flocks[i].CurrentCount = flockCurrentCount(flocks[i], records)
+		flocks[i].EggsLast7Days = stats.eggs
+		flocks[i].LayingRate = stats.layingRate()
+		for _, r := range records {
+			flocks[i].Mortality += r.Mortality
+		}
+	}
+	return flocks, nil
+}
+
+// GetFlock returns a single flock by ID
+func (s *FlockService) GetFlock(id int64) (*Flock, error) {
+	flocks, err := s.GetFlocks()
+	if err != nil {
+		return nil, err
+	}
+	for _, f := range flocks {
+		if f.ID == id {
+			return &f, nil
+		}
+	}
+	return nil, fmt.Errorf("flock not found")
+}
+
+// AddFlock adds a new flock
+func (s *FlockService) AddFlock(flock Flock) (int64, error) {
+	if err := normalizeFlock(&flock); err != nil {
+		return 0, err
+	}
+	result, err := db.Exec(`
+		INSERT INTO flocks (name, species, purpose, breed, placed_date, initial_count, status, notes)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+	`, flock.Name, flock.Species, flock.Purpose, flock.Breed, flock.PlacedDate, flock.InitialCount, flock.Status, flock.Notes)
+	if err != nil {
+		return 0, err
+	}
+	return result.LastInsertId()
+}
+
+// UpdateFlock updates an existing flock
+func (s *FlockService) UpdateFlock(flock Flock) error {
+	if err := normalizeFlock(&flock); err != nil {
+		return err
+	}
+	_, err := db.Exec(`
+		UPDATE flocks SET name = ?, species = ?, purpose = ?, breed = ?, placed_date = ?, initial_count = ?, status = ?, notes = ?
+		WHERE id = ?
+	`, flock.Name, flock.Species, flock.Purpose, flock.Breed, flock.PlacedDate, flock.InitialCount, flock.Status, flock.Notes, flock.ID)
+	return err
+}
+
+// DeleteFlock deletes a flock and its daily records
+func (s *FlockService) DeleteFlock(id int64) error {
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	if _, err := tx.Exec(`DELETE FROM flock_records WHERE flock_id = ?`, id); err != nil {
+		return err
+	}
+	if _, err := tx.Exec(`DELETE FROM flocks WHERE id = ?`, id); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// normalizeFlock fills defaults and validates a flock before it is saved
+func normalizeFlock(flock *Flock) error {
+	flock.Name = strings.TrimSpace(flock.Name)
+	if flock.Name == "" {
+		return fmt.Errorf("flock name is required")
+	}
+	if flock.InitialCount <= 0 {
+		return fmt.Errorf("a flock needs at least one bird")
+	}
+	if flock.PlacedDate == "" {
+		flock.PlacedDate = time.Now().Format("2006-01-02")
+	}
+	if _, err := time.Parse("2006-01-02", flock.PlacedDate); err != nil {
+		return fmt.Errorf("invalid placed date %q", flock.PlacedDate)
+	}
+	flock.Species = firstNonEmpty(flock.Species, "poultry")
+	if p, ok := speciesProfile(flock.Species); !ok || !p.FlockOnly {
+		return fmt.Errorf("%s are recorded as individual animals, not flocks", flock.Species)
+	}
+	flock.Purpose = firstNonEmpty(flock.Purpose, "layers")
+	flock.Status = firstNonEmpty(flock.Status, "active")
+	return nil
+}
+
+// GetFlockRecords returns daily records for a flock, or all flocks if
+// flockID is 0, within a date range, oldest first
+func (s *FlockService) GetFlockRecords(flockID int64, startDate, endDate string) ([]FlockRecord, error) {
+	query := `
+		SELECT r.id, r.flock_id, f.name, r.date, r.eggs, r.broken_eggs, r.mortality, r.culled, r.sold, r.feed_kg, r.notes, r.created_at
+		FROM flock_records r
+		JOIN flocks f ON r.flock_id = f.id
+		WHERE 1=1
+	`
+	args := []interface{}{}
+
+	if flockID > 0 {
+		query += " AND r.flock_id = ?"
+		args = append(args, flockID)
+	}
+	if startDate != "" {
+		query += " AND r.date >= ?"
+		args = append(args, startDate)
+	}
+	if endDate != "" {
+		query += " AND r.date <= ?"
+		args = append(args, endDate)
+	}
+	query += " ORDER BY r.date, f.name"
+
+	rows, err := db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	records := []FlockRecord{}
+	for rows.Next() {
+		var r FlockRecord
+		var notes sql.NullString
+		err := rows.Scan(&r.ID, &r.FlockID, &r.FlockName, &r.Date, &r.Eggs, &r.BrokenEggs, &r.Mortality, &r.Culled, &r.Sold, &r.FeedKg, &notes, &r.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+		r.Notes = notes.String
+		records = append(records, r)
+	}
+	return records, nil
+}
+
+// SaveFlockRecord records a flock's counts for a day, replacing any record
+// already saved for that flock and date
+func (s *FlockService) SaveFlockRecord(record FlockRecord) (int64, error) {
+	if record.Date == "" {
+		record.Date = time.Now().Format("2006-01-02")
+	}
+	if record.Eggs < 0 || record.BrokenEggs < 0 || record.Mortality < 0 || record.Culled < 0 || record.Sold < 0 || record.FeedKg < 0 {
+		return 0, fmt.Errorf("counts cannot be negative")
+	}
+	if record.BrokenEggs > record.Eggs {
+		return 0, fmt.Errorf("broken eggs cannot exceed eggs collected")
+	}
+
+	flock, err := s.GetFlock(record.FlockID)
+	if err != nil {
+		return 0, err
+	}
+	if record.Date < flock.PlacedDate {
+		return 0, fmt.Errorf("%s was placed on %s", flock.Name, flock.PlacedDate)
+	}
+
+	// Birds lost on other days limit how many can be lost on this one
+	var otherLosses int
+	err = db.QueryRow(`
+		SELECT COALESCE(SUM(mortality + culled + sold), 0) FROM flock_records WHERE flock_id = ? AND date != ?
+	`, record.FlockID, record.Date).Scan(&otherLosses)
+	if err != nil {
+		return 0, err
+	}
+	if remaining := flock.InitialCount - otherLosses; record.Mortality+record.Culled+record.Sold > remaining {
+		return 0, fmt.Errorf("%s only has %d birds", flock.Name, remaining)
+	}
+
+	_, err = db.Exec(`
+		INSERT INTO flock_records (flock_id, date, eggs, broken_eggs, mortality, culled, sold, feed_kg, notes)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT(flock_id, date) DO UPDATE SET
+			eggs = excluded.eggs, broken_eggs = excluded.broken_eggs, mortality = excluded.mortality,
+			culled = excluded.culled, sold = excluded.sold, feed_kg = excluded.feed_kg, notes = excluded.notes
+	`, record.FlockID, record.Date, record.Eggs, record.BrokenEggs, record.Mortality, record.Culled, record.Sold, record.FeedKg, record.Notes)
+	if err != nil {
+		return 0, err
+	}
+
+	var id int64
+	err = db.QueryRow(`SELECT id FROM flock_records WHERE flock_id = ? AND date = ?`, record.FlockID, record.Date).Scan(&id)
+	return id, err
+}
+
+// DeleteFlockRecord deletes a daily flock record
+func (s *FlockService) DeleteFlockRecord(id int64) error {
+	_, err := db.Exec(`DELETE FROM flock_records WHERE id = ?`, id)
+	return err
+}
+
+// flockStats are a flock's production figures over a period
+type flockStats struct {
+	eggs      int
+	henDays   int // live birds summed over the days of the period
+	mortality int
+}
+
+// layingRate returns eggs per 100 hen-days
+func (st flockStats) layingRate() float64 {
+	if st.henDays == 0 {
+		return 0
+	}
+	return math.Round(float64(st.eggs)/float64(st.henDays)*1000) / 10
+}
+
+// flockPeriodStats walks a flock's days from placement to the end of the
+// period, counting the birds alive each day so the laying rate follows the
+// flock size as birds die or are sold. Records must be sorted by date.
+func flockPeriodStats(flock Flock, records []FlockRecord, startDate, endDate string) flockStats {
+	byDate := make(map[string]FlockRecord, len(records))
+	for _, r := range records {
+		byDate[r.Date] = r
+	}
+
+	var st flockStats
+	day, err := time.Parse("2006-01-02", flock.PlacedDate)
+	if err != nil {
+		return st
+	}
+	alive := flock.InitialCount
+	for date := day.Format("2006-01-02"); date <= endDate; date = day.Format("2006-01-02") {
+		r := byDate[date]
+		if date >= startDate {
+			st.henDays += alive
+			st.eggs += r.Eggs
+			st.mortality += r.Mortality
+		}
+		alive -= r.Mortality + r.Culled + r.Sold
+		day = day.AddDate(0, 0, 1)
+	}
+	return st
+}
+
+// flockCurrentCount returns the birds left after all recorded losses and sales
+func flockCurrentCount(flock Flock, records []FlockRecord) int {
+	count := flock.InitialCount
+	for _, r := range records {
+		count -= r.Mortality + r.Culled + r.Sold
+	}
+	if count < 0 {
+		return 0
+	}
+	return count
+}
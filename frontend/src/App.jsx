@@ -14,6 +14,11 @@ import { Settings } from './pages/Settings';
 import { Notifications } from './pages/Notifications';
 import { AnimalDetails } from './pages/AnimalDetails';
 import { FieldDetails } from './pages/FieldDetails';
+import { Poultry } from './pages/Poultry';
+import { Buyers } from './pages/Buyers';
+import { Cooperative } from './pages/Cooperative';
+import { MilkBalance } from './pages/MilkBalance';
+import { Reconciliation } from './pages/Reconciliation';
 import { toast } from 'sonner';
 
 function App() {
@@ -55,13 +60,18 @@ function App() {
                 <Route index element={<Dashboard />} />
                 <Route path="livestock" element={<Livestock />} />
                 <Route path="livestock/:id" element={<AnimalDetails />} />
+                <Route path="poultry" element={<Poultry />} />
                 <Route path="milk-sales" element={<MilkSales />} />
+                <Route path="buyers" element={<Buyers />} />
+                <Route path="cooperative" element={<Cooperative />} />
+                <Route path="milk-balance" element={<MilkBalance />} />
                 <Route path="crops" element={<Crops />} />
                 <Route path="crops/field/:id" element={<FieldDetails />} />
                 <Route path="inventory" element={<Inventory />} />
                 <Route path="feed" element={<Feed />} />
                 <Route path="health" element={<Health />} />
                 <Route path="finances" element={<Finances />} />
+                <Route path="reconciliation" element={<Reconciliation />} />
                 <Route path="breeding" element={<Breeding />} />
                 <Route path="settings" element={<Settings />} />
                 <Route path="notifications" element={<Notifications />} />
@@ -1,5 +1,5 @@
 import React, { useState, useEffect } from 'react';
-import { Bell, Calendar, Box, Activity, AlertCircle, ChevronRight, Check } from 'lucide-react';
+import { Bell, Calendar, Box, Activity, AlertCircle, ChevronRight, Check, Milk } from 'lucide-react';
 import './NotificationCenter.css';
 
 export function NotificationCenter() {
@@ -27,6 +27,8 @@ export function NotificationCenter() {
             case 'vet_followup': return <AlertCircle size={18} className="text-info" />;
             case 'breeding': return <Calendar size={18} className="text-primary" />;
             case 'low_stock': return <Box size={18} className="text-warning" />;
+            case 'milk_drop':
+            case 'milk_missing': return <Milk size={18} className="text-warning" />;
             default: return <Bell size={18} />;
         }
     };
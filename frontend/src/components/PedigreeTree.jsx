@@ -0,0 +1,92 @@
+import React, { useState, useEffect } from 'react';
+import { useNavigate } from 'react-router-dom';
+import { GitBranch, Users } from 'lucide-react';
+import './PedigreeTree.css';
+
+const generations = 3;
+
+// Lays the ancestors out one column per generation, dam above sire, with
+// empty slots for parents that were not recorded
+const ancestorColumns = (tree) => {
+    const columns = [];
+    let level = [tree];
+    for (let g = 1; g <= generations; g++) {
+        level = level.flatMap(n => [n?.mother || null, n?.father || null]);
+        columns.push(level);
+    }
+    return columns;
+};
+
+const genderMark = (gender) => (gender === 'female' ? '♀' : gender === 'male' ? '♂' : '');
+
+export function PedigreeTree({ animal }) {
+    const navigate = useNavigate();
+    const [ancestors, setAncestors] = useState(null);
+    const [descendants, setDescendants] = useState(null);
+
+    useEffect(() => {
+        window.go.main.BreedingService.GetPedigree(animal.id, generations)
+            .then(setAncestors)
+            .catch(err => console.error('Failed to load pedigree:', err));
+        window.go.main.BreedingService.GetDescendants(animal.id)
+            .then(setDescendants)
+            .catch(err => console.error('Failed to load descendants:', err));
+    }, [animal.id]);
+
+    const renderAncestor = (node, i) => (
+        node ? (
+            <button key={i} type="button" className="pedigree-node" onClick={() => navigate(`/livestock/${node.animalId}`)}>
+                <span className="pedigree-name">{genderMark(node.gender)} {node.name}</span>
+                <span className="pedigree-meta">{[node.tagNumber, node.breed].filter(Boolean).join(' · ') || '-'}</span>
+            </button>
+        ) : (
+            <div key={i} className="pedigree-node unknown">Unknown</div>
+        )
+    );
+
+    const renderDescendants = (children) => (
+        <ul className="descendant-list">
+            {children.map(c => (
+                <li key={c.animalId}>
+                    <button type="button" className="descendant-item" onClick={() => navigate(`/livestock/${c.animalId}`)}>
+                        <span>{genderMark(c.gender)} {c.name}</span>
+                        {c.dateOfBirth && <span className="pedigree-meta font-mono">{c.dateOfBirth}</span>}
+                    </button>
+                    {c.children?.length > 0 && renderDescendants(c.children)}
+                </li>
+            ))}
+        </ul>
+    );
+
+    const hasAncestors = ancestors && (ancestors.mother || ancestors.father);
+    const children = descendants?.children || [];
+
+    return (
+        <>
+            <section>
+                <h4 className="data-label mb-6"><GitBranch size={12} className="inline mr-1" /> Pedigree</h4>
+                {!hasAncestors ? (
+                    <p className="movement-empty">No parents recorded</p>
+                ) : (
+                    <div className="pedigree-grid">
+                        {ancestorColumns(ancestors).map((column, g) => (
+                            <div key={g} className="pedigree-column">
+                                <span className="pedigree-generation">{['Parents', 'Grandparents', 'Great-grandparents'][g]}</span>
+                                {column.map(renderAncestor)}
+                            </div>
+                        ))}
+                    </div>
+                )}
+            </section>
+
+            <div className="details-divider"></div>
+
+            <section>
+                <h4 className="data-label mb-6"><Users size={12} className="inline mr-1" /> Offspring & Descendants</h4>
+                {children.length === 0 ? (
+                    <p className="movement-empty">No offspring recorded</p>
+                ) : renderDescendants(children)}
+            </section>
+        </>
+    );
+}
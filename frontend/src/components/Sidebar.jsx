@@ -3,6 +3,7 @@ import { NavLink } from 'react-router-dom';
 import {
   LayoutDashboard,
   Beef,
+  Bird,
   Milk,
   Wheat,
   Package,
@@ -14,7 +15,11 @@ import {
   RefreshCw,
   Baby,
   Settings,
-  Bell
+  Bell,
+  Users,
+  Truck,
+  Scale,
+  ListChecks
 } from 'lucide-react';
 import { UpdateManager, UpdateBadge } from './UpdateManager';
 import logo from '../assets/logo.png';
@@ -31,7 +36,11 @@ const navGroups = [
     title: 'Farm Management',
     items: [
       { path: '/livestock', icon: Beef, label: 'Livestock' },
+      { path: '/poultry', icon: Bird, label: 'Poultry' },
       { path: '/milk-sales', icon: Milk, label: 'Milk Sales' },
+      { path: '/buyers', icon: Users, label: 'Buyers' },
+      { path: '/cooperative', icon: Truck, label: 'Cooperative' },
+      { path: '/milk-balance', icon: Scale, label: 'Milk Balance' },
       { path: '/crops', icon: Wheat, label: 'Crops' },
     ]
   },
@@ -47,6 +56,7 @@ const navGroups = [
     title: 'System',
     items: [
       { path: '/finances', icon: DollarSign, label: 'Finances' },
+      { path: '/reconciliation', icon: ListChecks, label: 'Reconciliation' },
       { path: '/settings', icon: Settings, label: 'Settings' },
     ]
   }
@@ -0,0 +1,182 @@
+import React, { useState, useEffect } from 'react';
+import { Scale, Plus, Edit2, Trash2 } from 'lucide-react';
+import { LineChart, Line, XAxis, YAxis, CartesianGrid, Tooltip as RechartsTooltip, ResponsiveContainer, Legend } from 'recharts';
+import { Button } from './ui/Button';
+import { Modal } from './ui/Modal';
+import { ConfirmDialog } from './ui/ConfirmDialog';
+import { Input, Label, FormGroup, Select, Textarea, FormRow } from './ui/Form';
+import { toast } from 'sonner';
+import { curveForBreed, targetWeightAt, ageInMonths } from '../utils/growth';
+import './WeightHistory.css';
+
+const methods = [
+    { value: 'scale', label: 'Scale' },
+    { value: 'weigh_band', label: 'Weigh band' },
+    { value: 'estimate', label: 'Estimate' },
+];
+
+const emptyForm = () => ({ id: null, date: new Date().toISOString().split('T')[0], weightKg: '', method: 'scale', notes: '' });
+
+export function WeightHistory({ animal }) {
+    const [analysis, setAnalysis] = useState(null);
+    const [curve, setCurve] = useState(null);
+    const [showModal, setShowModal] = useState(false);
+    const [form, setForm] = useState(emptyForm());
+    const [confirmDelete, setConfirmDelete] = useState({ show: false, id: null });
+
+    useEffect(() => { loadGrowth(); }, [animal.id]);
+
+    const loadGrowth = async () => {
+        try {
+            const [data, curves] = await Promise.all([
+                window.go.main.LivestockService.GetGrowthAnalysis(animal.id),
+                window.go.main.LivestockService.GetGrowthTargetCurves()
+            ]);
+            setAnalysis(data);
+            setCurve(curveForBreed(curves, animal.species, animal.breed));
+        } catch (err) {
+            console.error('Failed to load weights:', err);
+        }
+    };
+
+    const openModal = (record) => {
+        setForm(record
+            ? { id: record.id, date: record.date, weightKg: record.weightKg, method: record.method || 'scale', notes: record.notes || '' }
+            : emptyForm());
+        setShowModal(true);
+    };
+
+    const handleSubmit = async (e) => {
+        e.preventDefault();
+        const record = { ...form, animalId: animal.id, weightKg: parseFloat(form.weightKg) || 0 };
+        try {
+            if (form.id) {
+                await window.go.main.LivestockService.UpdateWeightRecord(record);
+            } else {
+                await window.go.main.LivestockService.AddWeightRecord({ ...record, id: 0 });
+            }
+            toast.success(form.id ? 'Weight updated' : 'Weight recorded');
+            setShowModal(false);
+            loadGrowth();
+        } catch (err) {
+            console.error(err);
+            toast.error(typeof err === 'string' ? err : 'Failed to save weight');
+        }
+    };
+
+    const handleDelete = async () => {
+        try {
+            await window.go.main.LivestockService.DeleteWeightRecord(confirmDelete.id);
+            toast.success('Weight deleted');
+            loadGrowth();
+        } catch (err) {
+            console.error(err);
+            toast.error(typeof err === 'string' ? err : 'Failed to delete weight');
+        } finally {
+            setConfirmDelete({ show: false, id: null });
+        }
+    };
+
+    const records = analysis?.records || [];
+    const chartData = records.map(r => {
+        const target = animal.dateOfBirth ? targetWeightAt(curve, ageInMonths(animal.dateOfBirth, r.date)) : 0;
+        return { date: r.date, weight: r.weightKg, target: target > 0 ? Math.round(target) : null };
+    });
+    const hasTarget = chartData.some(p => p.target);
+
+    return (
+        <section>
+            <div className="movement-header">
+                <h4 className="data-label"><Scale size={12} className="inline mr-1" /> Weight & Growth</h4>
+                <Button variant="outline" size="sm" icon={Plus} onClick={() => openModal(null)}>Add Weight</Button>
+            </div>
+
+            {records.length === 0 ? (
+                <p className="movement-empty">No weights recorded</p>
+            ) : (
+                <>
+                    <div className="growth-summary">
+                        <div className="data-field">
+                            <span className="data-label">Latest</span>
+                            <span className="data-value">{analysis.latestWeight.toFixed(1)} kg</span>
+                        </div>
+                        <div className="data-field">
+                            <span className="data-label">Overall ADG</span>
+                            <span className="data-value">{records.length > 1 ? `${(analysis.overallAdg * 1000).toFixed(0)} g/day` : '-'}</span>
+                        </div>
+                        <div className="data-field">
+                            <span className="data-label">Target</span>
+                            <span className={`data-value ${analysis.belowTarget ? 'growth-below' : ''}`}>
+                                {analysis.targetWeight > 0
+                                    ? `${analysis.targetWeight.toFixed(0)} kg (${analysis.percentOfTarget.toFixed(0)}%)`
+                                    : '-'}
+                            </span>
+                        </div>
+                    </div>
+
+                    {records.length > 1 && (
+                        <div className="growth-chart">
+                            <ResponsiveContainer width="100%" height="100%">
+                                <LineChart data={chartData} margin={{ top: 10, right: 20, left: 0, bottom: 0 }}>
+                                    <CartesianGrid strokeDasharray="3 3" stroke="var(--color-neutral-200)" vertical={false} />
+                                    <XAxis dataKey="date" tick={{ fontSize: 10, fill: 'var(--color-neutral-500)' }} axisLine={false} tickLine={false} />
+                                    <YAxis tick={{ fontSize: 10, fill: 'var(--color-neutral-500)' }} axisLine={false} tickLine={false} tickFormatter={(val) => `${val}kg`} />
+                                    <RechartsTooltip formatter={(value, name) => [`${value} kg`, name]} />
+                                    {hasTarget && <Legend wrapperStyle={{ fontSize: 'var(--font-size-xs)' }} />}
+                                    <Line type="monotone" dataKey="weight" name="Weight" stroke="var(--color-primary-500)" strokeWidth={2} dot={{ r: 3 }} />
+                                    {hasTarget && (
+                                        <Line type="monotone" dataKey="target" name={`${curve.breed === 'default' ? 'Target' : `${curve.breed} target`}`} stroke="var(--color-neutral-400)" strokeDasharray="4 3" dot={false} connectNulls />
+                                    )}
+                                </LineChart>
+                            </ResponsiveContainer>
+                        </div>
+                    )}
+
+                    <ul className="movement-list">
+                        {[...records].reverse().map(r => (
+                            <li key={r.id} className="movement-item">
+                                <span className="font-mono">{r.date}</span>
+                                <strong>{r.weightKg.toFixed(1)} kg</strong>
+                                {r.adg !== null && r.adg !== undefined && (
+                                    <span className={r.adg < 0 ? 'growth-below' : 'growth-gain'}>{r.adg >= 0 ? '+' : ''}{(r.adg * 1000).toFixed(0)} g/day</span>
+                                )}
+                                <span className="movement-reason">{methods.find(m => m.value === r.method)?.label || r.method}{r.notes ? ` · ${r.notes}` : ''}</span>
+                                <div className="action-buttons">
+                                    <button className="action-btn edit" onClick={() => openModal(r)} title="Edit"><Edit2 size={14} /></button>
+                                    <button className="action-btn delete" onClick={() => setConfirmDelete({ show: true, id: r.id })} title="Delete"><Trash2 size={14} /></button>
+                                </div>
+                            </li>
+                        ))}
+                    </ul>
+                </>
+            )}
+
+            <Modal isOpen={showModal} onClose={() => setShowModal(false)} title={`${form.id ? 'Edit' : 'Record'} Weight - ${animal.name}`} size="sm">
+                <form onSubmit={handleSubmit}>
+                    <FormRow>
+                        <FormGroup><Label htmlFor="weightDate" required>Date</Label><Input id="weightDate" type="date" value={form.date} onChange={(e) => setForm({ ...form, date: e.target.value })} required /></FormGroup>
+                        <FormGroup><Label htmlFor="weightKg" required>Weight (kg)</Label><Input id="weightKg" type="number" step="0.1" min="0" value={form.weightKg} onChange={(e) => setForm({ ...form, weightKg: e.target.value })} required /></FormGroup>
+                    </FormRow>
+                    <FormGroup>
+                        <Label htmlFor="weightMethod">Method</Label>
+                        <Select id="weightMethod" value={form.method} onChange={(e) => setForm({ ...form, method: e.target.value })}>
+                            {methods.map(m => <option key={m.value} value={m.value}>{m.label}</option>)}
+                        </Select>
+                    </FormGroup>
+                    <FormGroup><Label htmlFor="weightNotes">Notes</Label><Textarea id="weightNotes" value={form.notes} onChange={(e) => setForm({ ...form, notes: e.target.value })} rows={2} /></FormGroup>
+                    <div className="modal-actions"><Button variant="outline" type="button" onClick={() => setShowModal(false)}>Cancel</Button><Button type="submit">{form.id ? 'Update' : 'Save'} Weight</Button></div>
+                </form>
+            </Modal>
+
+            <ConfirmDialog
+                isOpen={confirmDelete.show}
+                onClose={() => setConfirmDelete({ show: false, id: null })}
+                onConfirm={handleDelete}
+                title="Delete Weight"
+                message="Are you sure you want to delete this weight record?"
+                type="danger"
+                confirmText="Delete"
+            />
+        </section>
+    );
+}
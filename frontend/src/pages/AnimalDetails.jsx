@@ -4,13 +4,19 @@ import {
     ArrowLeft, Edit2, Milk, Users, Info, Calendar,
     ChevronRight, Tag, Activity, Heart, Trash2,
     Database, Beef, TrendingUp, Camera,
-    Clipboard, Fingerprint, Save, CheckCircle
+    Clipboard, Fingerprint, Save, CheckCircle, MapPin, FileText, Package, LogOut, Undo2
 } from 'lucide-react';
 import { Button } from '../components/ui/Button';
 import { Card, CardContent } from '../components/ui/Card';
 import { Modal } from '../components/ui/Modal';
 import { PhotoGallery } from '../components/PhotoGallery';
-import { Input, Label, FormGroup, Select, Textarea, FormRow } from '../components/ui/Form';
+import { WeightHistory } from '../components/WeightHistory';
+import { PedigreeTree } from '../components/PedigreeTree';
+import { ConfirmDialog } from '../components/ui/ConfirmDialog';
+import { Input, Label, FormGroup, Select, Textarea, FormRow, Checkbox } from '../components/ui/Form';
+import { toast } from 'sonner';
+import { sessionsToForm, formToSessions, visibleSessions } from '../utils/milkSessions';
+import { formatType } from '../utils/species';
 import './Livestock.css';
 import '../components/EntityDetails.css';
 
@@ -29,17 +35,49 @@ export function AnimalDetails() {
     const saveTimeoutRef = useRef(null);
 
     const [milkForm, setMilkForm] = useState({
-        date: new Date().toISOString().split('T')[0], morningLiters: '', eveningLiters: '', notes: ''
+        date: new Date().toISOString().split('T')[0], liters: {}, discarded: false, notes: ''
     });
     const [existingMilkRecord, setExistingMilkRecord] = useState(null);
+    const [milkWithdrawal, setMilkWithdrawal] = useState(null);
+    const [milkSessions, setMilkSessions] = useState([]);
+    const [inbreeding, setInbreeding] = useState(null);
+    const [movements, setMovements] = useState([]);
+    const [typeHistory, setTypeHistory] = useState([]);
+    const [groups, setGroups] = useState([]);
+    const [speciesProfiles, setSpeciesProfiles] = useState([]);
+    const [showMoveModal, setShowMoveModal] = useState(false);
+    const [moveForm, setMoveForm] = useState({ groupId: '', date: new Date().toISOString().split('T')[0], reason: '' });
+    const [disposal, setDisposal] = useState(null);
+    const [showDisposeModal, setShowDisposeModal] = useState(false);
+    const [confirmReverse, setConfirmReverse] = useState(false);
+    const [disposalReasons, setDisposalReasons] = useState({ death: [], cull: [] });
+    const [disposeForm, setDisposeForm] = useState({
+        disposalType: 'sale', date: new Date().toISOString().split('T')[0], buyerName: '', price: '', causeOfDeath: '', cullReason: '', notes: ''
+    });
 
     const [editForm, setEditForm] = useState({
         id: id, tagNumber: '', name: '', type: '', breed: '', dateOfBirth: '',
-        gender: '', motherId: null, fatherId: null, status: '', notes: ''
+        gender: '', motherId: null, fatherId: null, status: '', notes: '',
+        acquisitionType: 'born', seller: '', purchaseDate: '', purchasePrice: '', transportCost: '', quarantineDays: ''
     });
 
     useEffect(() => { loadAnimal(); }, [id]);
 
+    useEffect(() => {
+        window.go.main.LivestockService.GetMilkingSessions()
+            .then(data => setMilkSessions(data || []))
+            .catch(err => console.error(err));
+        window.go.main.LivestockService.GetSpeciesProfiles()
+            .then(data => setSpeciesProfiles(data || []))
+            .catch(err => console.error(err));
+        Promise.all([
+            window.go.main.LivestockService.GetDeathCauses(),
+            window.go.main.LivestockService.GetCullReasons()
+        ])
+            .then(([death, cull]) => setDisposalReasons({ death: death || [], cull: cull || [] }))
+            .catch(err => console.error(err));
+    }, []);
+
     const loadAnimal = async () => {
         try {
             setLoading(true);
@@ -48,28 +86,107 @@ export function AnimalDetails() {
                 setAnimal(data);
                 setNotes(data.notes || '');
                 setEditForm({ ...data, motherId: data.motherId || null, fatherId: data.fatherId || null });
+                window.go.main.BreedingService.GetInbreedingCoefficient(data.id)
+                    .then(setInbreeding)
+                    .catch(() => setInbreeding(null));
+                window.go.main.GroupService.GetAnimalMovements(data.id)
+                    .then(list => setMovements(list || []))
+                    .catch(err => console.error(err));
+                window.go.main.LivestockService.GetAnimalTypeHistory(data.id)
+                    .then(list => setTypeHistory(list || []))
+                    .catch(err => console.error(err));
+                if (data.status !== 'active') {
+                    window.go.main.LivestockService.GetDisposals('', '', '')
+                        .then(list => setDisposal((list || []).find(d => d.animalId === data.id) || null))
+                        .catch(err => console.error(err));
+                } else {
+                    setDisposal(null);
+                }
             }
         } catch (err) { console.error(err); }
         finally { setLoading(false); }
     };
 
+    const openMoveModal = async () => {
+        try {
+            const data = await window.go.main.GroupService.GetGroups();
+            setGroups((data || []).filter(g => g.active));
+        } catch (err) { console.error(err); }
+        setMoveForm({ groupId: animal.groupId ? String(animal.groupId) : '', date: new Date().toISOString().split('T')[0], reason: '' });
+        setShowMoveModal(true);
+    };
+
+    const handleMoveSubmit = async (e) => {
+        e.preventDefault();
+        try {
+            const moved = await window.go.main.GroupService.MoveAnimals([animal.id], parseInt(moveForm.groupId) || 0, moveForm.date, moveForm.reason);
+            toast.success(moved ? `${animal.name} moved` : `${animal.name} is already there`);
+            setShowMoveModal(false);
+            loadAnimal();
+        } catch (err) {
+            console.error(err);
+            toast.error(typeof err === 'string' ? err : 'Failed to move animal');
+        }
+    };
+
+    const openDisposeModal = () => {
+        setDisposeForm({
+            disposalType: 'sale', date: new Date().toISOString().split('T')[0], buyerName: '', price: '', causeOfDeath: '', cullReason: '', notes: ''
+        });
+        setShowDisposeModal(true);
+    };
+
+    const handleDisposeSubmit = async (e) => {
+        e.preventDefault();
+        try {
+            await window.go.main.LivestockService.DisposeAnimal({
+                ...disposeForm,
+                animalId: animal.id,
+                price: parseFloat(disposeForm.price) || 0
+            });
+            toast.success(`${animal.name} recorded as ${disposeForm.disposalType === 'death' ? 'dead' : disposeForm.disposalType === 'cull' ? 'culled' : 'sold'}`);
+            setShowDisposeModal(false);
+            loadAnimal();
+        } catch (err) {
+            console.error(err);
+            toast.error(typeof err === 'string' ? err : 'Failed to record disposal');
+        }
+    };
+
+    const handleReverseDisposal = async () => {
+        try {
+            await window.go.main.LivestockService.ReverseDisposal(disposal.id);
+            toast.success(`${animal.name} is active again`);
+            loadAnimal();
+        } catch (err) {
+            console.error(err);
+            toast.error(typeof err === 'string' ? err : 'Failed to reverse disposal');
+        } finally {
+            setConfirmReverse(false);
+        }
+    };
+
     const loadMilkRecord = async (animalId, date) => {
         try {
-            const record = await window.go.main.LivestockService.GetMilkRecordByAnimalAndDate(animalId, date);
+            const [record, withdrawal] = await Promise.all([
+                window.go.main.LivestockService.GetMilkRecordByAnimalAndDate(animalId, date),
+                window.go.main.HealthService.GetMilkWithdrawal(animalId, date)
+            ]);
+            setMilkWithdrawal(withdrawal);
             if (record) {
                 setExistingMilkRecord(record);
                 setMilkForm({
                     date: record.date,
-                    morningLiters: record.morningLiters > 0 ? record.morningLiters.toString() : '',
-                    eveningLiters: record.eveningLiters > 0 ? record.eveningLiters.toString() : '',
+                    liters: sessionsToForm(record.sessions),
+                    discarded: record.discarded,
                     notes: record.notes || ''
                 });
             } else {
                 setExistingMilkRecord(null);
                 setMilkForm({
                     date: date,
-                    morningLiters: '',
-                    eveningLiters: '',
+                    liters: {},
+                    discarded: !!withdrawal,
                     notes: ''
                 });
             }
@@ -134,22 +251,25 @@ export function AnimalDetails() {
                     id: existingMilkRecord.id,
                     animalId: parseInt(id),
                     date: milkForm.date,
-                    morningLiters: parseFloat(milkForm.morningLiters) || 0,
-                    eveningLiters: parseFloat(milkForm.eveningLiters) || 0,
+                    sessions: formToSessions(milkForm.liters),
+                    discarded: milkForm.discarded,
                     notes: milkForm.notes
                 });
             } else {
                 await window.go.main.LivestockService.AddMilkRecord({
                     animalId: parseInt(id),
                     date: milkForm.date,
-                    morningLiters: parseFloat(milkForm.morningLiters) || 0,
-                    eveningLiters: parseFloat(milkForm.eveningLiters) || 0,
+                    sessions: formToSessions(milkForm.liters),
+                    discarded: milkForm.discarded,
                     notes: milkForm.notes
                 });
             }
+            if (milkWithdrawal && !milkForm.discarded) {
+                toast.warning(`${animal.name}'s milk is withheld until ${milkWithdrawal.milkWithheldUntil} and was recorded as saleable`);
+            }
             setShowMilkModal(false);
             setExistingMilkRecord(null);
-            setMilkForm({ date: new Date().toISOString().split('T')[0], morningLiters: '', eveningLiters: '', notes: '' });
+            setMilkForm({ date: new Date().toISOString().split('T')[0], liters: {}, discarded: false, notes: '' });
         } catch (err) { console.error(err); }
     };
 
@@ -160,13 +280,33 @@ export function AnimalDetails() {
                 ...editForm,
                 id: parseInt(id),
                 motherId: editForm.motherId ? parseInt(editForm.motherId) : null,
-                fatherId: editForm.fatherId ? parseInt(editForm.fatherId) : null
+                fatherId: editForm.fatherId ? parseInt(editForm.fatherId) : null,
+                purchasePrice: parseFloat(editForm.purchasePrice) || 0,
+                transportCost: parseFloat(editForm.transportCost) || 0,
+                quarantineDays: parseInt(editForm.quarantineDays) || 0
             });
             setShowEditModal(false);
             loadAnimal();
         } catch (err) { console.error(err); }
     };
 
+    const handleExportPassport = async (asPackage) => {
+        const loadingToast = toast.loading(`Generating passport for ${animal.name}...`);
+        try {
+            const res = asPackage
+                ? await window.go.main.ExportService.ExportAnimalPassportPackage(animal.id)
+                : await window.go.main.ExportService.ExportAnimalPassportPDF(animal.id);
+            if (res) {
+                toast.success('Passport exported', { id: loadingToast, description: `File saved to ${res.path}` });
+            } else {
+                toast.dismiss(loadingToast);
+            }
+        } catch (err) {
+            console.error('Passport export failed:', err);
+            toast.error(typeof err === 'string' ? err : 'Failed to export passport', { id: loadingToast });
+        }
+    };
+
     const handleMilkDateChange = (newDate) => {
         if (animal) {
             loadMilkRecord(animal.id, newDate);
@@ -205,6 +345,11 @@ export function AnimalDetails() {
                             setShowMilkModal(true);
                         }}>Record Milk</Button>
                     )}
+                    <Button icon={FileText} variant="outline" onClick={() => handleExportPassport(false)} title="Printable passport with identity, pedigree, health and production history">Passport PDF</Button>
+                    <Button icon={Package} variant="outline" onClick={() => handleExportPassport(true)} title="Zip with passport.json and photos for transfer to another system">Passport Package</Button>
+                    {animal.status === 'active' && (
+                        <Button icon={LogOut} variant="outline" onClick={openDisposeModal} title="Record a sale, death or cull">Dispose</Button>
+                    )}
                     <Button icon={Edit2} onClick={() => setShowEditModal(true)}>Edit Details</Button>
                 </div>
             </header>
@@ -234,10 +379,53 @@ export function AnimalDetails() {
                                         </div>
                                         <div className="data-field">
                                             <span className="data-label">Animal Type</span>
-                                            <span className="data-value capitalize">{animal.type}</span>
+                                            <span className="data-value">{formatType(animal.type)}{animal.species && animal.species !== 'cattle' ? ` (${animal.species})` : ''}</span>
+                                        </div>
+                                        <div className="data-field">
+                                            <span className="data-label">Group / Pen</span>
+                                            <span className="data-value">{animal.groupName || 'No group'}</span>
+                                        </div>
+                                        <div className="data-field">
+                                            <span className="data-label">Acquired</span>
+                                            <span className="data-value">
+                                                {animal.acquisitionType === 'purchased'
+                                                    ? `Purchased ${animal.purchaseDate}${animal.seller ? ` from ${animal.seller}` : ''}`
+                                                    : 'Born on the farm'}
+                                            </span>
                                         </div>
+                                        {animal.acquisitionType === 'purchased' && (
+                                            <div className="data-field">
+                                                <span className="data-label">Purchase Cost</span>
+                                                <span className="data-value">
+                                                    {animal.purchasePrice.toLocaleString()}{animal.transportCost > 0 && ` + ${animal.transportCost.toLocaleString()} transport`}
+                                                </span>
+                                            </div>
+                                        )}
+                                        {animal.quarantineEndDate && (
+                                            <div className="data-field">
+                                                <span className="data-label">Quarantine Until</span>
+                                                <span className="data-value">{animal.quarantineEndDate}</span>
+                                            </div>
+                                        )}
                                     </div>
 
+                                    {disposal && (
+                                        <div className="disposal-summary">
+                                            <div>
+                                                <span className="data-label">Left the herd</span>
+                                                <span className="data-value">
+                                                    <span className="disposal-type">{disposal.disposalType}</span> on {disposal.date}
+                                                    {disposal.buyerName && ` to ${disposal.buyerName}`}
+                                                    {disposal.price > 0 && ` for ${disposal.price.toLocaleString()}`}
+                                                </span>
+                                                {(disposal.causeOfDeath || disposal.cullReason) && (
+                                                    <span className="disposal-cause">{formatType(disposal.causeOfDeath || disposal.cullReason)}</span>
+                                                )}
+                                            </div>
+                                            <Button variant="outline" size="sm" icon={Undo2} onClick={() => setConfirmReverse(true)} title="Undo a disposal entered by mistake">Reverse</Button>
+                                        </div>
+                                    )}
+
                                     <div className="details-divider"></div>
 
                                     <section>
@@ -258,8 +446,74 @@ export function AnimalDetails() {
                                                 </div>
                                             </div>
                                         </div>
+                                        {inbreeding && (
+                                            <div className={`inbreeding-summary ${inbreeding.assessment}`}>
+                                                <div className="inbreeding-value">
+                                                    <span className="data-label">Inbreeding Coefficient</span>
+                                                    <span className="data-value">{inbreeding.percent.toFixed(2)}%</span>
+                                                </div>
+                                                {inbreeding.commonAncestors?.length > 0 ? (
+                                                    <span className="inbreeding-ancestors">
+                                                        Common ancestors: {inbreeding.commonAncestors.slice(0, 3).map(a => a.name).join(', ')}
+                                                    </span>
+                                                ) : (
+                                                    <span className="inbreeding-ancestors">No common ancestors recorded</span>
+                                                )}
+                                            </div>
+                                        )}
+                                    </section>
+
+                                    <div className="details-divider"></div>
+
+                                    <PedigreeTree animal={animal} />
+
+                                    <div className="details-divider"></div>
+
+                                    <section>
+                                        <div className="movement-header">
+                                            <h4 className="data-label"><MapPin size={12} className="inline mr-1" /> Movement History</h4>
+                                            {animal.status === 'active' && <Button variant="outline" size="sm" icon={MapPin} onClick={openMoveModal}>Move</Button>}
+                                        </div>
+                                        {movements.length === 0 ? (
+                                            <p className="movement-empty">No moves recorded</p>
+                                        ) : (
+                                            <ul className="movement-list">
+                                                {movements.map(m => (
+                                                    <li key={m.id} className="movement-item">
+                                                        <span className="font-mono">{m.date}</span>
+                                                        <span>{m.fromGroupName || 'No group'} → <strong>{m.toGroupName || 'No group'}</strong></span>
+                                                        {m.reason && <span className="movement-reason">{m.reason}</span>}
+                                                    </li>
+                                                ))}
+                                            </ul>
+                                        )}
                                     </section>
 
+                                    {typeHistory.length > 0 && (
+                                        <>
+                                            <div className="details-divider"></div>
+
+                                            <section>
+                                                <div className="movement-header">
+                                                    <h4 className="data-label"><TrendingUp size={12} className="inline mr-1" /> Life Stages</h4>
+                                                </div>
+                                                <ul className="movement-list">
+                                                    {typeHistory.map(c => (
+                                                        <li key={c.id} className="movement-item">
+                                                            <span className="font-mono">{c.date}</span>
+                                                            <span>{formatType(c.fromType)} → <strong>{formatType(c.toType)}</strong></span>
+                                                            <span className="movement-reason">{c.reason}{c.automatic ? ' (automatic)' : ''}</span>
+                                                        </li>
+                                                    ))}
+                                                </ul>
+                                            </section>
+                                        </>
+                                    )}
+
+                                    <div className="details-divider"></div>
+
+                                    <WeightHistory animal={animal} />
+
                                     <div className="details-divider"></div>
 
                                     <section>
@@ -310,14 +564,88 @@ export function AnimalDetails() {
                         )}
                         <FormGroup><Label htmlFor="milkDate" required>Date</Label><Input id="milkDate" type="date" value={milkForm.date} onChange={(e) => handleMilkDateChange(e.target.value)} required /></FormGroup>
                         <FormRow>
-                            <FormGroup><Label htmlFor="morning">Morning (L)</Label><Input id="morning" type="number" step="0.1" value={milkForm.morningLiters} onChange={(e) => setMilkForm({ ...milkForm, morningLiters: e.target.value })} /></FormGroup>
-                            <FormGroup><Label htmlFor="evening">Evening (L)</Label><Input id="evening" type="number" step="0.1" value={milkForm.eveningLiters} onChange={(e) => setMilkForm({ ...milkForm, eveningLiters: e.target.value })} /></FormGroup>
+                            {visibleSessions(milkSessions, milkForm.liters).map(session => (
+                                <FormGroup key={session.id}><Label htmlFor={`session-${session.id}`}>{session.name} (L)</Label><Input id={`session-${session.id}`} type="number" step="0.1" min="0" value={milkForm.liters[session.id] || ''} onChange={(e) => setMilkForm({ ...milkForm, liters: { ...milkForm.liters, [session.id]: e.target.value } })} /></FormGroup>
+                            ))}
                         </FormRow>
+                        {milkWithdrawal && (
+                            <div className="milk-withdrawal-alert">
+                                Milk withheld until {milkWithdrawal.milkWithheldUntil} after {milkWithdrawal.milkMedicine || 'treatment'}
+                            </div>
+                        )}
+                        <FormGroup><Checkbox label="Milk discarded (not for sale)" checked={milkForm.discarded} onChange={(e) => setMilkForm({ ...milkForm, discarded: e.target.checked })} /></FormGroup>
                         <FormGroup><Label htmlFor="notes">Notes</Label><Textarea id="notes" value={milkForm.notes} onChange={(e) => setMilkForm({ ...milkForm, notes: e.target.value })} rows={2} /></FormGroup>
                         <div className="modal-actions"><Button variant="outline" type="button" onClick={() => setShowMilkModal(false)}>Cancel</Button><Button type="submit">{existingMilkRecord ? 'Update' : 'Save'} Record</Button></div>
                     </form>
                 </Modal>
 
+                <Modal isOpen={showMoveModal} onClose={() => setShowMoveModal(false)} title={`Move ${animal.name}`} size="sm">
+                    <form onSubmit={handleMoveSubmit}>
+                        <FormGroup>
+                            <Label htmlFor="moveGroup">To</Label>
+                            <Select id="moveGroup" value={moveForm.groupId} onChange={(e) => setMoveForm({ ...moveForm, groupId: e.target.value })}>
+                                <option value="">No group (remove)</option>
+                                {groups.map(g => <option key={g.id} value={g.id}>{g.name}</option>)}
+                            </Select>
+                        </FormGroup>
+                        <FormGroup><Label htmlFor="moveDate" required>Date</Label><Input id="moveDate" type="date" value={moveForm.date} onChange={(e) => setMoveForm({ ...moveForm, date: e.target.value })} required /></FormGroup>
+                        <FormGroup><Label htmlFor="moveReason">Reason</Label><Input id="moveReason" value={moveForm.reason} onChange={(e) => setMoveForm({ ...moveForm, reason: e.target.value })} placeholder="e.g., Dried off" /></FormGroup>
+                        <div className="modal-actions"><Button variant="outline" type="button" onClick={() => setShowMoveModal(false)}>Cancel</Button><Button type="submit">Move</Button></div>
+                    </form>
+                </Modal>
+
+                <Modal isOpen={showDisposeModal} onClose={() => setShowDisposeModal(false)} title={`Dispose of ${animal.name}`} size="sm">
+                    <form onSubmit={handleDisposeSubmit}>
+                        <FormRow>
+                            <FormGroup>
+                                <Label htmlFor="disposalType" required>Type</Label>
+                                <Select id="disposalType" value={disposeForm.disposalType} onChange={(e) => setDisposeForm({ ...disposeForm, disposalType: e.target.value })}>
+                                    <option value="sale">Sale</option>
+                                    <option value="death">Death</option>
+                                    <option value="cull">Cull</option>
+                                </Select>
+                            </FormGroup>
+                            <FormGroup><Label htmlFor="disposalDate" required>Date</Label><Input id="disposalDate" type="date" value={disposeForm.date} onChange={(e) => setDisposeForm({ ...disposeForm, date: e.target.value })} required /></FormGroup>
+                        </FormRow>
+                        {disposeForm.disposalType === 'death' ? (
+                            <FormGroup>
+                                <Label htmlFor="causeOfDeath">Cause of Death</Label>
+                                <Select id="causeOfDeath" value={disposeForm.causeOfDeath} onChange={(e) => setDisposeForm({ ...disposeForm, causeOfDeath: e.target.value })}>
+                                    <option value="">Not recorded</option>
+                                    {disposalReasons.death.map(c => <option key={c} value={c}>{formatType(c)}</option>)}
+                                </Select>
+                            </FormGroup>
+                        ) : (
+                            <>
+                                {disposeForm.disposalType === 'cull' && (
+                                    <FormGroup>
+                                        <Label htmlFor="cullReason">Cull Reason</Label>
+                                        <Select id="cullReason" value={disposeForm.cullReason} onChange={(e) => setDisposeForm({ ...disposeForm, cullReason: e.target.value })}>
+                                            <option value="">Not recorded</option>
+                                            {disposalReasons.cull.map(r => <option key={r} value={r}>{formatType(r)}</option>)}
+                                        </Select>
+                                    </FormGroup>
+                                )}
+                                <FormRow>
+                                    <FormGroup><Label htmlFor="buyerName">Buyer</Label><Input id="buyerName" value={disposeForm.buyerName} onChange={(e) => setDisposeForm({ ...disposeForm, buyerName: e.target.value })} /></FormGroup>
+                                    <FormGroup><Label htmlFor="disposalPrice">Price</Label><Input id="disposalPrice" type="number" step="0.01" min="0" value={disposeForm.price} onChange={(e) => setDisposeForm({ ...disposeForm, price: e.target.value })} placeholder={disposeForm.disposalType === 'cull' ? 'Leave empty if not sold' : ''} /></FormGroup>
+                                </FormRow>
+                            </>
+                        )}
+                        <FormGroup><Label htmlFor="disposalNotes">Notes</Label><Textarea id="disposalNotes" value={disposeForm.notes} onChange={(e) => setDisposeForm({ ...disposeForm, notes: e.target.value })} rows={2} /></FormGroup>
+                        <div className="modal-actions"><Button variant="outline" type="button" onClick={() => setShowDisposeModal(false)}>Cancel</Button><Button type="submit">Record Disposal</Button></div>
+                    </form>
+                </Modal>
+
+                <ConfirmDialog
+                    isOpen={confirmReverse}
+                    onClose={() => setConfirmReverse(false)}
+                    onConfirm={handleReverseDisposal}
+                    title="Reverse Disposal"
+                    message={`${animal.name} will be active again and any sale transaction posted for the disposal will be removed.`}
+                    confirmText="Reverse"
+                />
+
                 <Modal isOpen={showEditModal} onClose={() => setShowEditModal(false)} title="Edit Animal Details" size="md">
                     <form onSubmit={handleEditSubmit}>
                         <FormRow>
@@ -325,13 +653,38 @@ export function AnimalDetails() {
                             <FormGroup><Label htmlFor="name" required>Name</Label><Input id="name" value={editForm.name} onChange={(e) => setEditForm({ ...editForm, name: e.target.value })} required /></FormGroup>
                         </FormRow>
                         <FormRow>
-                            <FormGroup><Label htmlFor="type">Type</Label><Select id="type" value={editForm.type} onChange={(e) => setEditForm({ ...editForm, type: e.target.value })}><option value="cow">Cow</option><option value="bull">Bull</option><option value="heifer">Heifer</option><option value="calf">Calf</option></Select></FormGroup>
+                            <FormGroup><Label htmlFor="type">Type</Label><Select id="type" value={editForm.type} onChange={(e) => setEditForm({ ...editForm, type: e.target.value })}>{(speciesProfiles.find(p => p.species === (editForm.species || 'cattle'))?.types || [editForm.type]).map(t => <option key={t} value={t}>{formatType(t)}</option>)}</Select></FormGroup>
                             <FormGroup><Label htmlFor="breed">Breed</Label><Input id="breed" value={editForm.breed} onChange={(e) => setEditForm({ ...editForm, breed: e.target.value })} /></FormGroup>
                         </FormRow>
                         <FormRow>
                             <FormGroup><Label htmlFor="dob">Date of Birth</Label><Input id="dob" type="date" value={editForm.dateOfBirth} onChange={(e) => setEditForm({ ...editForm, dateOfBirth: e.target.value })} /></FormGroup>
-                            <FormGroup><Label htmlFor="status">Status</Label><Select id="status" value={editForm.status} onChange={(e) => setEditForm({ ...editForm, status: e.target.value })}><option value="active">Active</option><option value="sold">Sold</option><option value="deceased">Deceased</option></Select></FormGroup>
+                            <FormGroup><Label htmlFor="status">Status</Label><Select id="status" value={editForm.status} onChange={(e) => setEditForm({ ...editForm, status: e.target.value })}><option value="active">Active</option><option value="sold">Sold</option><option value="deceased">Deceased</option><option value="culled">Culled</option></Select></FormGroup>
                         </FormRow>
+                        <FormRow>
+                            <FormGroup>
+                                <Label htmlFor="acquisitionType">Acquired</Label>
+                                <Select id="acquisitionType" value={editForm.acquisitionType} onChange={(e) => setEditForm({ ...editForm, acquisitionType: e.target.value, purchaseDate: editForm.purchaseDate || new Date().toISOString().split('T')[0] })}>
+                                    <option value="born">Born on the farm</option>
+                                    <option value="purchased">Purchased</option>
+                                </Select>
+                            </FormGroup>
+                            {editForm.acquisitionType === 'purchased' && (
+                                <FormGroup><Label htmlFor="seller">Seller</Label><Input id="seller" value={editForm.seller} onChange={(e) => setEditForm({ ...editForm, seller: e.target.value })} /></FormGroup>
+                            )}
+                        </FormRow>
+                        {editForm.acquisitionType === 'purchased' && (
+                            <>
+                                <FormRow>
+                                    <FormGroup><Label htmlFor="purchaseDate">Purchase Date</Label><Input id="purchaseDate" type="date" value={editForm.purchaseDate} onChange={(e) => setEditForm({ ...editForm, purchaseDate: e.target.value })} /></FormGroup>
+                                    <FormGroup><Label htmlFor="quarantineDays">Quarantine (days)</Label><Input id="quarantineDays" type="number" min="0" value={editForm.quarantineDays} onChange={(e) => setEditForm({ ...editForm, quarantineDays: e.target.value })} /></FormGroup>
+                                </FormRow>
+                                <FormRow>
+                                    <FormGroup><Label htmlFor="purchasePrice">Purchase Price</Label><Input id="purchasePrice" type="number" step="0.01" min="0" value={editForm.purchasePrice} onChange={(e) => setEditForm({ ...editForm, purchasePrice: e.target.value })} /></FormGroup>
+                                    <FormGroup><Label htmlFor="transportCost">Transport Cost</Label><Input id="transportCost" type="number" step="0.01" min="0" value={editForm.transportCost} onChange={(e) => setEditForm({ ...editForm, transportCost: e.target.value })} /></FormGroup>
+                                </FormRow>
+                                <p className="acquisition-note">Changing the price or transport cost does not adjust the expenses already posted for this purchase.</p>
+                            </>
+                        )}
                         <FormGroup><Label htmlFor="notes">Notes</Label><Textarea id="notes" value={editForm.notes} onChange={(e) => setEditForm({ ...editForm, notes: e.target.value })} rows={3} /></FormGroup>
                         <div className="modal-actions"><Button variant="outline" type="button" onClick={() => setShowEditModal(false)}>Cancel</Button><Button type="submit">Update Animal</Button></div>
                     </form>
@@ -36,6 +36,7 @@ export function Breeding() {
         offspringId: '',
         actualBirthDate: new Date().toISOString().split('T')[0]
     });
+    const [matingCheck, setMatingCheck] = useState(null);
     const [currentPage, setCurrentPage] = useState(1);
 
     const itemsPerPage = 10;
@@ -45,6 +46,20 @@ export function Breeding() {
         loadData();
     }, []);
 
+    // Check the pairing for inbreeding whenever a recorded cow and bull are chosen
+    useEffect(() => {
+        if (!showAddModal || formData.breedingMethod !== 'natural' || !formData.femaleId || !formData.maleId) {
+            setMatingCheck(null);
+            return;
+        }
+        window.go.main.BreedingService.GetProspectiveInbreeding(parseInt(formData.femaleId), parseInt(formData.maleId))
+            .then(setMatingCheck)
+            .catch(err => {
+                console.error('Failed to check pairing:', err);
+                setMatingCheck(null);
+            });
+    }, [showAddModal, formData.femaleId, formData.maleId, formData.breedingMethod]);
+
     const loadData = async () => {
         try {
             const [breedingRecords, pregnant, females, males, animals] = await Promise.all([
@@ -333,6 +348,20 @@ export function Breeding() {
                                     <option key={a.id} value={a.id}>{a.name} ({a.tagNumber || a.type})</option>
                                 ))}
                             </Select>
+                            {matingCheck && (
+                                <div className={`mating-check ${matingCheck.assessment}`}>
+                                    <span>
+                                        Calf inbreeding: <strong>{matingCheck.percent.toFixed(2)}%</strong>
+                                        {matingCheck.assessment === 'avoid' && ' - avoid this pairing'}
+                                        {matingCheck.assessment === 'caution' && ' - use with caution'}
+                                    </span>
+                                    <span className="mating-ancestors">
+                                        {matingCheck.commonAncestors?.length > 0
+                                            ? `Common ancestors: ${matingCheck.commonAncestors.slice(0, 3).map(a => a.name).join(', ')}`
+                                            : 'No common ancestors recorded'}
+                                    </span>
+                                </div>
+                            )}
                         </FormGroup>
                     ) : (
                         <FormGroup>
@@ -0,0 +1,415 @@
+import React, { useState, useEffect } from 'react';
+import { Plus, Edit2, Trash2, Users, Wallet, FileText, Clock, Download } from 'lucide-react';
+import { Button } from '../components/ui/Button';
+import { Card } from '../components/ui/Card';
+import { Modal } from '../components/ui/Modal';
+import { FormGroup, FormRow, Label, Input, Select, Textarea, Checkbox } from '../components/ui/Form';
+import { Table, TableHeader, TableBody, TableRow, TableHead, TableCell } from '../components/ui/Table';
+import { EmptyState } from '../components/ui/EmptyState';
+import { ConfirmDialog } from '../components/ui/ConfirmDialog';
+import { StatCard } from '../components/ui/StatCard';
+import { toast } from 'sonner';
+import './Buyers.css';
+
+const today = () => new Date().toISOString().split('T')[0];
+const thisMonth = () => new Date().toISOString().slice(0, 7);
+const emptyBuyer = { name: '', phone: '', email: '', address: '', agreedPrice: '', active: true, notes: '' };
+const emptyPayment = { buyerId: '', date: today(), amount: '', method: 'cash', reference: '', milkSaleId: '', notes: '' };
+
+export function Buyers() {
+    const [buyers, setBuyers] = useState([]);
+    const [payments, setPayments] = useState([]);
+    const [ageing, setAgeing] = useState(null);
+    const [sales, setSales] = useState([]);
+    const [methods, setMethods] = useState([]);
+    const [loading, setLoading] = useState(true);
+    const [showBuyerModal, setShowBuyerModal] = useState(false);
+    const [editingBuyer, setEditingBuyer] = useState(null);
+    const [buyerForm, setBuyerForm] = useState(emptyBuyer);
+    const [showPaymentModal, setShowPaymentModal] = useState(false);
+    const [paymentForm, setPaymentForm] = useState(emptyPayment);
+    const [statementBuyer, setStatementBuyer] = useState(null);
+    const [statementMonth, setStatementMonth] = useState(thisMonth());
+    const [statement, setStatement] = useState(null);
+    const [confirmDelete, setConfirmDelete] = useState({ show: false, type: null, id: null });
+
+    useEffect(() => { loadData(); }, []);
+
+    const loadData = async () => {
+        try {
+            const [buyerList, paymentList, report, saleList, methodList] = await Promise.all([
+                window.go.main.BuyerService.GetBuyers(),
+                window.go.main.BuyerService.GetBuyerPayments(0, '', ''),
+                window.go.main.BuyerService.GetAgeingReport(''),
+                window.go.main.LivestockService.GetMilkSales('', ''),
+                window.go.main.BuyerService.GetPaymentMethods()
+            ]);
+            setBuyers(buyerList || []);
+            setPayments(paymentList || []);
+            setAgeing(report);
+            setSales(saleList || []);
+            setMethods(methodList || []);
+        } catch (err) { console.error(err); }
+        finally { setLoading(false); }
+    };
+
+    const formatCurrency = (amt) => new Intl.NumberFormat('en-KE', { style: 'currency', currency: 'KES' }).format(amt);
+
+    const openAddBuyer = () => {
+        setEditingBuyer(null);
+        setBuyerForm(emptyBuyer);
+        setShowBuyerModal(true);
+    };
+
+    const openEditBuyer = (buyer) => {
+        setEditingBuyer(buyer);
+        setBuyerForm({ ...buyer, agreedPrice: buyer.agreedPrice ? buyer.agreedPrice.toString() : '' });
+        setShowBuyerModal(true);
+    };
+
+    const handleBuyerSubmit = async (e) => {
+        e.preventDefault();
+        try {
+            const data = { ...buyerForm, agreedPrice: parseFloat(buyerForm.agreedPrice) || 0 };
+            if (editingBuyer) {
+                await window.go.main.BuyerService.UpdateBuyer({ ...data, id: editingBuyer.id });
+                toast.success('Buyer updated');
+            } else {
+                await window.go.main.BuyerService.AddBuyer(data);
+                toast.success('Buyer added', { description: 'Earlier sales under the same name were moved to the account' });
+            }
+            setShowBuyerModal(false);
+            loadData();
+        } catch (err) {
+            console.error(err);
+            toast.error(typeof err === 'string' ? err : 'Failed to save buyer');
+        }
+    };
+
+    const openPayment = (buyer) => {
+        setPaymentForm({ ...emptyPayment, date: today(), buyerId: buyer ? buyer.id.toString() : '', amount: buyer && buyer.balance > 0 ? buyer.balance.toString() : '' });
+        setShowPaymentModal(true);
+    };
+
+    const handlePaymentSubmit = async (e) => {
+        e.preventDefault();
+        try {
+            await window.go.main.BuyerService.AddBuyerPayment({
+                buyerId: parseInt(paymentForm.buyerId),
+                date: paymentForm.date,
+                amount: parseFloat(paymentForm.amount),
+                method: paymentForm.method,
+                reference: paymentForm.reference,
+                milkSaleId: paymentForm.milkSaleId ? parseInt(paymentForm.milkSaleId) : null,
+                notes: paymentForm.notes
+            });
+            toast.success('Payment recorded');
+            setShowPaymentModal(false);
+            loadData();
+        } catch (err) {
+            console.error(err);
+            toast.error(typeof err === 'string' ? err : 'Failed to record payment');
+        }
+    };
+
+    const loadStatement = async (buyer, month) => {
+        setStatementBuyer(buyer);
+        setStatementMonth(month);
+        try {
+            setStatement(await window.go.main.BuyerService.GetBuyerStatement(buyer.id, month));
+        } catch (err) {
+            console.error(err);
+            toast.error(typeof err === 'string' ? err : 'Failed to load statement');
+        }
+    };
+
+    const handleExportStatement = async () => {
+        const loadingToast = toast.loading('Generating statement...');
+        try {
+            const res = await window.go.main.BuyerService.ExportBuyerStatementPDF(statementBuyer.id, statementMonth);
+            if (res) {
+                toast.success('Statement saved', { id: loadingToast, description: `File saved to ${res.path}` });
+            } else {
+                toast.dismiss(loadingToast);
+            }
+        } catch (err) {
+            console.error(err);
+            toast.error('Failed to export statement', { id: loadingToast });
+        }
+    };
+
+    const handleConfirmDelete = async () => {
+        try {
+            if (confirmDelete.type === 'buyer') {
+                await window.go.main.BuyerService.DeleteBuyer(confirmDelete.id);
+                toast.success('Buyer deleted');
+            } else {
+                await window.go.main.BuyerService.DeleteBuyerPayment(confirmDelete.id);
+                toast.success('Payment deleted');
+            }
+            setConfirmDelete({ show: false, type: null, id: null });
+            loadData();
+        } catch (err) {
+            console.error(err);
+            toast.error(typeof err === 'string' ? err : 'Failed to delete');
+            setConfirmDelete({ show: false, type: null, id: null });
+        }
+    };
+
+    const unpaidSales = sales.filter(s => s.buyerId === parseInt(paymentForm.buyerId) && s.totalAmount - s.amountPaid > 0.005);
+    const overdue = ageing ? ageing.totals.days31To60 + ageing.totals.days61To90 + ageing.totals.over90 : 0;
+
+    return (
+        <div className="buyers-page">
+            <header className="page-header">
+                <div className="page-header-content">
+                    <h1>Buyers</h1>
+                    <p>Buyer accounts, payments and what each buyer owes</p>
+                </div>
+                <div className="page-actions">
+                    <Button variant="outline" icon={Wallet} onClick={() => openPayment(null)} disabled={buyers.length === 0}>Record Payment</Button>
+                    <Button icon={Plus} onClick={openAddBuyer}>Add Buyer</Button>
+                </div>
+            </header>
+
+            <div className="stats-grid stats-grid--two">
+                <StatCard title="Owed to the Farm" value={formatCurrency(ageing?.totals.outstanding || 0)} subtitle={`${buyers.filter(b => b.balance > 0).length} buyers with a balance`} icon={Wallet} color="primary" />
+                <StatCard title="Overdue (over 30 days)" value={formatCurrency(overdue)} subtitle={`${formatCurrency(ageing?.totals.over90 || 0)} over 90 days`} icon={Clock} color="secondary" />
+            </div>
+
+            <Card padding="none">
+                <div className="card-header-bar"><h3>Buyer Accounts</h3></div>
+                {loading ? (
+                    <div className="loading-container"><div className="loading-spinner"></div></div>
+                ) : buyers.length === 0 ? (
+                    <EmptyState icon={Users} title="No buyers yet" description="Add regular buyers to track what they owe and print statements" action={<Button icon={Plus} onClick={openAddBuyer}>Add Buyer</Button>} />
+                ) : (
+                    <Table>
+                        <TableHeader>
+                            <TableRow>
+                                <TableHead>Buyer</TableHead>
+                                <TableHead>Agreed Price</TableHead>
+                                <TableHead>Sales</TableHead>
+                                <TableHead>Payments</TableHead>
+                                <TableHead>Balance</TableHead>
+                                <TableHead>Actions</TableHead>
+                            </TableRow>
+                        </TableHeader>
+                        <TableBody>
+                            {buyers.map(buyer => (
+                                <TableRow key={buyer.id} className={buyer.active ? '' : 'buyer-inactive'}>
+                                    <TableCell>
+                                        <div className="buyer-info">
+                                            <span className="buyer-name">{buyer.name}</span>
+                                            {(buyer.phone || buyer.email) && <span className="buyer-meta">{[buyer.phone, buyer.email].filter(Boolean).join(' · ')}</span>}
+                                        </div>
+                                    </TableCell>
+                                    <TableCell className="font-mono">{buyer.agreedPrice ? `${formatCurrency(buyer.agreedPrice)}/L` : '-'}</TableCell>
+                                    <TableCell className="font-mono">{formatCurrency(buyer.totalSales)}</TableCell>
+                                    <TableCell className="font-mono">{formatCurrency(buyer.totalPayments)}</TableCell>
+                                    <TableCell className={`font-mono font-bold ${buyer.balance > 0 ? 'balance-owed' : 'balance-clear'}`}>
+                                        {buyer.balance < 0 ? `${formatCurrency(-buyer.balance)} credit` : formatCurrency(buyer.balance)}
+                                    </TableCell>
+                                    <TableCell>
+                                        <div className="action-buttons">
+                                            <button className="action-btn record" onClick={() => openPayment(buyer)} title="Record a payment"><Wallet size={16} /></button>
+                                            <button className="action-btn record" onClick={() => loadStatement(buyer, thisMonth())} title="Monthly statement"><FileText size={16} /></button>
+                                            <button className="action-btn edit" onClick={() => openEditBuyer(buyer)} title="Edit buyer"><Edit2 size={16} /></button>
+                                            <button className="action-btn delete" onClick={() => setConfirmDelete({ show: true, type: 'buyer', id: buyer.id })} title="Delete buyer"><Trash2 size={16} /></button>
+                                        </div>
+                                    </TableCell>
+                                </TableRow>
+                            ))}
+                        </TableBody>
+                    </Table>
+                )}
+            </Card>
+
+            <Card padding="none" className="buyers-section">
+                <div className="card-header-bar"><h3>Receivables Ageing{ageing && ` as of ${ageing.asOf}`}</h3></div>
+                {!ageing || ageing.buyers.length === 0 ? (
+                    <EmptyState icon={Clock} title="Nothing outstanding" description="Every buyer sale has been paid" />
+                ) : (
+                    <Table>
+                        <TableHeader>
+                            <TableRow>
+                                <TableHead>Buyer</TableHead>
+                                <TableHead>0-30 Days</TableHead>
+                                <TableHead>31-60 Days</TableHead>
+                                <TableHead>61-90 Days</TableHead>
+                                <TableHead>Over 90 Days</TableHead>
+                                <TableHead>Credit</TableHead>
+                                <TableHead>Outstanding</TableHead>
+                            </TableRow>
+                        </TableHeader>
+                        <TableBody>
+                            {[...ageing.buyers, ageing.totals].map(a => (
+                                <TableRow key={a.buyerId || 'total'} className={a.buyerId ? '' : 'ageing-total'}>
+                                    <TableCell>
+                                        <div className="buyer-info">
+                                            <span className="buyer-name">{a.buyerName}</span>
+                                            {a.oldestSale && <span className="buyer-meta">oldest unpaid {a.oldestSale}</span>}
+                                        </div>
+                                    </TableCell>
+                                    <TableCell className="font-mono">{formatCurrency(a.current)}</TableCell>
+                                    <TableCell className="font-mono">{formatCurrency(a.days31To60)}</TableCell>
+                                    <TableCell className="font-mono">{formatCurrency(a.days61To90)}</TableCell>
+                                    <TableCell className={`font-mono ${a.over90 > 0 ? 'balance-owed' : ''}`}>{formatCurrency(a.over90)}</TableCell>
+                                    <TableCell className="font-mono">{a.credit ? formatCurrency(a.credit) : '-'}</TableCell>
+                                    <TableCell className="font-mono font-bold">{formatCurrency(a.outstanding)}</TableCell>
+                                </TableRow>
+                            ))}
+                        </TableBody>
+                    </Table>
+                )}
+            </Card>
+
+            <Card padding="none" className="buyers-section">
+                <div className="card-header-bar"><h3>Payments</h3></div>
+                {payments.length === 0 ? (
+                    <EmptyState icon={Wallet} title="No payments recorded" description="Payments are applied to the oldest unpaid sales first" />
+                ) : (
+                    <Table>
+                        <TableHeader>
+                            <TableRow>
+                                <TableHead>Date</TableHead>
+                                <TableHead>Buyer</TableHead>
+                                <TableHead>Amount</TableHead>
+                                <TableHead>Method</TableHead>
+                                <TableHead>Reference</TableHead>
+                                <TableHead>Applied</TableHead>
+                                <TableHead>Actions</TableHead>
+                            </TableRow>
+                        </TableHeader>
+                        <TableBody>
+                            {payments.map(p => (
+                                <TableRow key={p.id}>
+                                    <TableCell className="font-mono">{p.date}</TableCell>
+                                    <TableCell>{p.buyerName}</TableCell>
+                                    <TableCell className="font-mono font-bold">{formatCurrency(p.amount)}</TableCell>
+                                    <TableCell className="capitalize">{p.method}</TableCell>
+                                    <TableCell className="font-mono">{p.reference || '-'}</TableCell>
+                                    <TableCell className="font-mono">{p.amount - p.allocated > 0.005 ? `${formatCurrency(p.allocated)} (${formatCurrency(p.amount - p.allocated)} credit)` : 'In full'}</TableCell>
+                                    <TableCell>
+                                        <div className="action-buttons">
+                                            <button className="action-btn delete" onClick={() => setConfirmDelete({ show: true, type: 'payment', id: p.id })} title="Delete payment"><Trash2 size={16} /></button>
+                                        </div>
+                                    </TableCell>
+                                </TableRow>
+                            ))}
+                        </TableBody>
+                    </Table>
+                )}
+            </Card>
+
+            <Modal isOpen={showBuyerModal} onClose={() => setShowBuyerModal(false)} title={editingBuyer ? 'Edit Buyer' : 'Add Buyer'} size="md">
+                <form onSubmit={handleBuyerSubmit}>
+                    <FormRow>
+                        <FormGroup><Label htmlFor="buyerName" required>Name</Label><Input id="buyerName" value={buyerForm.name} onChange={(e) => setBuyerForm({ ...buyerForm, name: e.target.value })} placeholder="e.g., Savanna Hotel" required /></FormGroup>
+                        <FormGroup><Label htmlFor="buyerPrice">Agreed Price/Liter (KES)</Label><Input id="buyerPrice" type="number" min="0" step="0.5" value={buyerForm.agreedPrice} onChange={(e) => setBuyerForm({ ...buyerForm, agreedPrice: e.target.value })} /></FormGroup>
+                    </FormRow>
+                    <FormRow>
+                        <FormGroup><Label htmlFor="buyerPhone">Phone</Label><Input id="buyerPhone" value={buyerForm.phone} onChange={(e) => setBuyerForm({ ...buyerForm, phone: e.target.value })} placeholder="e.g., 0712 345678" /></FormGroup>
+                        <FormGroup><Label htmlFor="buyerEmail">Email</Label><Input id="buyerEmail" type="email" value={buyerForm.email} onChange={(e) => setBuyerForm({ ...buyerForm, email: e.target.value })} /></FormGroup>
+                    </FormRow>
+                    <FormGroup><Label htmlFor="buyerAddress">Address</Label><Input id="buyerAddress" value={buyerForm.address} onChange={(e) => setBuyerForm({ ...buyerForm, address: e.target.value })} /></FormGroup>
+                    {editingBuyer && <FormGroup><Checkbox label="Active buyer" checked={buyerForm.active} onChange={(e) => setBuyerForm({ ...buyerForm, active: e.target.checked })} /></FormGroup>}
+                    <FormGroup><Label htmlFor="buyerNotes">Notes</Label><Textarea id="buyerNotes" value={buyerForm.notes} onChange={(e) => setBuyerForm({ ...buyerForm, notes: e.target.value })} rows={2} /></FormGroup>
+                    <div className="modal-actions"><Button variant="outline" type="button" onClick={() => setShowBuyerModal(false)}>Cancel</Button><Button type="submit">{editingBuyer ? 'Update' : 'Add'} Buyer</Button></div>
+                </form>
+            </Modal>
+
+            <Modal isOpen={showPaymentModal} onClose={() => setShowPaymentModal(false)} title="Record Payment" size="sm">
+                <form onSubmit={handlePaymentSubmit}>
+                    <FormGroup>
+                        <Label htmlFor="paymentBuyer" required>Buyer</Label>
+                        <Select id="paymentBuyer" value={paymentForm.buyerId} onChange={(e) => setPaymentForm({ ...paymentForm, buyerId: e.target.value, milkSaleId: '' })} required>
+                            <option value="">Select buyer</option>
+                            {buyers.filter(b => b.active || b.id.toString() === paymentForm.buyerId).map(b => <option key={b.id} value={b.id}>{b.name}</option>)}
+                        </Select>
+                    </FormGroup>
+                    <FormRow>
+                        <FormGroup><Label htmlFor="paymentDate" required>Date</Label><Input id="paymentDate" type="date" value={paymentForm.date} onChange={(e) => setPaymentForm({ ...paymentForm, date: e.target.value })} required /></FormGroup>
+                        <FormGroup><Label htmlFor="paymentAmount" required>Amount (KES)</Label><Input id="paymentAmount" type="number" min="0" step="0.01" value={paymentForm.amount} onChange={(e) => setPaymentForm({ ...paymentForm, amount: e.target.value })} required /></FormGroup>
+                    </FormRow>
+                    <FormRow>
+                        <FormGroup><Label htmlFor="paymentMethod">Method</Label><Select id="paymentMethod" value={paymentForm.method} onChange={(e) => setPaymentForm({ ...paymentForm, method: e.target.value })}>{methods.map(m => <option key={m} value={m}>{m === 'mpesa' ? 'M-Pesa' : m.charAt(0).toUpperCase() + m.slice(1)}</option>)}</Select></FormGroup>
+                        <FormGroup><Label htmlFor="paymentReference">Reference</Label><Input id="paymentReference" value={paymentForm.reference} onChange={(e) => setPaymentForm({ ...paymentForm, reference: e.target.value })} placeholder="e.g., M-Pesa code" /></FormGroup>
+                    </FormRow>
+                    <FormGroup>
+                        <Label htmlFor="paymentSale">For Sale</Label>
+                        <Select id="paymentSale" value={paymentForm.milkSaleId} onChange={(e) => setPaymentForm({ ...paymentForm, milkSaleId: e.target.value })}>
+                            <option value="">Oldest unpaid sales first</option>
+                            {unpaidSales.map(s => <option key={s.id} value={s.id}>{s.date} - {s.liters.toFixed(1)} L, {formatCurrency(s.totalAmount - s.amountPaid)} due</option>)}
+                        </Select>
+                    </FormGroup>
+                    <FormGroup><Label htmlFor="paymentNotes">Notes</Label><Textarea id="paymentNotes" value={paymentForm.notes} onChange={(e) => setPaymentForm({ ...paymentForm, notes: e.target.value })} rows={2} /></FormGroup>
+                    <div className="modal-actions"><Button variant="outline" type="button" onClick={() => setShowPaymentModal(false)}>Cancel</Button><Button type="submit">Record Payment</Button></div>
+                </form>
+            </Modal>
+
+            <Modal isOpen={!!statementBuyer} onClose={() => { setStatementBuyer(null); setStatement(null); }} title={`Statement - ${statementBuyer?.name || ''}`} size="lg">
+                <div className="statement-toolbar">
+                    <FormGroup><Label htmlFor="statementMonth">Month</Label><Input id="statementMonth" type="month" value={statementMonth} onChange={(e) => e.target.value && loadStatement(statementBuyer, e.target.value)} /></FormGroup>
+                    <Button variant="outline" icon={Download} onClick={handleExportStatement} disabled={!statement}>Export PDF</Button>
+                </div>
+                {statement && (
+                    <>
+                        <Table>
+                            <TableHeader>
+                                <TableRow>
+                                    <TableHead>Date</TableHead>
+                                    <TableHead>Description</TableHead>
+                                    <TableHead>Reference</TableHead>
+                                    <TableHead>Sales</TableHead>
+                                    <TableHead>Payments</TableHead>
+                                    <TableHead>Balance</TableHead>
+                                </TableRow>
+                            </TableHeader>
+                            <TableBody>
+                                <TableRow className="ageing-total">
+                                    <TableCell className="font-mono">{statement.startDate}</TableCell>
+                                    <TableCell>Opening balance</TableCell>
+                                    <TableCell></TableCell>
+                                    <TableCell></TableCell>
+                                    <TableCell></TableCell>
+                                    <TableCell className="font-mono">{formatCurrency(statement.openingBalance)}</TableCell>
+                                </TableRow>
+                                {statement.lines.map((line, i) => (
+                                    <TableRow key={i}>
+                                        <TableCell className="font-mono">{line.date}</TableCell>
+                                        <TableCell>{line.description}</TableCell>
+                                        <TableCell className="font-mono">{line.reference}</TableCell>
+                                        <TableCell className="font-mono">{line.debit ? formatCurrency(line.debit) : ''}</TableCell>
+                                        <TableCell className="font-mono">{line.credit ? formatCurrency(line.credit) : ''}</TableCell>
+                                        <TableCell className="font-mono">{formatCurrency(line.balance)}</TableCell>
+                                    </TableRow>
+                                ))}
+                            </TableBody>
+                        </Table>
+                        <div className="statement-summary">
+                            <span>{statement.totalLiters.toFixed(1)} L supplied</span>
+                            <span>Sales {formatCurrency(statement.totalSales)}</span>
+                            <span>Payments {formatCurrency(statement.totalPayments)}</span>
+                            <span className="font-bold">
+                                {statement.closingBalance < 0 ? `In credit ${formatCurrency(-statement.closingBalance)}` : `Balance due ${formatCurrency(statement.closingBalance)}`}
+                            </span>
+                        </div>
+                    </>
+                )}
+            </Modal>
+
+            <ConfirmDialog
+                isOpen={confirmDelete.show}
+                onClose={() => setConfirmDelete({ show: false, type: null, id: null })}
+                onConfirm={handleConfirmDelete}
+                title={confirmDelete.type === 'buyer' ? 'Delete Buyer' : 'Delete Payment'}
+                message={confirmDelete.type === 'buyer'
+                    ? 'Are you sure you want to delete this buyer? Buyers with sales or payments can only be deactivated.'
+                    : 'Are you sure you want to delete this payment? The sales it paid will be outstanding again.'}
+                type="danger"
+                confirmText="Delete"
+            />
+        </div>
+    );
+}
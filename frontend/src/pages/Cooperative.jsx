@@ -0,0 +1,491 @@
+import React, { useState, useEffect } from 'react';
+import { Plus, Edit2, Trash2, Truck, Droplets, AlertTriangle, Wallet, Building2, X } from 'lucide-react';
+import { Button } from '../components/ui/Button';
+import { Card } from '../components/ui/Card';
+import { Modal } from '../components/ui/Modal';
+import { FormGroup, FormRow, Label, Input, Select, Textarea, Checkbox } from '../components/ui/Form';
+import { Table, TableHeader, TableBody, TableRow, TableHead, TableCell } from '../components/ui/Table';
+import { EmptyState } from '../components/ui/EmptyState';
+import { ConfirmDialog } from '../components/ui/ConfirmDialog';
+import { StatCard } from '../components/ui/StatCard';
+import { toast } from 'sonner';
+import './Cooperative.css';
+
+const today = () => new Date().toISOString().split('T')[0];
+const thisMonth = () => new Date().toISOString().slice(0, 7);
+const emptyCoop = { name: '', memberNumber: '', pricePerLiter: '', active: true, notes: '' };
+const emptyDelivery = { date: today(), liters: '', coopLiters: '', deliveryNote: '', notes: '' };
+const emptyPayout = { payoutDate: today(), liters: '', pricePerLiter: '', grossAmount: '', reference: '', notes: '', deductions: [] };
+
+export function Cooperative() {
+    const [coops, setCoops] = useState([]);
+    const [coopId, setCoopId] = useState('');
+    const [month, setMonth] = useState(thisMonth());
+    const [reconciliation, setReconciliation] = useState(null);
+    const [deliveries, setDeliveries] = useState([]);
+    const [categories, setCategories] = useState([]);
+    const [loading, setLoading] = useState(true);
+    const [showCoopModal, setShowCoopModal] = useState(false);
+    const [editingCoop, setEditingCoop] = useState(null);
+    const [coopForm, setCoopForm] = useState(emptyCoop);
+    const [showDeliveryModal, setShowDeliveryModal] = useState(false);
+    const [editingDelivery, setEditingDelivery] = useState(null);
+    const [deliveryForm, setDeliveryForm] = useState(emptyDelivery);
+    const [showPayoutModal, setShowPayoutModal] = useState(false);
+    const [payoutForm, setPayoutForm] = useState(emptyPayout);
+    const [confirmDelete, setConfirmDelete] = useState({ show: false, type: null, id: null });
+
+    useEffect(() => { loadCoops(); }, []);
+    useEffect(() => { if (coopId) loadMonth(); }, [coopId, month]);
+
+    const loadCoops = async (selectId) => {
+        try {
+            const [list, categoryList] = await Promise.all([
+                window.go.main.CooperativeService.GetCooperatives(),
+                window.go.main.CooperativeService.GetCoopDeductionCategories()
+            ]);
+            setCoops(list || []);
+            setCategories(categoryList || []);
+            if (selectId) {
+                setCoopId(selectId.toString());
+            } else if (!coopId && list && list.length > 0) {
+                setCoopId(list[0].id.toString());
+            }
+        } catch (err) { console.error(err); }
+        finally { setLoading(false); }
+    };
+
+    const loadMonth = async () => {
+        const [year, m] = month.split('-').map(Number);
+        const end = new Date(Date.UTC(year, m, 0)).toISOString().split('T')[0];
+        try {
+            const [rec, deliveryList] = await Promise.all([
+                window.go.main.CooperativeService.GetCoopReconciliation(parseInt(coopId), month),
+                window.go.main.CooperativeService.GetCoopDeliveries(parseInt(coopId), `${month}-01`, end)
+            ]);
+            setReconciliation(rec);
+            setDeliveries(deliveryList || []);
+        } catch (err) {
+            console.error(err);
+            toast.error(typeof err === 'string' ? err : 'Failed to load deliveries');
+        }
+    };
+
+    const formatCurrency = (amt) => new Intl.NumberFormat('en-KE', { style: 'currency', currency: 'KES' }).format(amt);
+    const coop = coops.find(c => c.id.toString() === coopId);
+    const payout = reconciliation?.payout;
+
+    const openAddCoop = () => {
+        setEditingCoop(null);
+        setCoopForm(emptyCoop);
+        setShowCoopModal(true);
+    };
+
+    const openEditCoop = () => {
+        setEditingCoop(coop);
+        setCoopForm({ ...coop, pricePerLiter: coop.pricePerLiter ? coop.pricePerLiter.toString() : '' });
+        setShowCoopModal(true);
+    };
+
+    const handleCoopSubmit = async (e) => {
+        e.preventDefault();
+        try {
+            const data = { ...coopForm, pricePerLiter: parseFloat(coopForm.pricePerLiter) || 0 };
+            if (editingCoop) {
+                await window.go.main.CooperativeService.UpdateCooperative({ ...data, id: editingCoop.id });
+                toast.success('Cooperative updated');
+                loadCoops();
+                loadMonth();
+            } else {
+                const id = await window.go.main.CooperativeService.AddCooperative(data);
+                toast.success('Cooperative added');
+                loadCoops(id);
+            }
+            setShowCoopModal(false);
+        } catch (err) {
+            console.error(err);
+            toast.error(typeof err === 'string' ? err : 'Failed to save cooperative');
+        }
+    };
+
+    const openAddDelivery = () => {
+        setEditingDelivery(null);
+        setDeliveryForm({ ...emptyDelivery, date: today() });
+        setShowDeliveryModal(true);
+    };
+
+    const openEditDelivery = (delivery) => {
+        setEditingDelivery(delivery);
+        setDeliveryForm({
+            date: delivery.date,
+            liters: delivery.liters.toString(),
+            coopLiters: delivery.coopLiters != null ? delivery.coopLiters.toString() : '',
+            deliveryNote: delivery.deliveryNote,
+            notes: delivery.notes
+        });
+        setShowDeliveryModal(true);
+    };
+
+    const handleDeliverySubmit = async (e) => {
+        e.preventDefault();
+        try {
+            const data = {
+                cooperativeId: parseInt(coopId),
+                date: deliveryForm.date,
+                liters: parseFloat(deliveryForm.liters),
+                coopLiters: deliveryForm.coopLiters === '' ? null : parseFloat(deliveryForm.coopLiters),
+                deliveryNote: deliveryForm.deliveryNote,
+                notes: deliveryForm.notes
+            };
+            if (editingDelivery) {
+                await window.go.main.CooperativeService.UpdateCoopDelivery({ ...data, id: editingDelivery.id });
+                toast.success('Delivery updated');
+            } else {
+                await window.go.main.CooperativeService.AddCoopDelivery(data);
+                toast.success('Delivery recorded');
+            }
+            if (data.coopLiters !== null && data.liters - data.coopLiters > 0.5) {
+                toast.warning(`The cooperative recorded ${(data.liters - data.coopLiters).toFixed(1)} L less than delivered`);
+            }
+            setShowDeliveryModal(false);
+            loadMonth();
+        } catch (err) {
+            console.error(err);
+            toast.error(typeof err === 'string' ? err : 'Failed to save delivery');
+        }
+    };
+
+    const openPayout = () => {
+        if (payout) {
+            setPayoutForm({
+                payoutDate: payout.payoutDate,
+                liters: payout.liters.toString(),
+                pricePerLiter: payout.pricePerLiter.toString(),
+                grossAmount: payout.grossAmount.toString(),
+                reference: payout.reference,
+                notes: payout.notes,
+                deductions: payout.deductions.map(d => ({ category: d.category, description: d.description, amount: d.amount.toString() }))
+            });
+        } else {
+            setPayoutForm({
+                ...emptyPayout,
+                payoutDate: today(),
+                liters: reconciliation && reconciliation.coopLiters ? reconciliation.coopLiters.toString() : '',
+                pricePerLiter: coop && coop.pricePerLiter ? coop.pricePerLiter.toString() : '',
+                deductions: []
+            });
+        }
+        setShowPayoutModal(true);
+    };
+
+    const updateDeduction = (index, field, value) => {
+        setPayoutForm({ ...payoutForm, deductions: payoutForm.deductions.map((d, i) => i === index ? { ...d, [field]: value } : d) });
+    };
+
+    const addDeduction = () => {
+        setPayoutForm({ ...payoutForm, deductions: [...payoutForm.deductions, { category: 'transport', description: '', amount: '' }] });
+    };
+
+    const removeDeduction = (index) => {
+        setPayoutForm({ ...payoutForm, deductions: payoutForm.deductions.filter((_, i) => i !== index) });
+    };
+
+    const payoutGross = parseFloat(payoutForm.grossAmount) || (parseFloat(payoutForm.liters) || 0) * (parseFloat(payoutForm.pricePerLiter) || 0);
+    const payoutDeductions = payoutForm.deductions.reduce((sum, d) => sum + (parseFloat(d.amount) || 0), 0);
+
+    const handlePayoutSubmit = async (e) => {
+        e.preventDefault();
+        try {
+            const data = {
+                cooperativeId: parseInt(coopId),
+                month,
+                payoutDate: payoutForm.payoutDate,
+                liters: parseFloat(payoutForm.liters) || 0,
+                pricePerLiter: parseFloat(payoutForm.pricePerLiter) || 0,
+                grossAmount: parseFloat(payoutForm.grossAmount) || 0,
+                reference: payoutForm.reference,
+                notes: payoutForm.notes,
+                deductions: payoutForm.deductions.map(d => ({ category: d.category, description: d.description, amount: parseFloat(d.amount) || 0 }))
+            };
+            if (payout) {
+                await window.go.main.CooperativeService.UpdateCoopPayout({ ...data, id: payout.id });
+                toast.success('Payout updated', { description: 'The income in Finances was updated to match' });
+            } else {
+                await window.go.main.CooperativeService.AddCoopPayout(data);
+                toast.success('Payout recorded', { description: 'The net payout was added to Finances' });
+            }
+            setShowPayoutModal(false);
+            loadMonth();
+        } catch (err) {
+            console.error(err);
+            toast.error(typeof err === 'string' ? err : 'Failed to save payout');
+        }
+    };
+
+    const handleConfirmDelete = async () => {
+        try {
+            if (confirmDelete.type === 'coop') {
+                await window.go.main.CooperativeService.DeleteCooperative(confirmDelete.id);
+                toast.success('Cooperative deleted');
+                setCoopId('');
+                setReconciliation(null);
+                setDeliveries([]);
+                loadCoops();
+            } else if (confirmDelete.type === 'payout') {
+                await window.go.main.CooperativeService.DeleteCoopPayout(confirmDelete.id);
+                toast.success('Payout deleted');
+                loadMonth();
+            } else {
+                await window.go.main.CooperativeService.DeleteCoopDelivery(confirmDelete.id);
+                toast.success('Delivery deleted');
+                loadMonth();
+            }
+        } catch (err) {
+            console.error(err);
+            toast.error(typeof err === 'string' ? err : 'Failed to delete');
+        }
+        setConfirmDelete({ show: false, type: null, id: null });
+    };
+
+    const deleteMessages = {
+        coop: 'Are you sure you want to delete this cooperative? Cooperatives with deliveries or payouts can only be deactivated.',
+        payout: 'Are you sure you want to delete this payout? Its income will be removed from Finances.',
+        delivery: 'Are you sure you want to delete this delivery record?'
+    };
+
+    if (loading) {
+        return <div className="loading-container"><div className="loading-spinner"></div></div>;
+    }
+
+    return (
+        <div className="cooperative-page">
+            <header className="page-header">
+                <div className="page-header-content">
+                    <h1>Cooperative</h1>
+                    <p>Milk delivered to the cooperative, its payouts and deductions</p>
+                </div>
+                <div className="page-actions">
+                    <Button variant="outline" icon={Plus} onClick={openAddCoop}>Add Cooperative</Button>
+                    {coop && <Button icon={Truck} onClick={openAddDelivery}>Record Delivery</Button>}
+                </div>
+            </header>
+
+            {coops.length === 0 ? (
+                <Card>
+                    <EmptyState icon={Building2} title="No cooperative yet" description="Add the cooperative you deliver milk to" action={<Button icon={Plus} onClick={openAddCoop}>Add Cooperative</Button>} />
+                </Card>
+            ) : (
+                <>
+                    <div className="coop-toolbar">
+                        <div className="coop-toolbar-filters">
+                            <Select value={coopId} onChange={(e) => setCoopId(e.target.value)}>
+                                {coops.map(c => <option key={c.id} value={c.id}>{c.name}{c.active ? '' : ' (inactive)'}</option>)}
+                            </Select>
+                            <Input type="month" value={month} onChange={(e) => e.target.value && setMonth(e.target.value)} />
+                        </div>
+                        {coop && (
+                            <div className="action-buttons">
+                                <button className="action-btn edit" onClick={openEditCoop} title="Edit cooperative"><Edit2 size={16} /></button>
+                                <button className="action-btn delete" onClick={() => setConfirmDelete({ show: true, type: 'coop', id: coop.id })} title="Delete cooperative"><Trash2 size={16} /></button>
+                            </div>
+                        )}
+                    </div>
+
+                    {reconciliation && (
+                        <div className="stats-grid">
+                            <StatCard title="Delivered" value={`${reconciliation.deliveredLiters.toFixed(1)} L`} subtitle={`${reconciliation.days.length} delivery days`} icon={Truck} color="primary" />
+                            <StatCard title="Co-op Measured" value={`${reconciliation.coopLiters.toFixed(1)} L`} subtitle={reconciliation.unmeasuredDays ? `${reconciliation.unmeasuredDays} days not yet measured` : 'All days measured'} icon={Droplets} color="info" />
+                            <StatCard title="Short Recorded" value={`${(reconciliation.shortfallLiters + reconciliation.unpaidLiters).toFixed(1)} L`} subtitle={`${formatCurrency(reconciliation.shortfallValue)} at the payout price`} icon={AlertTriangle} color="accent" />
+                            <StatCard title="Net Payout" value={payout ? formatCurrency(payout.netAmount) : '-'} subtitle={payout ? `Paid ${payout.payoutDate}` : 'Not recorded yet'} icon={Wallet} color="secondary" />
+                        </div>
+                    )}
+
+                    {reconciliation && reconciliation.issues.length > 0 && (
+                        <div className="coop-issues">
+                            <AlertTriangle size={18} />
+                            <ul>{reconciliation.issues.map((issue, i) => <li key={i}>{issue}</li>)}</ul>
+                        </div>
+                    )}
+
+                    <Card padding="none">
+                        <div className="card-header-bar">
+                            <h3>Payout Statement</h3>
+                            <div className="action-buttons">
+                                <Button size="sm" variant="outline" icon={payout ? Edit2 : Plus} onClick={openPayout}>{payout ? 'Edit Payout' : 'Record Payout'}</Button>
+                                {payout && <button className="action-btn delete" onClick={() => setConfirmDelete({ show: true, type: 'payout', id: payout.id })} title="Delete payout"><Trash2 size={16} /></button>}
+                            </div>
+                        </div>
+                        {!payout ? (
+                            <EmptyState icon={Wallet} title="No payout for this month" description="Record the cooperative's payout statement once it arrives" />
+                        ) : (
+                            <Table>
+                                <TableBody>
+                                    <TableRow>
+                                        <TableCell>Milk: {payout.liters.toFixed(1)} L at {formatCurrency(payout.pricePerLiter)}/L{payout.reference && ` (ref ${payout.reference})`}</TableCell>
+                                        <TableCell className="font-mono coop-amount">{formatCurrency(payout.grossAmount)}</TableCell>
+                                    </TableRow>
+                                    {payout.deductions.map(d => (
+                                        <TableRow key={d.id}>
+                                            <TableCell><span className="capitalize">{d.category}</span>{d.description && ` - ${d.description}`}</TableCell>
+                                            <TableCell className="font-mono coop-amount coop-deduction">-{formatCurrency(d.amount)}</TableCell>
+                                        </TableRow>
+                                    ))}
+                                    <TableRow className="coop-total">
+                                        <TableCell>Net payout{payout.transactionId ? ' (in Finances)' : ''}</TableCell>
+                                        <TableCell className="font-mono coop-amount">{formatCurrency(payout.netAmount)}</TableCell>
+                                    </TableRow>
+                                </TableBody>
+                            </Table>
+                        )}
+                    </Card>
+
+                    <Card padding="none" className="coop-section">
+                        <div className="card-header-bar"><h3>Daily Reconciliation</h3></div>
+                        {!reconciliation || reconciliation.days.length === 0 ? (
+                            <EmptyState icon={Truck} title="No deliveries this month" description="Record each delivery with the liters on the delivery note" action={<Button icon={Truck} onClick={openAddDelivery}>Record Delivery</Button>} />
+                        ) : (
+                            <Table>
+                                <TableHeader>
+                                    <TableRow>
+                                        <TableHead>Date</TableHead>
+                                        <TableHead>Delivered</TableHead>
+                                        <TableHead>Co-op Measured</TableHead>
+                                        <TableHead>Short</TableHead>
+                                        <TableHead>Delivery Notes</TableHead>
+                                    </TableRow>
+                                </TableHeader>
+                                <TableBody>
+                                    {reconciliation.days.map(day => (
+                                        <TableRow key={day.date} className={day.flagged ? 'coop-flagged' : ''}>
+                                            <TableCell className="font-mono">{day.date}</TableCell>
+                                            <TableCell className="font-mono">{day.liters.toFixed(1)} L</TableCell>
+                                            <TableCell className="font-mono">
+                                                {day.coopLiters || day.measured ? `${day.coopLiters.toFixed(1)} L` : '-'}
+                                                {!day.measured && <span className="coop-pending-tag">pending</span>}
+                                            </TableCell>
+                                            <TableCell className="font-mono">
+                                                {day.shortfall > 0 ? `${day.shortfall.toFixed(1)} L` : '-'}
+                                                {day.flagged && <span className="coop-flag-tag">short</span>}
+                                            </TableCell>
+                                            <TableCell className="font-mono">{day.deliveryNotes || '-'}</TableCell>
+                                        </TableRow>
+                                    ))}
+                                </TableBody>
+                            </Table>
+                        )}
+                    </Card>
+
+                    {deliveries.length > 0 && (
+                        <Card padding="none" className="coop-section">
+                            <div className="card-header-bar"><h3>Deliveries</h3></div>
+                            <Table>
+                                <TableHeader>
+                                    <TableRow>
+                                        <TableHead>Date</TableHead>
+                                        <TableHead>Delivery Note</TableHead>
+                                        <TableHead>Liters</TableHead>
+                                        <TableHead>Co-op Liters</TableHead>
+                                        <TableHead>Notes</TableHead>
+                                        <TableHead>Actions</TableHead>
+                                    </TableRow>
+                                </TableHeader>
+                                <TableBody>
+                                    {deliveries.map(d => (
+                                        <TableRow key={d.id}>
+                                            <TableCell className="font-mono">{d.date}</TableCell>
+                                            <TableCell className="font-mono">{d.deliveryNote || '-'}</TableCell>
+                                            <TableCell className="font-mono">{d.liters.toFixed(1)} L</TableCell>
+                                            <TableCell className="font-mono">{d.coopLiters != null ? `${d.coopLiters.toFixed(1)} L` : '-'}</TableCell>
+                                            <TableCell>{d.notes || '-'}</TableCell>
+                                            <TableCell>
+                                                <div className="action-buttons">
+                                                    <button className="action-btn edit" onClick={() => openEditDelivery(d)} title="Edit delivery"><Edit2 size={16} /></button>
+                                                    <button className="action-btn delete" onClick={() => setConfirmDelete({ show: true, type: 'delivery', id: d.id })} title="Delete delivery"><Trash2 size={16} /></button>
+                                                </div>
+                                            </TableCell>
+                                        </TableRow>
+                                    ))}
+                                </TableBody>
+                            </Table>
+                        </Card>
+                    )}
+                </>
+            )}
+
+            <Modal isOpen={showCoopModal} onClose={() => setShowCoopModal(false)} title={editingCoop ? 'Edit Cooperative' : 'Add Cooperative'} size="sm">
+                <form onSubmit={handleCoopSubmit}>
+                    <FormGroup><Label htmlFor="coopName" required>Name</Label><Input id="coopName" value={coopForm.name} onChange={(e) => setCoopForm({ ...coopForm, name: e.target.value })} placeholder="e.g., Githunguri Dairy" required /></FormGroup>
+                    <FormRow>
+                        <FormGroup><Label htmlFor="coopMember">Member Number</Label><Input id="coopMember" value={coopForm.memberNumber} onChange={(e) => setCoopForm({ ...coopForm, memberNumber: e.target.value })} /></FormGroup>
+                        <FormGroup><Label htmlFor="coopPrice">Price/Liter (KES)</Label><Input id="coopPrice" type="number" min="0" step="0.01" value={coopForm.pricePerLiter} onChange={(e) => setCoopForm({ ...coopForm, pricePerLiter: e.target.value })} /></FormGroup>
+                    </FormRow>
+                    {editingCoop && <FormGroup><Checkbox label="Active cooperative" checked={coopForm.active} onChange={(e) => setCoopForm({ ...coopForm, active: e.target.checked })} /></FormGroup>}
+                    <FormGroup><Label htmlFor="coopNotes">Notes</Label><Textarea id="coopNotes" value={coopForm.notes} onChange={(e) => setCoopForm({ ...coopForm, notes: e.target.value })} rows={2} /></FormGroup>
+                    <div className="modal-actions"><Button variant="outline" type="button" onClick={() => setShowCoopModal(false)}>Cancel</Button><Button type="submit">{editingCoop ? 'Update' : 'Add'} Cooperative</Button></div>
+                </form>
+            </Modal>
+
+            <Modal isOpen={showDeliveryModal} onClose={() => setShowDeliveryModal(false)} title={`${editingDelivery ? 'Edit' : 'Record'} Delivery - ${coop?.name || ''}`} size="sm">
+                <form onSubmit={handleDeliverySubmit}>
+                    <FormRow>
+                        <FormGroup><Label htmlFor="deliveryDate" required>Date</Label><Input id="deliveryDate" type="date" value={deliveryForm.date} onChange={(e) => setDeliveryForm({ ...deliveryForm, date: e.target.value })} required /></FormGroup>
+                        <FormGroup><Label htmlFor="deliveryNote">Delivery Note No.</Label><Input id="deliveryNote" value={deliveryForm.deliveryNote} onChange={(e) => setDeliveryForm({ ...deliveryForm, deliveryNote: e.target.value })} /></FormGroup>
+                    </FormRow>
+                    <FormRow>
+                        <FormGroup><Label htmlFor="deliveryLiters" required>Liters Delivered</Label><Input id="deliveryLiters" type="number" min="0" step="0.1" value={deliveryForm.liters} onChange={(e) => setDeliveryForm({ ...deliveryForm, liters: e.target.value })} required /></FormGroup>
+                        <FormGroup><Label htmlFor="deliveryCoopLiters">Co-op Measured</Label><Input id="deliveryCoopLiters" type="number" min="0" step="0.1" value={deliveryForm.coopLiters} onChange={(e) => setDeliveryForm({ ...deliveryForm, coopLiters: e.target.value })} placeholder="If known" /></FormGroup>
+                    </FormRow>
+                    <FormGroup><Label htmlFor="deliveryNotes">Notes</Label><Textarea id="deliveryNotes" value={deliveryForm.notes} onChange={(e) => setDeliveryForm({ ...deliveryForm, notes: e.target.value })} rows={2} /></FormGroup>
+                    <div className="modal-actions"><Button variant="outline" type="button" onClick={() => setShowDeliveryModal(false)}>Cancel</Button><Button type="submit">{editingDelivery ? 'Update' : 'Record'} Delivery</Button></div>
+                </form>
+            </Modal>
+
+            <Modal isOpen={showPayoutModal} onClose={() => setShowPayoutModal(false)} title={`Payout for ${month} - ${coop?.name || ''}`} size="md">
+                <form onSubmit={handlePayoutSubmit}>
+                    <FormRow>
+                        <FormGroup><Label htmlFor="payoutDate" required>Payout Date</Label><Input id="payoutDate" type="date" value={payoutForm.payoutDate} onChange={(e) => setPayoutForm({ ...payoutForm, payoutDate: e.target.value })} required /></FormGroup>
+                        <FormGroup><Label htmlFor="payoutReference">Reference</Label><Input id="payoutReference" value={payoutForm.reference} onChange={(e) => setPayoutForm({ ...payoutForm, reference: e.target.value })} /></FormGroup>
+                    </FormRow>
+                    <FormRow>
+                        <FormGroup><Label htmlFor="payoutLiters" required>Liters Paid</Label><Input id="payoutLiters" type="number" min="0" step="0.1" value={payoutForm.liters} onChange={(e) => setPayoutForm({ ...payoutForm, liters: e.target.value })} required /></FormGroup>
+                        <FormGroup><Label htmlFor="payoutPrice">Price/Liter (KES)</Label><Input id="payoutPrice" type="number" min="0" step="0.01" value={payoutForm.pricePerLiter} onChange={(e) => setPayoutForm({ ...payoutForm, pricePerLiter: e.target.value })} /></FormGroup>
+                        <FormGroup><Label htmlFor="payoutGross">Gross (KES)</Label><Input id="payoutGross" type="number" min="0" step="0.01" value={payoutForm.grossAmount} onChange={(e) => setPayoutForm({ ...payoutForm, grossAmount: e.target.value })} placeholder="Liters x price" /></FormGroup>
+                    </FormRow>
+
+                    <div className="deductions-header">
+                        <Label>Deductions</Label>
+                        <Button size="sm" variant="outline" type="button" icon={Plus} onClick={addDeduction}>Add Deduction</Button>
+                    </div>
+                    {payoutForm.deductions.map((d, i) => (
+                        <div className="deduction-row" key={i}>
+                            <div className="deduction-category">
+                                <Select value={d.category} onChange={(e) => updateDeduction(i, 'category', e.target.value)}>
+                                    {categories.map(c => <option key={c} value={c}>{c.charAt(0).toUpperCase() + c.slice(1)}</option>)}
+                                </Select>
+                            </div>
+                            <div className="deduction-description"><Input value={d.description} onChange={(e) => updateDeduction(i, 'description', e.target.value)} placeholder="Description" /></div>
+                            <div className="deduction-amount"><Input type="number" min="0" step="0.01" value={d.amount} onChange={(e) => updateDeduction(i, 'amount', e.target.value)} placeholder="KES" required /></div>
+                            <button type="button" className="action-btn delete" onClick={() => removeDeduction(i)} title="Remove deduction"><X size={16} /></button>
+                        </div>
+                    ))}
+
+                    <div className="total-preview">
+                        Gross {formatCurrency(payoutGross)} less deductions {formatCurrency(payoutDeductions)} = Net {formatCurrency(payoutGross - payoutDeductions)}
+                    </div>
+                    <FormGroup><Label htmlFor="payoutNotes">Notes</Label><Textarea id="payoutNotes" value={payoutForm.notes} onChange={(e) => setPayoutForm({ ...payoutForm, notes: e.target.value })} rows={2} /></FormGroup>
+                    <div className="modal-actions"><Button variant="outline" type="button" onClick={() => setShowPayoutModal(false)}>Cancel</Button><Button type="submit">{payout ? 'Update' : 'Record'} Payout</Button></div>
+                </form>
+            </Modal>
+
+            <ConfirmDialog
+                isOpen={confirmDelete.show}
+                onClose={() => setConfirmDelete({ show: false, type: null, id: null })}
+                onConfirm={handleConfirmDelete}
+                title="Confirm Delete"
+                message={deleteMessages[confirmDelete.type] || ''}
+                type="danger"
+                confirmText="Delete"
+            />
+        </div>
+    );
+}
@@ -1,18 +1,40 @@
 import React, { useState, useEffect } from 'react';
-import { Beef, Milk, Wheat, DollarSign, Calendar, TrendingUp, Activity } from 'lucide-react';
+import { Beef, Milk, Wheat, DollarSign, Calendar, TrendingUp, Activity, FileText } from 'lucide-react';
 import { AreaChart, Area, XAxis, YAxis, CartesianGrid, Tooltip as RechartsTooltip, ResponsiveContainer } from 'recharts';
 import { StatCard } from '../components/ui/StatCard';
 import { Card, CardHeader, CardTitle, CardContent } from '../components/ui/Card';
+import { Table, TableHeader, TableBody, TableRow, TableHead, TableCell } from '../components/ui/Table';
 import { WeatherWidget } from '../components/WeatherWidget';
 import { Skeleton } from '../components/ui/Skeleton';
+import { Button } from '../components/ui/Button';
+import { Modal } from '../components/ui/Modal';
+import { FormGroup, Label, Input, Checkbox } from '../components/ui/Form';
+import { toast } from 'sonner';
 import './Dashboard.css';
 
+const sessionColors = ['var(--color-secondary-500)', 'var(--color-accent-500)', 'var(--color-neutral-500)'];
+const reportSections = [
+    ['includeMilk', 'Milk production per cow and 12-month trend'],
+    ['includeFinance', 'Financial summary by category'],
+    ['includeVet', 'Veterinary activity'],
+    ['includeBreeding', 'Breeding status'],
+    ['includeLowStock', 'Low stock'],
+    ['includeCrops', 'Crop status']
+];
+const emptyReport = () => ({
+    month: new Date().toISOString().slice(0, 7),
+    ...Object.fromEntries(reportSections.map(([key]) => [key, true]))
+});
+
 export function Dashboard() {
     const [stats, setStats] = useState(null);
     const [milkData, setMilkData] = useState([]);
     const [chartTimeframe, setChartTimeframe] = useState('week'); // week, month, year
     const [recentActivity, setRecentActivity] = useState([]);
+    const [lactations, setLactations] = useState([]);
     const [loading, setLoading] = useState(true);
+    const [showReport, setShowReport] = useState(false);
+    const [reportOptions, setReportOptions] = useState(emptyReport);
 
     useEffect(() => {
         loadDashboardData();
@@ -20,14 +42,16 @@ export function Dashboard() {
 
     const loadDashboardData = async () => {
         try {
-            const [statsData, chartData, activityData] = await Promise.all([
+            const [statsData, chartData, activityData, lactationData] = await Promise.all([
                 window.go.main.DashboardService.GetDashboardStats(),
                 window.go.main.DashboardService.GetMilkProductionChart(chartTimeframe),
-                window.go.main.DashboardService.GetRecentActivity()
+                window.go.main.DashboardService.GetRecentActivity(),
+                window.go.main.DashboardService.GetLactationComparison()
             ]);
             setStats(statsData);
             setMilkData(chartData || []);
             setRecentActivity(activityData || []);
+            setLactations(lactationData || []);
         } catch (err) {
             console.error('Error loading dashboard:', err);
         } finally {
@@ -48,6 +72,23 @@ export function Dashboard() {
         if (!loading) updateChart();
     }, [chartTimeframe]);
 
+    const handleExportReport = async (e) => {
+        e.preventDefault();
+        const loadingToast = toast.loading('Generating monthly report...');
+        try {
+            const res = await window.go.main.ExportService.ExportMonthlyReportPDF(reportOptions);
+            if (res) {
+                toast.success('Monthly report saved', { id: loadingToast, description: `File saved to ${res.path}` });
+                setShowReport(false);
+            } else {
+                toast.dismiss(loadingToast);
+            }
+        } catch (err) {
+            console.error('Report failed:', err);
+            toast.error(typeof err === 'string' ? err : 'Failed to generate report', { id: loadingToast });
+        }
+    };
+
     const formatCurrency = (amount) => {
         return new Intl.NumberFormat('en-KE', { style: 'currency', currency: 'KES' }).format(amount || 0);
     };
@@ -109,6 +150,9 @@ export function Dashboard() {
         );
     }
 
+    // Milking sessions present in the chart, drawn as dashed lines under the total
+    const chartSessions = [...new Set(milkData.flatMap(point => Object.keys(point.sessions || {})))];
+
     return (
         <div className="dashboard">
             <header className="page-header">
@@ -116,6 +160,7 @@ export function Dashboard() {
                     <h1>Dashboard</h1>
                 </div>
                 <div className="header-actions">
+                    <Button variant="outline" icon={FileText} onClick={() => { setReportOptions(emptyReport()); setShowReport(true); }}>Monthly Report</Button>
                     <WeatherWidget variant="minimal" />
                     <div className="dashboard-date">
                         <Calendar size={18} />
@@ -128,7 +173,12 @@ export function Dashboard() {
                 <StatCard
                     title="Today's Milk"
                     value={`${(stats?.todayMilkLiters || 0).toFixed(1)} L`}
-                    subtitle={`${(stats?.monthMilkLiters || 0).toFixed(0)} L this month`}
+                    subtitle={[
+                        ...(stats?.todayMilkLiters > 0 ? (stats.todayMilkBySession || []).map(s => `${s.sessionName} ${s.liters.toFixed(1)} L`) : []),
+                        `${(stats?.monthMilkLiters || 0).toFixed(0)} L this month`,
+                        ...(stats?.monthDiscardedMilkLiters > 0 ? [`${stats.monthDiscardedMilkLiters.toFixed(0)} L discarded`] : []),
+                        ...(stats?.animalsUnderWithdrawal > 0 ? [`${stats.animalsUnderWithdrawal} under drug withdrawal`] : [])
+                    ].join(' · ')}
                     icon={Milk}
                     color="primary"
                 />
@@ -216,7 +266,7 @@ export function Dashboard() {
                                             }}
                                             itemStyle={{ fontSize: 'var(--font-size-xs)', fontWeight: 'var(--font-weight-bold)' }}
                                             labelStyle={{ fontSize: 'var(--font-size-xs)', color: 'var(--color-neutral-500)', marginBottom: 'var(--space-1)' }}
-                                            formatter={(value) => [`${value} Liters`, 'Production']}
+                                            formatter={(value, name) => [`${Number(value).toFixed(1)} Liters`, name]}
                                         />
                                         <Area
                                             type="monotone"
@@ -225,7 +275,21 @@ export function Dashboard() {
                                             strokeWidth={3}
                                             fill="url(#milkGradient)"
                                             animationDuration={1500}
+                                            name="Total"
                                         />
+                                        {chartSessions.length > 1 && chartSessions.map((session, i) => (
+                                            <Area
+                                                key={session}
+                                                type="monotone"
+                                                dataKey={(point) => point.sessions?.[session] || 0}
+                                                name={session}
+                                                stroke={sessionColors[i % sessionColors.length]}
+                                                strokeWidth={1.5}
+                                                strokeDasharray="4 3"
+                                                fill="none"
+                                                animationDuration={1500}
+                                            />
+                                        ))}
                                     </AreaChart>
                                 </ResponsiveContainer>
                             ) : (
@@ -262,6 +326,69 @@ export function Dashboard() {
                     </Card>
                 </div>
             </div>
+
+            {lactations.length > 0 && (
+                <Card className="lactation-card">
+                    <CardHeader>
+                        <div className="card-header-row">
+                            <CardTitle>Lactation Comparison</CardTitle>
+                            <TrendingUp size={20} className="text-muted" />
+                        </div>
+                    </CardHeader>
+                    <CardContent>
+                        <Table>
+                            <TableHeader>
+                                <TableRow>
+                                    <TableHead>Cow</TableHead>
+                                    <TableHead>Lactation</TableHead>
+                                    <TableHead>Calved</TableHead>
+                                    <TableHead>Days in Milk</TableHead>
+                                    <TableHead>Peak (L/day)</TableHead>
+                                    <TableHead>Average (L/day)</TableHead>
+                                    <TableHead>Cumulative (L)</TableHead>
+                                    <TableHead>305-day (L)</TableHead>
+                                </TableRow>
+                            </TableHeader>
+                            <TableBody>
+                                {lactations.map(l => (
+                                    <TableRow key={`${l.animalId}-${l.number}`}>
+                                        <TableCell>{l.animalName}</TableCell>
+                                        <TableCell>{l.number}</TableCell>
+                                        <TableCell>{l.calvingDate}</TableCell>
+                                        <TableCell>{l.daysInMilk}</TableCell>
+                                        <TableCell>{l.peakYield.toFixed(1)}{l.peakDim > 0 ? ` (day ${l.peakDim})` : ''}</TableCell>
+                                        <TableCell>{l.averageYield.toFixed(1)}</TableCell>
+                                        <TableCell>{l.cumulativeYield.toFixed(0)}</TableCell>
+                                        <TableCell>
+                                            {l.yield305 > 0 ? l.yield305.toFixed(0) : '-'}
+                                            {l.yield305Projected && <span className="lactation-projected" title="Projected from the lactation so far">*</span>}
+                                        </TableCell>
+                                    </TableRow>
+                                ))}
+                            </TableBody>
+                        </Table>
+                    </CardContent>
+                </Card>
+            )}
+
+            <Modal isOpen={showReport} onClose={() => setShowReport(false)} title="Monthly Farm Report" size="sm">
+                <form onSubmit={handleExportReport}>
+                    <FormGroup>
+                        <Label htmlFor="reportMonth" required>Month</Label>
+                        <Input id="reportMonth" type="month" value={reportOptions.month} onChange={(e) => setReportOptions({ ...reportOptions, month: e.target.value })} required />
+                    </FormGroup>
+                    <FormGroup>
+                        <Label>Sections</Label>
+                        {reportSections.map(([key, label]) => (
+                            <Checkbox key={key} label={label} checked={reportOptions[key]} onChange={(e) => setReportOptions({ ...reportOptions, [key]: e.target.checked })} />
+                        ))}
+                    </FormGroup>
+                    <div className="modal-actions">
+                        <Button variant="outline" type="button" onClick={() => setShowReport(false)}>Cancel</Button>
+                        <Button type="submit" icon={FileText}>Save PDF</Button>
+                    </div>
+                </form>
+            </Modal>
         </div>
     );
 }
@@ -16,13 +16,14 @@ const feedingTimes = ['morning', 'afternoon', 'evening'];
 export function Feed() {
     const [feedTypes, setFeedTypes] = useState([]);
     const [animals, setAnimals] = useState([]);
+    const [groups, setGroups] = useState([]);
     const [records, setRecords] = useState([]);
     const [loading, setLoading] = useState(true);
     const [currentPage, setCurrentPage] = useState(1);
     const [showModal, setShowModal] = useState(false);
     const [editingRecord, setEditingRecord] = useState(null);
     const [confirmDelete, setConfirmDelete] = useState({ show: false, id: null });
-    const [formData, setFormData] = useState({ date: new Date().toISOString().split('T')[0], feedTypeId: '', quantityKg: '', animalIds: [], selectAll: false, animalCount: '', feedingTime: 'morning', notes: '' });
+    const [formData, setFormData] = useState({ date: new Date().toISOString().split('T')[0], feedTypeId: '', quantityKg: '', animalIds: [], selectAll: false, animalCount: '', groupId: '', feedingTime: 'morning', notes: '' });
     const [selectedFeedUnit, setSelectedFeedUnit] = useState('kg');
     const [isDropdownOpen, setIsDropdownOpen] = useState(false);
     const dropdownRef = useRef(null);
@@ -34,7 +35,7 @@ export function Feed() {
     };
 
     const resetForm = () => {
-        setFormData({ date: new Date().toISOString().split('T')[0], feedTypeId: '', quantityKg: '', animalIds: [], selectAll: false, animalCount: '', feedingTime: 'morning', notes: '' });
+        setFormData({ date: new Date().toISOString().split('T')[0], feedTypeId: '', quantityKg: '', animalIds: [], selectAll: false, animalCount: '', groupId: '', feedingTime: 'morning', notes: '' });
         setSelectedFeedUnit('kg');
         setEditingRecord(null);
         setIsDropdownOpen(false);
@@ -61,16 +62,18 @@ export function Feed() {
 
     const loadData = async () => {
         try {
-            const [inventory, feeds, animalsList] = await Promise.all([
+            const [inventory, feeds, animalsList, groupsList] = await Promise.all([
                 window.go.main.InventoryService.GetAllInventory(),
                 window.go.main.FeedService.GetFeedRecords('', ''),
-                window.go.main.LivestockService.GetAllAnimals()
+                window.go.main.LivestockService.GetAllAnimals(),
+                window.go.main.GroupService.GetGroups()
             ]);
             // Filter inventory for items in the 'feed' category
             const feedInventory = (inventory || []).filter(item => item.category === 'feed');
             setFeedTypes(feedInventory);
             setRecords(feeds || []);
             setAnimals(animalsList || []);
+            setGroups(groupsList || []);
         } catch (err) { console.error(err); }
         finally { setLoading(false); }
     };
@@ -79,8 +82,10 @@ export function Feed() {
         e.preventDefault();
         const loadingToast = toast.loading(editingRecord ? 'Updating feed record...' : 'Adding feed record...');
         try {
-            // Use animal IDs length as count if animals are selected, otherwise use manual count
-            const animalCount = formData.animalIds.length > 0 ? formData.animalIds.length : (parseInt(formData.animalCount) || 0);
+            // Use animal IDs length as count if animals are selected, otherwise use manual count.
+            // A group feeding without a count takes the group's head count.
+            const animalCount = formData.groupId ? (parseInt(formData.animalCount) || 0)
+                : formData.animalIds.length > 0 ? formData.animalIds.length : (parseInt(formData.animalCount) || 0);
             
             const recordData = {
                 date: formData.date,
@@ -88,6 +93,7 @@ export function Feed() {
                 quantityKg: parseFloat(formData.quantityKg) || 0,
                 unit: selectedFeedUnit,
                 animalCount: animalCount,
+                groupId: formData.groupId ? parseInt(formData.groupId) : null,
                 feedingTime: formData.feedingTime,
                 notes: formData.notes
             };
@@ -138,6 +144,7 @@ export function Feed() {
             animalIds: [],  // Reset animal selection for editing
             selectAll: false,
             animalCount: record.animalCount.toString(),
+            groupId: record.groupId ? record.groupId.toString() : '',
             feedingTime: record.feedingTime,
             notes: record.notes || ''
         });
@@ -181,7 +188,7 @@ export function Feed() {
                                     <TableCell className="font-mono">{new Date(record.date).toLocaleDateString('en-KE')}</TableCell>
                                     <TableCell>{record.feedTypeName}</TableCell>
                                     <TableCell className="font-mono">{record.quantityKg} {record.unit || 'kg'}</TableCell>
-                                    <TableCell className="font-mono">{record.animalCount}</TableCell>
+                                    <TableCell className="font-mono">{record.groupName ? `${record.groupName} (${record.animalCount})` : record.animalCount}</TableCell>
                                     <TableCell className="capitalize">{record.feedingTime}</TableCell>
                                     <TableCell>
                                         <div style={{ display: 'flex', gap: '8px' }}>
@@ -209,6 +216,16 @@ export function Feed() {
                     <FormGroup><Label htmlFor="feedDate" required>Date</Label><Input id="feedDate" type="date" value={formData.date} onChange={(e) => setFormData({ ...formData, date: e.target.value })} required /></FormGroup>
                     <FormGroup><Label htmlFor="feedType" required>Feed Type</Label><Select id="feedType" value={formData.feedTypeId} onChange={(e) => { const newFeedTypeId = e.target.value; const unit = getSelectedFeedUnit(newFeedTypeId); setFormData({ ...formData, feedTypeId: newFeedTypeId }); setSelectedFeedUnit(unit); }} required><option value="">Select feed</option>{feedTypes.map(ft => <option key={ft.id} value={ft.id}>{ft.name}</option>)}</Select></FormGroup>
                     <FormGroup><Label htmlFor="feedQty">Quantity ({selectedFeedUnit})</Label><Input id="feedQty" type="number" step="0.1" value={formData.quantityKg} onChange={(e) => setFormData({ ...formData, quantityKg: e.target.value })} /></FormGroup>
+                    <FormGroup>
+                        <Label htmlFor="feedGroup">Group Fed</Label>
+                        <Select id="feedGroup" value={formData.groupId} onChange={(e) => setFormData({ ...formData, groupId: e.target.value, animalIds: [], selectAll: false, animalCount: '' })}>
+                            <option value="">Individual animals</option>
+                            {groups.filter(g => g.active || String(g.id) === formData.groupId).map(g => <option key={g.id} value={g.id}>{g.name} ({g.headCount})</option>)}
+                        </Select>
+                    </FormGroup>
+                    {formData.groupId ? (
+                        <FormGroup><Label htmlFor="feedAnimalCount">Animal Count</Label><Input id="feedAnimalCount" type="number" min="0" value={formData.animalCount} onChange={(e) => setFormData({ ...formData, animalCount: e.target.value })} placeholder="Defaults to the group's head count" /></FormGroup>
+                    ) : (
                     <FormGroup>
                         <Label htmlFor="animals">Animals Fed</Label>
                         <div className="multi-select-dropdown" ref={dropdownRef}>
@@ -307,6 +324,7 @@ export function Feed() {
                             </p>
                         )}
                     </FormGroup>
+                    )}
                     <FormGroup><Label htmlFor="feedTime">Feeding Time</Label><Select id="feedTime" value={formData.feedingTime} onChange={(e) => setFormData({ ...formData, feedingTime: e.target.value })}>{feedingTimes.map(t => <option key={t} value={t}>{t.charAt(0).toUpperCase() + t.slice(1)}</option>)}</Select></FormGroup>
                     <FormGroup><Label htmlFor="feedNotes">Notes</Label><Textarea id="feedNotes" value={formData.notes} onChange={(e) => setFormData({ ...formData, notes: e.target.value })} rows={2} /></FormGroup>
                     <div className="modal-actions"><Button variant="outline" type="button" onClick={() => setShowModal(false)}>Cancel</Button><Button type="submit">{editingRecord ? 'Update Record' : 'Save Record'}</Button></div>
@@ -11,10 +11,11 @@ import { Table, TableHeader, TableBody, TableRow, TableHead, TableCell } from '.
 import { EmptyState } from '../components/ui/EmptyState';
 import { ConfirmDialog } from '../components/ui/ConfirmDialog';
 import { formatLabel } from '../utils/formatting';
+import { toast } from 'sonner';
 import './Finances.css';
 
 const incomeCategories = ['milk_sales', 'crop_sales', 'livestock_sales', 'other_income'];
-const expenseCategories = ['feed', 'veterinary', 'labor', 'equipment', 'seeds', 'fertilizer', 'fuel', 'maintenance', 'transport', 'utilities', 'other_expense'];
+const expenseCategories = ['feed', 'veterinary', 'livestock_purchase', 'labor', 'equipment', 'seeds', 'fertilizer', 'fuel', 'maintenance', 'transport', 'utilities', 'other_expense'];
 const paymentMethods = ['cash', 'mpesa', 'bank'];
 
 export function Finances() {
@@ -84,9 +85,12 @@ export function Finances() {
     const confirmDeleteTransaction = async () => {
         try {
             await window.go.main.FinancialService.DeleteTransaction(confirmDelete.id);
-            setConfirmDelete({ show: false, id: null });
             loadData();
-        } catch (err) { console.error(err); }
+        } catch (err) {
+            console.error(err);
+            toast.error(typeof err === 'string' ? err : 'Failed to delete transaction');
+        }
+        setConfirmDelete({ show: false, id: null });
     };
 
     const resetForm = () => setFormData({ date: new Date().toISOString().split('T')[0], type: 'income', category: 'milk_sales', description: '', amount: '', paymentMethod: 'cash', relatedEntity: '', notes: '' });
@@ -1,5 +1,5 @@
 import React, { useState, useEffect, useRef } from 'react';
-import { Plus, Search, Edit2, Trash2, Heart, Activity, Calendar, AlertCircle, Syringe } from 'lucide-react';
+import { Plus, Search, Edit2, Trash2, Heart, Activity, Calendar, AlertCircle, Syringe, Ban } from 'lucide-react';
 import { Pagination } from '../components/ui/Pagination';
 import { Button } from '../components/ui/Button';
 import { Card } from '../components/ui/Card';
@@ -18,11 +18,13 @@ export function Health() {
     const [records, setRecords] = useState([]);
     const [animals, setAnimals] = useState([]);
     const [upcoming, setUpcoming] = useState([]);
+    const [withdrawals, setWithdrawals] = useState([]);
+    const [medicineWithdrawals, setMedicineWithdrawals] = useState([]);
     const [loading, setLoading] = useState(true);
     const [showModal, setShowModal] = useState(false);
     const [editingRecord, setEditingRecord] = useState(null);
     const [confirmDelete, setConfirmDelete] = useState({ show: false, id: null });
-    const [formData, setFormData] = useState({ animalIds: [], selectAll: false, date: new Date().toISOString().split('T')[0], recordType: 'treatment', description: '', diagnosis: '', treatment: '', medicine: '', dosage: '', vetName: '', cost: '', nextDueDate: '', notes: '' });
+    const [formData, setFormData] = useState({ animalIds: [], selectAll: false, date: new Date().toISOString().split('T')[0], recordType: 'treatment', description: '', diagnosis: '', treatment: '', medicine: '', dosage: '', vetName: '', cost: '', nextDueDate: '', notes: '', milkWithdrawalDays: '', meatWithdrawalDays: '' });
     const [currentPage, setCurrentPage] = useState(1);
     const [isDropdownOpen, setIsDropdownOpen] = useState(false);
     const [searchTerm, setSearchTerm] = useState('');
@@ -69,18 +71,32 @@ export function Health() {
 
     const loadData = async () => {
         try {
-            const [recs, anims, upcom] = await Promise.all([
+            const [recs, anims, upcom, active, periods] = await Promise.all([
                 window.go.main.HealthService.GetVetRecords(0),
                 window.go.main.LivestockService.GetAllAnimals(),
-                window.go.main.HealthService.GetUpcomingVaccinations()
+                window.go.main.HealthService.GetUpcomingVaccinations(),
+                window.go.main.HealthService.GetActiveWithdrawals(),
+                window.go.main.HealthService.GetMedicineWithdrawals()
             ]);
             setRecords(recs || []);
             setAnimals(anims || []);
             setUpcoming(upcom || []);
+            setWithdrawals(active || []);
+            setMedicineWithdrawals(periods || []);
         } catch (err) { console.error(err); }
         finally { setLoading(false); }
     };
 
+    // Fill the withdrawal periods saved for a medicine when it is chosen
+    const changeMedicine = (medicine) => {
+        const known = medicineWithdrawals.find(m => m.medicine.toLowerCase() === medicine.trim().toLowerCase());
+        setFormData({
+            ...formData,
+            medicine,
+            ...(known ? { milkWithdrawalDays: known.milkDays.toString(), meatWithdrawalDays: known.meatDays.toString() } : {})
+        });
+    };
+
     const handleSubmit = async (e) => {
         e.preventDefault();
         
@@ -102,7 +118,9 @@ export function Health() {
                     vetName: formData.vetName,
                     cost: cost,
                     nextDueDate: formData.nextDueDate,
-                    notes: formData.notes
+                    notes: formData.notes,
+                    milkWithdrawalDays: parseInt(formData.milkWithdrawalDays) || 0,
+                    meatWithdrawalDays: parseInt(formData.meatWithdrawalDays) || 0
                 });
                 
                 toast.success('Health record updated', { id: loadingToast });
@@ -112,7 +130,7 @@ export function Health() {
                 loadData();
             } catch (err) {
                 console.error(err);
-                toast.error('Failed to update health record', { id: loadingToast });
+                toast.error(typeof err === 'string' ? err : 'Failed to update health record', { id: loadingToast });
             }
         } else {
             // Add new records
@@ -142,7 +160,9 @@ export function Health() {
                         vetName: formData.vetName,
                         cost: index === 0 ? cost : 0,  // Only first animal gets the cost
                         nextDueDate: formData.nextDueDate,
-                        notes: formData.notes
+                        notes: formData.notes,
+                        milkWithdrawalDays: parseInt(formData.milkWithdrawalDays) || 0,
+                        meatWithdrawalDays: parseInt(formData.meatWithdrawalDays) || 0
                     })
                 );
                 
@@ -191,13 +211,15 @@ export function Health() {
             vetName: record.vetName || '',
             cost: record.cost ? record.cost.toString() : '',
             nextDueDate: record.nextDueDate || '',
-            notes: record.notes || ''
+            notes: record.notes || '',
+            milkWithdrawalDays: record.milkWithdrawalDays ? record.milkWithdrawalDays.toString() : '',
+            meatWithdrawalDays: record.meatWithdrawalDays ? record.meatWithdrawalDays.toString() : ''
         });
         setShowModal(true);
     };
 
     const resetForm = () => {
-        setFormData({ animalIds: [], selectAll: false, date: new Date().toISOString().split('T')[0], recordType: 'treatment', description: '', diagnosis: '', treatment: '', medicine: '', dosage: '', vetName: '', cost: '', nextDueDate: '', notes: '' });
+        setFormData({ animalIds: [], selectAll: false, date: new Date().toISOString().split('T')[0], recordType: 'treatment', description: '', diagnosis: '', treatment: '', medicine: '', dosage: '', vetName: '', cost: '', nextDueDate: '', notes: '', milkWithdrawalDays: '', meatWithdrawalDays: '' });
         setEditingRecord(null);
         setIsDropdownOpen(false);
     };
@@ -234,6 +256,21 @@ export function Health() {
                 </Card>
             )}
 
+            {withdrawals.length > 0 && (
+                <Card className="upcoming-card withdrawal-card">
+                    <div className="upcoming-header"><Ban size={20} /> Under Drug Withdrawal</div>
+                    <div className="upcoming-list">
+                        {withdrawals.map(w => (
+                            <div key={w.animalId} className="upcoming-item">
+                                <span className="upcoming-animal">{w.animalName}{w.tagNumber && <span className="animal-tag"> #{w.tagNumber}</span>}</span>
+                                {w.milkWithheldUntil && <span className="upcoming-date">Milk until {new Date(w.milkWithheldUntil).toLocaleDateString('en-KE')} ({w.milkMedicine || 'treatment'})</span>}
+                                {w.meatWithheldUntil && <span className="upcoming-date">Meat until {new Date(w.meatWithheldUntil).toLocaleDateString('en-KE')} ({w.meatMedicine || 'treatment'})</span>}
+                            </div>
+                        ))}
+                    </div>
+                </Card>
+            )}
+
             <div style={{ display: 'flex', gap: '12px', marginBottom: '24px', alignItems: 'center' }}>
                 <div style={{ position: 'relative', flex: 1, maxWidth: '400px' }}>
                     <Search size={18} style={{ position: 'absolute', left: '12px', top: '50%', transform: 'translateY(-50%)', color: '#64748b' }} />
@@ -310,7 +347,10 @@ export function Health() {
                                             {record.description || '-'}
                                         </span>
                                     </TableCell>
-                                    <TableCell>{record.medicine || '-'}</TableCell>
+                                    <TableCell>
+                                        {record.medicine || '-'}
+                                        {record.milkWithheldUntil && <span className="withheld-note">Milk withheld until {new Date(record.milkWithheldUntil).toLocaleDateString('en-KE')}</span>}
+                                    </TableCell>
                                     <TableCell>{record.vetName || '-'}</TableCell>
                                     <TableCell className="font-mono">
                                         {record.nextDueDate ? new Date(record.nextDueDate).toLocaleDateString('en-KE') : '-'}
@@ -446,9 +486,13 @@ export function Health() {
                         <FormGroup><Label htmlFor="treatment">Treatment</Label><Input id="treatment" value={formData.treatment} onChange={(e) => setFormData({ ...formData, treatment: e.target.value })} /></FormGroup>
                     </FormRow>
                     <FormRow>
-                        <FormGroup><Label htmlFor="medicine">Medicine</Label><Input id="medicine" value={formData.medicine} onChange={(e) => setFormData({ ...formData, medicine: e.target.value })} placeholder="e.g., Oxytetracycline" /></FormGroup>
+                        <FormGroup><Label htmlFor="medicine">Medicine</Label><Input id="medicine" list="medicine-options" value={formData.medicine} onChange={(e) => changeMedicine(e.target.value)} placeholder="e.g., Oxytetracycline" /><datalist id="medicine-options">{medicineWithdrawals.map(m => <option key={m.medicine} value={m.medicine} />)}</datalist></FormGroup>
                         <FormGroup><Label htmlFor="dosage">Dosage</Label><Input id="dosage" value={formData.dosage} onChange={(e) => setFormData({ ...formData, dosage: e.target.value })} placeholder="e.g., 5ml" /></FormGroup>
                     </FormRow>
+                    <FormRow>
+                        <FormGroup><Label htmlFor="milkWithdrawal">Milk Withdrawal (days)</Label><Input id="milkWithdrawal" type="number" min="0" value={formData.milkWithdrawalDays} onChange={(e) => setFormData({ ...formData, milkWithdrawalDays: e.target.value })} placeholder="0" /></FormGroup>
+                        <FormGroup><Label htmlFor="meatWithdrawal">Meat Withdrawal (days)</Label><Input id="meatWithdrawal" type="number" min="0" value={formData.meatWithdrawalDays} onChange={(e) => setFormData({ ...formData, meatWithdrawalDays: e.target.value })} placeholder="0" /></FormGroup>
+                    </FormRow>
                     <FormRow>
                         <FormGroup><Label htmlFor="cost">Cost (KES)</Label><Input id="cost" type="number" value={formData.cost} onChange={(e) => setFormData({ ...formData, cost: e.target.value })} /></FormGroup>
                         <FormGroup><Label htmlFor="nextDue">Next Due Date</Label><Input id="nextDue" type="date" value={formData.nextDueDate} onChange={(e) => setFormData({ ...formData, nextDueDate: e.target.value })} /></FormGroup>
@@ -1,23 +1,27 @@
 import React, { useState, useEffect } from 'react';
 import { useNavigate } from 'react-router-dom';
-import { Plus, Search, Edit2, Trash2, Milk, Beef, FileSpreadsheet, Calendar, Info, Users, Droplets } from 'lucide-react';
+import { Plus, Search, Edit2, Trash2, Milk, Beef, FileSpreadsheet, Calendar, Info, Users, Droplets, MapPin, Power, TrendingDown, LogOut } from 'lucide-react';
 import { Pagination } from '../components/ui/Pagination';
 import { Button } from '../components/ui/Button';
 import { Card, CardContent } from '../components/ui/Card';
 import { Modal } from '../components/ui/Modal';
-import { FormGroup, FormRow, Label, Input, Select, Textarea } from '../components/ui/Form';
+import { FormGroup, FormRow, Label, Input, Select, Textarea, Checkbox } from '../components/ui/Form';
 import { Table, TableHeader, TableBody, TableRow, TableHead, TableCell } from '../components/ui/Table';
 import { EmptyState } from '../components/ui/EmptyState';
 import { PhotoGallery } from '../components/PhotoGallery';
 import { ConfirmDialog, AlertDialog } from '../components/ui/ConfirmDialog';
 import { Skeleton } from '../components/ui/Skeleton';
 import { toast } from 'sonner';
+import { sessionsToForm, formToSessions, visibleSessions, sumSessions } from '../utils/milkSessions';
+import { breedsBySpecies, formatType, animalSpecies, describeProduction } from '../utils/species';
+import { StatCard } from '../components/ui/StatCard';
 import './Livestock.css';
 import '../components/EntityDetails.css';
 
-const animalTypes = ['cow', 'bull', 'heifer', 'calf'];
-const breeds = ['Friesian', 'Ayrshire', 'Jersey', 'Guernsey', 'Sahiwal', 'Boran', 'Mixed'];
-const statuses = ['active', 'sold', 'deceased'];
+const statuses = ['active', 'sold', 'deceased', 'culled'];
+const groupKinds = ['group', 'pen', 'paddock'];
+const today = () => new Date().toISOString().split('T')[0];
+const emptyAcquisition = { acquisitionType: 'born', seller: '', purchaseDate: '', purchasePrice: '', transportCost: '', quarantineDays: '' };
 
 export function Livestock() {
     const [animals, setAnimals] = useState([]);
@@ -33,13 +37,64 @@ export function Livestock() {
     const [alert, setAlert] = useState({ show: false, title: '', message: '', type: 'info' });
     const [tempPhotoId, setTempPhotoId] = useState(null);
     const [formData, setFormData] = useState({
-        tagNumber: '', name: '', type: 'cow', breed: '', dateOfBirth: '',
-        gender: 'female', motherId: null, fatherId: null, status: 'active', notes: ''
+        tagNumber: '', name: '', species: 'cattle', type: 'cow', breed: '', dateOfBirth: '',
+        gender: 'female', motherId: null, fatherId: null, status: 'active', notes: '', groupId: '',
+        ...emptyAcquisition
     });
-    const [milkForm, setMilkForm] = useState({ date: new Date().toISOString().split('T')[0], morningLiters: '', eveningLiters: '', notes: '' });
+    const [milkForm, setMilkForm] = useState({ date: new Date().toISOString().split('T')[0], liters: {}, discarded: false, notes: '' });
+    const [milkWithdrawal, setMilkWithdrawal] = useState(null);
+    const [milkSessions, setMilkSessions] = useState([]);
     const [existingMilkRecord, setExistingMilkRecord] = useState(null);
+    const [showBulkMilkModal, setShowBulkMilkModal] = useState(false);
+    const [showRankingModal, setShowRankingModal] = useState(false);
+    const [herdRanking, setHerdRanking] = useState(null);
+    const [bulkMilkDate, setBulkMilkDate] = useState(new Date().toISOString().split('T')[0]);
+    const [bulkMilkRows, setBulkMilkRows] = useState([]);
+    const [bulkMilkResults, setBulkMilkResults] = useState({});
+    const [groups, setGroups] = useState([]);
+    const [speciesProfiles, setSpeciesProfiles] = useState([]);
+    const [speciesSummaries, setSpeciesSummaries] = useState([]);
+    const [groupFilter, setGroupFilter] = useState('');
+    const [showGroupsModal, setShowGroupsModal] = useState(false);
+    const [groupMilk, setGroupMilk] = useState({});
+    const [groupForm, setGroupForm] = useState({ name: '', kind: 'group', description: '' });
+    const [moveForm, setMoveForm] = useState({ groupId: '', date: today(), reason: '', animalIds: [] });
+    const [showDisposalModal, setShowDisposalModal] = useState(false);
+    const [disposalRange, setDisposalRange] = useState({ start: `${new Date().getFullYear()}-01-01`, end: today() });
+    const [disposalReport, setDisposalReport] = useState(null);
 
-    useEffect(() => { loadAnimals(); }, []);
+    useEffect(() => { loadAnimals(); loadMilkSessions(); loadGroups(); loadSpecies(); }, []);
+
+    const loadSpecies = async () => {
+        try {
+            const [profiles, summaries] = await Promise.all([
+                window.go.main.LivestockService.GetSpeciesProfiles(),
+                window.go.main.LivestockService.GetSpeciesSummaries()
+            ]);
+            setSpeciesProfiles(profiles || []);
+            setSpeciesSummaries(summaries || []);
+        } catch (err) { console.error(err); }
+    };
+
+    const loadGroups = async () => {
+        try {
+            const [data, milk] = await Promise.all([
+                window.go.main.GroupService.GetGroups(),
+                window.go.main.GroupService.GetGroupMilkTotals('', '')
+            ]);
+            setGroups(data || []);
+            const byGroup = {};
+            (milk || []).forEach(t => { byGroup[t.groupId] = t.liters; });
+            setGroupMilk(byGroup);
+        } catch (err) { console.error(err); }
+    };
+
+    const loadMilkSessions = async () => {
+        try {
+            const data = await window.go.main.LivestockService.GetMilkingSessions();
+            setMilkSessions(data || []);
+        } catch (err) { console.error(err); }
+    };
 
     const loadAnimals = async () => {
         try {
@@ -51,21 +106,25 @@ export function Livestock() {
 
     const loadMilkRecord = async (animalId, date) => {
         try {
-            const record = await window.go.main.LivestockService.GetMilkRecordByAnimalAndDate(animalId, date);
+            const [record, withdrawal] = await Promise.all([
+                window.go.main.LivestockService.GetMilkRecordByAnimalAndDate(animalId, date),
+                window.go.main.HealthService.GetMilkWithdrawal(animalId, date)
+            ]);
+            setMilkWithdrawal(withdrawal);
             if (record) {
                 setExistingMilkRecord(record);
                 setMilkForm({
                     date: record.date,
-                    morningLiters: record.morningLiters > 0 ? record.morningLiters.toString() : '',
-                    eveningLiters: record.eveningLiters > 0 ? record.eveningLiters.toString() : '',
+                    liters: sessionsToForm(record.sessions),
+                    discarded: record.discarded,
                     notes: record.notes || ''
                 });
             } else {
                 setExistingMilkRecord(null);
                 setMilkForm({
                     date: date,
-                    morningLiters: '',
-                    eveningLiters: '',
+                    liters: {},
+                    discarded: !!withdrawal,
                     notes: ''
                 });
             }
@@ -82,7 +141,11 @@ export function Livestock() {
             const animalData = {
                 ...formData,
                 motherId: formData.motherId ? parseInt(formData.motherId) : null,
-                fatherId: formData.fatherId ? parseInt(formData.fatherId) : null
+                fatherId: formData.fatherId ? parseInt(formData.fatherId) : null,
+                groupId: formData.groupId ? parseInt(formData.groupId) : null,
+                purchasePrice: parseFloat(formData.purchasePrice) || 0,
+                transportCost: parseFloat(formData.transportCost) || 0,
+                quarantineDays: parseInt(formData.quarantineDays) || 0
             };
             if (editingAnimal) {
                 await window.go.main.LivestockService.UpdateAnimal({ ...animalData, id: editingAnimal.id });
@@ -99,9 +162,67 @@ export function Livestock() {
             setTempPhotoId(null);
             resetForm();
             loadAnimals();
+            loadGroups();
+            loadSpecies();
+        } catch (err) {
+            console.error(err);
+            toast.error(typeof err === 'string' ? err : 'Failed to save animal record', { id: loadingToast });
+        }
+    };
+
+    const handleAddGroup = async (e) => {
+        e.preventDefault();
+        try {
+            await window.go.main.GroupService.AddGroup({ ...groupForm, active: true });
+            toast.success(`${groupForm.name} added`);
+            setGroupForm({ name: '', kind: 'group', description: '' });
+            loadGroups();
+        } catch (err) {
+            console.error(err);
+            toast.error(typeof err === 'string' ? err : 'Failed to add group');
+        }
+    };
+
+    const toggleGroupActive = async (group) => {
+        try {
+            await window.go.main.GroupService.UpdateGroup({ ...group, active: !group.active });
+            loadGroups();
+        } catch (err) {
+            console.error(err);
+            toast.error(typeof err === 'string' ? err : 'Failed to update group');
+        }
+    };
+
+    const deleteGroup = async (group) => {
+        try {
+            await window.go.main.GroupService.DeleteGroup(group.id);
+            toast.success(`${group.name} deleted`);
+            loadGroups();
         } catch (err) {
             console.error(err);
-            toast.error('Failed to save animal record', { id: loadingToast });
+            toast.error(typeof err === 'string' ? err : 'Failed to delete group');
+        }
+    };
+
+    const toggleMoveAnimal = (animalId) => {
+        setMoveForm(form => ({
+            ...form,
+            animalIds: form.animalIds.includes(animalId) ? form.animalIds.filter(id => id !== animalId) : [...form.animalIds, animalId]
+        }));
+    };
+
+    const handleMoveAnimals = async (e) => {
+        e.preventDefault();
+        const loadingToast = toast.loading('Moving animals...');
+        try {
+            const moved = await window.go.main.GroupService.MoveAnimals(moveForm.animalIds, parseInt(moveForm.groupId) || 0, moveForm.date, moveForm.reason);
+            toast.success(`Moved ${moved} animal${moved !== 1 ? 's' : ''}`, { id: loadingToast });
+            setMoveForm({ groupId: '', date: today(), reason: '', animalIds: [] });
+            loadAnimals();
+            loadGroups();
+        } catch (err) {
+            console.error(err);
+            toast.error(typeof err === 'string' ? err : 'Failed to move animals', { id: loadingToast });
         }
     };
 
@@ -114,8 +235,8 @@ export function Livestock() {
                     id: existingMilkRecord.id,
                     animalId: selectedAnimal.id,
                     date: milkForm.date,
-                    morningLiters: parseFloat(milkForm.morningLiters) || 0,
-                    eveningLiters: parseFloat(milkForm.eveningLiters) || 0,
+                    sessions: formToSessions(milkForm.liters),
+                    discarded: milkForm.discarded,
                     notes: milkForm.notes
                 });
                 toast.success('Milk record updated', { id: loadingToast });
@@ -123,18 +244,103 @@ export function Livestock() {
                 await window.go.main.LivestockService.AddMilkRecord({
                     animalId: selectedAnimal.id,
                     date: milkForm.date,
-                    morningLiters: parseFloat(milkForm.morningLiters) || 0,
-                    eveningLiters: parseFloat(milkForm.eveningLiters) || 0,
+                    sessions: formToSessions(milkForm.liters),
+                    discarded: milkForm.discarded,
                     notes: milkForm.notes
                 });
                 toast.success('Milk record saved', { id: loadingToast });
             }
+            if (milkWithdrawal && !milkForm.discarded) {
+                toast.warning(`${selectedAnimal.name}'s milk is withheld until ${milkWithdrawal.milkWithheldUntil} and was recorded as saleable`);
+            }
             setShowMilkModal(false);
             setExistingMilkRecord(null);
-            setMilkForm({ date: new Date().toISOString().split('T')[0], morningLiters: '', eveningLiters: '', notes: '' });
+            setMilkForm({ date: new Date().toISOString().split('T')[0], liters: {}, discarded: false, notes: '' });
         } catch (err) {
             console.error(err);
-            toast.error('Failed to save milk record', { id: loadingToast });
+            toast.error(typeof err === 'string' ? err : 'Failed to save milk record', { id: loadingToast });
+        }
+    };
+
+    const loadBulkMilkSheet = async (date) => {
+        setBulkMilkDate(date);
+        setBulkMilkResults({});
+        try {
+            const sheet = await window.go.main.LivestockService.GetMilkEntrySheet(date);
+            setBulkMilkRows((sheet || []).map(row => ({ ...row, liters: sessionsToForm(row.sessions) })));
+        } catch (err) {
+            console.error('Failed to load milking sheet:', err);
+            toast.error('Failed to load milking cows');
+        }
+    };
+
+    const openBulkMilk = async () => {
+        await loadBulkMilkSheet(new Date().toISOString().split('T')[0]);
+        setShowBulkMilkModal(true);
+    };
+
+    const updateBulkMilkRow = (animalId, sessionId, value) => {
+        setBulkMilkRows(rows => rows.map(row => row.animalId === animalId ? { ...row, liters: { ...row.liters, [sessionId]: value } } : row));
+    };
+
+    const toggleBulkMilkDiscarded = (animalId, discarded) => {
+        setBulkMilkRows(rows => rows.map(row => row.animalId === animalId ? { ...row, discarded } : row));
+    };
+
+    const openHerdRanking = async () => {
+        setHerdRanking(null);
+        setShowRankingModal(true);
+        try {
+            setHerdRanking(await window.go.main.LivestockService.GetHerdRanking());
+        } catch (err) {
+            console.error('Failed to rank herd:', err);
+            toast.error(typeof err === 'string' ? err : 'Failed to rank the herd');
+            setShowRankingModal(false);
+        }
+    };
+
+    const loadDisposalReport = async (range) => {
+        setDisposalRange(range);
+        try {
+            setDisposalReport(await window.go.main.LivestockService.GetDisposalReport(range.start, range.end));
+        } catch (err) {
+            console.error('Failed to load disposal report:', err);
+            toast.error(typeof err === 'string' ? err : 'Failed to load disposals');
+        }
+    };
+
+    const openDisposalReport = async () => {
+        setDisposalReport(null);
+        setShowDisposalModal(true);
+        await loadDisposalReport(disposalRange);
+    };
+
+    const handleBulkMilkSubmit = async (e) => {
+        e.preventDefault();
+        const loadingToast = toast.loading('Saving milking...');
+        try {
+            const results = await window.go.main.LivestockService.SaveMilkEntries(bulkMilkDate, bulkMilkRows.map(row => ({
+                animalId: row.animalId,
+                sessions: formToSessions(row.liters),
+                discarded: row.discarded,
+                notes: row.notes || ''
+            })));
+            const byAnimal = {};
+            (results || []).forEach(r => { byAnimal[r.animalId] = r; });
+            setBulkMilkResults(byAnimal);
+
+            const saved = (results || []).filter(r => r.status === 'created' || r.status === 'updated').length;
+            const failed = (results || []).filter(r => r.status === 'failed').length;
+            const warned = (results || []).filter(r => r.warning).length;
+            if (failed > 0 || warned > 0) {
+                toast.warning(`Saved ${saved} records, ${failed + warned} need attention`, { id: loadingToast });
+            } else {
+                toast.success(`Saved ${saved} milk records for ${bulkMilkDate}`, { id: loadingToast });
+                setShowBulkMilkModal(false);
+            }
+        } catch (err) {
+            console.error(err);
+            toast.error('Failed to save milking', { id: loadingToast });
         }
     };
 
@@ -158,10 +364,12 @@ export function Livestock() {
     const openEdit = (animal) => {
         setEditingAnimal(animal);
         setFormData({
-            tagNumber: animal.tagNumber, name: animal.name, type: animal.type,
+            tagNumber: animal.tagNumber, name: animal.name, species: animal.species || 'cattle', type: animal.type,
             breed: animal.breed, dateOfBirth: animal.dateOfBirth, gender: animal.gender,
             motherId: animal.motherId || '', fatherId: animal.fatherId || '',
-            status: animal.status, notes: animal.notes
+            status: animal.status, notes: animal.notes,
+            acquisitionType: animal.acquisitionType || 'born', seller: animal.seller || '', purchaseDate: animal.purchaseDate || '',
+            purchasePrice: animal.purchasePrice || '', transportCost: animal.transportCost || '', quarantineDays: animal.quarantineDays || ''
         });
         setShowModal(true);
     };
@@ -186,14 +394,16 @@ export function Livestock() {
 
     const resetForm = () => {
         setFormData({
-            tagNumber: '', name: '', type: 'cow', breed: '', dateOfBirth: '',
-            gender: 'female', motherId: null, fatherId: null, status: 'active', notes: ''
+            tagNumber: '', name: '', species: 'cattle', type: 'cow', breed: '', dateOfBirth: '',
+            gender: 'female', motherId: null, fatherId: null, status: 'active', notes: '', groupId: '',
+            ...emptyAcquisition
         });
     };
 
     const filteredAnimals = animals.filter(animal =>
-        animal.name.toLowerCase().includes(searchTerm.toLowerCase()) ||
-        animal.tagNumber?.toLowerCase().includes(searchTerm.toLowerCase())
+        (animal.name.toLowerCase().includes(searchTerm.toLowerCase()) ||
+        animal.tagNumber?.toLowerCase().includes(searchTerm.toLowerCase())) &&
+        (groupFilter === '' || (groupFilter === 'none' ? !animal.groupId : animal.groupId === parseInt(groupFilter)))
     );
 
     const itemsPerPage = 10;
@@ -201,10 +411,24 @@ export function Livestock() {
 
     useEffect(() => {
         setCurrentPage(1);
-    }, [searchTerm]);
+    }, [searchTerm, groupFilter]);
+
+    const bulkSessions = milkSessions.filter(session => session.active || bulkMilkRows.some(row => parseFloat(row.liters[session.id]) > 0));
+
+    const activeGroups = groups.filter(g => g.active);
+    const formSpecies = speciesProfiles.find(p => p.species === formData.species);
+    const speciesTypes = formSpecies?.types || [formData.type];
+    const speciesBreeds = breedsBySpecies[formData.species] || breedsBySpecies.cattle;
+
+    const changeSpecies = (species) => {
+        const profile = speciesProfiles.find(p => p.species === species);
+        setFormData({ ...formData, species, type: profile?.types[0] || '', breed: '' });
+    };
+    const activeAnimals = animals.filter(a => a.status === 'active');
 
-    const potentialMothers = animals.filter(a => a.gender === 'female' && a.id !== editingAnimal?.id);
-    const potentialFathers = animals.filter(a => a.gender === 'male' && a.id !== editingAnimal?.id);
+    const sameSpecies = (a) => (a.species || 'cattle') === formData.species;
+    const potentialMothers = animals.filter(a => a.gender === 'female' && a.id !== editingAnimal?.id && sameSpecies(a));
+    const potentialFathers = animals.filter(a => a.gender === 'male' && a.id !== editingAnimal?.id && sameSpecies(a));
 
     const handleExportCSV = async () => {
         const loadingToast = toast.loading('Generating comprehensive livestock CSV...');
@@ -222,6 +446,24 @@ export function Livestock() {
         }
     };
 
+    const handleExportWorkbook = async () => {
+        const loadingToast = toast.loading('Generating farm workbook...');
+        try {
+            const res = await window.go.main.ExportService.ExportFarmWorkbookXLSX('', '');
+            if (res) {
+                toast.success(`Exported ${res.records} rows to Excel`, {
+                    id: loadingToast,
+                    description: `File saved to ${res.path}`
+                });
+            } else {
+                toast.dismiss(loadingToast);
+            }
+        } catch (err) {
+            console.error('Export failed:', err);
+            toast.error('Failed to export workbook', { id: loadingToast });
+        }
+    };
+
     return (
         <div className="livestock-page">
             <header className="page-header">
@@ -231,6 +473,11 @@ export function Livestock() {
                 </div>
                 <div className="page-actions">
                     <Button variant="outline" icon={FileSpreadsheet} onClick={handleExportCSV} title="Generate comprehensive livestock CSV report">Export CSV</Button>
+                    <Button variant="outline" icon={FileSpreadsheet} onClick={handleExportWorkbook} title="Export the whole farm to one Excel workbook with a sheet per module">Export Excel</Button>
+                    <Button variant="outline" icon={MapPin} onClick={() => setShowGroupsModal(true)} title="Manage groups and pens and move animals">Groups</Button>
+                    <Button variant="outline" icon={TrendingDown} onClick={openHerdRanking} title="Rank cows on yield, fertility, health cost and age to find culling candidates">Herd Ranking</Button>
+                    <Button variant="outline" icon={LogOut} onClick={openDisposalReport} title="Sales, deaths and culls with mortality and cull rates">Disposals</Button>
+                    <Button variant="outline" icon={Milk} onClick={openBulkMilk} title="Enter milk for all milking cows at once">Record Milking</Button>
                     <Button icon={Plus} onClick={() => {
                         resetForm();
                         setEditingAnimal(null);
@@ -240,13 +487,35 @@ export function Livestock() {
                 </div>
             </header>
 
+            {speciesSummaries.length > 1 && (
+                <div className="stats-grid stats-grid--four">
+                    {speciesSummaries.map(summary => (
+                        <StatCard
+                            key={summary.species}
+                            title={summary.label}
+                            value={`${summary.headCount} ${summary.metric === 'eggs' ? 'birds' : 'head'}`}
+                            subtitle={describeProduction(summary)}
+                            icon={summary.metric === 'milk' ? Milk : Beef}
+                            color={summary.metric === 'milk' ? 'primary' : 'secondary'}
+                        />
+                    ))}
+                </div>
+            )}
+
             <Card padding="none" className="livestock-card">
                 <div className="livestock-toolbar">
                     <div className="search-box">
                         <Search size={18} />
                         <input type="text" placeholder="Search by name or tag..." value={searchTerm} onChange={(e) => setSearchTerm(e.target.value)} />
                     </div>
-                    <div className="livestock-count">{filteredAnimals.length} animals</div>
+                    <div className="livestock-toolbar-filters">
+                        <Select value={groupFilter} onChange={(e) => setGroupFilter(e.target.value)} aria-label="Filter by group">
+                            <option value="">All groups</option>
+                            {groups.map(g => <option key={g.id} value={g.id}>{g.name}</option>)}
+                            <option value="none">No group</option>
+                        </Select>
+                        <div className="livestock-count">{filteredAnimals.length} animals</div>
+                    </div>
                 </div>
 
                 {loading ? (
@@ -256,6 +525,7 @@ export function Livestock() {
                                 <TableHead>Tag / Name</TableHead>
                                 <TableHead>Type</TableHead>
                                 <TableHead>Breed</TableHead>
+                                <TableHead>Group</TableHead>
                                 <TableHead>Status</TableHead>
                                 <TableHead>Actions</TableHead>
                             </TableRow>
@@ -266,6 +536,7 @@ export function Livestock() {
                                     <TableCell><Skeleton variant="text" width="120px" /></TableCell>
                                     <TableCell><Skeleton variant="rounded" width="60px" height="20px" /></TableCell>
                                     <TableCell><Skeleton variant="text" width="80px" /></TableCell>
+                                    <TableCell><Skeleton variant="text" width="80px" /></TableCell>
                                     <TableCell><Skeleton variant="rounded" width="70px" height="20px" /></TableCell>
                                     <TableCell><div className="flex gap-2"><Skeleton variant="circular" width="24px" height="24px" /><Skeleton variant="circular" width="24px" height="24px" /></div></TableCell>
                                 </TableRow>
@@ -281,6 +552,7 @@ export function Livestock() {
                                 <TableHead>Tag / Name</TableHead>
                                 <TableHead>Type</TableHead>
                                 <TableHead>Breed</TableHead>
+                                <TableHead>Group</TableHead>
                                 <TableHead>Status</TableHead>
                                 <TableHead>Actions</TableHead>
                             </TableRow>
@@ -294,8 +566,12 @@ export function Livestock() {
                                             {animal.tagNumber && <span className="animal-tag">#{animal.tagNumber}</span>}
                                         </div>
                                     </TableCell>
-                                    <TableCell><span className={`type-badge type-${animal.type}`}>{animal.type}</span></TableCell>
+                                    <TableCell>
+                                        <span className={`type-badge type-${animal.type}`}>{formatType(animal.type)}</span>
+                                        {animal.species && animal.species !== 'cattle' && <span className="species-label">{animal.species}</span>}
+                                    </TableCell>
                                     <TableCell>{animal.breed || '-'}</TableCell>
+                                    <TableCell>{animal.groupName || '-'}</TableCell>
                                     <TableCell><span className={`status-badge status-${animal.status}`}>{animal.status}</span></TableCell>
                                     <TableCell>
                                         <div className="action-buttons">
@@ -328,8 +604,11 @@ export function Livestock() {
                         <FormGroup><Label htmlFor="name" required>Name</Label><Input id="name" value={formData.name} onChange={(e) => setFormData({ ...formData, name: e.target.value })} placeholder="Enter name" required /></FormGroup>
                     </FormRow>
                     <FormRow>
-                        <FormGroup><Label htmlFor="type">Type</Label><Select id="type" value={formData.type} onChange={(e) => setFormData({ ...formData, type: e.target.value })}>{animalTypes.map(t => <option key={t} value={t}>{t.charAt(0).toUpperCase() + t.slice(1)}</option>)}</Select></FormGroup>
-                        <FormGroup><Label htmlFor="breed">Breed</Label><Select id="breed" value={formData.breed} onChange={(e) => setFormData({ ...formData, breed: e.target.value })}><option value="">Select breed</option>{breeds.map(b => <option key={b} value={b}>{b}</option>)}</Select></FormGroup>
+                        <FormGroup><Label htmlFor="species">Species</Label><Select id="species" value={formData.species} onChange={(e) => changeSpecies(e.target.value)}>{animalSpecies(speciesProfiles).map(p => <option key={p.species} value={p.species}>{p.label}</option>)}</Select></FormGroup>
+                        <FormGroup><Label htmlFor="type">Type</Label><Select id="type" value={formData.type} onChange={(e) => setFormData({ ...formData, type: e.target.value })}>{speciesTypes.map(t => <option key={t} value={t}>{formatType(t)}</option>)}</Select></FormGroup>
+                    </FormRow>
+                    <FormRow>
+                        <FormGroup><Label htmlFor="breed">Breed</Label><Select id="breed" value={formData.breed} onChange={(e) => setFormData({ ...formData, breed: e.target.value })}><option value="">Select breed</option>{speciesBreeds.map(b => <option key={b} value={b}>{b}</option>)}{formData.breed && !speciesBreeds.includes(formData.breed) && <option value={formData.breed}>{formData.breed}</option>}</Select></FormGroup>
                     </FormRow>
                     <FormRow>
                         <FormGroup><Label htmlFor="gender">Gender</Label><Select id="gender" value={formData.gender} onChange={(e) => setFormData({ ...formData, gender: e.target.value })}><option value="female">Female</option><option value="male">Male</option></Select></FormGroup>
@@ -353,7 +632,45 @@ export function Livestock() {
                     </FormRow>
                     <FormRow>
                         <FormGroup><Label htmlFor="status">Status</Label><Select id="status" value={formData.status} onChange={(e) => setFormData({ ...formData, status: e.target.value })}>{statuses.map(s => <option key={s} value={s}>{s.charAt(0).toUpperCase() + s.slice(1)}</option>)}</Select></FormGroup>
+                        {!editingAnimal && (
+                            <FormGroup>
+                                <Label htmlFor="groupId">Group / Pen</Label>
+                                <Select id="groupId" value={formData.groupId} onChange={(e) => setFormData({ ...formData, groupId: e.target.value })}>
+                                    <option value="">No group</option>
+                                    {activeGroups.map(g => <option key={g.id} value={g.id}>{g.name}</option>)}
+                                </Select>
+                            </FormGroup>
+                        )}
                     </FormRow>
+                    <FormRow>
+                        <FormGroup>
+                            <Label htmlFor="acquisitionType">Acquired</Label>
+                            <Select id="acquisitionType" value={formData.acquisitionType} onChange={(e) => setFormData({ ...formData, acquisitionType: e.target.value, purchaseDate: formData.purchaseDate || today() })}>
+                                <option value="born">Born on the farm</option>
+                                <option value="purchased">Purchased</option>
+                            </Select>
+                        </FormGroup>
+                        {formData.acquisitionType === 'purchased' && (
+                            <FormGroup><Label htmlFor="seller">Seller</Label><Input id="seller" value={formData.seller} onChange={(e) => setFormData({ ...formData, seller: e.target.value })} /></FormGroup>
+                        )}
+                    </FormRow>
+                    {formData.acquisitionType === 'purchased' && (
+                        <>
+                            <FormRow>
+                                <FormGroup><Label htmlFor="purchaseDate">Purchase Date</Label><Input id="purchaseDate" type="date" value={formData.purchaseDate} onChange={(e) => setFormData({ ...formData, purchaseDate: e.target.value })} /></FormGroup>
+                                <FormGroup><Label htmlFor="quarantineDays">Quarantine (days)</Label><Input id="quarantineDays" type="number" min="0" value={formData.quarantineDays} onChange={(e) => setFormData({ ...formData, quarantineDays: e.target.value })} placeholder="e.g., 21" /></FormGroup>
+                            </FormRow>
+                            <FormRow>
+                                <FormGroup><Label htmlFor="purchasePrice">Purchase Price</Label><Input id="purchasePrice" type="number" step="0.01" min="0" value={formData.purchasePrice} onChange={(e) => setFormData({ ...formData, purchasePrice: e.target.value })} /></FormGroup>
+                                <FormGroup><Label htmlFor="transportCost">Transport Cost</Label><Input id="transportCost" type="number" step="0.01" min="0" value={formData.transportCost} onChange={(e) => setFormData({ ...formData, transportCost: e.target.value })} /></FormGroup>
+                            </FormRow>
+                            <p className="acquisition-note">
+                                {editingAnimal
+                                    ? 'Changing the price or transport cost does not adjust the expenses already posted for this purchase.'
+                                    : 'The purchase price and transport cost are posted to Finances as expenses.'}
+                            </p>
+                        </>
+                    )}
                     <FormGroup><Label htmlFor="notes">Notes</Label><Textarea id="notes" value={formData.notes} onChange={(e) => setFormData({ ...formData, notes: e.target.value })} placeholder="Any additional notes..." rows={3} /></FormGroup>
 
                     <PhotoGallery
@@ -374,14 +691,261 @@ export function Livestock() {
                     )}
                     <FormGroup><Label htmlFor="milkDate" required>Date</Label><Input id="milkDate" type="date" value={milkForm.date} onChange={(e) => handleMilkDateChange(e.target.value)} required /></FormGroup>
                     <FormRow>
-                        <FormGroup><Label htmlFor="morning">Morning (L)</Label><Input id="morning" type="number" step="0.1" value={milkForm.morningLiters} onChange={(e) => setMilkForm({ ...milkForm, morningLiters: e.target.value })} placeholder="0.0" /></FormGroup>
-                        <FormGroup><Label htmlFor="evening">Evening (L)</Label><Input id="evening" type="number" step="0.1" value={milkForm.eveningLiters} onChange={(e) => setMilkForm({ ...milkForm, eveningLiters: e.target.value })} placeholder="0.0" /></FormGroup>
+                        {visibleSessions(milkSessions, milkForm.liters).map(session => (
+                            <FormGroup key={session.id}><Label htmlFor={`session-${session.id}`}>{session.name} (L)</Label><Input id={`session-${session.id}`} type="number" step="0.1" min="0" value={milkForm.liters[session.id] || ''} onChange={(e) => setMilkForm({ ...milkForm, liters: { ...milkForm.liters, [session.id]: e.target.value } })} placeholder="0.0" /></FormGroup>
+                        ))}
                     </FormRow>
+                    {milkWithdrawal && (
+                        <div className="milk-withdrawal-alert">
+                            Milk withheld until {milkWithdrawal.milkWithheldUntil} after {milkWithdrawal.milkMedicine || 'treatment'}
+                        </div>
+                    )}
+                    <FormGroup><Checkbox label="Milk discarded (not for sale)" checked={milkForm.discarded} onChange={(e) => setMilkForm({ ...milkForm, discarded: e.target.checked })} /></FormGroup>
                     <FormGroup><Label htmlFor="milkNotes">Notes</Label><Textarea id="milkNotes" value={milkForm.notes} onChange={(e) => setMilkForm({ ...milkForm, notes: e.target.value })} rows={2} /></FormGroup>
                     <div className="modal-actions"><Button variant="outline" type="button" onClick={() => setShowMilkModal(false)}>Cancel</Button><Button type="submit">{existingMilkRecord ? 'Update' : 'Save'} Record</Button></div>
                 </form>
             </Modal>
 
+            <Modal isOpen={showBulkMilkModal} onClose={() => setShowBulkMilkModal(false)} title="Record Milking" size="lg">
+                <form onSubmit={handleBulkMilkSubmit}>
+                    <FormGroup><Label htmlFor="bulkMilkDate" required>Date</Label><Input id="bulkMilkDate" type="date" value={bulkMilkDate} onChange={(e) => loadBulkMilkSheet(e.target.value)} required /></FormGroup>
+                    {bulkMilkRows.length === 0 ? (
+                        <EmptyState icon={Milk} title="No milking cows" description="Active cows and heifers appear here" />
+                    ) : (
+                        <Table>
+                            <TableHeader>
+                                <TableRow>
+                                    <TableHead>Cow</TableHead>
+                                    {bulkSessions.map(session => <TableHead key={session.id}>{session.name} (L)</TableHead>)}
+                                    <TableHead>Total</TableHead>
+                                    <TableHead>Discarded</TableHead>
+                                    <TableHead>Result</TableHead>
+                                </TableRow>
+                            </TableHeader>
+                            <TableBody>
+                                {bulkMilkRows.map(row => {
+                                    const result = bulkMilkResults[row.animalId];
+                                    return (
+                                        <TableRow key={row.animalId}>
+                                            <TableCell>
+                                                <div className="animal-info">
+                                                    <span className="animal-name">{row.animalName}</span>
+                                                    {row.tagNumber && <span className="animal-tag">#{row.tagNumber}</span>}
+                                                    {row.milkWithheldUntil && <span className="milk-withheld-tag">Withheld until {row.milkWithheldUntil}</span>}
+                                                </div>
+                                            </TableCell>
+                                            {bulkSessions.map(session => (
+                                                <TableCell key={session.id}><Input type="number" step="0.1" min="0" value={row.liters[session.id] || ''} onChange={(e) => updateBulkMilkRow(row.animalId, session.id, e.target.value)} placeholder="0.0" /></TableCell>
+                                            ))}
+                                            <TableCell>{sumSessions(row.liters).toFixed(1)} L</TableCell>
+                                            <TableCell><input type="checkbox" checked={!!row.discarded} onChange={(e) => toggleBulkMilkDiscarded(row.animalId, e.target.checked)} title="Milk discarded (not for sale)" /></TableCell>
+                                            <TableCell>
+                                                {result ? (
+                                                    <span className={`bulk-milk-result bulk-milk-${result.warning ? 'warning' : result.status}`} title={result.error || result.warning}>{result.error || result.warning || result.status}</span>
+                                                ) : row.recordId ? (
+                                                    <span className="bulk-milk-result">recorded</span>
+                                                ) : '-'}
+                                            </TableCell>
+                                        </TableRow>
+                                    );
+                                })}
+                            </TableBody>
+                        </Table>
+                    )}
+                    <div className="modal-actions"><Button variant="outline" type="button" onClick={() => setShowBulkMilkModal(false)}>Cancel</Button><Button type="submit" disabled={bulkMilkRows.length === 0}>Save Milking</Button></div>
+                </form>
+            </Modal>
+
+            <Modal isOpen={showRankingModal} onClose={() => setShowRankingModal(false)} title="Herd Ranking" size="lg">
+                {!herdRanking ? (
+                    <p className="herd-ranking-note">Ranking the herd...</p>
+                ) : herdRanking.cows.length === 0 ? (
+                    <EmptyState icon={TrendingDown} title="No cows to rank" description="Cows appear here once they have calved or been milked" />
+                ) : (
+                    <>
+                        <p className="herd-ranking-note">
+                            {herdRanking.candidates} culling candidate{herdRanking.candidates === 1 ? '' : 's'} from {herdRanking.startDate} to {herdRanking.endDate}.
+                            Scores weigh yield 40%, fertility 30%, health cost 15% and age 15% against the herd.
+                        </p>
+                        <Table>
+                            <TableHeader>
+                                <TableRow>
+                                    <TableHead>Rank</TableHead>
+                                    <TableHead>Cow</TableHead>
+                                    <TableHead>Score</TableHead>
+                                    <TableHead>Yield</TableHead>
+                                    <TableHead>Fertility</TableHead>
+                                    <TableHead>Vet Costs</TableHead>
+                                    <TableHead>Age</TableHead>
+                                    <TableHead>Reasons</TableHead>
+                                </TableRow>
+                            </TableHeader>
+                            <TableBody>
+                                {herdRanking.cows.map(cow => (
+                                    <TableRow key={cow.animalId} className={cow.cullCandidate ? 'cull-candidate' : ''}>
+                                        <TableCell className="font-mono">{cow.rank}</TableCell>
+                                        <TableCell>
+                                            <div className="animal-info">
+                                                <span className="animal-name">{cow.animalName}</span>
+                                                {cow.tagNumber && <span className="animal-tag">#{cow.tagNumber}</span>}
+                                                {cow.cullCandidate && <span className="cull-candidate-tag">Cull candidate</span>}
+                                            </div>
+                                        </TableCell>
+                                        <TableCell className="font-mono">{cow.score.toFixed(0)}</TableCell>
+                                        <TableCell className="font-mono">{cow.recordedDays > 0 ? `${cow.avgDailyLiters.toFixed(1)} L/day` : '-'}</TableCell>
+                                        <TableCell className="font-mono">
+                                            {cow.conceptions > 0 ? `${cow.servicesPerConception.toFixed(1)} SPC` : `${cow.services} svc`}
+                                            {cow.calvingIntervalDays > 0 && ` · ${cow.calvingIntervalDays} d CI`}
+                                        </TableCell>
+                                        <TableCell className="font-mono">{cow.healthCost.toFixed(0)}</TableCell>
+                                        <TableCell className="font-mono">{cow.ageYears > 0 ? `${cow.ageYears.toFixed(1)} y` : '-'}</TableCell>
+                                        <TableCell>
+                                            <ul className="herd-ranking-reasons">
+                                                {cow.reasons.map(reason => <li key={reason}>{reason}</li>)}
+                                            </ul>
+                                        </TableCell>
+                                    </TableRow>
+                                ))}
+                            </TableBody>
+                        </Table>
+                    </>
+                )}
+            </Modal>
+
+            <Modal isOpen={showDisposalModal} onClose={() => setShowDisposalModal(false)} title="Disposals" size="lg">
+                <FormRow>
+                    <FormGroup><Label htmlFor="disposalStart">From</Label><Input id="disposalStart" type="date" value={disposalRange.start} onChange={(e) => loadDisposalReport({ ...disposalRange, start: e.target.value })} /></FormGroup>
+                    <FormGroup><Label htmlFor="disposalEnd">To</Label><Input id="disposalEnd" type="date" value={disposalRange.end} onChange={(e) => loadDisposalReport({ ...disposalRange, end: e.target.value })} /></FormGroup>
+                </FormRow>
+                {!disposalReport ? (
+                    <p className="herd-ranking-note">Loading disposals...</p>
+                ) : (
+                    <>
+                        <div className="stats-grid stats-grid--four">
+                            <StatCard title="Sales" value={disposalReport.sales} subtitle={`${disposalReport.salesIncome.toLocaleString()} income`} icon={LogOut} color="primary" />
+                            <StatCard title="Deaths" value={disposalReport.deaths} subtitle={`${disposalReport.mortalityRate.toFixed(1)}% mortality`} icon={TrendingDown} color="accent" />
+                            <StatCard title="Culls" value={disposalReport.culls} subtitle={`${disposalReport.cullRate.toFixed(1)}% cull rate`} icon={Beef} color="secondary" />
+                            <StatCard title="Herd at Risk" value={disposalReport.herdAtRisk} subtitle="Animals present in the period" icon={Users} color="info" />
+                        </div>
+                        {(Object.keys(disposalReport.deathsByCause || {}).length > 0 || Object.keys(disposalReport.cullsByReason || {}).length > 0) && (
+                            <p className="herd-ranking-note">
+                                {Object.entries(disposalReport.deathsByCause || {}).map(([cause, n]) => `${formatType(cause)}: ${n} died`)
+                                    .concat(Object.entries(disposalReport.cullsByReason || {}).map(([reason, n]) => `${formatType(reason)}: ${n} culled`))
+                                    .join(' · ')}
+                            </p>
+                        )}
+                        {disposalReport.disposals.length === 0 ? (
+                            <EmptyState icon={LogOut} title="No disposals" description="Animals sold, dead or culled in this period appear here" />
+                        ) : (
+                            <Table>
+                                <TableHeader>
+                                    <TableRow>
+                                        <TableHead>Date</TableHead>
+                                        <TableHead>Animal</TableHead>
+                                        <TableHead>Type</TableHead>
+                                        <TableHead>Details</TableHead>
+                                        <TableHead>Price</TableHead>
+                                    </TableRow>
+                                </TableHeader>
+                                <TableBody>
+                                    {disposalReport.disposals.map(d => (
+                                        <TableRow key={d.id} onClick={() => navigate(`/livestock/${d.animalId}`)}>
+                                            <TableCell className="font-mono">{d.date}</TableCell>
+                                            <TableCell>
+                                                <div className="animal-info">
+                                                    <span className="animal-name">{d.animalName}</span>
+                                                    {d.tagNumber && <span className="animal-tag">#{d.tagNumber}</span>}
+                                                </div>
+                                            </TableCell>
+                                            <TableCell><span className="disposal-type">{d.disposalType}</span></TableCell>
+                                            <TableCell>{d.buyerName || formatType(d.causeOfDeath || d.cullReason) || '-'}</TableCell>
+                                            <TableCell className="font-mono">{d.price > 0 ? d.price.toLocaleString() : '-'}</TableCell>
+                                        </TableRow>
+                                    ))}
+                                </TableBody>
+                            </Table>
+                        )}
+                    </>
+                )}
+            </Modal>
+
+            <Modal isOpen={showGroupsModal} onClose={() => setShowGroupsModal(false)} title="Groups & Pens" size="lg">
+                {groups.length === 0 ? (
+                    <EmptyState icon={MapPin} title="No groups yet" description="Add groups such as Milking, Dry or Calves, or pens and paddocks" />
+                ) : (
+                    <Table>
+                        <TableHeader>
+                            <TableRow>
+                                <TableHead>Name</TableHead>
+                                <TableHead>Kind</TableHead>
+                                <TableHead>Animals</TableHead>
+                                <TableHead>Milk This Month</TableHead>
+                                <TableHead>Actions</TableHead>
+                            </TableRow>
+                        </TableHeader>
+                        <TableBody>
+                            {groups.map(g => (
+                                <TableRow key={g.id} className={g.active ? '' : 'group-inactive'}>
+                                    <TableCell>
+                                        <div className="animal-info">
+                                            <span className="animal-name">{g.name}</span>
+                                            {g.description && <span className="animal-tag">{g.description}</span>}
+                                        </div>
+                                    </TableCell>
+                                    <TableCell className="capitalize">{g.kind}</TableCell>
+                                    <TableCell className="font-mono">{g.headCount}</TableCell>
+                                    <TableCell className="font-mono">{(groupMilk[g.id] || 0).toFixed(1)} L</TableCell>
+                                    <TableCell>
+                                        <div className="action-buttons">
+                                            <button className="action-btn" onClick={() => { setGroupFilter(String(g.id)); setShowGroupsModal(false); }} title="Show animals in this group"><Users size={16} /></button>
+                                            <button className="action-btn edit" onClick={() => toggleGroupActive(g)} title={g.active ? 'Deactivate group' : 'Reactivate group'}><Power size={16} /></button>
+                                            <button className="action-btn delete" onClick={() => deleteGroup(g)} title="Delete unused group"><Trash2 size={16} /></button>
+                                        </div>
+                                    </TableCell>
+                                </TableRow>
+                            ))}
+                        </TableBody>
+                    </Table>
+                )}
+
+                <form onSubmit={handleAddGroup} className="group-form">
+                    <FormRow>
+                        <FormGroup><Label htmlFor="groupName" required>New Group</Label><Input id="groupName" value={groupForm.name} onChange={(e) => setGroupForm({ ...groupForm, name: e.target.value })} placeholder="e.g., Milking, Calf Pen 1" required /></FormGroup>
+                        <FormGroup><Label htmlFor="groupKind">Kind</Label><Select id="groupKind" value={groupForm.kind} onChange={(e) => setGroupForm({ ...groupForm, kind: e.target.value })}>{groupKinds.map(k => <option key={k} value={k}>{k.charAt(0).toUpperCase() + k.slice(1)}</option>)}</Select></FormGroup>
+                        <FormGroup><Label htmlFor="groupDescription">Description</Label><Input id="groupDescription" value={groupForm.description} onChange={(e) => setGroupForm({ ...groupForm, description: e.target.value })} /></FormGroup>
+                    </FormRow>
+                    <div className="modal-actions"><Button type="submit" icon={Plus}>Add Group</Button></div>
+                </form>
+
+                <div className="details-divider"></div>
+
+                <form onSubmit={handleMoveAnimals}>
+                    <h4 className="data-label mb-6">Move Animals</h4>
+                    <FormRow>
+                        <FormGroup>
+                            <Label htmlFor="moveGroup">To</Label>
+                            <Select id="moveGroup" value={moveForm.groupId} onChange={(e) => setMoveForm({ ...moveForm, groupId: e.target.value })}>
+                                <option value="">No group (remove)</option>
+                                {activeGroups.map(g => <option key={g.id} value={g.id}>{g.name}</option>)}
+                            </Select>
+                        </FormGroup>
+                        <FormGroup><Label htmlFor="moveDate" required>Date</Label><Input id="moveDate" type="date" value={moveForm.date} onChange={(e) => setMoveForm({ ...moveForm, date: e.target.value })} required /></FormGroup>
+                        <FormGroup><Label htmlFor="moveReason">Reason</Label><Input id="moveReason" value={moveForm.reason} onChange={(e) => setMoveForm({ ...moveForm, reason: e.target.value })} placeholder="e.g., Dried off" /></FormGroup>
+                    </FormRow>
+                    <div className="move-animals-list">
+                        {activeAnimals.map(a => (
+                            <label key={a.id} className="move-animal-item">
+                                <input type="checkbox" checked={moveForm.animalIds.includes(a.id)} onChange={() => toggleMoveAnimal(a.id)} />
+                                <span className="animal-name">{a.name}</span>
+                                {a.tagNumber && <span className="animal-tag">#{a.tagNumber}</span>}
+                                <span className="move-animal-group">{a.groupName || 'No group'}</span>
+                            </label>
+                        ))}
+                    </div>
+                    <div className="modal-actions"><Button variant="outline" type="button" onClick={() => setShowGroupsModal(false)}>Close</Button><Button type="submit" disabled={moveForm.animalIds.length === 0}>Move {moveForm.animalIds.length || ''} Animals</Button></div>
+                </form>
+            </Modal>
+
             <ConfirmDialog
                 isOpen={confirmDelete.show}
                 onClose={() => setConfirmDelete({ show: false, id: null })}
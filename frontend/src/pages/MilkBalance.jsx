@@ -0,0 +1,240 @@
+import React, { useState, useEffect } from 'react';
+import { Plus, Edit2, Trash2, Scale, Droplets, AlertTriangle, CheckCircle, Baby } from 'lucide-react';
+import { Button } from '../components/ui/Button';
+import { Card } from '../components/ui/Card';
+import { Modal } from '../components/ui/Modal';
+import { FormGroup, FormRow, Label, Input, Select, Textarea } from '../components/ui/Form';
+import { Table, TableHeader, TableBody, TableRow, TableHead, TableCell } from '../components/ui/Table';
+import { EmptyState } from '../components/ui/EmptyState';
+import { ConfirmDialog } from '../components/ui/ConfirmDialog';
+import { StatCard } from '../components/ui/StatCard';
+import { toast } from 'sonner';
+import './MilkBalance.css';
+
+const today = () => new Date().toISOString().split('T')[0];
+const thisMonth = () => new Date().toISOString().slice(0, 7);
+const useLabels = { calf_feeding: 'Calf feeding', household: 'Household', spoilage: 'Spoilage', other: 'Other' };
+const emptyUsage = { date: today(), useType: 'calf_feeding', liters: '', notes: '' };
+
+export function MilkBalance() {
+    const [period, setPeriod] = useState('day');
+    const [month, setMonth] = useState(thisMonth());
+    const [report, setReport] = useState(null);
+    const [usages, setUsages] = useState([]);
+    const [useTypes, setUseTypes] = useState([]);
+    const [loading, setLoading] = useState(true);
+    const [showModal, setShowModal] = useState(false);
+    const [editingUsage, setEditingUsage] = useState(null);
+    const [formData, setFormData] = useState(emptyUsage);
+    const [confirmDelete, setConfirmDelete] = useState({ show: false, id: null });
+
+    useEffect(() => { loadData(); }, [period, month]);
+
+    const loadData = async () => {
+        const [year, m] = month.split('-').map(Number);
+        const start = `${month}-01`;
+        const end = new Date(Date.UTC(year, m, 0)).toISOString().split('T')[0];
+        try {
+            const [balance, usageList, types] = await Promise.all([
+                period === 'day'
+                    ? window.go.main.LivestockService.GetMilkBalance(start, end, 'day')
+                    : window.go.main.LivestockService.GetMilkBalance('', '', 'month'),
+                window.go.main.LivestockService.GetMilkUsages(start, end),
+                window.go.main.LivestockService.GetMilkUseTypes()
+            ]);
+            setReport(balance);
+            setUsages(usageList || []);
+            setUseTypes(types || []);
+        } catch (err) { console.error(err); }
+        finally { setLoading(false); }
+    };
+
+    const openAdd = () => {
+        setEditingUsage(null);
+        setFormData({ ...emptyUsage, date: today() });
+        setShowModal(true);
+    };
+
+    const openEdit = (usage) => {
+        setEditingUsage(usage);
+        setFormData({ date: usage.date, useType: usage.useType, liters: usage.liters.toString(), notes: usage.notes });
+        setShowModal(true);
+    };
+
+    const handleSubmit = async (e) => {
+        e.preventDefault();
+        try {
+            const data = { ...formData, liters: parseFloat(formData.liters) };
+            if (editingUsage) {
+                await window.go.main.LivestockService.UpdateMilkUsage({ ...data, id: editingUsage.id });
+                toast.success('Milk use updated');
+            } else {
+                await window.go.main.LivestockService.AddMilkUsage(data);
+                toast.success('Milk use recorded');
+            }
+            setShowModal(false);
+            loadData();
+        } catch (err) {
+            console.error(err);
+            toast.error(typeof err === 'string' ? err : 'Failed to save milk use');
+        }
+    };
+
+    const confirmDeleteUsage = async () => {
+        try {
+            await window.go.main.LivestockService.DeleteMilkUsage(confirmDelete.id);
+            toast.success('Milk use deleted');
+            setConfirmDelete({ show: false, id: null });
+            loadData();
+        } catch (err) {
+            console.error(err);
+            toast.error('Failed to delete milk use');
+        }
+    };
+
+    const liters = (value) => value ? value.toFixed(1) : '-';
+    const totals = report?.totals;
+    const periodLabel = period === 'day' ? 'days' : 'months';
+
+    return (
+        <div className="milk-balance-page">
+            <header className="page-header">
+                <div className="page-header-content">
+                    <h1>Milk Balance</h1>
+                    <p>Where the milk went: sold, delivered, used on the farm or discarded</p>
+                </div>
+                <Button icon={Plus} onClick={openAdd}>Record Milk Use</Button>
+            </header>
+
+            <div className="milk-balance-toolbar">
+                <Select value={period} onChange={(e) => setPeriod(e.target.value)}>
+                    <option value="day">Daily</option>
+                    <option value="month">Monthly (last 12 months)</option>
+                </Select>
+                {period === 'day' && <Input type="month" value={month} onChange={(e) => e.target.value && setMonth(e.target.value)} />}
+            </div>
+
+            {totals && (
+                <div className="stats-grid">
+                    <StatCard title="Produced" value={`${totals.produced.toFixed(1)} L`} subtitle={`${report.startDate} to ${report.endDate}`} icon={Droplets} color="primary" />
+                    <StatCard title="Accounted For" value={`${totals.accounted.toFixed(1)} L`} subtitle={`${(totals.sold + totals.cooperative).toFixed(1)} L sold or delivered`} icon={CheckCircle} color="info" />
+                    <StatCard title="Unaccounted" value={`${totals.unaccounted.toFixed(1)} L`} subtitle={`${totals.unaccountedPct.toFixed(1)}% of production`} icon={Scale} color="accent" />
+                    <StatCard title="Flagged" value={report.flaggedRows} subtitle={`${periodLabel} beyond the 2% tolerance`} icon={AlertTriangle} color="secondary" />
+                </div>
+            )}
+
+            <Card padding="none">
+                <div className="card-header-bar"><h3>{period === 'day' ? 'Daily' : 'Monthly'} Balance</h3></div>
+                {loading ? (
+                    <div className="loading-container"><div className="loading-spinner"></div></div>
+                ) : !report || report.rows.length === 0 ? (
+                    <EmptyState icon={Scale} title="No milk recorded" description="Milk records, sales and uses in the period will be balanced here" />
+                ) : (
+                    <div className="table-wrapper">
+                        <Table>
+                            <TableHeader>
+                                <TableRow>
+                                    <TableHead>{period === 'day' ? 'Date' : 'Month'}</TableHead>
+                                    <TableHead>Produced</TableHead>
+                                    <TableHead>Sold</TableHead>
+                                    <TableHead>Co-op</TableHead>
+                                    <TableHead>Calves</TableHead>
+                                    <TableHead>Household</TableHead>
+                                    <TableHead>Spoilage</TableHead>
+                                    <TableHead>Other</TableHead>
+                                    <TableHead>Discarded</TableHead>
+                                    <TableHead>Unaccounted</TableHead>
+                                </TableRow>
+                            </TableHeader>
+                            <TableBody>
+                                {[...report.rows, totals].map(r => (
+                                    <TableRow key={r.period} className={`${r.flagged ? 'balance-flagged' : ''} ${r.period === 'total' ? 'balance-total' : ''}`}>
+                                        <TableCell className="font-mono">{r.period === 'total' ? 'Total' : r.period}</TableCell>
+                                        <TableCell className="font-mono font-bold">{liters(r.produced)}</TableCell>
+                                        <TableCell className="font-mono">{liters(r.sold)}</TableCell>
+                                        <TableCell className="font-mono">{liters(r.cooperative)}</TableCell>
+                                        <TableCell className="font-mono">{liters(r.calfFeeding)}</TableCell>
+                                        <TableCell className="font-mono">{liters(r.household)}</TableCell>
+                                        <TableCell className="font-mono">{liters(r.spoilage)}</TableCell>
+                                        <TableCell className="font-mono">{liters(r.other)}</TableCell>
+                                        <TableCell className="font-mono">{liters(r.discarded)}</TableCell>
+                                        <TableCell className="font-mono font-bold">
+                                            {r.unaccounted.toFixed(1)} L
+                                            {r.produced > 0 && <span className="balance-pct">{r.unaccountedPct.toFixed(1)}%</span>}
+                                            {r.flagged && <AlertTriangle size={14} className="balance-flag-icon" />}
+                                        </TableCell>
+                                    </TableRow>
+                                ))}
+                            </TableBody>
+                        </Table>
+                    </div>
+                )}
+                <p className="milk-balance-note">
+                    Unaccounted milk is production less everything sold, delivered, used and discarded; a negative figure means more was recorded going out than was milked. Milk discarded during a drug withdrawal is counted from the milk records, so do not record it again here.
+                </p>
+            </Card>
+
+            <Card padding="none" className="milk-balance-section">
+                <div className="card-header-bar"><h3>Milk Used on the Farm in {month}</h3></div>
+                {usages.length === 0 ? (
+                    <EmptyState icon={Baby} title="No milk uses recorded" description="Record milk fed to calves, used at home or spoiled" action={<Button icon={Plus} onClick={openAdd}>Record Milk Use</Button>} />
+                ) : (
+                    <Table>
+                        <TableHeader>
+                            <TableRow>
+                                <TableHead>Date</TableHead>
+                                <TableHead>Use</TableHead>
+                                <TableHead>Liters</TableHead>
+                                <TableHead>Notes</TableHead>
+                                <TableHead>Actions</TableHead>
+                            </TableRow>
+                        </TableHeader>
+                        <TableBody>
+                            {usages.map(u => (
+                                <TableRow key={u.id}>
+                                    <TableCell className="font-mono">{u.date}</TableCell>
+                                    <TableCell>{useLabels[u.useType] || u.useType}</TableCell>
+                                    <TableCell className="font-mono">{u.liters.toFixed(1)} L</TableCell>
+                                    <TableCell>{u.notes || '-'}</TableCell>
+                                    <TableCell>
+                                        <div className="action-buttons">
+                                            <button className="action-btn edit" onClick={() => openEdit(u)} title="Edit"><Edit2 size={16} /></button>
+                                            <button className="action-btn delete" onClick={() => setConfirmDelete({ show: true, id: u.id })} title="Delete"><Trash2 size={16} /></button>
+                                        </div>
+                                    </TableCell>
+                                </TableRow>
+                            ))}
+                        </TableBody>
+                    </Table>
+                )}
+            </Card>
+
+            <Modal isOpen={showModal} onClose={() => setShowModal(false)} title={editingUsage ? 'Edit Milk Use' : 'Record Milk Use'} size="sm">
+                <form onSubmit={handleSubmit}>
+                    <FormRow>
+                        <FormGroup><Label htmlFor="usageDate" required>Date</Label><Input id="usageDate" type="date" value={formData.date} onChange={(e) => setFormData({ ...formData, date: e.target.value })} required /></FormGroup>
+                        <FormGroup><Label htmlFor="usageLiters" required>Liters</Label><Input id="usageLiters" type="number" min="0" step="0.1" value={formData.liters} onChange={(e) => setFormData({ ...formData, liters: e.target.value })} required /></FormGroup>
+                    </FormRow>
+                    <FormGroup>
+                        <Label htmlFor="usageType" required>Use</Label>
+                        <Select id="usageType" value={formData.useType} onChange={(e) => setFormData({ ...formData, useType: e.target.value })}>
+                            {useTypes.map(t => <option key={t} value={t}>{useLabels[t] || t}</option>)}
+                        </Select>
+                    </FormGroup>
+                    <FormGroup><Label htmlFor="usageNotes">Notes</Label><Textarea id="usageNotes" value={formData.notes} onChange={(e) => setFormData({ ...formData, notes: e.target.value })} rows={2} placeholder="e.g., which calves were fed" /></FormGroup>
+                    <div className="modal-actions"><Button variant="outline" type="button" onClick={() => setShowModal(false)}>Cancel</Button><Button type="submit">{editingUsage ? 'Update' : 'Record'}</Button></div>
+                </form>
+            </Modal>
+
+            <ConfirmDialog
+                isOpen={confirmDelete.show}
+                onClose={() => setConfirmDelete({ show: false, id: null })}
+                onConfirm={confirmDeleteUsage}
+                title="Delete Milk Use"
+                message="Are you sure you want to delete this milk use record?"
+                type="danger"
+                confirmText="Delete"
+            />
+        </div>
+    );
+}
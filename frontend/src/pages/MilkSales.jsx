@@ -1,24 +1,35 @@
 import React, { useState, useEffect } from 'react';
-import { Plus, Search, Edit2, Trash2, CheckCircle, Milk, Droplets, DollarSign, ClipboardList } from 'lucide-react';
+import { Plus, Search, Edit2, Trash2, CheckCircle, Milk, Droplets, DollarSign, ClipboardList, FlaskConical } from 'lucide-react';
 import { Pagination } from '../components/ui/Pagination';
 import { Button } from '../components/ui/Button';
 import { Card } from '../components/ui/Card';
 import { StatCard } from '../components/ui/StatCard';
 import { Modal } from '../components/ui/Modal';
-import { FormGroup, FormRow, Label, Input, Textarea, Checkbox } from '../components/ui/Form';
+import { FormGroup, FormRow, Label, Input, Select, Textarea, Checkbox } from '../components/ui/Form';
 import { Table, TableHeader, TableBody, TableRow, TableHead, TableCell } from '../components/ui/Table';
 import { EmptyState } from '../components/ui/EmptyState';
 import { ConfirmDialog } from '../components/ui/ConfirmDialog';
 import { toast } from 'sonner';
 import './MilkSales.css';
 
+const emptyQualityTest = () => ({ animalId: '', date: new Date().toISOString().split('T')[0], butterfatPct: '', proteinPct: '', scc: '', lactometer: '', notes: '' });
+
 export function MilkSales() {
     const [sales, setSales] = useState([]);
+    const [buyers, setBuyers] = useState([]);
     const [loading, setLoading] = useState(true);
     const [showModal, setShowModal] = useState(false);
     const [editingSale, setEditingSale] = useState(null);
     const [confirmDelete, setConfirmDelete] = useState({ show: false, id: null });
-    const [formData, setFormData] = useState({ date: new Date().toISOString().split('T')[0], buyerName: '', liters: '', pricePerLiter: '60', isPaid: true, notes: '' });
+    const [formData, setFormData] = useState({ date: new Date().toISOString().split('T')[0], buyerId: '', buyerName: '', liters: '', pricePerLiter: '60', isPaid: true, notes: '', butterfatPct: '', proteinPct: '', scc: '' });
+    const [qualityPrice, setQualityPrice] = useState(null);
+    const [showQualityModal, setShowQualityModal] = useState(false);
+    const [qualityTests, setQualityTests] = useState([]);
+    const [qualityForm, setQualityForm] = useState(emptyQualityTest());
+    const [qualityCows, setQualityCows] = useState([]);
+    const [sccThreshold, setSccThreshold] = useState(0);
+    const [trendCow, setTrendCow] = useState('');
+    const [qualityTrend, setQualityTrend] = useState([]);
     const [currentPage, setCurrentPage] = useState(1);
 
     const itemsPerPage = 10;
@@ -26,10 +37,28 @@ export function MilkSales() {
 
     useEffect(() => { loadSales(); }, []);
 
+    // Preview the buyer's quality adjustment for the results entered in the form
+    useEffect(() => {
+        const butterfat = parseFloat(formData.butterfatPct) || 0;
+        const protein = parseFloat(formData.proteinPct) || 0;
+        const scc = parseInt(formData.scc) || 0;
+        if (!showModal || (butterfat <= 0 && protein <= 0 && scc <= 0)) {
+            setQualityPrice(null);
+            return;
+        }
+        window.go.main.LivestockService.GetQualityAdjustedPrice(parseFloat(formData.pricePerLiter) || 0, butterfat, protein, scc)
+            .then(setQualityPrice)
+            .catch(() => setQualityPrice(null));
+    }, [showModal, formData.pricePerLiter, formData.butterfatPct, formData.proteinPct, formData.scc]);
+
     const loadSales = async () => {
         try {
-            const data = await window.go.main.LivestockService.GetMilkSales('', '');
+            const [data, buyerList] = await Promise.all([
+                window.go.main.LivestockService.GetMilkSales('', ''),
+                window.go.main.BuyerService.GetBuyers()
+            ]);
             setSales(data || []);
+            setBuyers(buyerList || []);
         } catch (err) { console.error(err); }
         finally { setLoading(false); }
     };
@@ -40,26 +69,35 @@ export function MilkSales() {
         try {
             const saleData = {
                 date: formData.date,
+                buyerId: formData.buyerId ? parseInt(formData.buyerId) : null,
                 buyerName: formData.buyerName,
                 liters: parseFloat(formData.liters),
                 pricePerLiter: parseFloat(formData.pricePerLiter),
                 isPaid: formData.isPaid,
-                notes: formData.notes
+                notes: formData.notes,
+                butterfatPct: parseFloat(formData.butterfatPct) || 0,
+                proteinPct: parseFloat(formData.proteinPct) || 0,
+                scc: parseInt(formData.scc) || 0
             };
             if (editingSale) {
                 await window.go.main.LivestockService.UpdateMilkSale({ ...saleData, id: editingSale.id });
+                if (saleData.butterfatPct !== editingSale.butterfatPct || saleData.proteinPct !== editingSale.proteinPct || saleData.scc !== editingSale.scc) {
+                    await window.go.main.LivestockService.SetMilkSaleQuality(editingSale.id, saleData.butterfatPct, saleData.proteinPct, saleData.scc);
+                }
                 toast.success('Sale record updated', { id: loadingToast });
             } else {
                 await window.go.main.LivestockService.AddMilkSale(saleData);
                 toast.success('Sale recorded and added to Finances', { id: loadingToast });
             }
+            const warnings = await window.go.main.LivestockService.GetMilkSaleWarnings(formData.date);
+            (warnings || []).forEach(warning => toast.warning(warning));
             setShowModal(false);
             setEditingSale(null);
             resetForm();
             loadSales();
         } catch (err) {
             console.error(err);
-            toast.error('Failed to save sale record', { id: loadingToast });
+            toast.error(typeof err === 'string' ? err : 'Failed to save sale record', { id: loadingToast });
         }
     };
 
@@ -82,11 +120,96 @@ export function MilkSales() {
 
     const openEdit = (sale) => {
         setEditingSale(sale);
-        setFormData({ date: sale.date, buyerName: sale.buyerName, liters: sale.liters.toString(), pricePerLiter: sale.pricePerLiter.toString(), isPaid: sale.isPaid, notes: sale.notes });
+        setFormData({
+            date: sale.date, buyerId: sale.buyerId ? sale.buyerId.toString() : '', buyerName: sale.buyerName, liters: sale.liters.toString(), pricePerLiter: sale.pricePerLiter.toString(), isPaid: sale.isPaid, notes: sale.notes,
+            butterfatPct: sale.butterfatPct ? sale.butterfatPct.toString() : '', proteinPct: sale.proteinPct ? sale.proteinPct.toString() : '', scc: sale.scc ? sale.scc.toString() : ''
+        });
         setShowModal(true);
     };
 
-    const resetForm = () => setFormData({ date: new Date().toISOString().split('T')[0], buyerName: '', liters: '', pricePerLiter: '60', isPaid: true, notes: '' });
+    const resetForm = () => setFormData({ date: new Date().toISOString().split('T')[0], buyerId: '', buyerName: '', liters: '', pricePerLiter: '60', isPaid: true, notes: '', butterfatPct: '', proteinPct: '', scc: '' });
+
+    const loadQualityTests = async () => {
+        try {
+            const data = await window.go.main.LivestockService.GetMilkQualityTests(0, '', '');
+            setQualityTests(data || []);
+        } catch (err) { console.error(err); }
+    };
+
+    const openQualityTests = async () => {
+        setQualityForm(emptyQualityTest());
+        setShowQualityModal(true);
+        loadQualityTests();
+        try {
+            const [cows, threshold] = await Promise.all([
+                window.go.main.LivestockService.GetDairyCows(),
+                window.go.main.LivestockService.GetSCCAlertThreshold()
+            ]);
+            setQualityCows(cows || []);
+            setSccThreshold(threshold);
+        } catch (err) { console.error(err); }
+    };
+
+    const loadQualityTrend = async (animalId) => {
+        setTrendCow(animalId);
+        if (!animalId) {
+            setQualityTrend([]);
+            return;
+        }
+        try {
+            const data = await window.go.main.LivestockService.GetMilkQualityTrend(parseInt(animalId), 12);
+            setQualityTrend(data || []);
+        } catch (err) { console.error(err); }
+    };
+
+    const handleQualitySubmit = async (e) => {
+        e.preventDefault();
+        try {
+            await window.go.main.LivestockService.AddMilkQualityTest({
+                animalId: qualityForm.animalId ? parseInt(qualityForm.animalId) : null,
+                date: qualityForm.date,
+                butterfatPct: parseFloat(qualityForm.butterfatPct) || 0,
+                proteinPct: parseFloat(qualityForm.proteinPct) || 0,
+                scc: parseInt(qualityForm.scc) || 0,
+                lactometer: parseFloat(qualityForm.lactometer) || 0,
+                notes: qualityForm.notes
+            });
+            toast.success('Quality test recorded');
+            setQualityForm({ ...emptyQualityTest(), animalId: qualityForm.animalId });
+            loadQualityTests();
+            if (trendCow) loadQualityTrend(trendCow);
+        } catch (err) {
+            console.error(err);
+            toast.error(typeof err === 'string' ? err : 'Failed to record quality test');
+        }
+    };
+
+    const deleteQualityTest = async (id) => {
+        try {
+            await window.go.main.LivestockService.DeleteMilkQualityTest(id);
+            loadQualityTests();
+            if (trendCow) loadQualityTrend(trendCow);
+        } catch (err) {
+            console.error(err);
+            toast.error('Failed to delete quality test');
+        }
+    };
+
+    const selectBuyer = (buyerId) => {
+        const buyer = buyers.find(b => b.id.toString() === buyerId);
+        setFormData({
+            ...formData,
+            buyerId,
+            buyerName: buyer ? buyer.name : '',
+            pricePerLiter: buyer && buyer.agreedPrice ? buyer.agreedPrice.toString() : formData.pricePerLiter
+        });
+    };
+
+    const paymentStatus = (sale) => {
+        if (sale.isPaid) return <span className="payment-badge paid"><CheckCircle size={14} /> Paid</span>;
+        if (sale.amountPaid > 0) return <span className="payment-badge partial" title={`${formatCurrency(sale.amountPaid)} received`}>Partial</span>;
+        return <span className="payment-badge pending">Pending</span>;
+    };
 
     const formatCurrency = (amt) => new Intl.NumberFormat('en-KE', { style: 'currency', currency: 'KES' }).format(amt);
 
@@ -103,7 +226,10 @@ export function MilkSales() {
                     </div>
                     <p>Track your milk sales and revenue</p>
                 </div>
-                <Button icon={Plus} onClick={() => { resetForm(); setEditingSale(null); setShowModal(true); }}>Record Sale</Button>
+                <div className="page-actions">
+                    <Button variant="outline" icon={FlaskConical} onClick={openQualityTests} title="Record butterfat, protein and cell count tests and follow each cow's trend">Quality Tests</Button>
+                    <Button icon={Plus} onClick={() => { resetForm(); setEditingSale(null); setShowModal(true); }}>Record Sale</Button>
+                </div>
             </header>
 
             <div className="stats-grid stats-grid--two">
@@ -146,13 +272,23 @@ export function MilkSales() {
                                         <TableCell className="font-mono">{new Date(sale.date).toLocaleDateString('en-KE')}</TableCell>
                                         <TableCell><span className="font-bold text-neutral-900">{sale.buyerName || 'Walk-in'}</span></TableCell>
                                         <TableCell className="font-mono">{sale.liters.toFixed(1)} L</TableCell>
-                                        <TableCell className="font-mono text-neutral-500">{formatCurrency(sale.pricePerLiter)}</TableCell>
-                                        <TableCell className="font-mono font-bold text-primary-600">{formatCurrency(sale.totalAmount)}</TableCell>
-                                        <TableCell>
-                                            <span className={`payment-badge ${sale.isPaid ? 'paid' : 'pending'}`}>
-                                                {sale.isPaid ? <><CheckCircle size={14} /> Paid</> : 'Pending'}
-                                            </span>
+                                        <TableCell className="font-mono text-neutral-500">
+                                            {formatCurrency(sale.pricePerLiter)}
+                                            {(sale.butterfatPct > 0 || sale.proteinPct > 0 || sale.scc > 0) && (
+                                                <span
+                                                    className={`quality-adjustment ${sale.qualityAdjustment < 0 ? 'penalty' : ''}`}
+                                                    title={[
+                                                        sale.butterfatPct > 0 && `Butterfat ${sale.butterfatPct}%`,
+                                                        sale.proteinPct > 0 && `Protein ${sale.proteinPct}%`,
+                                                        sale.scc > 0 && `SCC ${sale.scc.toLocaleString()}`
+                                                    ].filter(Boolean).join(' · ')}
+                                                >
+                                                    {sale.qualityAdjustment >= 0 ? '+' : ''}{sale.qualityAdjustment.toFixed(2)} quality
+                                                </span>
+                                            )}
                                         </TableCell>
+                                        <TableCell className="font-mono font-bold text-primary-600">{formatCurrency(sale.totalAmount)}</TableCell>
+                                        <TableCell>{paymentStatus(sale)}</TableCell>
                                         <TableCell>
                                             <div className="action-buttons">
                                                 <button className="action-btn edit" onClick={() => openEdit(sale)}><Edit2 size={16} /></button>
@@ -176,20 +312,136 @@ export function MilkSales() {
             <Modal isOpen={showModal} onClose={() => setShowModal(false)} title={editingSale ? 'Edit Sale' : 'Record New Sale'} size="sm">
                 <form onSubmit={handleSubmit}>
                     <FormGroup><Label htmlFor="saleDate" required>Date</Label><Input id="saleDate" type="date" value={formData.date} onChange={(e) => setFormData({ ...formData, date: e.target.value })} required /></FormGroup>
-                    <FormGroup><Label htmlFor="buyer">Buyer Name</Label><Input id="buyer" value={formData.buyerName} onChange={(e) => setFormData({ ...formData, buyerName: e.target.value })} placeholder="e.g., John Kamau" /></FormGroup>
+                    {buyers.length > 0 && (
+                        <FormGroup>
+                            <Label htmlFor="buyerAccount">Buyer Account</Label>
+                            <Select id="buyerAccount" value={formData.buyerId} onChange={(e) => selectBuyer(e.target.value)}>
+                                <option value="">Other / walk-in</option>
+                                {buyers.filter(b => b.active || b.id.toString() === formData.buyerId).map(b => <option key={b.id} value={b.id}>{b.name}</option>)}
+                            </Select>
+                        </FormGroup>
+                    )}
+                    {!formData.buyerId && <FormGroup><Label htmlFor="buyer">Buyer Name</Label><Input id="buyer" value={formData.buyerName} onChange={(e) => setFormData({ ...formData, buyerName: e.target.value })} placeholder="e.g., John Kamau" /></FormGroup>}
                     <FormRow>
                         <FormGroup><Label htmlFor="liters" required>Liters</Label><Input id="liters" type="number" step="0.1" value={formData.liters} onChange={(e) => setFormData({ ...formData, liters: e.target.value })} required /></FormGroup>
                         <FormGroup><Label htmlFor="price" required>Price/Liter (KES)</Label><Input id="price" type="number" value={formData.pricePerLiter} onChange={(e) => setFormData({ ...formData, pricePerLiter: e.target.value })} required /></FormGroup>
                     </FormRow>
+                    <FormRow>
+                        <FormGroup><Label htmlFor="saleButterfat">Butterfat %</Label><Input id="saleButterfat" type="number" step="0.01" min="0" value={formData.butterfatPct} onChange={(e) => setFormData({ ...formData, butterfatPct: e.target.value })} placeholder="Optional" /></FormGroup>
+                        <FormGroup><Label htmlFor="saleProtein">Protein %</Label><Input id="saleProtein" type="number" step="0.01" min="0" value={formData.proteinPct} onChange={(e) => setFormData({ ...formData, proteinPct: e.target.value })} placeholder="Optional" /></FormGroup>
+                        <FormGroup><Label htmlFor="saleScc">SCC (cells/mL)</Label><Input id="saleScc" type="number" step="1000" min="0" value={formData.scc} onChange={(e) => setFormData({ ...formData, scc: e.target.value })} placeholder="Optional" /></FormGroup>
+                    </FormRow>
                     {formData.liters && formData.pricePerLiter && (
-                        <div className="total-preview">Total: {formatCurrency(parseFloat(formData.liters || 0) * parseFloat(formData.pricePerLiter || 0))}</div>
+                        <div className="total-preview">
+                            Total: {formatCurrency(parseFloat(formData.liters || 0) * (qualityPrice ? qualityPrice.pricePerLiter : parseFloat(formData.pricePerLiter || 0)))}
+                            {qualityPrice && ` (${qualityPrice.adjustment >= 0 ? '+' : ''}${qualityPrice.adjustment.toFixed(2)}/L quality)`}
+                        </div>
+                    )}
+                    {formData.buyerId ? (
+                        !editingSale && <FormGroup><Checkbox label="Paid on delivery" checked={formData.isPaid} onChange={(e) => setFormData({ ...formData, isPaid: e.target.checked })} /></FormGroup>
+                    ) : (
+                        <FormGroup><Checkbox label="Payment received" checked={formData.isPaid} onChange={(e) => setFormData({ ...formData, isPaid: e.target.checked })} /></FormGroup>
                     )}
-                    <FormGroup><Checkbox label="Payment received" checked={formData.isPaid} onChange={(e) => setFormData({ ...formData, isPaid: e.target.checked })} /></FormGroup>
                     <FormGroup><Label htmlFor="saleNotes">Notes</Label><Textarea id="saleNotes" value={formData.notes} onChange={(e) => setFormData({ ...formData, notes: e.target.value })} rows={2} /></FormGroup>
                     <div className="modal-actions"><Button variant="outline" type="button" onClick={() => setShowModal(false)}>Cancel</Button><Button type="submit">{editingSale ? 'Update' : 'Record'} Sale</Button></div>
                 </form>
             </Modal>
 
+            <Modal isOpen={showQualityModal} onClose={() => setShowQualityModal(false)} title="Milk Quality Tests" size="lg">
+                <form onSubmit={handleQualitySubmit} className="quality-test-form">
+                    <FormRow>
+                        <FormGroup>
+                            <Label htmlFor="qualityAnimal">Sample</Label>
+                            <Select id="qualityAnimal" value={qualityForm.animalId} onChange={(e) => setQualityForm({ ...qualityForm, animalId: e.target.value })}>
+                                <option value="">Bulk tank</option>
+                                {qualityCows.map(c => <option key={c.id} value={c.id}>{c.name}{c.tagNumber ? ` (#${c.tagNumber})` : ''}</option>)}
+                            </Select>
+                        </FormGroup>
+                        <FormGroup><Label htmlFor="qualityDate" required>Date</Label><Input id="qualityDate" type="date" value={qualityForm.date} onChange={(e) => setQualityForm({ ...qualityForm, date: e.target.value })} required /></FormGroup>
+                    </FormRow>
+                    <FormRow>
+                        <FormGroup><Label htmlFor="qualityButterfat">Butterfat %</Label><Input id="qualityButterfat" type="number" step="0.01" min="0" value={qualityForm.butterfatPct} onChange={(e) => setQualityForm({ ...qualityForm, butterfatPct: e.target.value })} /></FormGroup>
+                        <FormGroup><Label htmlFor="qualityProtein">Protein %</Label><Input id="qualityProtein" type="number" step="0.01" min="0" value={qualityForm.proteinPct} onChange={(e) => setQualityForm({ ...qualityForm, proteinPct: e.target.value })} /></FormGroup>
+                        <FormGroup><Label htmlFor="qualityScc">SCC (cells/mL)</Label><Input id="qualityScc" type="number" step="1000" min="0" value={qualityForm.scc} onChange={(e) => setQualityForm({ ...qualityForm, scc: e.target.value })} /></FormGroup>
+                        <FormGroup><Label htmlFor="qualityLactometer">Lactometer</Label><Input id="qualityLactometer" type="number" step="0.1" min="0" value={qualityForm.lactometer} onChange={(e) => setQualityForm({ ...qualityForm, lactometer: e.target.value })} placeholder="e.g., 28" /></FormGroup>
+                    </FormRow>
+                    <FormGroup><Label htmlFor="qualityNotes">Notes</Label><Input id="qualityNotes" value={qualityForm.notes} onChange={(e) => setQualityForm({ ...qualityForm, notes: e.target.value })} /></FormGroup>
+                    <div className="modal-actions"><Button type="submit" icon={Plus}>Add Test</Button></div>
+                </form>
+
+                {qualityTests.length === 0 ? (
+                    <EmptyState icon={FlaskConical} title="No quality tests" description="Tests from the buyer or a milk recording scheme appear here" />
+                ) : (
+                    <div className="quality-test-list">
+                        <Table>
+                            <TableHeader>
+                                <TableRow>
+                                    <TableHead>Date</TableHead>
+                                    <TableHead>Sample</TableHead>
+                                    <TableHead>Butterfat</TableHead>
+                                    <TableHead>Protein</TableHead>
+                                    <TableHead>SCC</TableHead>
+                                    <TableHead>Lactometer</TableHead>
+                                    <TableHead></TableHead>
+                                </TableRow>
+                            </TableHeader>
+                            <TableBody>
+                                {qualityTests.map(t => (
+                                    <TableRow key={t.id} className={sccThreshold && t.scc > sccThreshold ? 'quality-high-scc' : ''}>
+                                        <TableCell className="font-mono">{t.date}</TableCell>
+                                        <TableCell>{t.animalName}</TableCell>
+                                        <TableCell className="font-mono">{t.butterfatPct > 0 ? `${t.butterfatPct}%` : '-'}</TableCell>
+                                        <TableCell className="font-mono">{t.proteinPct > 0 ? `${t.proteinPct}%` : '-'}</TableCell>
+                                        <TableCell className="font-mono">{t.scc > 0 ? t.scc.toLocaleString() : '-'}</TableCell>
+                                        <TableCell className="font-mono">{t.lactometer > 0 ? t.lactometer : '-'}</TableCell>
+                                        <TableCell>
+                                            <button className="action-btn delete" onClick={() => deleteQualityTest(t.id)} title="Delete test"><Trash2 size={14} /></button>
+                                        </TableCell>
+                                    </TableRow>
+                                ))}
+                            </TableBody>
+                        </Table>
+                    </div>
+                )}
+
+                <div className="quality-trend">
+                    <FormGroup>
+                        <Label htmlFor="trendCow">Monthly trend</Label>
+                        <Select id="trendCow" value={trendCow} onChange={(e) => loadQualityTrend(e.target.value)}>
+                            <option value="">Select a cow</option>
+                            {qualityCows.map(c => <option key={c.id} value={c.id}>{c.name}</option>)}
+                        </Select>
+                    </FormGroup>
+                    {trendCow && (qualityTrend.length === 0 ? (
+                        <p className="quality-note">No tests for this cow in the last 12 months</p>
+                    ) : (
+                        <Table>
+                            <TableHeader>
+                                <TableRow>
+                                    <TableHead>Month</TableHead>
+                                    <TableHead>Tests</TableHead>
+                                    <TableHead>Butterfat</TableHead>
+                                    <TableHead>Protein</TableHead>
+                                    <TableHead>SCC</TableHead>
+                                </TableRow>
+                            </TableHeader>
+                            <TableBody>
+                                {qualityTrend.map(p => (
+                                    <TableRow key={p.month} className={sccThreshold && p.scc > sccThreshold ? 'quality-high-scc' : ''}>
+                                        <TableCell className="font-mono">{p.month}</TableCell>
+                                        <TableCell className="font-mono">{p.tests}</TableCell>
+                                        <TableCell className="font-mono">{p.butterfatPct > 0 ? `${p.butterfatPct.toFixed(2)}%` : '-'}</TableCell>
+                                        <TableCell className="font-mono">{p.proteinPct > 0 ? `${p.proteinPct.toFixed(2)}%` : '-'}</TableCell>
+                                        <TableCell className="font-mono">{p.scc > 0 ? Math.round(p.scc).toLocaleString() : '-'}</TableCell>
+                                    </TableRow>
+                                ))}
+                            </TableBody>
+                        </Table>
+                    ))}
+                    {sccThreshold > 0 && <p className="quality-note">Cell counts above {sccThreshold.toLocaleString()} are highlighted. Change the threshold and the pricing scheme in Settings.</p>}
+                </div>
+            </Modal>
+
             <ConfirmDialog
                 isOpen={confirmDelete.show}
                 onClose={() => setConfirmDelete({ show: false, id: null })}
@@ -1,6 +1,8 @@
 import React, { useState, useEffect } from 'react';
-import { Bell, Calendar, Box, Activity, AlertCircle, Check } from 'lucide-react';
+import { Bell, Calendar, Box, Activity, AlertCircle, Check, Milk, CalendarPlus } from 'lucide-react';
 import { Card, CardHeader, CardTitle, CardContent } from '../components/ui/Card';
+import { Button } from '../components/ui/Button';
+import { toast } from 'sonner';
 import { formatLabel } from '../utils/formatting';
 import './Notifications.css';
 
@@ -29,6 +31,8 @@ export function Notifications() {
             case 'vet_followup': return <AlertCircle size={20} className="text-info" />;
             case 'breeding': return <Calendar size={20} className="text-primary" />;
             case 'low_stock': return <Box size={20} className="text-warning" />;
+            case 'milk_drop':
+            case 'milk_missing': return <Milk size={20} className="text-warning" />;
             default: return <Bell size={20} />;
         }
     };
@@ -42,6 +46,21 @@ export function Notifications() {
         );
     }
 
+    const handleExportCalendar = async () => {
+        const loadingToast = toast.loading('Exporting reminders...');
+        try {
+            const res = await window.go.main.CalendarService.ExportRemindersICS();
+            if (res) {
+                toast.success(`Exported ${res.records} reminders`, { id: loadingToast, description: `File saved to ${res.path}` });
+            } else {
+                toast.dismiss(loadingToast);
+            }
+        } catch (err) {
+            console.error('Calendar export failed:', err);
+            toast.error('Failed to export reminders', { id: loadingToast });
+        }
+    };
+
     return (
         <div className="notifications-page">
             <header className="page-header">
@@ -49,6 +68,7 @@ export function Notifications() {
                     <h1>Reminders & Alerts</h1>
                     <p>Stay updated with your farm activities and scheduled tasks</p>
                 </div>
+                <Button variant="outline" icon={CalendarPlus} onClick={handleExportCalendar} title="Save upcoming reminders as an .ics file for Google, Outlook or phone calendars">Export to Calendar</Button>
             </header>
 
             <div className="notifications-content">
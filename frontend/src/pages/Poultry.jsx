@@ -0,0 +1,276 @@
+import React, { useState, useEffect } from 'react';
+import { Plus, Edit2, Trash2, Bird, Egg, ClipboardList } from 'lucide-react';
+import { Button } from '../components/ui/Button';
+import { Card } from '../components/ui/Card';
+import { Modal } from '../components/ui/Modal';
+import { FormGroup, FormRow, Label, Input, Select, Textarea } from '../components/ui/Form';
+import { Table, TableHeader, TableBody, TableRow, TableHead, TableCell } from '../components/ui/Table';
+import { EmptyState } from '../components/ui/EmptyState';
+import { ConfirmDialog } from '../components/ui/ConfirmDialog';
+import { StatCard } from '../components/ui/StatCard';
+import { toast } from 'sonner';
+import './Poultry.css';
+
+const purposes = ['layers', 'broilers', 'breeders'];
+const today = () => new Date().toISOString().split('T')[0];
+const emptyFlock = { name: '', purpose: 'layers', breed: '', placedDate: today(), initialCount: '', status: 'active', notes: '' };
+const emptyRecord = { date: today(), eggs: '', brokenEggs: '', mortality: '', culled: '', sold: '', feedKg: '', notes: '' };
+
+export function Poultry() {
+    const [flocks, setFlocks] = useState([]);
+    const [records, setRecords] = useState([]);
+    const [loading, setLoading] = useState(true);
+    const [showFlockModal, setShowFlockModal] = useState(false);
+    const [editingFlock, setEditingFlock] = useState(null);
+    const [flockForm, setFlockForm] = useState(emptyFlock);
+    const [recordFlock, setRecordFlock] = useState(null);
+    const [recordForm, setRecordForm] = useState(emptyRecord);
+    const [confirmDelete, setConfirmDelete] = useState({ show: false, id: null });
+
+    useEffect(() => { loadData(); }, []);
+
+    const loadData = async () => {
+        try {
+            const start = new Date(Date.now() - 29 * 86400000).toISOString().split('T')[0];
+            const [flockList, recordList] = await Promise.all([
+                window.go.main.FlockService.GetFlocks(),
+                window.go.main.FlockService.GetFlockRecords(0, start, '')
+            ]);
+            setFlocks(flockList || []);
+            setRecords((recordList || []).slice().reverse());
+        } catch (err) { console.error(err); }
+        finally { setLoading(false); }
+    };
+
+    const openAddFlock = () => {
+        setEditingFlock(null);
+        setFlockForm({ ...emptyFlock, placedDate: today() });
+        setShowFlockModal(true);
+    };
+
+    const openEditFlock = (flock) => {
+        setEditingFlock(flock);
+        setFlockForm({ ...flock, initialCount: flock.initialCount.toString() });
+        setShowFlockModal(true);
+    };
+
+    const handleFlockSubmit = async (e) => {
+        e.preventDefault();
+        try {
+            const data = { ...flockForm, species: 'poultry', initialCount: parseInt(flockForm.initialCount) || 0 };
+            if (editingFlock) {
+                await window.go.main.FlockService.UpdateFlock({ ...data, id: editingFlock.id });
+                toast.success('Flock updated');
+            } else {
+                await window.go.main.FlockService.AddFlock(data);
+                toast.success('Flock added');
+            }
+            setShowFlockModal(false);
+            loadData();
+        } catch (err) {
+            console.error(err);
+            toast.error(typeof err === 'string' ? err : 'Failed to save flock');
+        }
+    };
+
+    const loadRecordForm = async (flock, date) => {
+        try {
+            const existing = await window.go.main.FlockService.GetFlockRecords(flock.id, date, date);
+            const r = (existing || [])[0];
+            setRecordForm(r ? {
+                date,
+                eggs: r.eggs.toString(), brokenEggs: r.brokenEggs.toString(), mortality: r.mortality.toString(),
+                culled: r.culled.toString(), sold: r.sold.toString(), feedKg: r.feedKg.toString(), notes: r.notes || ''
+            } : { ...emptyRecord, date });
+        } catch (err) { console.error(err); }
+    };
+
+    const openRecord = async (flock) => {
+        setRecordFlock(flock);
+        await loadRecordForm(flock, today());
+    };
+
+    const handleRecordSubmit = async (e) => {
+        e.preventDefault();
+        try {
+            await window.go.main.FlockService.SaveFlockRecord({
+                flockId: recordFlock.id,
+                date: recordForm.date,
+                eggs: parseInt(recordForm.eggs) || 0,
+                brokenEggs: parseInt(recordForm.brokenEggs) || 0,
+                mortality: parseInt(recordForm.mortality) || 0,
+                culled: parseInt(recordForm.culled) || 0,
+                sold: parseInt(recordForm.sold) || 0,
+                feedKg: parseFloat(recordForm.feedKg) || 0,
+                notes: recordForm.notes
+            });
+            toast.success(`Saved ${recordFlock.name} for ${recordForm.date}`);
+            setRecordFlock(null);
+            loadData();
+        } catch (err) {
+            console.error(err);
+            toast.error(typeof err === 'string' ? err : 'Failed to save flock record');
+        }
+    };
+
+    const confirmDeleteFlock = async () => {
+        try {
+            await window.go.main.FlockService.DeleteFlock(confirmDelete.id);
+            setConfirmDelete({ show: false, id: null });
+            toast.success('Flock deleted');
+            loadData();
+        } catch (err) {
+            console.error(err);
+            toast.error('Failed to delete flock');
+        }
+    };
+
+    const activeFlocks = flocks.filter(f => f.status === 'active');
+    const totalBirds = activeFlocks.reduce((sum, f) => sum + f.currentCount, 0);
+    const weekEggs = activeFlocks.reduce((sum, f) => sum + f.eggsLast7Days, 0);
+
+    return (
+        <div className="poultry-page">
+            <header className="page-header">
+                <div className="page-header-content">
+                    <h1>Poultry</h1>
+                    <p>Track flocks, daily egg collection and mortality</p>
+                </div>
+                <Button icon={Plus} onClick={openAddFlock}>Add Flock</Button>
+            </header>
+
+            <div className="stats-grid stats-grid--two">
+                <StatCard title="Live Birds" value={totalBirds} subtitle={`${activeFlocks.length} active flocks`} icon={Bird} color="primary" />
+                <StatCard title="Eggs (last 7 days)" value={weekEggs} subtitle={`${(weekEggs / 7).toFixed(0)} per day`} icon={Egg} color="secondary" />
+            </div>
+
+            <Card padding="none">
+                <div className="card-header-bar"><h3>Flocks</h3></div>
+                {loading ? (
+                    <div className="loading-container"><div className="loading-spinner"></div></div>
+                ) : flocks.length === 0 ? (
+                    <EmptyState icon={Bird} title="No flocks yet" description="Add a flock to start recording eggs and mortality" action={<Button icon={Plus} onClick={openAddFlock}>Add Flock</Button>} />
+                ) : (
+                    <Table>
+                        <TableHeader>
+                            <TableRow>
+                                <TableHead>Flock</TableHead>
+                                <TableHead>Purpose</TableHead>
+                                <TableHead>Birds</TableHead>
+                                <TableHead>Eggs (7 days)</TableHead>
+                                <TableHead>Laying Rate</TableHead>
+                                <TableHead>Mortality</TableHead>
+                                <TableHead>Actions</TableHead>
+                            </TableRow>
+                        </TableHeader>
+                        <TableBody>
+                            {flocks.map(flock => (
+                                <TableRow key={flock.id} className={flock.status === 'active' ? '' : 'flock-closed'}>
+                                    <TableCell>
+                                        <div className="flock-info">
+                                            <span className="flock-name">{flock.name}</span>
+                                            <span className="flock-meta">{flock.breed ? `${flock.breed} · ` : ''}placed {flock.placedDate}</span>
+                                        </div>
+                                    </TableCell>
+                                    <TableCell className="capitalize">{flock.purpose}</TableCell>
+                                    <TableCell className="font-mono">{flock.currentCount} / {flock.initialCount}</TableCell>
+                                    <TableCell className="font-mono">{flock.eggsLast7Days}</TableCell>
+                                    <TableCell className="font-mono">{flock.purpose === 'layers' ? `${flock.layingRate.toFixed(1)}%` : '-'}</TableCell>
+                                    <TableCell className="font-mono">{flock.mortality} ({(flock.mortality / flock.initialCount * 100).toFixed(1)}%)</TableCell>
+                                    <TableCell>
+                                        <div className="action-buttons">
+                                            {flock.status === 'active' && <button className="action-btn record" onClick={() => openRecord(flock)} title="Record today's counts"><ClipboardList size={16} /></button>}
+                                            <button className="action-btn edit" onClick={() => openEditFlock(flock)} title="Edit flock"><Edit2 size={16} /></button>
+                                            <button className="action-btn delete" onClick={() => setConfirmDelete({ show: true, id: flock.id })} title="Delete flock"><Trash2 size={16} /></button>
+                                        </div>
+                                    </TableCell>
+                                </TableRow>
+                            ))}
+                        </TableBody>
+                    </Table>
+                )}
+            </Card>
+
+            <Card padding="none" className="flock-records-card">
+                <div className="card-header-bar"><h3>Daily Records (last 30 days)</h3></div>
+                {records.length === 0 ? (
+                    <EmptyState icon={Egg} title="No daily records" description="Record eggs collected and birds lost each day" />
+                ) : (
+                    <Table>
+                        <TableHeader>
+                            <TableRow>
+                                <TableHead>Date</TableHead>
+                                <TableHead>Flock</TableHead>
+                                <TableHead>Eggs</TableHead>
+                                <TableHead>Broken</TableHead>
+                                <TableHead>Mortality</TableHead>
+                                <TableHead>Culled / Sold</TableHead>
+                                <TableHead>Feed</TableHead>
+                            </TableRow>
+                        </TableHeader>
+                        <TableBody>
+                            {records.map(r => (
+                                <TableRow key={r.id}>
+                                    <TableCell className="font-mono">{r.date}</TableCell>
+                                    <TableCell>{r.flockName}</TableCell>
+                                    <TableCell className="font-mono">{r.eggs}</TableCell>
+                                    <TableCell className="font-mono">{r.brokenEggs}</TableCell>
+                                    <TableCell className="font-mono">{r.mortality}</TableCell>
+                                    <TableCell className="font-mono">{r.culled} / {r.sold}</TableCell>
+                                    <TableCell className="font-mono">{r.feedKg ? `${r.feedKg} kg` : '-'}</TableCell>
+                                </TableRow>
+                            ))}
+                        </TableBody>
+                    </Table>
+                )}
+            </Card>
+
+            <Modal isOpen={showFlockModal} onClose={() => setShowFlockModal(false)} title={editingFlock ? 'Edit Flock' : 'Add Flock'} size="md">
+                <form onSubmit={handleFlockSubmit}>
+                    <FormRow>
+                        <FormGroup><Label htmlFor="flockName" required>Name</Label><Input id="flockName" value={flockForm.name} onChange={(e) => setFlockForm({ ...flockForm, name: e.target.value })} placeholder="e.g., Layers Batch 3" required /></FormGroup>
+                        <FormGroup><Label htmlFor="flockPurpose">Purpose</Label><Select id="flockPurpose" value={flockForm.purpose} onChange={(e) => setFlockForm({ ...flockForm, purpose: e.target.value })}>{purposes.map(p => <option key={p} value={p}>{p.charAt(0).toUpperCase() + p.slice(1)}</option>)}</Select></FormGroup>
+                    </FormRow>
+                    <FormRow>
+                        <FormGroup><Label htmlFor="flockBreed">Breed</Label><Input id="flockBreed" value={flockForm.breed} onChange={(e) => setFlockForm({ ...flockForm, breed: e.target.value })} placeholder="e.g., Kenbro, Isa Brown" /></FormGroup>
+                        <FormGroup><Label htmlFor="flockCount" required>Birds Placed</Label><Input id="flockCount" type="number" min="1" value={flockForm.initialCount} onChange={(e) => setFlockForm({ ...flockForm, initialCount: e.target.value })} required /></FormGroup>
+                    </FormRow>
+                    <FormRow>
+                        <FormGroup><Label htmlFor="flockPlaced" required>Placed On</Label><Input id="flockPlaced" type="date" value={flockForm.placedDate} onChange={(e) => setFlockForm({ ...flockForm, placedDate: e.target.value })} required /></FormGroup>
+                        <FormGroup><Label htmlFor="flockStatus">Status</Label><Select id="flockStatus" value={flockForm.status} onChange={(e) => setFlockForm({ ...flockForm, status: e.target.value })}><option value="active">Active</option><option value="closed">Closed</option></Select></FormGroup>
+                    </FormRow>
+                    <FormGroup><Label htmlFor="flockNotes">Notes</Label><Textarea id="flockNotes" value={flockForm.notes} onChange={(e) => setFlockForm({ ...flockForm, notes: e.target.value })} rows={2} /></FormGroup>
+                    <div className="modal-actions"><Button variant="outline" type="button" onClick={() => setShowFlockModal(false)}>Cancel</Button><Button type="submit">{editingFlock ? 'Update' : 'Add'} Flock</Button></div>
+                </form>
+            </Modal>
+
+            <Modal isOpen={!!recordFlock} onClose={() => setRecordFlock(null)} title={`Daily Record - ${recordFlock?.name || ''}`} size="sm">
+                <form onSubmit={handleRecordSubmit}>
+                    <FormGroup><Label htmlFor="recordDate" required>Date</Label><Input id="recordDate" type="date" value={recordForm.date} onChange={(e) => loadRecordForm(recordFlock, e.target.value)} required /></FormGroup>
+                    <FormRow>
+                        <FormGroup><Label htmlFor="recordEggs">Eggs Collected</Label><Input id="recordEggs" type="number" min="0" value={recordForm.eggs} onChange={(e) => setRecordForm({ ...recordForm, eggs: e.target.value })} /></FormGroup>
+                        <FormGroup><Label htmlFor="recordBroken">Broken</Label><Input id="recordBroken" type="number" min="0" value={recordForm.brokenEggs} onChange={(e) => setRecordForm({ ...recordForm, brokenEggs: e.target.value })} /></FormGroup>
+                    </FormRow>
+                    <FormRow>
+                        <FormGroup><Label htmlFor="recordMortality">Deaths</Label><Input id="recordMortality" type="number" min="0" value={recordForm.mortality} onChange={(e) => setRecordForm({ ...recordForm, mortality: e.target.value })} /></FormGroup>
+                        <FormGroup><Label htmlFor="recordCulled">Culled</Label><Input id="recordCulled" type="number" min="0" value={recordForm.culled} onChange={(e) => setRecordForm({ ...recordForm, culled: e.target.value })} /></FormGroup>
+                        <FormGroup><Label htmlFor="recordSold">Sold</Label><Input id="recordSold" type="number" min="0" value={recordForm.sold} onChange={(e) => setRecordForm({ ...recordForm, sold: e.target.value })} /></FormGroup>
+                    </FormRow>
+                    <FormGroup><Label htmlFor="recordFeed">Feed (kg)</Label><Input id="recordFeed" type="number" min="0" step="0.1" value={recordForm.feedKg} onChange={(e) => setRecordForm({ ...recordForm, feedKg: e.target.value })} /></FormGroup>
+                    <FormGroup><Label htmlFor="recordNotes">Notes</Label><Textarea id="recordNotes" value={recordForm.notes} onChange={(e) => setRecordForm({ ...recordForm, notes: e.target.value })} rows={2} /></FormGroup>
+                    <div className="modal-actions"><Button variant="outline" type="button" onClick={() => setRecordFlock(null)}>Cancel</Button><Button type="submit">Save Record</Button></div>
+                </form>
+            </Modal>
+
+            <ConfirmDialog
+                isOpen={confirmDelete.show}
+                onClose={() => setConfirmDelete({ show: false, id: null })}
+                onConfirm={confirmDeleteFlock}
+                title="Delete Flock"
+                message="Are you sure you want to delete this flock? All of its daily records will be removed."
+                type="danger"
+                confirmText="Delete Flock"
+            />
+        </div>
+    );
+}
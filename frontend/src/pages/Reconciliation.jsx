@@ -0,0 +1,502 @@
+import React, { useState, useEffect } from 'react';
+import { Smartphone, Landmark, Settings, ListChecks, Link2, Plus, EyeOff, CheckCircle, Clock, TrendingUp, TrendingDown, Search, Scissors, Trash2 } from 'lucide-react';
+import { Button } from '../components/ui/Button';
+import { Card } from '../components/ui/Card';
+import { Modal } from '../components/ui/Modal';
+import { FormGroup, FormRow, Label, Input, Select, Checkbox } from '../components/ui/Form';
+import { Table, TableHeader, TableBody, TableRow, TableHead, TableCell } from '../components/ui/Table';
+import { EmptyState } from '../components/ui/EmptyState';
+import { StatCard } from '../components/ui/StatCard';
+import { formatLabel } from '../utils/formatting';
+import { toast } from 'sonner';
+import './Reconciliation.css';
+
+const incomeCategories = ['milk_sales', 'crop_sales', 'livestock_sales', 'other_income'];
+const expenseCategories = ['feed', 'veterinary', 'livestock_purchase', 'labor', 'equipment', 'seeds', 'fertilizer', 'fuel', 'maintenance', 'transport', 'utilities', 'other_expense'];
+const statusFilters = [['review', 'To review'], ['matched', 'Matched'], ['created', 'Recorded'], ['split', 'Split'], ['ignored', 'Ignored'], ['', 'All lines']];
+const emptyPart = { transactionId: '', category: '', description: '', amount: '' };
+
+// shiftDate moves a YYYY-MM-DD date by a number of days
+const shiftDate = (date, days) => {
+    const d = new Date(`${date}T00:00:00Z`);
+    d.setUTCDate(d.getUTCDate() + days);
+    return d.toISOString().split('T')[0];
+};
+
+export function Reconciliation() {
+    const [lines, setLines] = useState([]);
+    const [summary, setSummary] = useState(null);
+    const [status, setStatus] = useState('review');
+    const [loading, setLoading] = useState(true);
+    const [importing, setImporting] = useState(false);
+    const [matchLine, setMatchLine] = useState(null);
+    const [candidateSales, setCandidateSales] = useState([]);
+    const [recordLine, setRecordLine] = useState(null);
+    const [recordCategory, setRecordCategory] = useState('');
+    const [proposalLine, setProposalLine] = useState(null);
+    const [proposals, setProposals] = useState([]);
+    const [splitLine, setSplitLine] = useState(null);
+    const [splitParts, setSplitParts] = useState([]);
+    const [openTransactions, setOpenTransactions] = useState([]);
+    const [showLayout, setShowLayout] = useState(false);
+    const [layout, setLayout] = useState(null);
+
+    useEffect(() => { loadData(); }, [status]);
+
+    const loadData = async () => {
+        try {
+            const [lineList, summaryData] = await Promise.all([
+                window.go.main.StatementService.GetStatementLines('', status),
+                window.go.main.StatementService.GetReconciliationSummary()
+            ]);
+            setLines(lineList || []);
+            setSummary(summaryData);
+        } catch (err) { console.error(err); }
+        finally { setLoading(false); }
+    };
+
+    const formatCurrency = (amt) => new Intl.NumberFormat('en-KE', { style: 'currency', currency: 'KES' }).format(amt);
+
+    const showImportResult = (result, loadingToast) => {
+        if (!result) {
+            toast.dismiss(loadingToast);
+            return;
+        }
+        toast.success(`Read ${result.lines} statement lines`, {
+            id: loadingToast,
+            description: `${result.matched} matched, ${result.review} to review, ${result.duplicates} already imported`
+        });
+        if (status !== 'review') setStatus('review');
+        else loadData();
+    };
+
+    const handleImportMpesa = async () => {
+        setImporting(true);
+        const loadingToast = toast.loading('Importing M-Pesa statement...');
+        try {
+            showImportResult(await window.go.main.StatementService.ImportMpesaStatement(), loadingToast);
+        } catch (err) {
+            console.error(err);
+            toast.error(typeof err === 'string' ? err : 'Import failed', { id: loadingToast });
+        } finally {
+            setImporting(false);
+        }
+    };
+
+    const handleImportBank = async () => {
+        setImporting(true);
+        const loadingToast = toast.loading('Importing bank statement...');
+        try {
+            showImportResult(await window.go.main.StatementService.ImportBankStatement(), loadingToast);
+        } catch (err) {
+            console.error(err);
+            toast.error(typeof err === 'string' ? err : 'Import failed', { id: loadingToast });
+        } finally {
+            setImporting(false);
+        }
+    };
+
+    const openLayout = async () => {
+        try {
+            setLayout(await window.go.main.StatementService.GetBankCSVLayout());
+            setShowLayout(true);
+        } catch (err) {
+            console.error(err);
+            toast.error(typeof err === 'string' ? err : 'Failed to load the CSV layout');
+        }
+    };
+
+    const handleLayoutSubmit = async (e) => {
+        e.preventDefault();
+        try {
+            await window.go.main.StatementService.SaveBankCSVLayout({ ...layout, skipRows: parseInt(layout.skipRows) || 0 });
+            toast.success('Bank CSV layout saved');
+            setShowLayout(false);
+        } catch (err) {
+            console.error(err);
+            toast.error(typeof err === 'string' ? err : 'Failed to save the CSV layout');
+        }
+    };
+
+    const openProposals = async (line) => {
+        setProposalLine(line);
+        try {
+            setProposals(await window.go.main.StatementService.GetMatchProposals(line.id) || []);
+        } catch (err) {
+            console.error(err);
+            setProposals([]);
+        }
+    };
+
+    const handleConfirm = async (transaction) => {
+        try {
+            await window.go.main.StatementService.ConfirmStatementMatch(proposalLine.id, transaction.id);
+            toast.success('Transaction cleared');
+            setProposalLine(null);
+            loadData();
+        } catch (err) {
+            console.error(err);
+            toast.error(typeof err === 'string' ? err : 'Failed to confirm match');
+        }
+    };
+
+    const openSplit = async (line) => {
+        setSplitLine(line);
+        setSplitParts([{ ...emptyPart, amount: line.amount.toString() }, { ...emptyPart }]);
+        try {
+            const list = await window.go.main.FinancialService.GetTransactions(shiftDate(line.date, -30), shiftDate(line.date, 30), line.direction === 'in' ? 'income' : 'expense', '');
+            setOpenTransactions((list || []).filter(t => t.reconciliationStatus !== 'cleared' && t.amount <= line.amount + 0.01));
+        } catch (err) {
+            console.error(err);
+            setOpenTransactions([]);
+        }
+    };
+
+    const updatePart = (index, changes) => {
+        setSplitParts(splitParts.map((p, i) => i === index ? { ...p, ...changes } : p));
+    };
+
+    const selectPartTransaction = (index, id) => {
+        const transaction = openTransactions.find(t => t.id === parseInt(id));
+        updatePart(index, transaction
+            ? { transactionId: id, amount: transaction.amount.toString(), category: '', description: '' }
+            : { transactionId: '' });
+    };
+
+    const handleSplitSubmit = async (e) => {
+        e.preventDefault();
+        try {
+            await window.go.main.StatementService.SplitStatementLine(splitLine.id, splitParts.map(p => ({
+                transactionId: parseInt(p.transactionId) || 0,
+                category: p.category,
+                description: p.description,
+                amount: parseFloat(p.amount) || 0
+            })));
+            toast.success('Statement line split');
+            setSplitLine(null);
+            loadData();
+        } catch (err) {
+            console.error(err);
+            toast.error(typeof err === 'string' ? err : 'Failed to split line');
+        }
+    };
+
+    // Sales made up to 30 days before, or 3 days after, the payment are offered for matching
+    const openMatchSale = async (line) => {
+        setMatchLine(line);
+        try {
+            const sales = await window.go.main.LivestockService.GetMilkSales(shiftDate(line.date, -30), shiftDate(line.date, 3));
+            setCandidateSales((sales || [])
+                .filter(s => !s.isPaid)
+                .sort((a, b) => Math.abs(a.totalAmount - line.amount) - Math.abs(b.totalAmount - line.amount)));
+        } catch (err) {
+            console.error(err);
+            setCandidateSales([]);
+        }
+    };
+
+    const handleMatchSale = async (sale) => {
+        try {
+            await window.go.main.StatementService.MatchStatementLineToSale(matchLine.id, sale.id);
+            toast.success(`Matched to the sale of ${sale.date}`);
+            setMatchLine(null);
+            loadData();
+        } catch (err) {
+            console.error(err);
+            toast.error(typeof err === 'string' ? err : 'Failed to match sale');
+        }
+    };
+
+    const openRecord = (line) => {
+        setRecordLine(line);
+        setRecordCategory(line.direction === 'in' ? 'other_income' : 'other_expense');
+    };
+
+    const handleRecord = async (e) => {
+        e.preventDefault();
+        try {
+            await window.go.main.StatementService.CreateTransactionFromStatementLine(recordLine.id, recordCategory);
+            toast.success('Transaction recorded');
+            setRecordLine(null);
+            loadData();
+        } catch (err) {
+            console.error(err);
+            toast.error(typeof err === 'string' ? err : 'Failed to record transaction');
+        }
+    };
+
+    const handleIgnore = async (line) => {
+        try {
+            await window.go.main.StatementService.IgnoreStatementLine(line.id);
+            toast.success('Line ignored');
+            loadData();
+        } catch (err) {
+            console.error(err);
+            toast.error(typeof err === 'string' ? err : 'Failed to ignore line');
+        }
+    };
+
+    const splitTotal = splitParts.reduce((sum, p) => sum + (parseFloat(p.amount) || 0), 0);
+
+    return (
+        <div className="reconciliation-page">
+            <header className="page-header">
+                <div className="page-header-content">
+                    <h1>Reconciliation</h1>
+                    <p>Import statements and match every payment to the farm records</p>
+                </div>
+                <div className="page-actions">
+                    <Button variant="outline" icon={Settings} onClick={openLayout} title="Columns used when reading bank CSV statements">CSV Layout</Button>
+                    <Button variant="outline" icon={Landmark} onClick={handleImportBank} disabled={importing} title="OFX, QFX or CSV bank statement">Import Bank</Button>
+                    <Button icon={Smartphone} onClick={handleImportMpesa} disabled={importing}>Import M-Pesa</Button>
+                </div>
+            </header>
+
+            {summary && (
+                <div className="stats-grid">
+                    <StatCard title="To Review" value={summary.reviewLines} subtitle="statement lines waiting for a decision" icon={ListChecks} color="primary" />
+                    <StatCard title="Cleared" value={summary.clearedCount} subtitle="transactions seen on a statement" icon={CheckCircle} color="info" />
+                    <StatCard title="Uncleared Income" value={formatCurrency(summary.unclearedIncome)} subtitle={`${summary.unclearedCount} uncleared transactions`} icon={TrendingUp} color="secondary" />
+                    <StatCard title="Uncleared Expenses" value={formatCurrency(summary.unclearedExpenses)} subtitle="not yet on a statement" icon={TrendingDown} color="accent" />
+                </div>
+            )}
+
+            <Card padding="none">
+                <div className="card-header-bar">
+                    <h3>Statement Lines</h3>
+                    <div className="recon-filter">
+                        <Select value={status} onChange={(e) => setStatus(e.target.value)}>
+                            {statusFilters.map(([value, label]) => <option key={value} value={value}>{label}</option>)}
+                        </Select>
+                    </div>
+                </div>
+                {loading ? (
+                    <div className="loading-container"><div className="loading-spinner"></div></div>
+                ) : lines.length === 0 ? (
+                    <EmptyState icon={Clock} title={status === 'review' ? 'Nothing to review' : 'No statement lines'} description="Import an M-Pesa or bank statement to match payments to sales and expenses" />
+                ) : (
+                    <Table>
+                        <TableHeader>
+                            <TableRow>
+                                <TableHead>Date</TableHead>
+                                <TableHead>Reference</TableHead>
+                                <TableHead>Details</TableHead>
+                                <TableHead>Amount</TableHead>
+                                <TableHead>Status</TableHead>
+                                <TableHead>Actions</TableHead>
+                            </TableRow>
+                        </TableHeader>
+                        <TableBody>
+                            {lines.map(line => (
+                                <TableRow key={line.id}>
+                                    <TableCell className="font-mono">{line.date}</TableCell>
+                                    <TableCell>
+                                        <div className="recon-info">
+                                            <span className="font-mono">{line.reference}</span>
+                                            <span className="recon-meta">{line.source === 'mpesa' ? 'M-Pesa' : 'Bank'}</span>
+                                        </div>
+                                    </TableCell>
+                                    <TableCell>
+                                        <div className="recon-info">
+                                            <span className="recon-party">{line.counterparty || line.description}</span>
+                                            {line.counterparty && <span className="recon-meta">{line.description}</span>}
+                                        </div>
+                                    </TableCell>
+                                    <TableCell className={`font-mono font-bold ${line.direction === 'in' ? 'recon-in' : 'recon-out'}`}>
+                                        {line.direction === 'in' ? '+' : '-'}{formatCurrency(line.amount)}
+                                    </TableCell>
+                                    <TableCell><span className={`recon-status ${line.status}`}>{line.status}</span></TableCell>
+                                    <TableCell>
+                                        {line.status === 'review' && (
+                                            <div className="action-buttons">
+                                                <button className="action-btn record" onClick={() => openProposals(line)} title="Find the matching transaction"><Search size={16} /></button>
+                                                {line.direction === 'in' && <button className="action-btn record" onClick={() => openMatchSale(line)} title="Match to a milk sale"><Link2 size={16} /></button>}
+                                                <button className="action-btn record" onClick={() => openSplit(line)} title="Split across several transactions"><Scissors size={16} /></button>
+                                                <button className="action-btn record" onClick={() => openRecord(line)} title="Record as a new transaction"><Plus size={16} /></button>
+                                                <button className="action-btn edit" onClick={() => handleIgnore(line)} title="Ignore (e.g. a personal or transfer line)"><EyeOff size={16} /></button>
+                                            </div>
+                                        )}
+                                    </TableCell>
+                                </TableRow>
+                            ))}
+                        </TableBody>
+                    </Table>
+                )}
+            </Card>
+
+            <Modal isOpen={!!matchLine} onClose={() => setMatchLine(null)} title="Match to Milk Sale" size="md">
+                {matchLine && (
+                    <>
+                        <p className="recon-modal-line">
+                            {matchLine.date} · {matchLine.counterparty || matchLine.description} · <strong>{formatCurrency(matchLine.amount)}</strong>
+                        </p>
+                        {candidateSales.length === 0 ? (
+                            <EmptyState icon={Link2} title="No unpaid sales" description="No unpaid milk sales in the 30 days before this payment" />
+                        ) : (
+                            <Table>
+                                <TableHeader>
+                                    <TableRow>
+                                        <TableHead>Date</TableHead>
+                                        <TableHead>Buyer</TableHead>
+                                        <TableHead>Total</TableHead>
+                                        <TableHead></TableHead>
+                                    </TableRow>
+                                </TableHeader>
+                                <TableBody>
+                                    {candidateSales.map(sale => {
+                                        const agrees = Math.abs(sale.totalAmount - matchLine.amount) <= 1;
+                                        return (
+                                            <TableRow key={sale.id} className={agrees ? '' : 'recon-mismatch'}>
+                                                <TableCell className="font-mono">{sale.date}</TableCell>
+                                                <TableCell>{sale.buyerName || '-'}</TableCell>
+                                                <TableCell className="font-mono">{formatCurrency(sale.totalAmount)}</TableCell>
+                                                <TableCell>
+                                                    <Button size="sm" variant="outline" onClick={() => handleMatchSale(sale)} disabled={!agrees} title={agrees ? 'Mark this sale as paid' : 'The amount does not match the payment'}>Match</Button>
+                                                </TableCell>
+                                            </TableRow>
+                                        );
+                                    })}
+                                </TableBody>
+                            </Table>
+                        )}
+                        <div className="modal-actions"><Button variant="outline" type="button" onClick={() => setMatchLine(null)}>Close</Button></div>
+                    </>
+                )}
+            </Modal>
+
+            <Modal isOpen={!!recordLine} onClose={() => setRecordLine(null)} title={recordLine?.direction === 'in' ? 'Record Income' : 'Record Expense'} size="sm">
+                {recordLine && (
+                    <form onSubmit={handleRecord}>
+                        <p className="recon-modal-line">
+                            {recordLine.date} · {recordLine.counterparty || recordLine.description} · <strong>{formatCurrency(recordLine.amount)}</strong>
+                        </p>
+                        <FormGroup>
+                            <Label htmlFor="recordCategory" required>Category</Label>
+                            <Select id="recordCategory" value={recordCategory} onChange={(e) => setRecordCategory(e.target.value)}>
+                                {(recordLine.direction === 'in' ? incomeCategories : expenseCategories).map(c => <option key={c} value={c}>{formatLabel(c)}</option>)}
+                            </Select>
+                        </FormGroup>
+                        <div className="modal-actions"><Button variant="outline" type="button" onClick={() => setRecordLine(null)}>Cancel</Button><Button type="submit">Record</Button></div>
+                    </form>
+                )}
+            </Modal>
+
+            <Modal isOpen={!!proposalLine} onClose={() => setProposalLine(null)} title="Match to Transaction" size="md">
+                {proposalLine && (
+                    <>
+                        <p className="recon-modal-line">
+                            {proposalLine.date} · {proposalLine.counterparty || proposalLine.description} · <strong>{formatCurrency(proposalLine.amount)}</strong>
+                        </p>
+                        {proposals.length === 0 ? (
+                            <EmptyState icon={Search} title="No matching transactions" description="No uncleared transaction for this amount within a week of the statement date. Record it as new or split it instead." />
+                        ) : (
+                            <Table>
+                                <TableHeader>
+                                    <TableRow>
+                                        <TableHead>Date</TableHead>
+                                        <TableHead>Category</TableHead>
+                                        <TableHead>Description</TableHead>
+                                        <TableHead>Amount</TableHead>
+                                        <TableHead></TableHead>
+                                    </TableRow>
+                                </TableHeader>
+                                <TableBody>
+                                    {proposals.map(p => (
+                                        <TableRow key={p.transaction.id}>
+                                            <TableCell className="font-mono">
+                                                {p.transaction.date}
+                                                {p.daysApart > 0 && <span className="recon-meta"> ({p.daysApart}d apart)</span>}
+                                            </TableCell>
+                                            <TableCell>{formatLabel(p.transaction.category)}</TableCell>
+                                            <TableCell>{p.transaction.description || '-'}</TableCell>
+                                            <TableCell className="font-mono">{formatCurrency(p.transaction.amount)}</TableCell>
+                                            <TableCell><Button size="sm" variant="outline" icon={CheckCircle} onClick={() => handleConfirm(p.transaction)}>Confirm</Button></TableCell>
+                                        </TableRow>
+                                    ))}
+                                </TableBody>
+                            </Table>
+                        )}
+                        <div className="modal-actions"><Button variant="outline" type="button" onClick={() => setProposalLine(null)}>Close</Button></div>
+                    </>
+                )}
+            </Modal>
+
+            <Modal isOpen={!!splitLine} onClose={() => setSplitLine(null)} title="Split Statement Line" size="lg">
+                {splitLine && (
+                    <form onSubmit={handleSplitSubmit}>
+                        <p className="recon-modal-line">
+                            {splitLine.date} · {splitLine.counterparty || splitLine.description} · <strong>{formatCurrency(splitLine.amount)}</strong>
+                        </p>
+                        {splitParts.map((part, i) => (
+                            <div key={i} className="split-row">
+                                <div className="split-transaction">
+                                    <Select value={part.transactionId} onChange={(e) => selectPartTransaction(i, e.target.value)}>
+                                        <option value="">New transaction</option>
+                                        {openTransactions.map(t => <option key={t.id} value={t.id}>{t.date} · {t.description || formatLabel(t.category)} · {formatCurrency(t.amount)}</option>)}
+                                    </Select>
+                                </div>
+                                {!part.transactionId && (
+                                    <>
+                                        <div className="split-category">
+                                            <Select value={part.category} onChange={(e) => updatePart(i, { category: e.target.value })}>
+                                                <option value="">Category...</option>
+                                                {(splitLine.direction === 'in' ? incomeCategories : expenseCategories).map(c => <option key={c} value={c}>{formatLabel(c)}</option>)}
+                                            </Select>
+                                        </div>
+                                        <div className="split-description">
+                                            <Input value={part.description} onChange={(e) => updatePart(i, { description: e.target.value })} placeholder="Description" />
+                                        </div>
+                                    </>
+                                )}
+                                <div className="split-amount">
+                                    <Input type="number" min="0" step="0.01" value={part.amount} onChange={(e) => updatePart(i, { amount: e.target.value })} disabled={!!part.transactionId} required />
+                                </div>
+                                <button type="button" className="action-btn edit" onClick={() => setSplitParts(splitParts.filter((_, j) => j !== i))} disabled={splitParts.length <= 2} title="Remove part"><Trash2 size={16} /></button>
+                            </div>
+                        ))}
+                        <Button variant="outline" size="sm" icon={Plus} onClick={() => setSplitParts([...splitParts, { ...emptyPart, amount: Math.max(splitLine.amount - splitTotal, 0).toFixed(2) }])}>Add Part</Button>
+                        <div className={`split-total ${Math.abs(splitTotal - splitLine.amount) > 0.01 ? 'off' : ''}`}>
+                            Parts: {formatCurrency(splitTotal)} of {formatCurrency(splitLine.amount)}
+                        </div>
+                        <div className="modal-actions">
+                            <Button variant="outline" type="button" onClick={() => setSplitLine(null)}>Cancel</Button>
+                            <Button type="submit" disabled={Math.abs(splitTotal - splitLine.amount) > 0.01}>Split</Button>
+                        </div>
+                    </form>
+                )}
+            </Modal>
+
+            <Modal isOpen={showLayout} onClose={() => setShowLayout(false)} title="Bank CSV Layout" size="md">
+                {layout && (
+                    <form onSubmit={handleLayoutSubmit}>
+                        <p className="recon-meta recon-layout-note">Enter the column headings from your bank's CSV export, or column numbers (1, 2, ...) if the file has no header row. OFX and QFX files do not need a layout.</p>
+                        <FormRow>
+                            <FormGroup>
+                                <Label htmlFor="layoutDelimiter">Delimiter</Label>
+                                <Select id="layoutDelimiter" value={layout.delimiter} onChange={(e) => setLayout({ ...layout, delimiter: e.target.value })}>
+                                    <option value=",">Comma</option>
+                                    <option value=";">Semicolon</option>
+                                    <option value="tab">Tab</option>
+                                </Select>
+                            </FormGroup>
+                            <FormGroup><Label htmlFor="layoutSkip">Rows to Skip</Label><Input id="layoutSkip" type="number" min="0" value={layout.skipRows} onChange={(e) => setLayout({ ...layout, skipRows: e.target.value })} /></FormGroup>
+                            <FormGroup><Label htmlFor="layoutDateFormat">Date Format</Label><Input id="layoutDateFormat" value={layout.dateFormat} onChange={(e) => setLayout({ ...layout, dateFormat: e.target.value })} placeholder="DD/MM/YYYY" /></FormGroup>
+                        </FormRow>
+                        <FormGroup><Checkbox label="First row is a header" checked={layout.hasHeader} onChange={(e) => setLayout({ ...layout, hasHeader: e.target.checked })} /></FormGroup>
+                        <FormRow>
+                            <FormGroup><Label htmlFor="layoutDate" required>Date Column</Label><Input id="layoutDate" value={layout.dateColumn} onChange={(e) => setLayout({ ...layout, dateColumn: e.target.value })} required /></FormGroup>
+                            <FormGroup><Label htmlFor="layoutDescription" required>Description Column</Label><Input id="layoutDescription" value={layout.descriptionColumn} onChange={(e) => setLayout({ ...layout, descriptionColumn: e.target.value })} required /></FormGroup>
+                            <FormGroup><Label htmlFor="layoutReference">Reference Column</Label><Input id="layoutReference" value={layout.referenceColumn} onChange={(e) => setLayout({ ...layout, referenceColumn: e.target.value })} /></FormGroup>
+                        </FormRow>
+                        <FormRow>
+                            <FormGroup><Label htmlFor="layoutAmount">Amount Column</Label><Input id="layoutAmount" value={layout.amountColumn} onChange={(e) => setLayout({ ...layout, amountColumn: e.target.value })} placeholder="Signed amount" /></FormGroup>
+                            <FormGroup><Label htmlFor="layoutDebit">Debit Column</Label><Input id="layoutDebit" value={layout.debitColumn} onChange={(e) => setLayout({ ...layout, debitColumn: e.target.value })} placeholder="If no amount column" /></FormGroup>
+                            <FormGroup><Label htmlFor="layoutCredit">Credit Column</Label><Input id="layoutCredit" value={layout.creditColumn} onChange={(e) => setLayout({ ...layout, creditColumn: e.target.value })} placeholder="If no amount column" /></FormGroup>
+                        </FormRow>
+                        <div className="modal-actions"><Button variant="outline" type="button" onClick={() => setShowLayout(false)}>Cancel</Button><Button type="submit">Save Layout</Button></div>
+                    </form>
+                )}
+            </Modal>
+        </div>
+    );
+}
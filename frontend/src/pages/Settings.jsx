@@ -1,11 +1,22 @@
 import React, { useState, useEffect } from 'react';
-import { Database, Download, Upload, HardDrive, RefreshCw, CheckCircle, AlertCircle, Search, MapPin, Sun, Bell } from 'lucide-react';
+import { Database, Download, Upload, HardDrive, RefreshCw, CheckCircle, AlertCircle, Search, MapPin, Sun, Bell, FileText, Milk, Plus, Trash2, ArrowUp, ArrowDown, Copy, TrendingUp, Syringe, FileSpreadsheet, CalendarDays, FlaskConical } from 'lucide-react';
+import { formatType } from '../utils/species';
 import { Card, CardHeader, CardTitle, CardContent } from '../components/ui/Card';
 import { Button } from '../components/ui/Button';
 import { ConfirmDialog, AlertDialog } from '../components/ui/ConfirmDialog';
 import { toast } from 'sonner';
 import './Settings.css';
 
+const csvExports = [
+    ['ExportMilkRecordsCSV', 'Milk Records'],
+    ['ExportFinancesCSV', 'Transactions'],
+    ['ExportCropRecordsCSV', 'Crop Records'],
+    ['ExportInventoryCSV', 'Inventory Items'],
+    ['ExportFeedRecordsCSV', 'Feed Records'],
+    ['ExportVetRecordsCSV', 'Vet Records'],
+    ['ExportBreedingRecordsCSV', 'Breeding Records']
+];
+
 export function Settings() {
     const [dbInfo, setDbInfo] = useState(null);
     const [version, setVersion] = useState('');
@@ -14,12 +25,28 @@ export function Settings() {
     const [searchResults, setSearchResults] = useState([]);
     const [searching, setSearching] = useState(false);
     const [confirmRestore, setConfirmRestore] = useState(false);
+    const [confirmImport, setConfirmImport] = useState(false);
     const [currentLocation, setCurrentLocation] = useState(null);
+    const [milkSessions, setMilkSessions] = useState([]);
+    const [milkAlerts, setMilkAlerts] = useState(null);
+    const [qualityPricing, setQualityPricing] = useState(null);
+    const [sccThreshold, setSccThreshold] = useState('');
+    const [lifeStages, setLifeStages] = useState(null);
+    const [withdrawals, setWithdrawals] = useState([]);
+    const [calendarFeed, setCalendarFeed] = useState(null);
+    const [exportRange, setExportRange] = useState({ startDate: '', endDate: '' });
+    const [feedPort, setFeedPort] = useState('');
 
     useEffect(() => {
         loadDatabaseInfo();
         loadVersion();
         loadWeatherLocation();
+        loadMilkSessions();
+        loadMilkAlerts();
+        loadMilkQuality();
+        loadLifeStages();
+        loadWithdrawals();
+        loadCalendarFeed();
     }, []);
 
     // Debounced search effect
@@ -117,6 +144,87 @@ export function Settings() {
         }
     };
 
+    const handleExportDataset = async () => {
+        setLoading(true);
+        const loadingToast = toast.loading('Exporting farm dataset...');
+        try {
+            const result = await window.go.main.ExportService.ExportDatasetJSON();
+            if (result) {
+                toast.success(`Exported ${result.records} records`, {
+                    id: loadingToast,
+                    description: `Saved to ${result.path}`
+                });
+            } else {
+                toast.info('Export cancelled', { id: loadingToast });
+            }
+        } catch (err) {
+            toast.error(err.message || 'Export failed', { id: loadingToast });
+        } finally {
+            setLoading(false);
+        }
+    };
+
+    const handleExportWorkbook = async () => {
+        setLoading(true);
+        const loadingToast = toast.loading('Exporting farm workbook...');
+        try {
+            const result = await window.go.main.ExportService.ExportFarmWorkbookXLSX(exportRange.startDate, exportRange.endDate);
+            if (result) {
+                toast.success(`Exported ${result.records} rows`, {
+                    id: loadingToast,
+                    description: `Saved to ${result.path}`
+                });
+            } else {
+                toast.info('Export cancelled', { id: loadingToast });
+            }
+        } catch (err) {
+            toast.error(err.message || 'Export failed', { id: loadingToast });
+        } finally {
+            setLoading(false);
+        }
+    };
+
+    const handleExportCSV = async (method, label) => {
+        setLoading(true);
+        const loadingToast = toast.loading(`Exporting ${label.toLowerCase()}...`);
+        try {
+            const result = await window.go.main.ExportService[method](exportRange.startDate, exportRange.endDate);
+            if (result) {
+                toast.success(`Exported ${result.records} ${label.toLowerCase()}`, {
+                    id: loadingToast,
+                    description: `Saved to ${result.path}`
+                });
+            } else {
+                toast.info('Export cancelled', { id: loadingToast });
+            }
+        } catch (err) {
+            toast.error(err.message || 'Export failed', { id: loadingToast });
+        } finally {
+            setLoading(false);
+        }
+    };
+
+    const confirmImportDataset = async () => {
+        setConfirmImport(false);
+        setLoading(true);
+        const loadingToast = toast.loading('Importing farm dataset...');
+        try {
+            const result = await window.go.main.ExportService.ImportDatasetJSON();
+            if (result) {
+                toast.success(`Imported ${result.records} records`, {
+                    id: loadingToast,
+                    description: 'Reload the app to see changes.'
+                });
+                loadDatabaseInfo();
+            } else {
+                toast.info('Import cancelled', { id: loadingToast });
+            }
+        } catch (err) {
+            toast.error(err.message || 'Import failed', { id: loadingToast });
+        } finally {
+            setLoading(false);
+        }
+    };
 
     const loadWeatherLocation = async () => {
         if (!window.go?.main?.WeatherService) return;
@@ -160,6 +268,233 @@ export function Settings() {
         }
     };
 
+    const loadMilkSessions = async () => {
+        if (!window.go?.main?.LivestockService) return;
+        try {
+            const sessions = await window.go.main.LivestockService.GetMilkingSessions();
+            setMilkSessions(sessions || []);
+        } catch (err) {
+            console.error('Failed to load milking sessions:', err);
+        }
+    };
+
+    const updateMilkSession = (index, changes) => {
+        setMilkSessions(sessions => sessions.map((session, i) => i === index ? { ...session, ...changes } : session));
+    };
+
+    const moveMilkSession = (index, offset) => {
+        setMilkSessions(sessions => {
+            const next = [...sessions];
+            const [session] = next.splice(index, 1);
+            next.splice(index + offset, 0, session);
+            return next;
+        });
+    };
+
+    const handleRemoveMilkSession = async (index) => {
+        const session = milkSessions[index];
+        if (!session.id) {
+            setMilkSessions(sessions => sessions.filter((_, i) => i !== index));
+            return;
+        }
+        try {
+            await window.go.main.LivestockService.DeleteMilkingSession(session.id);
+            setMilkSessions(sessions => sessions.filter((_, i) => i !== index));
+            toast.success(`Removed the ${session.name} session`);
+        } catch (err) {
+            toast.error(typeof err === 'string' ? err : 'Failed to remove session');
+        }
+    };
+
+    const handleSaveMilkSessions = async () => {
+        const loadingToast = toast.loading('Saving milking sessions...');
+        try {
+            const saved = await window.go.main.LivestockService.SaveMilkingSessions(milkSessions);
+            setMilkSessions(saved || []);
+            toast.success('Milking sessions saved', { id: loadingToast });
+        } catch (err) {
+            toast.error(typeof err === 'string' ? err : 'Failed to save milking sessions', { id: loadingToast });
+        }
+    };
+
+    const loadMilkAlerts = async () => {
+        if (!window.go?.main?.LivestockService) return;
+        try {
+            setMilkAlerts(await window.go.main.LivestockService.GetMilkAnomalySettings());
+        } catch (err) {
+            console.error('Failed to load milk alert settings:', err);
+        }
+    };
+
+    const handleSaveMilkAlerts = async (changes) => {
+        const next = { ...milkAlerts, ...changes };
+        try {
+            const saved = await window.go.main.LivestockService.SaveMilkAnomalySettings(next);
+            setMilkAlerts(saved);
+            toast.success('Milk alert settings saved');
+        } catch (err) {
+            toast.error(typeof err === 'string' ? err : 'Failed to save milk alert settings');
+        }
+    };
+
+    const loadMilkQuality = async () => {
+        if (!window.go?.main?.LivestockService) return;
+        try {
+            const [pricing, threshold] = await Promise.all([
+                window.go.main.LivestockService.GetMilkQualityPricing(),
+                window.go.main.LivestockService.GetSCCAlertThreshold()
+            ]);
+            setQualityPricing(pricing);
+            setSccThreshold(String(threshold));
+        } catch (err) {
+            console.error('Failed to load milk quality settings:', err);
+        }
+    };
+
+    const updateSCCBand = (index, changes) => {
+        setQualityPricing(p => ({ ...p, sccBands: p.sccBands.map((b, i) => i === index ? { ...b, ...changes } : b) }));
+    };
+
+    const handleSaveMilkQuality = async () => {
+        try {
+            await window.go.main.LivestockService.SaveMilkQualityPricing(qualityPricing);
+            await window.go.main.LivestockService.SaveSCCAlertThreshold(parseInt(sccThreshold) || 0);
+            loadMilkQuality();
+            toast.success('Milk quality settings saved');
+        } catch (err) {
+            toast.error(typeof err === 'string' ? err : 'Failed to save milk quality settings');
+        }
+    };
+
+    const loadLifeStages = async () => {
+        if (!window.go?.main?.LivestockService) return;
+        try {
+            setLifeStages(await window.go.main.LivestockService.GetLifeStageSettings());
+        } catch (err) {
+            console.error('Failed to load life stage settings:', err);
+        }
+    };
+
+    const updateLifeStageRule = (index, changes) => {
+        setLifeStages({ ...lifeStages, rules: lifeStages.rules.map((r, i) => i === index ? { ...r, ...changes } : r) });
+    };
+
+    const handleCopyLifeStageRule = (index) => {
+        const rules = [...lifeStages.rules];
+        rules.splice(index + 1, 0, { ...rules[index], breed: '' });
+        setLifeStages({ ...lifeStages, rules });
+    };
+
+    const handleRemoveLifeStageRule = (index) => {
+        setLifeStages({ ...lifeStages, rules: lifeStages.rules.filter((_, i) => i !== index) });
+    };
+
+    const handleSaveLifeStages = async (changes = {}) => {
+        try {
+            const saved = await window.go.main.LivestockService.SaveLifeStageSettings({ ...lifeStages, ...changes });
+            setLifeStages(saved);
+            toast.success('Life stage rules saved');
+        } catch (err) {
+            toast.error(typeof err === 'string' ? err : 'Failed to save life stage rules');
+        }
+    };
+
+    const handleApplyLifeStages = async () => {
+        try {
+            const changes = await window.go.main.LivestockService.ApplyLifeStageTransitions();
+            if (!changes || changes.length === 0) {
+                toast.info('No animals are due a new life stage');
+                return;
+            }
+            toast.success(`Updated ${changes.length} life stage${changes.length === 1 ? '' : 's'}`, {
+                description: changes.slice(0, 5).map(c => `${c.animalName}: ${formatType(c.fromType)} → ${formatType(c.toType)}`).join(', ')
+            });
+        } catch (err) {
+            toast.error(typeof err === 'string' ? err : 'Failed to apply life stages');
+        }
+    };
+
+    const loadWithdrawals = async () => {
+        if (!window.go?.main?.HealthService) return;
+        try {
+            setWithdrawals(await window.go.main.HealthService.GetMedicineWithdrawals() || []);
+        } catch (err) {
+            console.error('Failed to load withdrawal periods:', err);
+        }
+    };
+
+    const updateWithdrawal = (index, changes) => {
+        setWithdrawals(rows => rows.map((w, i) => i === index ? { ...w, ...changes } : w));
+    };
+
+    const handleSaveWithdrawals = async () => {
+        try {
+            const saved = await window.go.main.HealthService.SaveMedicineWithdrawals(withdrawals);
+            setWithdrawals(saved || []);
+            toast.success('Withdrawal periods saved');
+        } catch (err) {
+            toast.error(typeof err === 'string' ? err : 'Failed to save withdrawal periods');
+        }
+    };
+
+    const loadCalendarFeed = async () => {
+        if (!window.go?.main?.CalendarService) return;
+        try {
+            const status = await window.go.main.CalendarService.GetCalendarFeedStatus();
+            setCalendarFeed(status);
+            setFeedPort(String(status.port));
+        } catch (err) {
+            console.error('Failed to load calendar feed status:', err);
+        }
+    };
+
+    const handleToggleCalendarFeed = async (enabled) => {
+        try {
+            if (enabled) {
+                setCalendarFeed(await window.go.main.CalendarService.StartCalendarFeed(parseInt(feedPort) || 0));
+                toast.success('Calendar feed started');
+            } else {
+                await window.go.main.CalendarService.StopCalendarFeed();
+                loadCalendarFeed();
+                toast.success('Calendar feed stopped');
+            }
+        } catch (err) {
+            toast.error(typeof err === 'string' ? err : 'Failed to change the calendar feed');
+        }
+    };
+
+    const handleRegenerateFeedToken = async () => {
+        try {
+            setCalendarFeed(await window.go.main.CalendarService.RegenerateCalendarFeedToken());
+            toast.success('New calendar link created', { description: 'Existing subscriptions will stop updating.' });
+        } catch (err) {
+            toast.error('Failed to create a new link');
+        }
+    };
+
+    const handleCopyFeedURL = async (url) => {
+        try {
+            await navigator.clipboard.writeText(url);
+            toast.success('Link copied');
+        } catch (err) {
+            toast.error('Failed to copy link');
+        }
+    };
+
+    const handleExportCalendar = async () => {
+        const loadingToast = toast.loading('Exporting reminders...');
+        try {
+            const result = await window.go.main.CalendarService.ExportRemindersICS();
+            if (result) {
+                toast.success(`Exported ${result.records} reminders`, { id: loadingToast, description: `Saved to ${result.path}` });
+            } else {
+                toast.info('Export cancelled', { id: loadingToast });
+            }
+        } catch (err) {
+            toast.error(err.message || 'Export failed', { id: loadingToast });
+        }
+    };
+
     const handleTestNotification = async () => {
         setLoading(true);
         const loadingToast = toast.loading('Sending test notification...');
@@ -211,6 +546,55 @@ export function Settings() {
                         <p className="backup-note">
                             Create local copies of your data for safety or move your database to another device.
                         </p>
+
+                        <div className="backup-actions">
+                            <Button icon={FileText} variant="outline" onClick={handleExportDataset} disabled={loading}>
+                                Export Dataset (JSON)
+                            </Button>
+                            <Button icon={Upload} variant="outline" onClick={() => setConfirmImport(true)} disabled={loading}>
+                                Import Dataset
+                            </Button>
+                        </div>
+
+                        <p className="backup-note">
+                            The dataset is an open JSON format covering every table, for archiving or your own analysis.
+                        </p>
+                    </CardContent>
+                </Card>
+
+                <Card>
+                    <CardHeader>
+                        <CardTitle><Download size={20} /> Export Data</CardTitle>
+                    </CardHeader>
+                    <CardContent>
+                        <div className="export-range">
+                            <label>
+                                <span className="data-label">From</span>
+                                <input type="date" value={exportRange.startDate} onChange={(e) => setExportRange({ ...exportRange, startDate: e.target.value })} />
+                            </label>
+                            <label>
+                                <span className="data-label">To</span>
+                                <input type="date" value={exportRange.endDate} onChange={(e) => setExportRange({ ...exportRange, endDate: e.target.value })} />
+                            </label>
+                        </div>
+
+                        <div className="backup-actions">
+                            <Button icon={FileSpreadsheet} onClick={handleExportWorkbook} disabled={loading}>
+                                Export Workbook (Excel)
+                            </Button>
+                        </div>
+
+                        <div className="backup-actions">
+                            {csvExports.map(([method, label]) => (
+                                <Button key={method} icon={FileText} variant="outline" size="sm" onClick={() => handleExportCSV(method, label)} disabled={loading}>
+                                    {label} CSV
+                                </Button>
+                            ))}
+                        </div>
+
+                        <p className="backup-note">
+                            The workbook has one sheet per module. CSV files open in any spreadsheet. Leave the dates empty to export everything; the animal list is exported from the Livestock page.
+                        </p>
                     </CardContent>
                 </Card>
 
@@ -290,6 +674,292 @@ export function Settings() {
                     </CardContent>
                 </Card>
 
+                {calendarFeed && (
+                    <Card>
+                        <CardHeader>
+                            <CardTitle><CalendarDays size={20} /> Calendar</CardTitle>
+                        </CardHeader>
+                        <CardContent>
+                            <div className="backup-actions">
+                                <Button icon={Download} variant="outline" onClick={handleExportCalendar}>Export Reminders (.ics)</Button>
+                            </div>
+                            <div className="milk-alert-settings">
+                                <label className="milk-session-active">
+                                    <input
+                                        type="checkbox"
+                                        checked={calendarFeed.running}
+                                        onChange={(e) => handleToggleCalendarFeed(e.target.checked)}
+                                    />
+                                    Share reminders as a calendar feed on the local network
+                                </label>
+                                <div className="calendar-feed-port">
+                                    <span className="data-label">Port</span>
+                                    <input
+                                        type="number"
+                                        min="1024"
+                                        max="65535"
+                                        value={feedPort}
+                                        disabled={calendarFeed.running}
+                                        onChange={(e) => setFeedPort(e.target.value)}
+                                    />
+                                </div>
+                                {[['Calendar link', calendarFeed.url], ['Phone (webcal) link', calendarFeed.webcalUrl]].map(([label, url]) => (
+                                    <div key={label} className="calendar-feed-url">
+                                        <span className="data-label">{label}</span>
+                                        <div className="calendar-feed-url-row">
+                                            <code>{url}</code>
+                                            <button type="button" className="milk-session-btn" onClick={() => handleCopyFeedURL(url)} title="Copy link"><Copy size={14} /></button>
+                                        </div>
+                                    </div>
+                                ))}
+                                <Button variant="outline" size="sm" icon={RefreshCw} onClick={handleRegenerateFeedToken}>New Secret Link</Button>
+                                <p className="settings-note">
+                                    {calendarFeed.running ? 'The feed is running.' : 'The feed is stopped.'} Subscribe to the link from a phone or computer on the same network.
+                                    The last part of the link is a secret token; create a new link to cut off anyone who should no longer see your reminders.
+                                </p>
+                            </div>
+                        </CardContent>
+                    </Card>
+                )}
+
+                <Card>
+                    <CardHeader>
+                        <CardTitle><Milk size={20} /> Milking</CardTitle>
+                    </CardHeader>
+                    <CardContent>
+                        <div className="milk-sessions">
+                            {milkSessions.map((session, index) => (
+                                <div key={session.id || `new-${index}`} className="milk-session-row">
+                                    <input
+                                        type="text"
+                                        value={session.name}
+                                        placeholder="Session name"
+                                        onChange={(e) => updateMilkSession(index, { name: e.target.value })}
+                                    />
+                                    <label className="milk-session-active">
+                                        <input
+                                            type="checkbox"
+                                            checked={session.active}
+                                            onChange={(e) => updateMilkSession(index, { active: e.target.checked })}
+                                        />
+                                        Active
+                                    </label>
+                                    <button type="button" className="milk-session-btn" onClick={() => moveMilkSession(index, -1)} disabled={index === 0} title="Milked earlier"><ArrowUp size={14} /></button>
+                                    <button type="button" className="milk-session-btn" onClick={() => moveMilkSession(index, 1)} disabled={index === milkSessions.length - 1} title="Milked later"><ArrowDown size={14} /></button>
+                                    <button type="button" className="milk-session-btn" onClick={() => handleRemoveMilkSession(index)} title="Remove session"><Trash2 size={14} /></button>
+                                </div>
+                            ))}
+                        </div>
+                        <div className="backup-actions">
+                            <Button variant="outline" icon={Plus} onClick={() => setMilkSessions([...milkSessions, { id: 0, name: '', active: true }])}>Add Session</Button>
+                            <Button onClick={handleSaveMilkSessions}>Save Sessions</Button>
+                        </div>
+                        <p className="settings-note mt-4">
+                            Milk is recorded per session in this order. Deactivate a session you no longer milk to keep its history.
+                        </p>
+                        {milkAlerts && (
+                            <div className="milk-alert-settings mt-4">
+                                <label className="milk-session-active">
+                                    <input
+                                        type="checkbox"
+                                        checked={milkAlerts.enabled}
+                                        onChange={(e) => handleSaveMilkAlerts({ enabled: e.target.checked })}
+                                    />
+                                    Alert on milk drops and missed recordings
+                                </label>
+                                <select
+                                    value={milkAlerts.sensitivity}
+                                    disabled={!milkAlerts.enabled}
+                                    onChange={(e) => handleSaveMilkAlerts({ sensitivity: e.target.value })}
+                                >
+                                    <option value="low">Low sensitivity</option>
+                                    <option value="medium">Medium sensitivity</option>
+                                    <option value="high">High sensitivity</option>
+                                    {milkAlerts.sensitivity === 'custom' && <option value="custom">Custom</option>}
+                                </select>
+                                <p className="settings-note">
+                                    Alerts when a cow gives {milkAlerts.dropPercent}% less than her {milkAlerts.baselineDays}-day average,
+                                    or has no milk recorded for {milkAlerts.missedDays} day{milkAlerts.missedDays === 1 ? '' : 's'}.
+                                </p>
+                            </div>
+                        )}
+                    </CardContent>
+                </Card>
+
+                {qualityPricing && (
+                    <Card>
+                        <CardHeader>
+                            <CardTitle><FlaskConical size={20} /> Milk Quality</CardTitle>
+                        </CardHeader>
+                        <CardContent>
+                            <div className="life-stage-rules">
+                                {[['Butterfat', 'baseButterfat', 'butterfatRate'], ['Protein', 'baseProtein', 'proteinRate']].map(([label, base, rate]) => (
+                                    <div key={label} className="life-stage-row">
+                                        <span className="life-stage-label">{label} base %</span>
+                                        <input
+                                            type="number"
+                                            min="0"
+                                            step="0.1"
+                                            className="life-stage-age"
+                                            value={qualityPricing[base]}
+                                            onChange={(e) => setQualityPricing({ ...qualityPricing, [base]: parseFloat(e.target.value) || 0 })}
+                                        />
+                                        <span className="quality-rate-label">KES/L per 0.1%</span>
+                                        <input
+                                            type="number"
+                                            step="0.01"
+                                            className="life-stage-age"
+                                            value={qualityPricing[rate]}
+                                            onChange={(e) => setQualityPricing({ ...qualityPricing, [rate]: parseFloat(e.target.value) || 0 })}
+                                        />
+                                    </div>
+                                ))}
+                                {qualityPricing.sccBands.map((band, index) => (
+                                    <div key={index} className="life-stage-row">
+                                        <span className="life-stage-label">Cell count up to</span>
+                                        <input
+                                            type="number"
+                                            min="0"
+                                            step="1000"
+                                            value={band.maxScc || ''}
+                                            placeholder="No limit"
+                                            onChange={(e) => updateSCCBand(index, { maxScc: parseInt(e.target.value) || 0 })}
+                                        />
+                                        <span className="quality-rate-label">KES/L</span>
+                                        <input
+                                            type="number"
+                                            step="0.1"
+                                            className="life-stage-age"
+                                            value={band.adjustment}
+                                            onChange={(e) => updateSCCBand(index, { adjustment: parseFloat(e.target.value) || 0 })}
+                                        />
+                                        <button type="button" className="milk-session-btn" onClick={() => setQualityPricing({ ...qualityPricing, sccBands: qualityPricing.sccBands.filter((_, i) => i !== index) })} title="Remove band"><Trash2 size={14} /></button>
+                                    </div>
+                                ))}
+                                <div className="life-stage-row">
+                                    <span className="life-stage-label">Alert when a cow's cell count is above</span>
+                                    <input
+                                        type="number"
+                                        min="1000"
+                                        step="1000"
+                                        value={sccThreshold}
+                                        onChange={(e) => setSccThreshold(e.target.value)}
+                                    />
+                                </div>
+                            </div>
+                            <div className="backup-actions">
+                                <Button variant="outline" icon={Plus} onClick={() => setQualityPricing({ ...qualityPricing, sccBands: [...qualityPricing.sccBands, { maxScc: 0, adjustment: 0 }] })}>Add Band</Button>
+                                <Button onClick={handleSaveMilkQuality}>Save Quality Pricing</Button>
+                            </div>
+                            <p className="settings-note mt-4">
+                                Sales with test results are paid the base price plus these adjustments. The first cell count band a sale fits applies;
+                                leave the limit empty for the band above all others.
+                            </p>
+                        </CardContent>
+                    </Card>
+                )}
+
+                {lifeStages && (
+                    <Card>
+                        <CardHeader>
+                            <CardTitle><TrendingUp size={20} /> Life Stages</CardTitle>
+                        </CardHeader>
+                        <CardContent>
+                            <label className="milk-session-active mb-4">
+                                <input
+                                    type="checkbox"
+                                    checked={lifeStages.enabled}
+                                    onChange={(e) => handleSaveLifeStages({ enabled: e.target.checked })}
+                                />
+                                Move animals to their next life stage automatically
+                            </label>
+                            <div className="life-stage-rules">
+                                {lifeStages.rules.map((rule, index) => (
+                                    <div key={index} className="life-stage-row">
+                                        <span className="life-stage-label">
+                                            <span className="capitalize">{rule.species}</span> {rule.gender && `(${rule.gender})`}: {formatType(rule.fromType)} → {formatType(rule.toType)}
+                                        </span>
+                                        <input
+                                            type="text"
+                                            value={rule.breed === 'default' ? '' : rule.breed}
+                                            placeholder="All breeds"
+                                            onChange={(e) => updateLifeStageRule(index, { breed: e.target.value })}
+                                        />
+                                        {rule.trigger === 'age' ? (
+                                            <input
+                                                type="number"
+                                                min="0.5"
+                                                step="0.5"
+                                                className="life-stage-age"
+                                                value={rule.ageMonths}
+                                                title="Age in months"
+                                                onChange={(e) => updateLifeStageRule(index, { ageMonths: parseFloat(e.target.value) || 0 })}
+                                            />
+                                        ) : (
+                                            <span className="life-stage-trigger">First birth</span>
+                                        )}
+                                        <button type="button" className="milk-session-btn" onClick={() => handleCopyLifeStageRule(index)} title="Add a rule for another breed"><Copy size={14} /></button>
+                                        <button type="button" className="milk-session-btn" onClick={() => handleRemoveLifeStageRule(index)} title="Remove rule"><Trash2 size={14} /></button>
+                                    </div>
+                                ))}
+                            </div>
+                            <div className="backup-actions">
+                                <Button variant="outline" onClick={handleApplyLifeStages}>Apply Now</Button>
+                                <Button onClick={() => handleSaveLifeStages()}>Save Rules</Button>
+                            </div>
+                            <p className="settings-note mt-4">
+                                Ages are in months from the date of birth. A breed rule overrides the all-breeds rule for that breed.
+                                Changes are recorded in each animal's type history.
+                            </p>
+                        </CardContent>
+                    </Card>
+                )}
+
+                <Card>
+                    <CardHeader>
+                        <CardTitle><Syringe size={20} /> Drug Withdrawal Periods</CardTitle>
+                    </CardHeader>
+                    <CardContent>
+                        <div className="life-stage-rules">
+                            {withdrawals.map((w, index) => (
+                                <div key={index} className="life-stage-row">
+                                    <input
+                                        type="text"
+                                        className="withdrawal-medicine"
+                                        value={w.medicine}
+                                        placeholder="Medicine"
+                                        onChange={(e) => updateWithdrawal(index, { medicine: e.target.value })}
+                                    />
+                                    <input
+                                        type="number"
+                                        min="0"
+                                        className="life-stage-age"
+                                        value={w.milkDays}
+                                        title="Milk withdrawal (days)"
+                                        onChange={(e) => updateWithdrawal(index, { milkDays: parseInt(e.target.value) || 0 })}
+                                    />
+                                    <input
+                                        type="number"
+                                        min="0"
+                                        className="life-stage-age"
+                                        value={w.meatDays}
+                                        title="Meat withdrawal (days)"
+                                        onChange={(e) => updateWithdrawal(index, { meatDays: parseInt(e.target.value) || 0 })}
+                                    />
+                                    <button type="button" className="milk-session-btn" onClick={() => setWithdrawals(withdrawals.filter((_, i) => i !== index))} title="Remove medicine"><Trash2 size={14} /></button>
+                                </div>
+                            ))}
+                        </div>
+                        <div className="backup-actions">
+                            <Button variant="outline" icon={Plus} onClick={() => setWithdrawals([...withdrawals, { medicine: '', milkDays: 0, meatDays: 0 }])}>Add Medicine</Button>
+                            <Button onClick={handleSaveWithdrawals}>Save Periods</Button>
+                        </div>
+                        <p className="settings-note mt-4">
+                            Milk and meat days are filled in when the medicine is given in a vet record. Always check the product label.
+                        </p>
+                    </CardContent>
+                </Card>
+
                 <Card>
                     <CardHeader>
                         <CardTitle>About</CardTitle>
@@ -316,6 +986,16 @@ export function Settings() {
                 type="danger"
                 confirmText="Restore Data"
             />
+
+            <ConfirmDialog
+                isOpen={confirmImport}
+                onClose={() => setConfirmImport(false)}
+                onConfirm={confirmImportDataset}
+                title="Import Dataset"
+                message="Importing a dataset replaces all your current farm data with the contents of the file. A copy of the current database is kept as farmland.db.pre-import."
+                type="danger"
+                confirmText="Import Data"
+            />
         </div>
     );
 }
@@ -0,0 +1,52 @@
+/**
+ * Helpers for growth target curves from LivestockService.GetGrowthTargetCurves.
+ * These mirror curveForBreed and targetWeightAt in the Go service.
+ */
+
+/**
+ * Finds the curve for a breed of a species, falling back to the species'
+ * default curve. Curves without a species are cattle curves.
+ * @param {Array} curves - GrowthTargetCurve list
+ * @param {string} species
+ * @param {string} breed
+ * @returns {Object|null}
+ */
+export const curveForBreed = (curves, species, breed) => {
+    const wanted = species || 'cattle';
+    let fallback = null;
+    for (const curve of curves || []) {
+        if ((curve.species || 'cattle') !== wanted) continue;
+        if (curve.breed.toLowerCase() === (breed || '').toLowerCase()) return curve;
+        if (curve.breed.toLowerCase() === 'default') fallback = curve;
+    }
+    return fallback;
+};
+
+/**
+ * Interpolates the target weight at an age, or returns 0 outside the curve
+ * @param {Object|null} curve
+ * @param {number} ageMonths
+ * @returns {number}
+ */
+export const targetWeightAt = (curve, ageMonths) => {
+    const points = curve?.points || [];
+    if (points.length < 2 || ageMonths < points[0].ageMonths || ageMonths > points[points.length - 1].ageMonths) return 0;
+    for (let i = 1; i < points.length; i++) {
+        if (ageMonths <= points[i].ageMonths) {
+            const a = points[i - 1];
+            const b = points[i];
+            const span = b.ageMonths - a.ageMonths;
+            if (span <= 0) return b.weightKg;
+            return a.weightKg + (b.weightKg - a.weightKg) * (ageMonths - a.ageMonths) / span;
+        }
+    }
+    return 0;
+};
+
+/**
+ * Age in months between two YYYY-MM-DD dates, matching the service's 30.44-day month
+ * @param {string} from
+ * @param {string} to
+ * @returns {number}
+ */
+export const ageInMonths = (from, to) => (new Date(to) - new Date(from)) / (1000 * 60 * 60 * 24) / 30.44;
@@ -0,0 +1,37 @@
+/**
+ * Helpers for milk entered per milking session
+ */
+
+/**
+ * Turns a record's session quantities into form values keyed by session id
+ * @param {Array} sessions - [{ sessionId, liters }]
+ * @returns {Object} - { [sessionId]: '12.5' }
+ */
+export const sessionsToForm = (sessions) => {
+    const values = {};
+    (sessions || []).forEach(s => {
+        if (s.liters > 0) values[s.sessionId] = s.liters.toString();
+    });
+    return values;
+};
+
+/**
+ * Turns form values keyed by session id back into session quantities
+ * @param {Object} values - { [sessionId]: '12.5' }
+ * @returns {Array} - [{ sessionId, liters }] without empty sessions
+ */
+export const formToSessions = (values) => Object.entries(values || {})
+    .map(([id, value]) => ({ sessionId: parseInt(id), liters: parseFloat(value) || 0 }))
+    .filter(s => s.liters > 0);
+
+/**
+ * Sessions to offer for entry: the active ones, plus inactive ones that already hold milk
+ */
+export const visibleSessions = (sessions, values) =>
+    (sessions || []).filter(s => s.active || parseFloat(values?.[s.id]) > 0);
+
+/**
+ * Sums form values keyed by session id
+ */
+export const sumSessions = (values) =>
+    Object.values(values || {}).reduce((sum, value) => sum + (parseFloat(value) || 0), 0);
@@ -0,0 +1,45 @@
+/**
+ * Helpers for the species kept on the farm. Species, their animal types and
+ * gestation lengths come from LivestockService.GetSpeciesProfiles.
+ */
+
+/**
+ * Common breeds offered in the animal form for each species
+ */
+export const breedsBySpecies = {
+    cattle: ['Friesian', 'Ayrshire', 'Jersey', 'Guernsey', 'Sahiwal', 'Boran', 'Mixed'],
+    goat: ['Saanen', 'Toggenburg', 'Alpine', 'Galla', 'Boer', 'Mixed'],
+    sheep: ['Dorper', 'Merino', 'Red Maasai', 'Hampshire Down', 'Mixed'],
+    pig: ['Large White', 'Landrace', 'Duroc', 'Hampshire', 'Mixed'],
+};
+
+/**
+ * Formats an animal type for display, e.g. 'ewe_lamb' -> 'Ewe Lamb'
+ * @param {string} type
+ * @returns {string}
+ */
+export const formatType = (type) =>
+    (type || '').split('_').map(w => w.charAt(0).toUpperCase() + w.slice(1)).join(' ');
+
+/**
+ * Species that are recorded as individual animals rather than flocks
+ * @param {Array} profiles - species profiles
+ * @returns {Array}
+ */
+export const animalSpecies = (profiles) => (profiles || []).filter(p => !p.flockOnly);
+
+/**
+ * Describes a species summary's production for its metric
+ * @param {Object} summary - SpeciesSummary
+ * @returns {string}
+ */
+export const describeProduction = (summary) => {
+    switch (summary.metric) {
+        case 'milk':
+            return `${summary.producing} milking · ${summary.milkLiters.toFixed(1)} L this month`;
+        case 'eggs':
+            return `${summary.eggs} eggs this month · ${summary.layingRate.toFixed(1)}% lay`;
+        default:
+            return summary.averageAdg ? `${(summary.averageAdg * 1000).toFixed(0)} g/day gain` : 'No recent weighings';
+    }
+};
@@ -12,8 +12,16 @@ export function GetBreedingHistoryForAnimal(arg1:number):Promise<Array<main.Bree
 
 export function GetBreedingRecord(arg1:number):Promise<main.BreedingRecord>;
 
+export function GetDescendants(arg1:number):Promise<main.PedigreeNode>;
+
+export function GetInbreedingCoefficient(arg1:number):Promise<main.InbreedingResult>;
+
+export function GetPedigree(arg1:number,arg2:number):Promise<main.PedigreeNode>;
+
 export function GetPregnantAnimals():Promise<Array<main.BreedingRecord>>;
 
+export function GetProspectiveInbreeding(arg1:number,arg2:number):Promise<main.InbreedingResult>;
+
 export function RecordBirth(arg1:number,arg2:number,arg3:string):Promise<void>;
 
 export function UpdateBreedingRecord(arg1:main.BreedingRecord):Promise<void>;
@@ -22,10 +22,26 @@ export function GetBreedingRecord(arg1) {
   return window['go']['main']['BreedingService']['GetBreedingRecord'](arg1);
 }
 
+export function GetDescendants(arg1) {
+  return window['go']['main']['BreedingService']['GetDescendants'](arg1);
+}
+
+export function GetInbreedingCoefficient(arg1) {
+  return window['go']['main']['BreedingService']['GetInbreedingCoefficient'](arg1);
+}
+
+export function GetPedigree(arg1, arg2) {
+  return window['go']['main']['BreedingService']['GetPedigree'](arg1, arg2);
+}
+
 export function GetPregnantAnimals() {
   return window['go']['main']['BreedingService']['GetPregnantAnimals']();
 }
 
+export function GetProspectiveInbreeding(arg1, arg2) {
+  return window['go']['main']['BreedingService']['GetProspectiveInbreeding'](arg1, arg2);
+}
+
 export function RecordBirth(arg1, arg2, arg3) {
   return window['go']['main']['BreedingService']['RecordBirth'](arg1, arg2, arg3);
 }
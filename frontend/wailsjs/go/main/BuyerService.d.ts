@@ -0,0 +1,32 @@
+// Cynhyrchwyd y ffeil hon yn awtomatig. PEIDIWCH Â MODIWL
+// This file is automatically generated. DO NOT EDIT
+import {main} from '../models';
+import {context} from '../models';
+
+export function AddBuyer(arg1:main.Buyer):Promise<number>;
+
+export function AddBuyerPayment(arg1:main.BuyerPayment):Promise<number>;
+
+export function DeleteBuyer(arg1:number):Promise<void>;
+
+export function DeleteBuyerPayment(arg1:number):Promise<void>;
+
+export function ExportBuyerStatementPDF(arg1:number,arg2:string):Promise<main.ExportResult>;
+
+export function GetAgeingReport(arg1:string):Promise<main.AgeingReport>;
+
+export function GetBuyer(arg1:number):Promise<main.Buyer>;
+
+export function GetBuyerPayments(arg1:number,arg2:string,arg3:string):Promise<Array<main.BuyerPayment>>;
+
+export function GetBuyerStatement(arg1:number,arg2:string):Promise<main.BuyerStatement>;
+
+export function GetBuyers():Promise<Array<main.Buyer>>;
+
+export function GetPaymentMethods():Promise<Array<string>>;
+
+export function SetContext(arg1:context.Context):Promise<void>;
+
+export function UpdateBuyer(arg1:main.Buyer):Promise<void>;
+
+export function UpdateBuyerPayment(arg1:main.BuyerPayment):Promise<void>;
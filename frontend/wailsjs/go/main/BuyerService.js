@@ -0,0 +1,59 @@
+// @ts-check
+// Cynhyrchwyd y ffeil hon yn awtomatig. PEIDIWCH Â MODIWL
+// This file is automatically generated. DO NOT EDIT
+
+export function AddBuyer(arg1) {
+  return window['go']['main']['BuyerService']['AddBuyer'](arg1);
+}
+
+export function AddBuyerPayment(arg1) {
+  return window['go']['main']['BuyerService']['AddBuyerPayment'](arg1);
+}
+
+export function DeleteBuyer(arg1) {
+  return window['go']['main']['BuyerService']['DeleteBuyer'](arg1);
+}
+
+export function DeleteBuyerPayment(arg1) {
+  return window['go']['main']['BuyerService']['DeleteBuyerPayment'](arg1);
+}
+
+export function ExportBuyerStatementPDF(arg1, arg2) {
+  return window['go']['main']['BuyerService']['ExportBuyerStatementPDF'](arg1, arg2);
+}
+
+export function GetAgeingReport(arg1) {
+  return window['go']['main']['BuyerService']['GetAgeingReport'](arg1);
+}
+
+export function GetBuyer(arg1) {
+  return window['go']['main']['BuyerService']['GetBuyer'](arg1);
+}
+
+export function GetBuyerPayments(arg1, arg2, arg3) {
+  return window['go']['main']['BuyerService']['GetBuyerPayments'](arg1, arg2, arg3);
+}
+
+export function GetBuyerStatement(arg1, arg2) {
+  return window['go']['main']['BuyerService']['GetBuyerStatement'](arg1, arg2);
+}
+
+export function GetBuyers() {
+  return window['go']['main']['BuyerService']['GetBuyers']();
+}
+
+export function GetPaymentMethods() {
+  return window['go']['main']['BuyerService']['GetPaymentMethods']();
+}
+
+export function SetContext(arg1) {
+  return window['go']['main']['BuyerService']['SetContext'](arg1);
+}
+
+export function UpdateBuyer(arg1) {
+  return window['go']['main']['BuyerService']['UpdateBuyer'](arg1);
+}
+
+export function UpdateBuyerPayment(arg1) {
+  return window['go']['main']['BuyerService']['UpdateBuyerPayment'](arg1);
+}
@@ -0,0 +1,22 @@
+// Cynhyrchwyd y ffeil hon yn awtomatig. PEIDIWCH Â MODIWL
+// This file is automatically generated. DO NOT EDIT
+import {main} from '../models';
+import {context} from '../models';
+
+export function BuildRemindersICS():Promise<string>;
+
+export function ExportRemindersICS():Promise<main.ExportResult>;
+
+export function GetCalendarFeedStatus():Promise<main.CalendarFeedStatus>;
+
+export function RegenerateCalendarFeedToken():Promise<main.CalendarFeedStatus>;
+
+export function SetContext(arg1:context.Context):Promise<void>;
+
+export function Shutdown():Promise<void>;
+
+export function StartCalendarFeed(arg1:number):Promise<main.CalendarFeedStatus>;
+
+export function StartFeedIfEnabled():Promise<void>;
+
+export function StopCalendarFeed():Promise<void>;
@@ -0,0 +1,39 @@
+// @ts-check
+// Cynhyrchwyd y ffeil hon yn awtomatig. PEIDIWCH Â MODIWL
+// This file is automatically generated. DO NOT EDIT
+
+export function BuildRemindersICS() {
+  return window['go']['main']['CalendarService']['BuildRemindersICS']();
+}
+
+export function ExportRemindersICS() {
+  return window['go']['main']['CalendarService']['ExportRemindersICS']();
+}
+
+export function GetCalendarFeedStatus() {
+  return window['go']['main']['CalendarService']['GetCalendarFeedStatus']();
+}
+
+export function RegenerateCalendarFeedToken() {
+  return window['go']['main']['CalendarService']['RegenerateCalendarFeedToken']();
+}
+
+export function SetContext(arg1) {
+  return window['go']['main']['CalendarService']['SetContext'](arg1);
+}
+
+export function Shutdown() {
+  return window['go']['main']['CalendarService']['Shutdown']();
+}
+
+export function StartCalendarFeed(arg1) {
+  return window['go']['main']['CalendarService']['StartCalendarFeed'](arg1);
+}
+
+export function StartFeedIfEnabled() {
+  return window['go']['main']['CalendarService']['StartFeedIfEnabled']();
+}
+
+export function StopCalendarFeed() {
+  return window['go']['main']['CalendarService']['StopCalendarFeed']();
+}
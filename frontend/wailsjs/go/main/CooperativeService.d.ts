@@ -0,0 +1,35 @@
+// Cynhyrchwyd y ffeil hon yn awtomatig. PEIDIWCH Â MODIWL
+// This file is automatically generated. DO NOT EDIT
+import {main} from '../models';
+
+export function AddCoopDelivery(arg1:main.CoopDelivery):Promise<number>;
+
+export function AddCoopPayout(arg1:main.CoopPayout):Promise<number>;
+
+export function AddCooperative(arg1:main.Cooperative):Promise<number>;
+
+export function DeleteCoopDelivery(arg1:number):Promise<void>;
+
+export function DeleteCoopPayout(arg1:number):Promise<void>;
+
+export function DeleteCooperative(arg1:number):Promise<void>;
+
+export function GetCoopDeductionCategories():Promise<Array<string>>;
+
+export function GetCoopDeliveries(arg1:number,arg2:string,arg3:string):Promise<Array<main.CoopDelivery>>;
+
+export function GetCoopPayout(arg1:number):Promise<main.CoopPayout>;
+
+export function GetCoopPayouts(arg1:number):Promise<Array<main.CoopPayout>>;
+
+export function GetCoopReconciliation(arg1:number,arg2:string):Promise<main.CoopReconciliation>;
+
+export function GetCooperative(arg1:number):Promise<main.Cooperative>;
+
+export function GetCooperatives():Promise<Array<main.Cooperative>>;
+
+export function UpdateCoopDelivery(arg1:main.CoopDelivery):Promise<void>;
+
+export function UpdateCoopPayout(arg1:main.CoopPayout):Promise<void>;
+
+export function UpdateCooperative(arg1:main.Cooperative):Promise<void>;
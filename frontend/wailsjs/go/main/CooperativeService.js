@@ -0,0 +1,67 @@
+// @ts-check
+// Cynhyrchwyd y ffeil hon yn awtomatig. PEIDIWCH Â MODIWL
+// This file is automatically generated. DO NOT EDIT
+
+export function AddCoopDelivery(arg1) {
+  return window['go']['main']['CooperativeService']['AddCoopDelivery'](arg1);
+}
+
+export function AddCoopPayout(arg1) {
+  return window['go']['main']['CooperativeService']['AddCoopPayout'](arg1);
+}
+
+export function AddCooperative(arg1) {
+  return window['go']['main']['CooperativeService']['AddCooperative'](arg1);
+}
+
+export function DeleteCoopDelivery(arg1) {
+  return window['go']['main']['CooperativeService']['DeleteCoopDelivery'](arg1);
+}
+
+export function DeleteCoopPayout(arg1) {
+  return window['go']['main']['CooperativeService']['DeleteCoopPayout'](arg1);
+}
+
+export function DeleteCooperative(arg1) {
+  return window['go']['main']['CooperativeService']['DeleteCooperative'](arg1);
+}
+
+export function GetCoopDeductionCategories() {
+  return window['go']['main']['CooperativeService']['GetCoopDeductionCategories']();
+}
+
+export function GetCoopDeliveries(arg1, arg2, arg3) {
+  return window['go']['main']['CooperativeService']['GetCoopDeliveries'](arg1, arg2, arg3);
+}
+
+export function GetCoopPayout(arg1) {
+  return window['go']['main']['CooperativeService']['GetCoopPayout'](arg1);
+}
+
+export function GetCoopPayouts(arg1) {
+  return window['go']['main']['CooperativeService']['GetCoopPayouts'](arg1);
+}
+
+export function GetCoopReconciliation(arg1, arg2) {
+  return window['go']['main']['CooperativeService']['GetCoopReconciliation'](arg1, arg2);
+}
+
+export function GetCooperative(arg1) {
+  return window['go']['main']['CooperativeService']['GetCooperative'](arg1);
+}
+
+export function GetCooperatives() {
+  return window['go']['main']['CooperativeService']['GetCooperatives']();
+}
+
+export function UpdateCoopDelivery(arg1) {
+  return window['go']['main']['CooperativeService']['UpdateCoopDelivery'](arg1);
+}
+
+export function UpdateCoopPayout(arg1) {
+  return window['go']['main']['CooperativeService']['UpdateCoopPayout'](arg1);
+}
+
+export function UpdateCooperative(arg1) {
+  return window['go']['main']['CooperativeService']['UpdateCooperative'](arg1);
+}
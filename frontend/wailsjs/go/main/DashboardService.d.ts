@@ -4,6 +4,8 @@ import {main} from '../models';
 
 export function GetDashboardStats():Promise<main.DashboardStats>;
 
+export function GetLactationComparison():Promise<Array<main.Lactation>>;
+
 export function GetMilkProductionChart(arg1:string):Promise<Array<Record<string, any>>>;
 
 export function GetRecentActivity():Promise<Array<main.RecentActivity>>;
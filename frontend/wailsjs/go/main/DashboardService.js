@@ -6,6 +6,10 @@ export function GetDashboardStats() {
   return window['go']['main']['DashboardService']['GetDashboardStats']();
 }
 
+export function GetLactationComparison() {
+  return window['go']['main']['DashboardService']['GetLactationComparison']();
+}
+
 export function GetMilkProductionChart(arg1) {
   return window['go']['main']['DashboardService']['GetMilkProductionChart'](arg1);
 }
@@ -3,12 +3,36 @@
 import {main} from '../models';
 import {context} from '../models';
 
+export function ExportAnimalPassportPDF(arg1:number):Promise<main.ExportResult>;
+
+export function ExportAnimalPassportPackage(arg1:number):Promise<main.ExportResult>;
+
 export function ExportAnimalsCSV():Promise<main.ExportResult>;
 
+export function ExportBreedingRecordsCSV(arg1:string,arg2:string):Promise<main.ExportResult>;
+
+export function ExportCropRecordsCSV(arg1:string,arg2:string):Promise<main.ExportResult>;
+
+export function ExportDatasetJSON():Promise<main.ExportResult>;
+
+export function ExportFarmWorkbookXLSX(arg1:string,arg2:string):Promise<main.ExportResult>;
+
+export function ExportFeedRecordsCSV(arg1:string,arg2:string):Promise<main.ExportResult>;
+
 export function ExportFinancesCSV(arg1:string,arg2:string):Promise<main.ExportResult>;
 
+export function ExportInventoryCSV(arg1:string,arg2:string):Promise<main.ExportResult>;
+
 export function ExportMilkRecordsCSV(arg1:string,arg2:string):Promise<main.ExportResult>;
 
+export function ExportMonthlyReportPDF(arg1:main.MonthlyReportOptions):Promise<main.ExportResult>;
+
+export function ExportVetRecordsCSV(arg1:string,arg2:string):Promise<main.ExportResult>;
+
+export function GetAnimalPassport(arg1:number):Promise<main.AnimalPassport>;
+
 export function GetExportDirectory():Promise<string>;
 
+export function ImportDatasetJSON():Promise<main.DatasetImportResult>;
+
 export function SetContext(arg1:context.Context):Promise<void>;
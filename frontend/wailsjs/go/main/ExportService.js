@@ -2,22 +2,70 @@
 // Cynhyrchwyd y ffeil hon yn awtomatig. PEIDIWCH Â MODIWL
 // This file is automatically generated. DO NOT EDIT
 
+export function ExportAnimalPassportPDF(arg1) {
+  return window['go']['main']['ExportService']['ExportAnimalPassportPDF'](arg1);
+}
+
+export function ExportAnimalPassportPackage(arg1) {
+  return window['go']['main']['ExportService']['ExportAnimalPassportPackage'](arg1);
+}
+
 export function ExportAnimalsCSV() {
   return window['go']['main']['ExportService']['ExportAnimalsCSV']();
 }
 
+export function ExportBreedingRecordsCSV(arg1, arg2) {
+  return window['go']['main']['ExportService']['ExportBreedingRecordsCSV'](arg1, arg2);
+}
+
+export function ExportCropRecordsCSV(arg1, arg2) {
+  return window['go']['main']['ExportService']['ExportCropRecordsCSV'](arg1, arg2);
+}
+
+export function ExportDatasetJSON() {
+  return window['go']['main']['ExportService']['ExportDatasetJSON']();
+}
+
+export function ExportFarmWorkbookXLSX(arg1, arg2) {
+  return window['go']['main']['ExportService']['ExportFarmWorkbookXLSX'](arg1, arg2);
+}
+
+export function ExportFeedRecordsCSV(arg1, arg2) {
+  return window['go']['main']['ExportService']['ExportFeedRecordsCSV'](arg1, arg2);
+}
+
 export function ExportFinancesCSV(arg1, arg2) {
   return window['go']['main']['ExportService']['ExportFinancesCSV'](arg1, arg2);
 }
 
+export function ExportInventoryCSV(arg1, arg2) {
+  return window['go']['main']['ExportService']['ExportInventoryCSV'](arg1, arg2);
+}
+
 export function ExportMilkRecordsCSV(arg1, arg2) {
   return window['go']['main']['ExportService']['ExportMilkRecordsCSV'](arg1, arg2);
 }
 
+export function ExportMonthlyReportPDF(arg1) {
+  return window['go']['main']['ExportService']['ExportMonthlyReportPDF'](arg1);
+}
+
+export function ExportVetRecordsCSV(arg1, arg2) {
+  return window['go']['main']['ExportService']['ExportVetRecordsCSV'](arg1, arg2);
+}
+
+export function GetAnimalPassport(arg1) {
+  return window['go']['main']['ExportService']['GetAnimalPassport'](arg1);
+}
+
 export function GetExportDirectory() {
   return window['go']['main']['ExportService']['GetExportDirectory']();
 }
 
+export function ImportDatasetJSON() {
+  return window['go']['main']['ExportService']['ImportDatasetJSON']();
+}
+
 export function SetContext(arg1) {
   return window['go']['main']['ExportService']['SetContext'](arg1);
 }
@@ -18,4 +18,6 @@ export function GetMonthlyIncome():Promise<number>;
 
 export function GetTransactions(arg1:string,arg2:string,arg3:string,arg4:string):Promise<Array<main.Transaction>>;
 
+export function SetTransactionCleared(arg1:number,arg2:boolean,arg3:string):Promise<void>;
+
 export function UpdateTransaction(arg1:main.Transaction):Promise<void>;
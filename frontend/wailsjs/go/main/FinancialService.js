@@ -34,6 +34,10 @@ export function GetTransactions(arg1, arg2, arg3, arg4) {
   return window['go']['main']['FinancialService']['GetTransactions'](arg1, arg2, arg3, arg4);
 }
 
+export function SetTransactionCleared(arg1, arg2, arg3) {
+  return window['go']['main']['FinancialService']['SetTransactionCleared'](arg1, arg2, arg3);
+}
+
 export function UpdateTransaction(arg1) {
   return window['go']['main']['FinancialService']['UpdateTransaction'](arg1);
 }
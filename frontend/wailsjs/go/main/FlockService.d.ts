@@ -0,0 +1,19 @@
+// Cynhyrchwyd y ffeil hon yn awtomatig. PEIDIWCH Â MODIWL
+// This file is automatically generated. DO NOT EDIT
+import {main} from '../models';
+
+export function AddFlock(arg1:main.Flock):Promise<number>;
+
+export function DeleteFlock(arg1:number):Promise<void>;
+
+export function DeleteFlockRecord(arg1:number):Promise<void>;
+
+export function GetFlock(arg1:number):Promise<main.Flock>;
+
+export function GetFlockRecords(arg1:number,arg2:string,arg3:string):Promise<Array<main.FlockRecord>>;
+
+export function GetFlocks():Promise<Array<main.Flock>>;
+
+export function SaveFlockRecord(arg1:main.FlockRecord):Promise<number>;
+
+export function UpdateFlock(arg1:main.Flock):Promise<void>;
@@ -0,0 +1,35 @@
+// @ts-check
+// Cynhyrchwyd y ffeil hon yn awtomatig. PEIDIWCH Â MODIWL
+// This file is automatically generated. DO NOT EDIT
+
+export function AddFlock(arg1) {
+  return window['go']['main']['FlockService']['AddFlock'](arg1);
+}
+
+export function DeleteFlock(arg1) {
+  return window['go']['main']['FlockService']['DeleteFlock'](arg1);
+}
+
+export function DeleteFlockRecord(arg1) {
+  return window['go']['main']['FlockService']['DeleteFlockRecord'](arg1);
+}
+
+export function GetFlock(arg1) {
+  return window['go']['main']['FlockService']['GetFlock'](arg1);
+}
+
+export function GetFlockRecords(arg1, arg2, arg3) {
+  return window['go']['main']['FlockService']['GetFlockRecords'](arg1, arg2, arg3);
+}
+
+export function GetFlocks() {
+  return window['go']['main']['FlockService']['GetFlocks']();
+}
+
+export function SaveFlockRecord(arg1) {
+  return window['go']['main']['FlockService']['SaveFlockRecord'](arg1);
+}
+
+export function UpdateFlock(arg1) {
+  return window['go']['main']['FlockService']['UpdateFlock'](arg1);
+}
@@ -0,0 +1,25 @@
+// Cynhyrchwyd y ffeil hon yn awtomatig. PEIDIWCH Â MODIWL
+// This file is automatically generated. DO NOT EDIT
+import {main} from '../models';
+
+export function AddGroup(arg1:main.AnimalGroup):Promise<number>;
+
+export function DeleteGroup(arg1:number):Promise<void>;
+
+export function GetAnimalMovements(arg1:number):Promise<Array<main.AnimalMovement>>;
+
+export function GetGroup(arg1:number):Promise<main.AnimalGroup>;
+
+export function GetGroupAnimals(arg1:number):Promise<Array<main.Animal>>;
+
+export function GetGroupMilkTotals(arg1:string,arg2:string):Promise<Array<main.GroupMilkTotal>>;
+
+export function GetGroupMovements(arg1:number,arg2:string,arg3:string):Promise<Array<main.AnimalMovement>>;
+
+export function GetGroupSummary(arg1:number,arg2:string,arg3:string):Promise<main.GroupSummary>;
+
+export function GetGroups():Promise<Array<main.AnimalGroup>>;
+
+export function MoveAnimals(arg1:Array<number>,arg2:number,arg3:string,arg4:string):Promise<number>;
+
+export function UpdateGroup(arg1:main.AnimalGroup):Promise<void>;
@@ -0,0 +1,47 @@
+// @ts-check
+// Cynhyrchwyd y ffeil hon yn awtomatig. PEIDIWCH Â MODIWL
+// This file is automatically generated. DO NOT EDIT
+
+export function AddGroup(arg1) {
+  return window['go']['main']['GroupService']['AddGroup'](arg1);
+}
+
+export function DeleteGroup(arg1) {
+  return window['go']['main']['GroupService']['DeleteGroup'](arg1);
+}
+
+export function GetAnimalMovements(arg1) {
+  return window['go']['main']['GroupService']['GetAnimalMovements'](arg1);
+}
+
+export function GetGroup(arg1) {
+  return window['go']['main']['GroupService']['GetGroup'](arg1);
+}
+
+export function GetGroupAnimals(arg1) {
+  return window['go']['main']['GroupService']['GetGroupAnimals'](arg1);
+}
+
+export function GetGroupMilkTotals(arg1, arg2) {
+  return window['go']['main']['GroupService']['GetGroupMilkTotals'](arg1, arg2);
+}
+
+export function GetGroupMovements(arg1, arg2, arg3) {
+  return window['go']['main']['GroupService']['GetGroupMovements'](arg1, arg2, arg3);
+}
+
+export function GetGroupSummary(arg1, arg2, arg3) {
+  return window['go']['main']['GroupService']['GetGroupSummary'](arg1, arg2, arg3);
+}
+
+export function GetGroups() {
+  return window['go']['main']['GroupService']['GetGroups']();
+}
+
+export function MoveAnimals(arg1, arg2, arg3, arg4) {
+  return window['go']['main']['GroupService']['MoveAnimals'](arg1, arg2, arg3, arg4);
+}
+
+export function UpdateGroup(arg1) {
+  return window['go']['main']['GroupService']['UpdateGroup'](arg1);
+}
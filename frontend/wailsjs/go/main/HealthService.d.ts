@@ -6,8 +6,14 @@ export function AddVetRecord(arg1:main.VetRecord):Promise<number>;
 
 export function DeleteVetRecord(arg1:number):Promise<void>;
 
+export function GetActiveWithdrawals():Promise<Array<main.AnimalWithdrawal>>;
+
 export function GetCommonMedicines():Promise<Array<string>>;
 
+export function GetMedicineWithdrawals():Promise<Array<main.MedicineWithdrawal>>;
+
+export function GetMilkWithdrawal(arg1:number,arg2:string):Promise<main.AnimalWithdrawal>;
+
 export function GetPendingVetVisitsCount():Promise<number>;
 
 export function GetRecordTypes():Promise<Array<string>>;
@@ -16,4 +22,6 @@ export function GetUpcomingVaccinations():Promise<Array<main.VetRecord>>;
 
 export function GetVetRecords(arg1:number):Promise<Array<main.VetRecord>>;
 
+export function SaveMedicineWithdrawals(arg1:Array<main.MedicineWithdrawal>):Promise<Array<main.MedicineWithdrawal>>;
+
 export function UpdateVetRecord(arg1:main.VetRecord):Promise<void>;
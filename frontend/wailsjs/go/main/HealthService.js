@@ -10,10 +10,22 @@ export function DeleteVetRecord(arg1) {
   return window['go']['main']['HealthService']['DeleteVetRecord'](arg1);
 }
 
+export function GetActiveWithdrawals() {
+  return window['go']['main']['HealthService']['GetActiveWithdrawals']();
+}
+
 export function GetCommonMedicines() {
   return window['go']['main']['HealthService']['GetCommonMedicines']();
 }
 
+export function GetMedicineWithdrawals() {
+  return window['go']['main']['HealthService']['GetMedicineWithdrawals']();
+}
+
+export function GetMilkWithdrawal(arg1, arg2) {
+  return window['go']['main']['HealthService']['GetMilkWithdrawal'](arg1, arg2);
+}
+
 export function GetPendingVetVisitsCount() {
   return window['go']['main']['HealthService']['GetPendingVetVisitsCount']();
 }
@@ -30,6 +42,10 @@ export function GetVetRecords(arg1) {
   return window['go']['main']['HealthService']['GetVetRecords'](arg1);
 }
 
+export function SaveMedicineWithdrawals(arg1) {
+  return window['go']['main']['HealthService']['SaveMedicineWithdrawals'](arg1);
+}
+
 export function UpdateVetRecord(arg1) {
   return window['go']['main']['HealthService']['UpdateVetRecord'](arg1);
 }
@@ -4,40 +4,144 @@ import {main} from '../models';
 
 export function AddAnimal(arg1:main.Animal):Promise<number>;
 
+export function AddMilkQualityTest(arg1:main.MilkQualityTest):Promise<number>;
+
 export function AddMilkRecord(arg1:main.MilkRecord):Promise<number>;
 
 export function AddMilkSale(arg1:main.MilkSale):Promise<number>;
 
+export function AddMilkUsage(arg1:main.MilkUsage):Promise<number>;
+
+export function AddWeightRecord(arg1:main.WeightRecord):Promise<number>;
+
+export function ApplyLifeStageTransitions():Promise<Array<main.AnimalTypeChange>>;
+
 export function DeleteAnimal(arg1:number):Promise<void>;
 
+export function DeleteMilkQualityTest(arg1:number):Promise<void>;
+
 export function DeleteMilkRecord(arg1:number):Promise<void>;
 
 export function DeleteMilkSale(arg1:number):Promise<void>;
 
+export function DeleteMilkUsage(arg1:number):Promise<void>;
+
+export function DeleteMilkingSession(arg1:number):Promise<void>;
+
+export function DeleteWeightRecord(arg1:number):Promise<void>;
+
+export function DisposeAnimal(arg1:main.AnimalDisposal):Promise<number>;
+
 export function GetAllAnimals():Promise<Array<main.Animal>>;
 
 export function GetAnimal(arg1:number):Promise<main.Animal>;
 
+export function GetAnimalTypeHistory(arg1:number):Promise<Array<main.AnimalTypeChange>>;
+
+export function GetAnimalsBelowTarget():Promise<Array<main.GrowthAnalysis>>;
+
+export function GetCullReasons():Promise<Array<string>>;
+
+export function GetCurrentLactations():Promise<Array<main.Lactation>>;
+
 export function GetDairyCows():Promise<Array<main.Animal>>;
 
+export function GetDeathCauses():Promise<Array<string>>;
+
+export function GetDisposalReport(arg1:string,arg2:string):Promise<main.DisposalReport>;
+
+export function GetDisposals(arg1:string,arg2:string,arg3:string):Promise<Array<main.AnimalDisposal>>;
+
 export function GetFemaleAnimals():Promise<Array<main.Animal>>;
 
+export function GetGrowthAnalysis(arg1:number):Promise<main.GrowthAnalysis>;
+
+export function GetGrowthTargetCurves():Promise<Array<main.GrowthTargetCurve>>;
+
+export function GetHerdRanking():Promise<main.HerdRanking>;
+
+export function GetLactations(arg1:number):Promise<Array<main.Lactation>>;
+
+export function GetLifeStageSettings():Promise<main.LifeStageSettings>;
+
 export function GetMaleAnimals():Promise<Array<main.Animal>>;
 
+export function GetMilkAnomalies():Promise<Array<main.MilkAnomaly>>;
+
+export function GetMilkAnomalySettings():Promise<main.MilkAnomalySettings>;
+
+export function GetMilkBalance(arg1:string,arg2:string,arg3:string):Promise<main.MilkBalanceReport>;
+
+export function GetMilkEntrySheet(arg1:string):Promise<Array<main.MilkEntry>>;
+
+export function GetMilkQualityPricing():Promise<main.MilkQualityPricing>;
+
+export function GetMilkQualityTests(arg1:number,arg2:string,arg3:string):Promise<Array<main.MilkQualityTest>>;
+
+export function GetMilkQualityTrend(arg1:number,arg2:number):Promise<Array<main.MilkQualityTrendPoint>>;
+
 export function GetMilkRecordByAnimalAndDate(arg1:number,arg2:string):Promise<main.MilkRecord>;
 
 export function GetMilkRecords(arg1:number,arg2:string,arg3:string):Promise<Array<main.MilkRecord>>;
 
+export function GetMilkSaleWarnings(arg1:string):Promise<Array<string>>;
+
 export function GetMilkSales(arg1:string,arg2:string):Promise<Array<main.MilkSale>>;
 
+export function GetMilkTotalsBySession(arg1:string,arg2:string):Promise<Array<main.MilkSessionYield>>;
+
+export function GetMilkUsages(arg1:string,arg2:string):Promise<Array<main.MilkUsage>>;
+
+export function GetMilkUseTypes():Promise<Array<string>>;
+
+export function GetMilkingSessions():Promise<Array<main.MilkingSession>>;
+
 export function GetMonthMilkTotal():Promise<number>;
 
 export function GetOffspring(arg1:number):Promise<Array<main.Animal>>;
 
+export function GetQualityAdjustedPrice(arg1:number,arg2:number,arg3:number,arg4:number):Promise<main.QualityPrice>;
+
+export function GetSCCAlertThreshold():Promise<number>;
+
+export function GetSpeciesProfiles():Promise<Array<main.SpeciesProfile>>;
+
+export function GetSpeciesSummaries():Promise<Array<main.SpeciesSummary>>;
+
+export function GetTodayMilkBySession():Promise<Array<main.MilkSessionYield>>;
+
 export function GetTodayMilkTotal():Promise<number>;
 
+export function GetWeightRecords(arg1:number,arg2:string,arg3:string):Promise<Array<main.WeightRecord>>;
+
+export function ReverseDisposal(arg1:number):Promise<void>;
+
+export function SaveGrowthTargetCurves(arg1:Array<main.GrowthTargetCurve>):Promise<void>;
+
+export function SaveLifeStageSettings(arg1:main.LifeStageSettings):Promise<main.LifeStageSettings>;
+
+export function SaveMilkAnomalySettings(arg1:main.MilkAnomalySettings):Promise<main.MilkAnomalySettings>;
+
+export function SaveMilkEntries(arg1:string,arg2:Array<main.MilkEntry>):Promise<Array<main.MilkEntryResult>>;
+
+export function SaveMilkQualityPricing(arg1:main.MilkQualityPricing):Promise<void>;
+
+export function SaveMilkingSessions(arg1:Array<main.MilkingSession>):Promise<Array<main.MilkingSession>>;
+
+export function SaveSCCAlertThreshold(arg1:number):Promise<void>;
+
+export function SetMilkSaleQuality(arg1:number,arg2:number,arg3:number,arg4:number):Promise<void>;
+
+export function StartLifeStageWorker():Promise<void>;
+
 export function UpdateAnimal(arg1:main.Animal):Promise<void>;
 
+export function UpdateMilkQualityTest(arg1:main.MilkQualityTest):Promise<void>;
+
 export function UpdateMilkRecord(arg1:main.MilkRecord):Promise<void>;
 
 export function UpdateMilkSale(arg1:main.MilkSale):Promise<void>;
+
+export function UpdateMilkUsage(arg1:main.MilkUsage):Promise<void>;
+
+export function UpdateWeightRecord(arg1:main.WeightRecord):Promise<void>;
@@ -6,6 +6,10 @@ export function AddAnimal(arg1) {
   return window['go']['main']['LivestockService']['AddAnimal'](arg1);
 }
 
+export function AddMilkQualityTest(arg1) {
+  return window['go']['main']['LivestockService']['AddMilkQualityTest'](arg1);
+}
+
 export function AddMilkRecord(arg1) {
   return window['go']['main']['LivestockService']['AddMilkRecord'](arg1);
 }
@@ -14,10 +18,26 @@ export function AddMilkSale(arg1) {
   return window['go']['main']['LivestockService']['AddMilkSale'](arg1);
 }
 
+export function AddMilkUsage(arg1) {
+  return window['go']['main']['LivestockService']['AddMilkUsage'](arg1);
+}
+
+export function AddWeightRecord(arg1) {
+  return window['go']['main']['LivestockService']['AddWeightRecord'](arg1);
+}
+
+export function ApplyLifeStageTransitions() {
+  return window['go']['main']['LivestockService']['ApplyLifeStageTransitions']();
+}
+
 export function DeleteAnimal(arg1) {
   return window['go']['main']['LivestockService']['DeleteAnimal'](arg1);
 }
 
+export function DeleteMilkQualityTest(arg1) {
+  return window['go']['main']['LivestockService']['DeleteMilkQualityTest'](arg1);
+}
+
 export function DeleteMilkRecord(arg1) {
   return window['go']['main']['LivestockService']['DeleteMilkRecord'](arg1);
 }
@@ -26,6 +46,22 @@ export function DeleteMilkSale(arg1) {
   return window['go']['main']['LivestockService']['DeleteMilkSale'](arg1);
 }
 
+export function DeleteMilkUsage(arg1) {
+  return window['go']['main']['LivestockService']['DeleteMilkUsage'](arg1);
+}
+
+export function DeleteMilkingSession(arg1) {
+  return window['go']['main']['LivestockService']['DeleteMilkingSession'](arg1);
+}
+
+export function DeleteWeightRecord(arg1) {
+  return window['go']['main']['LivestockService']['DeleteWeightRecord'](arg1);
+}
+
+export function DisposeAnimal(arg1) {
+  return window['go']['main']['LivestockService']['DisposeAnimal'](arg1);
+}
+
 export function GetAllAnimals() {
   return window['go']['main']['LivestockService']['GetAllAnimals']();
 }
@@ -34,18 +70,94 @@ export function GetAnimal(arg1) {
   return window['go']['main']['LivestockService']['GetAnimal'](arg1);
 }
 
+export function GetAnimalTypeHistory(arg1) {
+  return window['go']['main']['LivestockService']['GetAnimalTypeHistory'](arg1);
+}
+
+export function GetAnimalsBelowTarget() {
+  return window['go']['main']['LivestockService']['GetAnimalsBelowTarget']();
+}
+
+export function GetCullReasons() {
+  return window['go']['main']['LivestockService']['GetCullReasons']();
+}
+
+export function GetCurrentLactations() {
+  return window['go']['main']['LivestockService']['GetCurrentLactations']();
+}
+
 export function GetDairyCows() {
   return window['go']['main']['LivestockService']['GetDairyCows']();
 }
 
+export function GetDeathCauses() {
+  return window['go']['main']['LivestockService']['GetDeathCauses']();
+}
+
+export function GetDisposalReport(arg1, arg2) {
+  return window['go']['main']['LivestockService']['GetDisposalReport'](arg1, arg2);
+}
+
+export function GetDisposals(arg1, arg2, arg3) {
+  return window['go']['main']['LivestockService']['GetDisposals'](arg1, arg2, arg3);
+}
+
 export function GetFemaleAnimals() {
   return window['go']['main']['LivestockService']['GetFemaleAnimals']();
 }
 
+export function GetGrowthAnalysis(arg1) {
+  return window['go']['main']['LivestockService']['GetGrowthAnalysis'](arg1);
+}
+
+export function GetGrowthTargetCurves() {
+  return window['go']['main']['LivestockService']['GetGrowthTargetCurves']();
+}
+
+export function GetHerdRanking() {
+  return window['go']['main']['LivestockService']['GetHerdRanking']();
+}
+
+export function GetLactations(arg1) {
+  return window['go']['main']['LivestockService']['GetLactations'](arg1);
+}
+
+export function GetLifeStageSettings() {
+  return window['go']['main']['LivestockService']['GetLifeStageSettings']();
+}
+
 export function GetMaleAnimals() {
   return window['go']['main']['LivestockService']['GetMaleAnimals']();
 }
 
+export function GetMilkAnomalies() {
+  return window['go']['main']['LivestockService']['GetMilkAnomalies']();
+}
+
+export function GetMilkAnomalySettings() {
+  return window['go']['main']['LivestockService']['GetMilkAnomalySettings']();
+}
+
+export function GetMilkBalance(arg1, arg2, arg3) {
+  return window['go']['main']['LivestockService']['GetMilkBalance'](arg1, arg2, arg3);
+}
+
+export function GetMilkEntrySheet(arg1) {
+  return window['go']['main']['LivestockService']['GetMilkEntrySheet'](arg1);
+}
+
+export function GetMilkQualityPricing() {
+  return window['go']['main']['LivestockService']['GetMilkQualityPricing']();
+}
+
+export function GetMilkQualityTests(arg1, arg2, arg3) {
+  return window['go']['main']['LivestockService']['GetMilkQualityTests'](arg1, arg2, arg3);
+}
+
+export function GetMilkQualityTrend(arg1, arg2) {
+  return window['go']['main']['LivestockService']['GetMilkQualityTrend'](arg1, arg2);
+}
+
 export function GetMilkRecordByAnimalAndDate(arg1, arg2) {
   return window['go']['main']['LivestockService']['GetMilkRecordByAnimalAndDate'](arg1, arg2);
 }
@@ -54,10 +166,30 @@ export function GetMilkRecords(arg1, arg2, arg3) {
   return window['go']['main']['LivestockService']['GetMilkRecords'](arg1, arg2, arg3);
 }
 
+export function GetMilkSaleWarnings(arg1) {
+  return window['go']['main']['LivestockService']['GetMilkSaleWarnings'](arg1);
+}
+
 export function GetMilkSales(arg1, arg2) {
   return window['go']['main']['LivestockService']['GetMilkSales'](arg1, arg2);
 }
 
+export function GetMilkTotalsBySession(arg1, arg2) {
+  return window['go']['main']['LivestockService']['GetMilkTotalsBySession'](arg1, arg2);
+}
+
+export function GetMilkUsages(arg1, arg2) {
+  return window['go']['main']['LivestockService']['GetMilkUsages'](arg1, arg2);
+}
+
+export function GetMilkUseTypes() {
+  return window['go']['main']['LivestockService']['GetMilkUseTypes']();
+}
+
+export function GetMilkingSessions() {
+  return window['go']['main']['LivestockService']['GetMilkingSessions']();
+}
+
 export function GetMonthMilkTotal() {
   return window['go']['main']['LivestockService']['GetMonthMilkTotal']();
 }
@@ -66,14 +198,82 @@ export function GetOffspring(arg1) {
   return window['go']['main']['LivestockService']['GetOffspring'](arg1);
 }
 
+export function GetQualityAdjustedPrice(arg1, arg2, arg3, arg4) {
+  return window['go']['main']['LivestockService']['GetQualityAdjustedPrice'](arg1, arg2, arg3, arg4);
+}
+
+export function GetSCCAlertThreshold() {
+  return window['go']['main']['LivestockService']['GetSCCAlertThreshold']();
+}
+
+export function GetSpeciesProfiles() {
+  return window['go']['main']['LivestockService']['GetSpeciesProfiles']();
+}
+
+export function GetSpeciesSummaries() {
+  return window['go']['main']['LivestockService']['GetSpeciesSummaries']();
+}
+
+export function GetTodayMilkBySession() {
+  return window['go']['main']['LivestockService']['GetTodayMilkBySession']();
+}
+
 export function GetTodayMilkTotal() {
   return window['go']['main']['LivestockService']['GetTodayMilkTotal']();
 }
 
+export function GetWeightRecords(arg1, arg2, arg3) {
+  return window['go']['main']['LivestockService']['GetWeightRecords'](arg1, arg2, arg3);
+}
+
+export function ReverseDisposal(arg1) {
+  return window['go']['main']['LivestockService']['ReverseDisposal'](arg1);
+}
+
+export function SaveGrowthTargetCurves(arg1) {
+  return window['go']['main']['LivestockService']['SaveGrowthTargetCurves'](arg1);
+}
+
+export function SaveLifeStageSettings(arg1) {
+  return window['go']['main']['LivestockService']['SaveLifeStageSettings'](arg1);
+}
+
+export function SaveMilkAnomalySettings(arg1) {
+  return window['go']['main']['LivestockService']['SaveMilkAnomalySettings'](arg1);
+}
+
+export function SaveMilkEntries(arg1, arg2) {
+  return window['go']['main']['LivestockService']['SaveMilkEntries'](arg1, arg2);
+}
+
+export function SaveMilkQualityPricing(arg1) {
+  return window['go']['main']['LivestockService']['SaveMilkQualityPricing'](arg1);
+}
+
+export function SaveMilkingSessions(arg1) {
+  return window['go']['main']['LivestockService']['SaveMilkingSessions'](arg1);
+}
+
+export function SaveSCCAlertThreshold(arg1) {
+  return window['go']['main']['LivestockService']['SaveSCCAlertThreshold'](arg1);
+}
+
+export function SetMilkSaleQuality(arg1, arg2, arg3, arg4) {
+  return window['go']['main']['LivestockService']['SetMilkSaleQuality'](arg1, arg2, arg3, arg4);
+}
+
+export function StartLifeStageWorker() {
+  return window['go']['main']['LivestockService']['StartLifeStageWorker']();
+}
+
 export function UpdateAnimal(arg1) {
   return window['go']['main']['LivestockService']['UpdateAnimal'](arg1);
 }
 
+export function UpdateMilkQualityTest(arg1) {
+  return window['go']['main']['LivestockService']['UpdateMilkQualityTest'](arg1);
+}
+
 export function UpdateMilkRecord(arg1) {
   return window['go']['main']['LivestockService']['UpdateMilkRecord'](arg1);
 }
@@ -81,3 +281,11 @@ export function UpdateMilkRecord(arg1) {
 export function UpdateMilkSale(arg1) {
   return window['go']['main']['LivestockService']['UpdateMilkSale'](arg1);
 }
+
+export function UpdateMilkUsage(arg1) {
+  return window['go']['main']['LivestockService']['UpdateMilkUsage'](arg1);
+}
+
+export function UpdateWeightRecord(arg1) {
+  return window['go']['main']['LivestockService']['UpdateWeightRecord'](arg1);
+}
@@ -7,8 +7,16 @@ export function CheckAndNotify():Promise<void>;
 
 export function GetAllNotifications():Promise<Array<main.Reminder>>;
 
+export function GetGrowthAlerts():Promise<Array<main.Reminder>>;
+
 export function GetLowStockAlerts():Promise<Array<main.Reminder>>;
 
+export function GetMilkAnomalyAlerts():Promise<Array<main.Reminder>>;
+
+export function GetRemindersWithin(arg1:number):Promise<Array<main.Reminder>>;
+
+export function GetSCCAlerts():Promise<Array<main.Reminder>>;
+
 export function GetUpcomingReminders():Promise<Array<main.Reminder>>;
 
 export function Notify(arg1:string,arg2:string):Promise<void>;
@@ -10,10 +10,26 @@ export function GetAllNotifications() {
   return window['go']['main']['NotificationService']['GetAllNotifications']();
 }
 
+export function GetGrowthAlerts() {
+  return window['go']['main']['NotificationService']['GetGrowthAlerts']();
+}
+
 export function GetLowStockAlerts() {
   return window['go']['main']['NotificationService']['GetLowStockAlerts']();
 }
 
+export function GetMilkAnomalyAlerts() {
+  return window['go']['main']['NotificationService']['GetMilkAnomalyAlerts']();
+}
+
+export function GetRemindersWithin(arg1) {
+  return window['go']['main']['NotificationService']['GetRemindersWithin'](arg1);
+}
+
+export function GetSCCAlerts() {
+  return window['go']['main']['NotificationService']['GetSCCAlerts']();
+}
+
 export function GetUpcomingReminders() {
   return window['go']['main']['NotificationService']['GetUpcomingReminders']();
 }
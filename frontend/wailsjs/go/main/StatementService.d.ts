@@ -0,0 +1,36 @@
+// Cynhyrchwyd y ffeil hon yn awtomatig. PEIDIWCH Â MODIWL
+// This file is automatically generated. DO NOT EDIT
+import {main} from '../models';
+import {context} from '../models';
+
+export function ConfirmStatementMatch(arg1:number,arg2:number):Promise<void>;
+
+export function CreateTransactionFromStatementLine(arg1:number,arg2:string):Promise<number>;
+
+export function GetBankCSVLayout():Promise<main.BankCSVLayout>;
+
+export function GetMatchProposals(arg1:number):Promise<Array<main.MatchProposal>>;
+
+export function GetReconciliationSummary():Promise<main.ReconciliationSummary>;
+
+export function GetReviewQueue():Promise<Array<main.StatementLine>>;
+
+export function GetStatementLines(arg1:string,arg2:string):Promise<Array<main.StatementLine>>;
+
+export function IgnoreStatementLine(arg1:number):Promise<void>;
+
+export function ImportBankStatement():Promise<main.StatementImportResult>;
+
+export function ImportBankStatementFile(arg1:string):Promise<main.StatementImportResult>;
+
+export function ImportMpesaStatement():Promise<main.StatementImportResult>;
+
+export function ImportMpesaStatementFile(arg1:string):Promise<main.StatementImportResult>;
+
+export function MatchStatementLineToSale(arg1:number,arg2:number):Promise<void>;
+
+export function SaveBankCSVLayout(arg1:main.BankCSVLayout):Promise<void>;
+
+export function SetContext(arg1:context.Context):Promise<void>;
+
+export function SplitStatementLine(arg1:number,arg2:Array<main.StatementSplitPart>):Promise<void>;
@@ -0,0 +1,67 @@
+// @ts-check
+// Cynhyrchwyd y ffeil hon yn awtomatig. PEIDIWCH Â MODIWL
+// This file is automatically generated. DO NOT EDIT
+
+export function ConfirmStatementMatch(arg1, arg2) {
+  return window['go']['main']['StatementService']['ConfirmStatementMatch'](arg1, arg2);
+}
+
+export function CreateTransactionFromStatementLine(arg1, arg2) {
+  return window['go']['main']['StatementService']['CreateTransactionFromStatementLine'](arg1, arg2);
+}
+
+export function GetBankCSVLayout() {
+  return window['go']['main']['StatementService']['GetBankCSVLayout']();
+}
+
+export function GetMatchProposals(arg1) {
+  return window['go']['main']['StatementService']['GetMatchProposals'](arg1);
+}
+
+export function GetReconciliationSummary() {
+  return window['go']['main']['StatementService']['GetReconciliationSummary']();
+}
+
+export function GetReviewQueue() {
+  return window['go']['main']['StatementService']['GetReviewQueue']();
+}
+
+export function GetStatementLines(arg1, arg2) {
+  return window['go']['main']['StatementService']['GetStatementLines'](arg1, arg2);
+}
+
+export function IgnoreStatementLine(arg1) {
+  return window['go']['main']['StatementService']['IgnoreStatementLine'](arg1);
+}
+
+export function ImportBankStatement() {
+  return window['go']['main']['StatementService']['ImportBankStatement']();
+}
+
+export function ImportBankStatementFile(arg1) {
+  return window['go']['main']['StatementService']['ImportBankStatementFile'](arg1);
+}
+
+export function ImportMpesaStatement() {
+  return window['go']['main']['StatementService']['ImportMpesaStatement']();
+}
+
+export function ImportMpesaStatementFile(arg1) {
+  return window['go']['main']['StatementService']['ImportMpesaStatementFile'](arg1);
+}
+
+export function MatchStatementLineToSale(arg1, arg2) {
+  return window['go']['main']['StatementService']['MatchStatementLineToSale'](arg1, arg2);
+}
+
+export function SaveBankCSVLayout(arg1) {
+  return window['go']['main']['StatementService']['SaveBankCSVLayout'](arg1);
+}
+
+export function SetContext(arg1) {
+  return window['go']['main']['StatementService']['SetContext'](arg1);
+}
+
+export function SplitStatementLine(arg1, arg2) {
+  return window['go']['main']['StatementService']['SplitStatementLine'](arg1, arg2);
+}
@@ -1,9 +1,72 @@
 export namespace main {
 	
+	export class BuyerAgeing {
+	    buyerId: number;
+	    buyerName: string;
+	    current: number;
+	    days31To60: number;
+	    days61To90: number;
+	    over90: number;
+	    credit: number;
+	    outstanding: number;
+	    oldestSale?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new BuyerAgeing(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.buyerId = source["buyerId"];
+	        this.buyerName = source["buyerName"];
+	        this.current = source["current"];
+	        this.days31To60 = source["days31To60"];
+	        this.days61To90 = source["days61To90"];
+	        this.over90 = source["over90"];
+	        this.credit = source["credit"];
+	        this.outstanding = source["outstanding"];
+	        this.oldestSale = source["oldestSale"];
+	    }
+	}
+	export class AgeingReport {
+	    asOf: string;
+	    buyers: BuyerAgeing[];
+	    totals: BuyerAgeing;
+	
+	    static createFrom(source: any = {}) {
+	        return new AgeingReport(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.asOf = source["asOf"];
+	        this.buyers = this.convertValues(source["buyers"], BuyerAgeing);
+	        this.totals = this.convertValues(source["totals"], BuyerAgeing);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
 	export class Animal {
 	    id: number;
 	    tagNumber: string;
 	    name: string;
+	    species: string;
 	    type: string;
 	    breed: string;
 	    dateOfBirth: string;
@@ -18,6 +81,15 @@ export namespace main {
 	    createdAt: any;
 	    // Go type: time
 	    updatedAt: any;
+	    acquisitionType: string;
+	    seller: string;
+	    purchaseDate: string;
+	    purchasePrice: number;
+	    transportCost: number;
+	    quarantineDays: number;
+	    quarantineEndDate: string;
+	    groupId?: number;
+	    groupName?: string;
 	
 	    static createFrom(source: any = {}) {
 	        return new Animal(source);
@@ -28,6 +100,7 @@ export namespace main {
 	        this.id = source["id"];
 	        this.tagNumber = source["tagNumber"];
 	        this.name = source["name"];
+	        this.species = source["species"];
 	        this.type = source["type"];
 	        this.breed = source["breed"];
 	        this.dateOfBirth = source["dateOfBirth"];
@@ -40,6 +113,15 @@ export namespace main {
 	        this.notes = source["notes"];
 	        this.createdAt = this.convertValues(source["createdAt"], null);
 	        this.updatedAt = this.convertValues(source["updatedAt"], null);
+	        this.acquisitionType = source["acquisitionType"];
+	        this.seller = source["seller"];
+	        this.purchaseDate = source["purchaseDate"];
+	        this.purchasePrice = source["purchasePrice"];
+	        this.transportCost = source["transportCost"];
+	        this.quarantineDays = source["quarantineDays"];
+	        this.quarantineEndDate = source["quarantineEndDate"];
+	        this.groupId = source["groupId"];
+	        this.groupName = source["groupName"];
 	    }
 	
 		convertValues(a: any, classs: any, asMap: boolean = false): any {
@@ -60,59 +142,39 @@ export namespace main {
 		    return a;
 		}
 	}
-	export class BackupInfo {
-	    path: string;
-	    size: number;
-	    timestamp: string;
-	
-	    static createFrom(source: any = {}) {
-	        return new BackupInfo(source);
-	    }
-	
-	    constructor(source: any = {}) {
-	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.path = source["path"];
-	        this.size = source["size"];
-	        this.timestamp = source["timestamp"];
-	    }
-	}
-	export class BreedingRecord {
+	export class AnimalDisposal {
 	    id: number;
-	    femaleId: number;
-	    femaleName?: string;
-	    maleId?: number;
-	    maleName?: string;
-	    breedingDate: string;
-	    breedingMethod: string;
-	    sireSource: string;
-	    expectedDueDate: string;
-	    actualBirthDate: string;
-	    offspringId?: number;
-	    offspringName?: string;
-	    pregnancyStatus: string;
+	    animalId: number;
+	    animalName?: string;
+	    tagNumber?: string;
+	    disposalType: string;
+	    date: string;
+	    buyerName: string;
+	    price: number;
+	    causeOfDeath: string;
+	    cullReason: string;
+	    transactionId?: number;
 	    notes: string;
 	    // Go type: time
 	    createdAt: any;
 	
 	    static createFrom(source: any = {}) {
-	        return new BreedingRecord(source);
+	        return new AnimalDisposal(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
 	        this.id = source["id"];
-	        this.femaleId = source["femaleId"];
-	        this.femaleName = source["femaleName"];
-	        this.maleId = source["maleId"];
-	        this.maleName = source["maleName"];
-	        this.breedingDate = source["breedingDate"];
-	        this.breedingMethod = source["breedingMethod"];
-	        this.sireSource = source["sireSource"];
-	        this.expectedDueDate = source["expectedDueDate"];
-	        this.actualBirthDate = source["actualBirthDate"];
-	        this.offspringId = source["offspringId"];
-	        this.offspringName = source["offspringName"];
-	        this.pregnancyStatus = source["pregnancyStatus"];
+	        this.animalId = source["animalId"];
+	        this.animalName = source["animalName"];
+	        this.tagNumber = source["tagNumber"];
+	        this.disposalType = source["disposalType"];
+	        this.date = source["date"];
+	        this.buyerName = source["buyerName"];
+	        this.price = source["price"];
+	        this.causeOfDeath = source["causeOfDeath"];
+	        this.cullReason = source["cullReason"];
+	        this.transactionId = source["transactionId"];
 	        this.notes = source["notes"];
 	        this.createdAt = this.convertValues(source["createdAt"], null);
 	    }
@@ -135,46 +197,28 @@ export namespace main {
 		    return a;
 		}
 	}
-	export class CropRecord {
+	export class AnimalGroup {
 	    id: number;
-	    fieldId: number;
-	    fieldName?: string;
-	    cropType: string;
-	    variety: string;
-	    plantingDate: string;
-	    expectedHarvest: string;
-	    actualHarvest: string;
-	    seedCost: number;
-	    fertilizerCost: number;
-	    laborCost: number;
-	    yieldKg: number;
-	    yieldValue: number;
-	    status: string;
-	    notes: string;
+	    name: string;
+	    kind: string;
+	    description: string;
+	    active: boolean;
+	    headCount: number;
 	    // Go type: time
 	    createdAt: any;
 	
 	    static createFrom(source: any = {}) {
-	        return new CropRecord(source);
+	        return new AnimalGroup(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
 	        this.id = source["id"];
-	        this.fieldId = source["fieldId"];
-	        this.fieldName = source["fieldName"];
-	        this.cropType = source["cropType"];
-	        this.variety = source["variety"];
-	        this.plantingDate = source["plantingDate"];
-	        this.expectedHarvest = source["expectedHarvest"];
-	        this.actualHarvest = source["actualHarvest"];
-	        this.seedCost = source["seedCost"];
-	        this.fertilizerCost = source["fertilizerCost"];
-	        this.laborCost = source["laborCost"];
-	        this.yieldKg = source["yieldKg"];
-	        this.yieldValue = source["yieldValue"];
-	        this.status = source["status"];
-	        this.notes = source["notes"];
+	        this.name = source["name"];
+	        this.kind = source["kind"];
+	        this.description = source["description"];
+	        this.active = source["active"];
+	        this.headCount = source["headCount"];
 	        this.createdAt = this.convertValues(source["createdAt"], null);
 	    }
 	
@@ -196,147 +240,46 @@ export namespace main {
 		    return a;
 		}
 	}
-	export class CurrentWeather {
-	    temperature: number;
-	    feelsLike: number;
-	    humidity: number;
-	    windSpeed: number;
-	    weatherCode: number;
-	    description: string;
-	    icon: string;
-	    isDay: boolean;
-	
-	    static createFrom(source: any = {}) {
-	        return new CurrentWeather(source);
-	    }
-	
-	    constructor(source: any = {}) {
-	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.temperature = source["temperature"];
-	        this.feelsLike = source["feelsLike"];
-	        this.humidity = source["humidity"];
-	        this.windSpeed = source["windSpeed"];
-	        this.weatherCode = source["weatherCode"];
-	        this.description = source["description"];
-	        this.icon = source["icon"];
-	        this.isDay = source["isDay"];
-	    }
-	}
-	export class DailyForecast {
-	    date: string;
-	    tempMax: number;
-	    tempMin: number;
-	    weatherCode: number;
-	    description: string;
-	    icon: string;
-	
-	    static createFrom(source: any = {}) {
-	        return new DailyForecast(source);
-	    }
-	
-	    constructor(source: any = {}) {
-	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.date = source["date"];
-	        this.tempMax = source["tempMax"];
-	        this.tempMin = source["tempMin"];
-	        this.weatherCode = source["weatherCode"];
-	        this.description = source["description"];
-	        this.icon = source["icon"];
-	    }
-	}
-	export class DashboardStats {
-	    totalAnimals: number;
-	    activeCows: number;
-	    todayMilkLiters: number;
-	    monthMilkLiters: number;
-	    activeFields: number;
-	    totalFieldsAcres: number;
-	    monthIncome: number;
-	    lastMonthIncome: number;
-	    monthExpenses: number;
-	    lowStockItems: number;
-	    pendingVetVisits: number;
-	
-	    static createFrom(source: any = {}) {
-	        return new DashboardStats(source);
-	    }
-	
-	    constructor(source: any = {}) {
-	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.totalAnimals = source["totalAnimals"];
-	        this.activeCows = source["activeCows"];
-	        this.todayMilkLiters = source["todayMilkLiters"];
-	        this.monthMilkLiters = source["monthMilkLiters"];
-	        this.activeFields = source["activeFields"];
-	        this.totalFieldsAcres = source["totalFieldsAcres"];
-	        this.monthIncome = source["monthIncome"];
-	        this.lastMonthIncome = source["lastMonthIncome"];
-	        this.monthExpenses = source["monthExpenses"];
-	        this.lowStockItems = source["lowStockItems"];
-	        this.pendingVetVisits = source["pendingVetVisits"];
-	    }
-	}
-	export class DownloadStatus {
-	    progress: number;
-	    isComplete: boolean;
-	    isError: boolean;
-	    errorMsg: string;
+	export class MonthlyMilkTotal {
+	    month: string;
+	    liters: number;
 	
 	    static createFrom(source: any = {}) {
-	        return new DownloadStatus(source);
+	        return new MonthlyMilkTotal(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.progress = source["progress"];
-	        this.isComplete = source["isComplete"];
-	        this.isError = source["isError"];
-	        this.errorMsg = source["errorMsg"];
+	        this.month = source["month"];
+	        this.liters = source["liters"];
 	    }
 	}
-	export class ExportResult {
-	    path: string;
+	export class AnimalMilkSummary {
 	    records: number;
+	    firstDate: string;
+	    lastDate: string;
+	    totalLiters: number;
+	    averagePerDay: number;
+	    bestDay: string;
+	    bestDayLiters: number;
+	    last30Days: number;
+	    monthly: MonthlyMilkTotal[];
 	
 	    static createFrom(source: any = {}) {
-	        return new ExportResult(source);
+	        return new AnimalMilkSummary(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.path = source["path"];
 	        this.records = source["records"];
-	    }
-	}
-	export class FeedRecord {
-	    id: number;
-	    date: string;
-	    feedTypeId: number;
-	    feedTypeName?: string;
-	    quantityKg: number;
-	    unit: string;
-	    animalCount: number;
-	    feedingTime: string;
-	    notes: string;
-	    // Go type: time
-	    createdAt: any;
-	
-	    static createFrom(source: any = {}) {
-	        return new FeedRecord(source);
-	    }
-	
-	    constructor(source: any = {}) {
-	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.id = source["id"];
-	        this.date = source["date"];
-	        this.feedTypeId = source["feedTypeId"];
-	        this.feedTypeName = source["feedTypeName"];
-	        this.quantityKg = source["quantityKg"];
-	        this.unit = source["unit"];
-	        this.animalCount = source["animalCount"];
-	        this.feedingTime = source["feedingTime"];
-	        this.notes = source["notes"];
-	        this.createdAt = this.convertValues(source["createdAt"], null);
+	        this.firstDate = source["firstDate"];
+	        this.lastDate = source["lastDate"];
+	        this.totalLiters = source["totalLiters"];
+	        this.averagePerDay = source["averagePerDay"];
+	        this.bestDay = source["bestDay"];
+	        this.bestDayLiters = source["bestDayLiters"];
+	        this.last30Days = source["last30Days"];
+	        this.monthly = this.convertValues(source["monthly"], MonthlyMilkTotal);
 	    }
 	
 		convertValues(a: any, classs: any, asMap: boolean = false): any {
@@ -357,58 +300,2214 @@ export namespace main {
 		    return a;
 		}
 	}
-	export class FeedType {
+	export class AnimalMovement {
 	    id: number;
-	    name: string;
-	    category: string;
-	    nutritionalInfo: string;
-	    costPerKg: number;
-	    notes: string;
+	    animalId: number;
+	    animalName?: string;
+	    date: string;
+	    fromGroupId?: number;
+	    fromGroupName?: string;
+	    toGroupId?: number;
+	    toGroupName?: string;
+	    reason: string;
+	    // Go type: time
+	    createdAt: any;
 	
 	    static createFrom(source: any = {}) {
-	        return new FeedType(source);
+	        return new AnimalMovement(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
 	        this.id = source["id"];
-	        this.name = source["name"];
-	        this.category = source["category"];
-	        this.nutritionalInfo = source["nutritionalInfo"];
-	        this.costPerKg = source["costPerKg"];
+	        this.animalId = source["animalId"];
+	        this.animalName = source["animalName"];
+	        this.date = source["date"];
+	        this.fromGroupId = source["fromGroupId"];
+	        this.fromGroupName = source["fromGroupName"];
+	        this.toGroupId = source["toGroupId"];
+	        this.toGroupName = source["toGroupName"];
+	        this.reason = source["reason"];
+	        this.createdAt = this.convertValues(source["createdAt"], null);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class Photo {
+	    id: number;
+	    entityType: string;
+	    entityId: number;
+	    filename: string;
+	    path: string;
+	    notes: string;
+	    // Go type: time
+	    createdAt: any;
+	
+	    static createFrom(source: any = {}) {
+	        return new Photo(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.entityType = source["entityType"];
+	        this.entityId = source["entityId"];
+	        this.filename = source["filename"];
+	        this.path = source["path"];
 	        this.notes = source["notes"];
+	        this.createdAt = this.convertValues(source["createdAt"], null);
 	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
 	}
-	export class Field {
+	export class BreedingRecord {
 	    id: number;
-	    name: string;
-	    sizeAcres: number;
-	    location: string;
-	    soilType: string;
-	    currentCrop: string;
-	    status: string;
+	    femaleId: number;
+	    femaleName?: string;
+	    maleId?: number;
+	    maleName?: string;
+	    breedingDate: string;
+	    breedingMethod: string;
+	    sireSource: string;
+	    expectedDueDate: string;
+	    actualBirthDate: string;
+	    offspringId?: number;
+	    offspringName?: string;
+	    pregnancyStatus: string;
 	    notes: string;
 	    // Go type: time
 	    createdAt: any;
+	
+	    static createFrom(source: any = {}) {
+	        return new BreedingRecord(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.femaleId = source["femaleId"];
+	        this.femaleName = source["femaleName"];
+	        this.maleId = source["maleId"];
+	        this.maleName = source["maleName"];
+	        this.breedingDate = source["breedingDate"];
+	        this.breedingMethod = source["breedingMethod"];
+	        this.sireSource = source["sireSource"];
+	        this.expectedDueDate = source["expectedDueDate"];
+	        this.actualBirthDate = source["actualBirthDate"];
+	        this.offspringId = source["offspringId"];
+	        this.offspringName = source["offspringName"];
+	        this.pregnancyStatus = source["pregnancyStatus"];
+	        this.notes = source["notes"];
+	        this.createdAt = this.convertValues(source["createdAt"], null);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class VetRecord {
+	    id: number;
+	    animalId: number;
+	    animalName?: string;
+	    date: string;
+	    recordType: string;
+	    description: string;
+	    diagnosis: string;
+	    treatment: string;
+	    medicine: string;
+	    dosage: string;
+	    vetName: string;
+	    cost: number;
+	    nextDueDate: string;
+	    notes: string;
 	    // Go type: time
-	    updatedAt: any;
+	    createdAt: any;
+	    milkWithdrawalDays: number;
+	    meatWithdrawalDays: number;
+	    milkWithheldUntil?: string;
+	    meatWithheldUntil?: string;
 	
 	    static createFrom(source: any = {}) {
-	        return new Field(source);
+	        return new VetRecord(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
 	        this.id = source["id"];
-	        this.name = source["name"];
-	        this.sizeAcres = source["sizeAcres"];
-	        this.location = source["location"];
-	        this.soilType = source["soilType"];
-	        this.currentCrop = source["currentCrop"];
-	        this.status = source["status"];
+	        this.animalId = source["animalId"];
+	        this.animalName = source["animalName"];
+	        this.date = source["date"];
+	        this.recordType = source["recordType"];
+	        this.description = source["description"];
+	        this.diagnosis = source["diagnosis"];
+	        this.treatment = source["treatment"];
+	        this.medicine = source["medicine"];
+	        this.dosage = source["dosage"];
+	        this.vetName = source["vetName"];
+	        this.cost = source["cost"];
+	        this.nextDueDate = source["nextDueDate"];
 	        this.notes = source["notes"];
 	        this.createdAt = this.convertValues(source["createdAt"], null);
-	        this.updatedAt = this.convertValues(source["updatedAt"], null);
+	        this.milkWithdrawalDays = source["milkWithdrawalDays"];
+	        this.meatWithdrawalDays = source["meatWithdrawalDays"];
+	        this.milkWithheldUntil = source["milkWithheldUntil"];
+	        this.meatWithheldUntil = source["meatWithheldUntil"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class AnimalPassport {
+	    generatedAt: string;
+	    animal: Animal;
+	    mother?: Animal;
+	    father?: Animal;
+	    offspring: Animal[];
+	    vetRecords: VetRecord[];
+	    breedingHistory: BreedingRecord[];
+	    milk: AnimalMilkSummary;
+	    photos: Photo[];
+	
+	    static createFrom(source: any = {}) {
+	        return new AnimalPassport(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.generatedAt = source["generatedAt"];
+	        this.animal = this.convertValues(source["animal"], Animal);
+	        this.mother = this.convertValues(source["mother"], Animal);
+	        this.father = this.convertValues(source["father"], Animal);
+	        this.offspring = this.convertValues(source["offspring"], Animal);
+	        this.vetRecords = this.convertValues(source["vetRecords"], VetRecord);
+	        this.breedingHistory = this.convertValues(source["breedingHistory"], BreedingRecord);
+	        this.milk = this.convertValues(source["milk"], AnimalMilkSummary);
+	        this.photos = this.convertValues(source["photos"], Photo);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class AnimalTypeChange {
+	    id: number;
+	    animalId: number;
+	    animalName?: string;
+	    date: string;
+	    fromType: string;
+	    toType: string;
+	    reason: string;
+	    automatic: boolean;
+	    // Go type: time
+	    createdAt: any;
+	
+	    static createFrom(source: any = {}) {
+	        return new AnimalTypeChange(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.animalId = source["animalId"];
+	        this.animalName = source["animalName"];
+	        this.date = source["date"];
+	        this.fromType = source["fromType"];
+	        this.toType = source["toType"];
+	        this.reason = source["reason"];
+	        this.automatic = source["automatic"];
+	        this.createdAt = this.convertValues(source["createdAt"], null);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class AnimalWithdrawal {
+	    animalId: number;
+	    animalName: string;
+	    tagNumber: string;
+	    milkWithheldUntil?: string;
+	    milkMedicine?: string;
+	    milkVetRecordId?: number;
+	    milkTreatmentDate?: string;
+	    meatWithheldUntil?: string;
+	    meatMedicine?: string;
+	    meatVetRecordId?: number;
+	    meatTreatmentDate?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new AnimalWithdrawal(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.animalId = source["animalId"];
+	        this.animalName = source["animalName"];
+	        this.tagNumber = source["tagNumber"];
+	        this.milkWithheldUntil = source["milkWithheldUntil"];
+	        this.milkMedicine = source["milkMedicine"];
+	        this.milkVetRecordId = source["milkVetRecordId"];
+	        this.milkTreatmentDate = source["milkTreatmentDate"];
+	        this.meatWithheldUntil = source["meatWithheldUntil"];
+	        this.meatMedicine = source["meatMedicine"];
+	        this.meatVetRecordId = source["meatVetRecordId"];
+	        this.meatTreatmentDate = source["meatTreatmentDate"];
+	    }
+	}
+	export class BackupInfo {
+	    path: string;
+	    size: number;
+	    timestamp: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new BackupInfo(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.path = source["path"];
+	        this.size = source["size"];
+	        this.timestamp = source["timestamp"];
+	    }
+	}
+	export class BankCSVLayout {
+	    delimiter: string;
+	    skipRows: number;
+	    hasHeader: boolean;
+	    dateColumn: string;
+	    descriptionColumn: string;
+	    referenceColumn: string;
+	    amountColumn: string;
+	    debitColumn: string;
+	    creditColumn: string;
+	    dateFormat: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new BankCSVLayout(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.delimiter = source["delimiter"];
+	        this.skipRows = source["skipRows"];
+	        this.hasHeader = source["hasHeader"];
+	        this.dateColumn = source["dateColumn"];
+	        this.descriptionColumn = source["descriptionColumn"];
+	        this.referenceColumn = source["referenceColumn"];
+	        this.amountColumn = source["amountColumn"];
+	        this.debitColumn = source["debitColumn"];
+	        this.creditColumn = source["creditColumn"];
+	        this.dateFormat = source["dateFormat"];
+	    }
+	}
+	
+	export class Buyer {
+	    id: number;
+	    name: string;
+	    phone: string;
+	    email: string;
+	    address: string;
+	    agreedPrice: number;
+	    active: boolean;
+	    notes: string;
+	    // Go type: time
+	    createdAt: any;
+	    totalSales: number;
+	    totalPayments: number;
+	    balance: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new Buyer(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.name = source["name"];
+	        this.phone = source["phone"];
+	        this.email = source["email"];
+	        this.address = source["address"];
+	        this.agreedPrice = source["agreedPrice"];
+	        this.active = source["active"];
+	        this.notes = source["notes"];
+	        this.createdAt = this.convertValues(source["createdAt"], null);
+	        this.totalSales = source["totalSales"];
+	        this.totalPayments = source["totalPayments"];
+	        this.balance = source["balance"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	
+	export class BuyerPayment {
+	    id: number;
+	    buyerId: number;
+	    buyerName?: string;
+	    date: string;
+	    amount: number;
+	    method: string;
+	    reference: string;
+	    milkSaleId?: number;
+	    notes: string;
+	    // Go type: time
+	    createdAt: any;
+	    allocated: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new BuyerPayment(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.buyerId = source["buyerId"];
+	        this.buyerName = source["buyerName"];
+	        this.date = source["date"];
+	        this.amount = source["amount"];
+	        this.method = source["method"];
+	        this.reference = source["reference"];
+	        this.milkSaleId = source["milkSaleId"];
+	        this.notes = source["notes"];
+	        this.createdAt = this.convertValues(source["createdAt"], null);
+	        this.allocated = source["allocated"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class BuyerStatementLine {
+	    date: string;
+	    type: string;
+	    description: string;
+	    reference: string;
+	    debit: number;
+	    credit: number;
+	    balance: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new BuyerStatementLine(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.date = source["date"];
+	        this.type = source["type"];
+	        this.description = source["description"];
+	        this.reference = source["reference"];
+	        this.debit = source["debit"];
+	        this.credit = source["credit"];
+	        this.balance = source["balance"];
+	    }
+	}
+	export class BuyerStatement {
+	    buyer: Buyer;
+	    month: string;
+	    startDate: string;
+	    endDate: string;
+	    openingBalance: number;
+	    lines: BuyerStatementLine[];
+	    totalSales: number;
+	    totalLiters: number;
+	    totalPayments: number;
+	    closingBalance: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new BuyerStatement(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.buyer = this.convertValues(source["buyer"], Buyer);
+	        this.month = source["month"];
+	        this.startDate = source["startDate"];
+	        this.endDate = source["endDate"];
+	        this.openingBalance = source["openingBalance"];
+	        this.lines = this.convertValues(source["lines"], BuyerStatementLine);
+	        this.totalSales = source["totalSales"];
+	        this.totalLiters = source["totalLiters"];
+	        this.totalPayments = source["totalPayments"];
+	        this.closingBalance = source["closingBalance"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	
+	export class CalendarFeedStatus {
+	    running: boolean;
+	    enabled: boolean;
+	    port: number;
+	    url: string;
+	    webcalUrl: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new CalendarFeedStatus(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.running = source["running"];
+	        this.enabled = source["enabled"];
+	        this.port = source["port"];
+	        this.url = source["url"];
+	        this.webcalUrl = source["webcalUrl"];
+	    }
+	}
+	export class CommonAncestor {
+	    animalId: number;
+	    name: string;
+	    paths: number;
+	    contribution: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new CommonAncestor(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.animalId = source["animalId"];
+	        this.name = source["name"];
+	        this.paths = source["paths"];
+	        this.contribution = source["contribution"];
+	    }
+	}
+	export class CoopDeduction {
+	    id: number;
+	    payoutId: number;
+	    category: string;
+	    description: string;
+	    amount: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new CoopDeduction(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.payoutId = source["payoutId"];
+	        this.category = source["category"];
+	        this.description = source["description"];
+	        this.amount = source["amount"];
+	    }
+	}
+	export class CoopDelivery {
+	    id: number;
+	    cooperativeId: number;
+	    cooperativeName?: string;
+	    date: string;
+	    liters: number;
+	    coopLiters?: number;
+	    deliveryNote: string;
+	    notes: string;
+	    // Go type: time
+	    createdAt: any;
+	
+	    static createFrom(source: any = {}) {
+	        return new CoopDelivery(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.cooperativeId = source["cooperativeId"];
+	        this.cooperativeName = source["cooperativeName"];
+	        this.date = source["date"];
+	        this.liters = source["liters"];
+	        this.coopLiters = source["coopLiters"];
+	        this.deliveryNote = source["deliveryNote"];
+	        this.notes = source["notes"];
+	        this.createdAt = this.convertValues(source["createdAt"], null);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class CoopPayout {
+	    id: number;
+	    cooperativeId: number;
+	    cooperativeName?: string;
+	    month: string;
+	    payoutDate: string;
+	    liters: number;
+	    pricePerLiter: number;
+	    grossAmount: number;
+	    deductions: CoopDeduction[];
+	    reference: string;
+	    transactionId?: number;
+	    notes: string;
+	    // Go type: time
+	    createdAt: any;
+	    totalDeductions: number;
+	    netAmount: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new CoopPayout(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.cooperativeId = source["cooperativeId"];
+	        this.cooperativeName = source["cooperativeName"];
+	        this.month = source["month"];
+	        this.payoutDate = source["payoutDate"];
+	        this.liters = source["liters"];
+	        this.pricePerLiter = source["pricePerLiter"];
+	        this.grossAmount = source["grossAmount"];
+	        this.deductions = this.convertValues(source["deductions"], CoopDeduction);
+	        this.reference = source["reference"];
+	        this.transactionId = source["transactionId"];
+	        this.notes = source["notes"];
+	        this.createdAt = this.convertValues(source["createdAt"], null);
+	        this.totalDeductions = source["totalDeductions"];
+	        this.netAmount = source["netAmount"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class CoopReconciliationDay {
+	    date: string;
+	    liters: number;
+	    coopLiters: number;
+	    shortfall: number;
+	    measured: boolean;
+	    flagged: boolean;
+	    deliveryNotes: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new CoopReconciliationDay(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.date = source["date"];
+	        this.liters = source["liters"];
+	        this.coopLiters = source["coopLiters"];
+	        this.shortfall = source["shortfall"];
+	        this.measured = source["measured"];
+	        this.flagged = source["flagged"];
+	        this.deliveryNotes = source["deliveryNotes"];
+	    }
+	}
+	export class CoopReconciliation {
+	    cooperativeId: number;
+	    cooperativeName: string;
+	    month: string;
+	    startDate: string;
+	    endDate: string;
+	    days: CoopReconciliationDay[];
+	    deliveredLiters: number;
+	    coopLiters: number;
+	    shortfallLiters: number;
+	    flaggedDays: number;
+	    unmeasuredDays: number;
+	    payout?: CoopPayout;
+	    unpaidLiters: number;
+	    shortfallValue: number;
+	    issues: string[];
+	
+	    static createFrom(source: any = {}) {
+	        return new CoopReconciliation(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.cooperativeId = source["cooperativeId"];
+	        this.cooperativeName = source["cooperativeName"];
+	        this.month = source["month"];
+	        this.startDate = source["startDate"];
+	        this.endDate = source["endDate"];
+	        this.days = this.convertValues(source["days"], CoopReconciliationDay);
+	        this.deliveredLiters = source["deliveredLiters"];
+	        this.coopLiters = source["coopLiters"];
+	        this.shortfallLiters = source["shortfallLiters"];
+	        this.flaggedDays = source["flaggedDays"];
+	        this.unmeasuredDays = source["unmeasuredDays"];
+	        this.payout = this.convertValues(source["payout"], CoopPayout);
+	        this.unpaidLiters = source["unpaidLiters"];
+	        this.shortfallValue = source["shortfallValue"];
+	        this.issues = source["issues"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	
+	export class Cooperative {
+	    id: number;
+	    name: string;
+	    memberNumber: string;
+	    pricePerLiter: number;
+	    active: boolean;
+	    notes: string;
+	    // Go type: time
+	    createdAt: any;
+	
+	    static createFrom(source: any = {}) {
+	        return new Cooperative(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.name = source["name"];
+	        this.memberNumber = source["memberNumber"];
+	        this.pricePerLiter = source["pricePerLiter"];
+	        this.active = source["active"];
+	        this.notes = source["notes"];
+	        this.createdAt = this.convertValues(source["createdAt"], null);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class CowRanking {
+	    animalId: number;
+	    animalName: string;
+	    tagNumber?: string;
+	    species: string;
+	    breed?: string;
+	    rank: number;
+	    score: number;
+	    yieldScore: number;
+	    fertilityScore: number;
+	    healthScore: number;
+	    ageScore: number;
+	    avgDailyLiters: number;
+	    recordedDays: number;
+	    services: number;
+	    conceptions: number;
+	    servicesPerConception: number;
+	    calvings: number;
+	    calvingIntervalDays: number;
+	    healthCost: number;
+	    ageYears: number;
+	    cullCandidate: boolean;
+	    reasons: string[];
+	
+	    static createFrom(source: any = {}) {
+	        return new CowRanking(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.animalId = source["animalId"];
+	        this.animalName = source["animalName"];
+	        this.tagNumber = source["tagNumber"];
+	        this.species = source["species"];
+	        this.breed = source["breed"];
+	        this.rank = source["rank"];
+	        this.score = source["score"];
+	        this.yieldScore = source["yieldScore"];
+	        this.fertilityScore = source["fertilityScore"];
+	        this.healthScore = source["healthScore"];
+	        this.ageScore = source["ageScore"];
+	        this.avgDailyLiters = source["avgDailyLiters"];
+	        this.recordedDays = source["recordedDays"];
+	        this.services = source["services"];
+	        this.conceptions = source["conceptions"];
+	        this.servicesPerConception = source["servicesPerConception"];
+	        this.calvings = source["calvings"];
+	        this.calvingIntervalDays = source["calvingIntervalDays"];
+	        this.healthCost = source["healthCost"];
+	        this.ageYears = source["ageYears"];
+	        this.cullCandidate = source["cullCandidate"];
+	        this.reasons = source["reasons"];
+	    }
+	}
+	export class CropRecord {
+	    id: number;
+	    fieldId: number;
+	    fieldName?: string;
+	    cropType: string;
+	    variety: string;
+	    plantingDate: string;
+	    expectedHarvest: string;
+	    actualHarvest: string;
+	    seedCost: number;
+	    fertilizerCost: number;
+	    laborCost: number;
+	    yieldKg: number;
+	    yieldValue: number;
+	    status: string;
+	    notes: string;
+	    // Go type: time
+	    createdAt: any;
+	
+	    static createFrom(source: any = {}) {
+	        return new CropRecord(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.fieldId = source["fieldId"];
+	        this.fieldName = source["fieldName"];
+	        this.cropType = source["cropType"];
+	        this.variety = source["variety"];
+	        this.plantingDate = source["plantingDate"];
+	        this.expectedHarvest = source["expectedHarvest"];
+	        this.actualHarvest = source["actualHarvest"];
+	        this.seedCost = source["seedCost"];
+	        this.fertilizerCost = source["fertilizerCost"];
+	        this.laborCost = source["laborCost"];
+	        this.yieldKg = source["yieldKg"];
+	        this.yieldValue = source["yieldValue"];
+	        this.status = source["status"];
+	        this.notes = source["notes"];
+	        this.createdAt = this.convertValues(source["createdAt"], null);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class CurrentWeather {
+	    temperature: number;
+	    feelsLike: number;
+	    humidity: number;
+	    windSpeed: number;
+	    weatherCode: number;
+	    description: string;
+	    icon: string;
+	    isDay: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new CurrentWeather(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.temperature = source["temperature"];
+	        this.feelsLike = source["feelsLike"];
+	        this.humidity = source["humidity"];
+	        this.windSpeed = source["windSpeed"];
+	        this.weatherCode = source["weatherCode"];
+	        this.description = source["description"];
+	        this.icon = source["icon"];
+	        this.isDay = source["isDay"];
+	    }
+	}
+	export class DailyForecast {
+	    date: string;
+	    tempMax: number;
+	    tempMin: number;
+	    weatherCode: number;
+	    description: string;
+	    icon: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new DailyForecast(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.date = source["date"];
+	        this.tempMax = source["tempMax"];
+	        this.tempMin = source["tempMin"];
+	        this.weatherCode = source["weatherCode"];
+	        this.description = source["description"];
+	        this.icon = source["icon"];
+	    }
+	}
+	export class MilkSessionYield {
+	    sessionId: number;
+	    sessionName?: string;
+	    liters: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new MilkSessionYield(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.sessionId = source["sessionId"];
+	        this.sessionName = source["sessionName"];
+	        this.liters = source["liters"];
+	    }
+	}
+	export class DashboardStats {
+	    totalAnimals: number;
+	    activeCows: number;
+	    todayMilkLiters: number;
+	    monthMilkLiters: number;
+	    activeFields: number;
+	    totalFieldsAcres: number;
+	    monthIncome: number;
+	    lastMonthIncome: number;
+	    monthExpenses: number;
+	    lowStockItems: number;
+	    pendingVetVisits: number;
+	    monthDiscardedMilkLiters: number;
+	    animalsUnderWithdrawal: number;
+	    todayMilkBySession: MilkSessionYield[];
+	
+	    static createFrom(source: any = {}) {
+	        return new DashboardStats(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.totalAnimals = source["totalAnimals"];
+	        this.activeCows = source["activeCows"];
+	        this.todayMilkLiters = source["todayMilkLiters"];
+	        this.monthMilkLiters = source["monthMilkLiters"];
+	        this.activeFields = source["activeFields"];
+	        this.totalFieldsAcres = source["totalFieldsAcres"];
+	        this.monthIncome = source["monthIncome"];
+	        this.lastMonthIncome = source["lastMonthIncome"];
+	        this.monthExpenses = source["monthExpenses"];
+	        this.lowStockItems = source["lowStockItems"];
+	        this.pendingVetVisits = source["pendingVetVisits"];
+	        this.monthDiscardedMilkLiters = source["monthDiscardedMilkLiters"];
+	        this.animalsUnderWithdrawal = source["animalsUnderWithdrawal"];
+	        this.todayMilkBySession = this.convertValues(source["todayMilkBySession"], MilkSessionYield);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class DatasetImportResult {
+	    tables: Record<string, number>;
+	    records: number;
+	    skipped: string[];
+	
+	    static createFrom(source: any = {}) {
+	        return new DatasetImportResult(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.tables = source["tables"];
+	        this.records = source["records"];
+	        this.skipped = source["skipped"];
+	    }
+	}
+	export class DisposalReport {
+	    startDate: string;
+	    endDate: string;
+	    sales: number;
+	    deaths: number;
+	    culls: number;
+	    salesIncome: number;
+	    herdAtRisk: number;
+	    mortalityRate: number;
+	    cullRate: number;
+	    deathsByCause: Record<string, number>;
+	    cullsByReason: Record<string, number>;
+	    disposals: AnimalDisposal[];
+	
+	    static createFrom(source: any = {}) {
+	        return new DisposalReport(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.startDate = source["startDate"];
+	        this.endDate = source["endDate"];
+	        this.sales = source["sales"];
+	        this.deaths = source["deaths"];
+	        this.culls = source["culls"];
+	        this.salesIncome = source["salesIncome"];
+	        this.herdAtRisk = source["herdAtRisk"];
+	        this.mortalityRate = source["mortalityRate"];
+	        this.cullRate = source["cullRate"];
+	        this.deathsByCause = source["deathsByCause"];
+	        this.cullsByReason = source["cullsByReason"];
+	        this.disposals = this.convertValues(source["disposals"], AnimalDisposal);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class DownloadStatus {
+	    progress: number;
+	    isComplete: boolean;
+	    isError: boolean;
+	    errorMsg: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new DownloadStatus(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.progress = source["progress"];
+	        this.isComplete = source["isComplete"];
+	        this.isError = source["isError"];
+	        this.errorMsg = source["errorMsg"];
+	    }
+	}
+	export class ExportResult {
+	    path: string;
+	    records: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new ExportResult(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.path = source["path"];
+	        this.records = source["records"];
+	    }
+	}
+	export class FeedRecord {
+	    id: number;
+	    date: string;
+	    feedTypeId: number;
+	    feedTypeName?: string;
+	    quantityKg: number;
+	    unit: string;
+	    animalCount: number;
+	    groupId?: number;
+	    groupName?: string;
+	    feedingTime: string;
+	    notes: string;
+	    // Go type: time
+	    createdAt: any;
+	
+	    static createFrom(source: any = {}) {
+	        return new FeedRecord(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.date = source["date"];
+	        this.feedTypeId = source["feedTypeId"];
+	        this.feedTypeName = source["feedTypeName"];
+	        this.quantityKg = source["quantityKg"];
+	        this.unit = source["unit"];
+	        this.animalCount = source["animalCount"];
+	        this.groupId = source["groupId"];
+	        this.groupName = source["groupName"];
+	        this.feedingTime = source["feedingTime"];
+	        this.notes = source["notes"];
+	        this.createdAt = this.convertValues(source["createdAt"], null);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class FeedType {
+	    id: number;
+	    name: string;
+	    category: string;
+	    nutritionalInfo: string;
+	    costPerKg: number;
+	    notes: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new FeedType(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.name = source["name"];
+	        this.category = source["category"];
+	        this.nutritionalInfo = source["nutritionalInfo"];
+	        this.costPerKg = source["costPerKg"];
+	        this.notes = source["notes"];
+	    }
+	}
+	export class Field {
+	    id: number;
+	    name: string;
+	    sizeAcres: number;
+	    location: string;
+	    soilType: string;
+	    currentCrop: string;
+	    status: string;
+	    notes: string;
+	    // Go type: time
+	    createdAt: any;
+	    // Go type: time
+	    updatedAt: any;
+	
+	    static createFrom(source: any = {}) {
+	        return new Field(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.name = source["name"];
+	        this.sizeAcres = source["sizeAcres"];
+	        this.location = source["location"];
+	        this.soilType = source["soilType"];
+	        this.currentCrop = source["currentCrop"];
+	        this.status = source["status"];
+	        this.notes = source["notes"];
+	        this.createdAt = this.convertValues(source["createdAt"], null);
+	        this.updatedAt = this.convertValues(source["updatedAt"], null);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class FinancialSummary {
+	    totalIncome: number;
+	    totalExpenses: number;
+	    netProfit: number;
+	    incomeByCategory: Record<string, number>;
+	    expenseByCategory: Record<string, number>;
+	
+	    static createFrom(source: any = {}) {
+	        return new FinancialSummary(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.totalIncome = source["totalIncome"];
+	        this.totalExpenses = source["totalExpenses"];
+	        this.netProfit = source["netProfit"];
+	        this.incomeByCategory = source["incomeByCategory"];
+	        this.expenseByCategory = source["expenseByCategory"];
+	    }
+	}
+	export class Flock {
+	    id: number;
+	    name: string;
+	    species: string;
+	    purpose: string;
+	    breed: string;
+	    placedDate: string;
+	    initialCount: number;
+	    currentCount: number;
+	    status: string;
+	    notes: string;
+	    // Go type: time
+	    createdAt: any;
+	    eggsLast7Days: number;
+	    layingRate: number;
+	    mortality: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new Flock(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.name = source["name"];
+	        this.species = source["species"];
+	        this.purpose = source["purpose"];
+	        this.breed = source["breed"];
+	        this.placedDate = source["placedDate"];
+	        this.initialCount = source["initialCount"];
+	        this.currentCount = source["currentCount"];
+	        this.status = source["status"];
+	        this.notes = source["notes"];
+	        this.createdAt = this.convertValues(source["createdAt"], null);
+	        this.eggsLast7Days = source["eggsLast7Days"];
+	        this.layingRate = source["layingRate"];
+	        this.mortality = source["mortality"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class FlockRecord {
+	    id: number;
+	    flockId: number;
+	    flockName?: string;
+	    date: string;
+	    eggs: number;
+	    brokenEggs: number;
+	    mortality: number;
+	    culled: number;
+	    sold: number;
+	    feedKg: number;
+	    notes: string;
+	    // Go type: time
+	    createdAt: any;
+	
+	    static createFrom(source: any = {}) {
+	        return new FlockRecord(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.flockId = source["flockId"];
+	        this.flockName = source["flockName"];
+	        this.date = source["date"];
+	        this.eggs = source["eggs"];
+	        this.brokenEggs = source["brokenEggs"];
+	        this.mortality = source["mortality"];
+	        this.culled = source["culled"];
+	        this.sold = source["sold"];
+	        this.feedKg = source["feedKg"];
+	        this.notes = source["notes"];
+	        this.createdAt = this.convertValues(source["createdAt"], null);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class GroupMilkTotal {
+	    groupId: number;
+	    groupName: string;
+	    liters: number;
+	    animals: number;
+	    milkRecords: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new GroupMilkTotal(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.groupId = source["groupId"];
+	        this.groupName = source["groupName"];
+	        this.liters = source["liters"];
+	        this.animals = source["animals"];
+	        this.milkRecords = source["milkRecords"];
+	    }
+	}
+	export class GroupSummary {
+	    group: AnimalGroup;
+	    startDate: string;
+	    endDate: string;
+	    animals: Animal[];
+	    milkLiters: number;
+	    milkRecords: number;
+	    feedRecords: FeedRecord[];
+	
+	    static createFrom(source: any = {}) {
+	        return new GroupSummary(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.group = this.convertValues(source["group"], AnimalGroup);
+	        this.startDate = source["startDate"];
+	        this.endDate = source["endDate"];
+	        this.animals = this.convertValues(source["animals"], Animal);
+	        this.milkLiters = source["milkLiters"];
+	        this.milkRecords = source["milkRecords"];
+	        this.feedRecords = this.convertValues(source["feedRecords"], FeedRecord);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class WeightRecord {
+	    id: number;
+	    animalId: number;
+	    animalName?: string;
+	    date: string;
+	    weightKg: number;
+	    method: string;
+	    notes: string;
+	    adg?: number;
+	    // Go type: time
+	    createdAt: any;
+	
+	    static createFrom(source: any = {}) {
+	        return new WeightRecord(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.animalId = source["animalId"];
+	        this.animalName = source["animalName"];
+	        this.date = source["date"];
+	        this.weightKg = source["weightKg"];
+	        this.method = source["method"];
+	        this.notes = source["notes"];
+	        this.adg = source["adg"];
+	        this.createdAt = this.convertValues(source["createdAt"], null);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class GrowthAnalysis {
+	    animalId: number;
+	    animalName: string;
+	    breed: string;
+	    records: WeightRecord[];
+	    latestWeight: number;
+	    latestDate: string;
+	    ageDays: number;
+	    overallAdg: number;
+	    targetWeight: number;
+	    percentOfTarget: number;
+	    belowTarget: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new GrowthAnalysis(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.animalId = source["animalId"];
+	        this.animalName = source["animalName"];
+	        this.breed = source["breed"];
+	        this.records = this.convertValues(source["records"], WeightRecord);
+	        this.latestWeight = source["latestWeight"];
+	        this.latestDate = source["latestDate"];
+	        this.ageDays = source["ageDays"];
+	        this.overallAdg = source["overallAdg"];
+	        this.targetWeight = source["targetWeight"];
+	        this.percentOfTarget = source["percentOfTarget"];
+	        this.belowTarget = source["belowTarget"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class GrowthTargetPoint {
+	    ageMonths: number;
+	    weightKg: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new GrowthTargetPoint(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.ageMonths = source["ageMonths"];
+	        this.weightKg = source["weightKg"];
+	    }
+	}
+	export class GrowthTargetCurve {
+	    species?: string;
+	    breed: string;
+	    points: GrowthTargetPoint[];
+	
+	    static createFrom(source: any = {}) {
+	        return new GrowthTargetCurve(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.species = source["species"];
+	        this.breed = source["breed"];
+	        this.points = this.convertValues(source["points"], GrowthTargetPoint);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	
+	export class HerdRanking {
+	    startDate: string;
+	    endDate: string;
+	    candidates: number;
+	    cows: CowRanking[];
+	
+	    static createFrom(source: any = {}) {
+	        return new HerdRanking(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.startDate = source["startDate"];
+	        this.endDate = source["endDate"];
+	        this.candidates = source["candidates"];
+	        this.cows = this.convertValues(source["cows"], CowRanking);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class InbreedingResult {
+	    animalId?: number;
+	    damId: number;
+	    sireId: number;
+	    coefficient: number;
+	    percent: number;
+	    commonAncestors: CommonAncestor[];
+	    assessment: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new InbreedingResult(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.animalId = source["animalId"];
+	        this.damId = source["damId"];
+	        this.sireId = source["sireId"];
+	        this.coefficient = source["coefficient"];
+	        this.percent = source["percent"];
+	        this.commonAncestors = this.convertValues(source["commonAncestors"], CommonAncestor);
+	        this.assessment = source["assessment"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class InventoryItem {
+	    id: number;
+	    name: string;
+	    category: string;
+	    quantity: number;
+	    unit: string;
+	    minimumStock: number;
+	    costPerUnit: number;
+	    supplier: string;
+	    notes: string;
+	    // Go type: time
+	    createdAt: any;
+	    // Go type: time
+	    updatedAt: any;
+	
+	    static createFrom(source: any = {}) {
+	        return new InventoryItem(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.name = source["name"];
+	        this.category = source["category"];
+	        this.quantity = source["quantity"];
+	        this.unit = source["unit"];
+	        this.minimumStock = source["minimumStock"];
+	        this.costPerUnit = source["costPerUnit"];
+	        this.supplier = source["supplier"];
+	        this.notes = source["notes"];
+	        this.createdAt = this.convertValues(source["createdAt"], null);
+	        this.updatedAt = this.convertValues(source["updatedAt"], null);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class Lactation {
+	    animalId: number;
+	    animalName: string;
+	    number: number;
+	    calvingDate: string;
+	    endDate: string;
+	    current: boolean;
+	    daysInMilk: number;
+	    recordedDays: number;
+	    peakYield: number;
+	    peakDate: string;
+	    peakDim: number;
+	    cumulativeYield: number;
+	    averageYield: number;
+	    yield305: number;
+	    yield305Projected: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new Lactation(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.animalId = source["animalId"];
+	        this.animalName = source["animalName"];
+	        this.number = source["number"];
+	        this.calvingDate = source["calvingDate"];
+	        this.endDate = source["endDate"];
+	        this.current = source["current"];
+	        this.daysInMilk = source["daysInMilk"];
+	        this.recordedDays = source["recordedDays"];
+	        this.peakYield = source["peakYield"];
+	        this.peakDate = source["peakDate"];
+	        this.peakDim = source["peakDim"];
+	        this.cumulativeYield = source["cumulativeYield"];
+	        this.averageYield = source["averageYield"];
+	        this.yield305 = source["yield305"];
+	        this.yield305Projected = source["yield305Projected"];
+	    }
+	}
+	export class LifeStageRule {
+	    species: string;
+	    breed: string;
+	    fromType: string;
+	    toType: string;
+	    gender: string;
+	    trigger: string;
+	    ageMonths: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new LifeStageRule(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.species = source["species"];
+	        this.breed = source["breed"];
+	        this.fromType = source["fromType"];
+	        this.toType = source["toType"];
+	        this.gender = source["gender"];
+	        this.trigger = source["trigger"];
+	        this.ageMonths = source["ageMonths"];
+	    }
+	}
+	export class LifeStageSettings {
+	    enabled: boolean;
+	    rules: LifeStageRule[];
+	
+	    static createFrom(source: any = {}) {
+	        return new LifeStageSettings(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.enabled = source["enabled"];
+	        this.rules = this.convertValues(source["rules"], LifeStageRule);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class Transaction {
+	    id: number;
+	    date: string;
+	    type: string;
+	    category: string;
+	    description: string;
+	    amount: number;
+	    paymentMethod: string;
+	    relatedEntity: string;
+	    notes: string;
+	    reconciliationStatus: string;
+	    clearedDate: string;
+	    // Go type: time
+	    createdAt: any;
+	
+	    static createFrom(source: any = {}) {
+	        return new Transaction(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.date = source["date"];
+	        this.type = source["type"];
+	        this.category = source["category"];
+	        this.description = source["description"];
+	        this.amount = source["amount"];
+	        this.paymentMethod = source["paymentMethod"];
+	        this.relatedEntity = source["relatedEntity"];
+	        this.notes = source["notes"];
+	        this.reconciliationStatus = source["reconciliationStatus"];
+	        this.clearedDate = source["clearedDate"];
+	        this.createdAt = this.convertValues(source["createdAt"], null);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class MatchProposal {
+	    transaction: Transaction;
+	    daysApart: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new MatchProposal(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.transaction = this.convertValues(source["transaction"], Transaction);
+	        this.daysApart = source["daysApart"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class MedicineWithdrawal {
+	    medicine: string;
+	    milkDays: number;
+	    meatDays: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new MedicineWithdrawal(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.medicine = source["medicine"];
+	        this.milkDays = source["milkDays"];
+	        this.meatDays = source["meatDays"];
+	    }
+	}
+	export class MilkAnomaly {
+	    animalId: number;
+	    animalName: string;
+	    type: string;
+	    date: string;
+	    liters: number;
+	    baselineLiters: number;
+	    dropPercent?: number;
+	    daysMissed?: number;
+	    explanation: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new MilkAnomaly(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.animalId = source["animalId"];
+	        this.animalName = source["animalName"];
+	        this.type = source["type"];
+	        this.date = source["date"];
+	        this.liters = source["liters"];
+	        this.baselineLiters = source["baselineLiters"];
+	        this.dropPercent = source["dropPercent"];
+	        this.daysMissed = source["daysMissed"];
+	        this.explanation = source["explanation"];
+	    }
+	}
+	export class MilkAnomalySettings {
+	    enabled: boolean;
+	    sensitivity: string;
+	    baselineDays: number;
+	    minBaselineRecords: number;
+	    dropPercent: number;
+	    missedDays: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new MilkAnomalySettings(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.enabled = source["enabled"];
+	        this.sensitivity = source["sensitivity"];
+	        this.baselineDays = source["baselineDays"];
+	        this.minBaselineRecords = source["minBaselineRecords"];
+	        this.dropPercent = source["dropPercent"];
+	        this.missedDays = source["missedDays"];
+	    }
+	}
+	export class MilkBalanceRow {
+	    period: string;
+	    produced: number;
+	    sold: number;
+	    cooperative: number;
+	    calfFeeding: number;
+	    household: number;
+	    spoilage: number;
+	    other: number;
+	    discarded: number;
+	    accounted: number;
+	    unaccounted: number;
+	    unaccountedPct: number;
+	    flagged: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new MilkBalanceRow(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.period = source["period"];
+	        this.produced = source["produced"];
+	        this.sold = source["sold"];
+	        this.cooperative = source["cooperative"];
+	        this.calfFeeding = source["calfFeeding"];
+	        this.household = source["household"];
+	        this.spoilage = source["spoilage"];
+	        this.other = source["other"];
+	        this.discarded = source["discarded"];
+	        this.accounted = source["accounted"];
+	        this.unaccounted = source["unaccounted"];
+	        this.unaccountedPct = source["unaccountedPct"];
+	        this.flagged = source["flagged"];
+	    }
+	}
+	export class MilkBalanceReport {
+	    startDate: string;
+	    endDate: string;
+	    period: string;
+	    rows: MilkBalanceRow[];
+	    totals: MilkBalanceRow;
+	    flaggedRows: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new MilkBalanceReport(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.startDate = source["startDate"];
+	        this.endDate = source["endDate"];
+	        this.period = source["period"];
+	        this.rows = this.convertValues(source["rows"], MilkBalanceRow);
+	        this.totals = this.convertValues(source["totals"], MilkBalanceRow);
+	        this.flaggedRows = source["flaggedRows"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	
+	export class MilkEntry {
+	    animalId: number;
+	    animalName?: string;
+	    tagNumber?: string;
+	    recordId: number;
+	    sessions: MilkSessionYield[];
+	    discarded: boolean;
+	    notes: string;
+	    milkWithheldUntil?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new MilkEntry(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.animalId = source["animalId"];
+	        this.animalName = source["animalName"];
+	        this.tagNumber = source["tagNumber"];
+	        this.recordId = source["recordId"];
+	        this.sessions = this.convertValues(source["sessions"], MilkSessionYield);
+	        this.discarded = source["discarded"];
+	        this.notes = source["notes"];
+	        this.milkWithheldUntil = source["milkWithheldUntil"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class MilkEntryResult {
+	    animalId: number;
+	    animalName: string;
+	    recordId: number;
+	    status: string;
+	    error?: string;
+	    warning?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new MilkEntryResult(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.animalId = source["animalId"];
+	        this.animalName = source["animalName"];
+	        this.recordId = source["recordId"];
+	        this.status = source["status"];
+	        this.error = source["error"];
+	        this.warning = source["warning"];
+	    }
+	}
+	export class SCCBand {
+	    maxScc: number;
+	    adjustment: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new SCCBand(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.maxScc = source["maxScc"];
+	        this.adjustment = source["adjustment"];
+	    }
+	}
+	export class MilkQualityPricing {
+	    baseButterfat: number;
+	    butterfatRate: number;
+	    baseProtein: number;
+	    proteinRate: number;
+	    sccBands: SCCBand[];
+	
+	    static createFrom(source: any = {}) {
+	        return new MilkQualityPricing(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.baseButterfat = source["baseButterfat"];
+	        this.butterfatRate = source["butterfatRate"];
+	        this.baseProtein = source["baseProtein"];
+	        this.proteinRate = source["proteinRate"];
+	        this.sccBands = this.convertValues(source["sccBands"], SCCBand);
 	    }
 	
 		convertValues(a: any, classs: any, asMap: boolean = false): any {
@@ -429,58 +2528,35 @@ export namespace main {
 		    return a;
 		}
 	}
-	export class FinancialSummary {
-	    totalIncome: number;
-	    totalExpenses: number;
-	    netProfit: number;
-	    incomeByCategory: Record<string, number>;
-	    expenseByCategory: Record<string, number>;
-	
-	    static createFrom(source: any = {}) {
-	        return new FinancialSummary(source);
-	    }
-	
-	    constructor(source: any = {}) {
-	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.totalIncome = source["totalIncome"];
-	        this.totalExpenses = source["totalExpenses"];
-	        this.netProfit = source["netProfit"];
-	        this.incomeByCategory = source["incomeByCategory"];
-	        this.expenseByCategory = source["expenseByCategory"];
-	    }
-	}
-	export class InventoryItem {
+	export class MilkQualityTest {
 	    id: number;
-	    name: string;
-	    category: string;
-	    quantity: number;
-	    unit: string;
-	    minimumStock: number;
-	    costPerUnit: number;
-	    supplier: string;
+	    animalId?: number;
+	    animalName?: string;
+	    date: string;
+	    butterfatPct: number;
+	    proteinPct: number;
+	    scc: number;
+	    lactometer: number;
 	    notes: string;
 	    // Go type: time
 	    createdAt: any;
-	    // Go type: time
-	    updatedAt: any;
 	
 	    static createFrom(source: any = {}) {
-	        return new InventoryItem(source);
+	        return new MilkQualityTest(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
 	        this.id = source["id"];
-	        this.name = source["name"];
-	        this.category = source["category"];
-	        this.quantity = source["quantity"];
-	        this.unit = source["unit"];
-	        this.minimumStock = source["minimumStock"];
-	        this.costPerUnit = source["costPerUnit"];
-	        this.supplier = source["supplier"];
+	        this.animalId = source["animalId"];
+	        this.animalName = source["animalName"];
+	        this.date = source["date"];
+	        this.butterfatPct = source["butterfatPct"];
+	        this.proteinPct = source["proteinPct"];
+	        this.scc = source["scc"];
+	        this.lactometer = source["lactometer"];
 	        this.notes = source["notes"];
 	        this.createdAt = this.convertValues(source["createdAt"], null);
-	        this.updatedAt = this.convertValues(source["updatedAt"], null);
 	    }
 	
 		convertValues(a: any, classs: any, asMap: boolean = false): any {
@@ -501,14 +2577,36 @@ export namespace main {
 		    return a;
 		}
 	}
+	export class MilkQualityTrendPoint {
+	    month: string;
+	    tests: number;
+	    butterfatPct: number;
+	    proteinPct: number;
+	    scc: number;
+	    lactometer: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new MilkQualityTrendPoint(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.month = source["month"];
+	        this.tests = source["tests"];
+	        this.butterfatPct = source["butterfatPct"];
+	        this.proteinPct = source["proteinPct"];
+	        this.scc = source["scc"];
+	        this.lactometer = source["lactometer"];
+	    }
+	}
 	export class MilkRecord {
 	    id: number;
 	    animalId: number;
 	    animalName?: string;
 	    date: string;
-	    morningLiters: number;
-	    eveningLiters: number;
+	    sessions: MilkSessionYield[];
 	    totalLiters: number;
+	    discarded: boolean;
 	    notes: string;
 	    // Go type: time
 	    createdAt: any;
@@ -523,9 +2621,9 @@ export namespace main {
 	        this.animalId = source["animalId"];
 	        this.animalName = source["animalName"];
 	        this.date = source["date"];
-	        this.morningLiters = source["morningLiters"];
-	        this.eveningLiters = source["eveningLiters"];
+	        this.sessions = this.convertValues(source["sessions"], MilkSessionYield);
 	        this.totalLiters = source["totalLiters"];
+	        this.discarded = source["discarded"];
 	        this.notes = source["notes"];
 	        this.createdAt = this.convertValues(source["createdAt"], null);
 	    }
@@ -551,14 +2649,20 @@ export namespace main {
 	export class MilkSale {
 	    id: number;
 	    date: string;
+	    buyerId?: number;
 	    buyerName: string;
 	    liters: number;
 	    pricePerLiter: number;
 	    totalAmount: number;
 	    isPaid: boolean;
+	    amountPaid: number;
 	    notes: string;
 	    // Go type: time
 	    createdAt: any;
+	    butterfatPct: number;
+	    proteinPct: number;
+	    scc: number;
+	    qualityAdjustment: number;
 	
 	    static createFrom(source: any = {}) {
 	        return new MilkSale(source);
@@ -568,13 +2672,19 @@ export namespace main {
 	        if ('string' === typeof source) source = JSON.parse(source);
 	        this.id = source["id"];
 	        this.date = source["date"];
+	        this.buyerId = source["buyerId"];
 	        this.buyerName = source["buyerName"];
 	        this.liters = source["liters"];
 	        this.pricePerLiter = source["pricePerLiter"];
 	        this.totalAmount = source["totalAmount"];
 	        this.isPaid = source["isPaid"];
+	        this.amountPaid = source["amountPaid"];
 	        this.notes = source["notes"];
 	        this.createdAt = this.convertValues(source["createdAt"], null);
+	        this.butterfatPct = source["butterfatPct"];
+	        this.proteinPct = source["proteinPct"];
+	        this.scc = source["scc"];
+	        this.qualityAdjustment = source["qualityAdjustment"];
 	    }
 	
 		convertValues(a: any, classs: any, asMap: boolean = false): any {
@@ -595,27 +2705,26 @@ export namespace main {
 		    return a;
 		}
 	}
-	export class Photo {
+	
+	export class MilkUsage {
 	    id: number;
-	    entityType: string;
-	    entityId: number;
-	    filename: string;
-	    path: string;
+	    date: string;
+	    useType: string;
+	    liters: number;
 	    notes: string;
 	    // Go type: time
 	    createdAt: any;
 	
 	    static createFrom(source: any = {}) {
-	        return new Photo(source);
+	        return new MilkUsage(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
 	        this.id = source["id"];
-	        this.entityType = source["entityType"];
-	        this.entityId = source["entityId"];
-	        this.filename = source["filename"];
-	        this.path = source["path"];
+	        this.date = source["date"];
+	        this.useType = source["useType"];
+	        this.liters = source["liters"];
 	        this.notes = source["notes"];
 	        this.createdAt = this.convertValues(source["createdAt"], null);
 	    }
@@ -638,6 +2747,120 @@ export namespace main {
 		    return a;
 		}
 	}
+	export class MilkingSession {
+	    id: number;
+	    name: string;
+	    sortOrder: number;
+	    active: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new MilkingSession(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.name = source["name"];
+	        this.sortOrder = source["sortOrder"];
+	        this.active = source["active"];
+	    }
+	}
+	
+	export class MonthlyReportOptions {
+	    month: string;
+	    includeMilk: boolean;
+	    includeFinance: boolean;
+	    includeVet: boolean;
+	    includeBreeding: boolean;
+	    includeLowStock: boolean;
+	    includeCrops: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new MonthlyReportOptions(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.month = source["month"];
+	        this.includeMilk = source["includeMilk"];
+	        this.includeFinance = source["includeFinance"];
+	        this.includeVet = source["includeVet"];
+	        this.includeBreeding = source["includeBreeding"];
+	        this.includeLowStock = source["includeLowStock"];
+	        this.includeCrops = source["includeCrops"];
+	    }
+	}
+	export class PedigreeNode {
+	    animalId: number;
+	    name: string;
+	    tagNumber?: string;
+	    gender: string;
+	    breed?: string;
+	    dateOfBirth?: string;
+	    generation: number;
+	    mother?: PedigreeNode;
+	    father?: PedigreeNode;
+	    children?: PedigreeNode[];
+	
+	    static createFrom(source: any = {}) {
+	        return new PedigreeNode(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.animalId = source["animalId"];
+	        this.name = source["name"];
+	        this.tagNumber = source["tagNumber"];
+	        this.gender = source["gender"];
+	        this.breed = source["breed"];
+	        this.dateOfBirth = source["dateOfBirth"];
+	        this.generation = source["generation"];
+	        this.mother = this.convertValues(source["mother"], PedigreeNode);
+	        this.father = this.convertValues(source["father"], PedigreeNode);
+	        this.children = this.convertValues(source["children"], PedigreeNode);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	
+	export class QualityPrice {
+	    basePrice: number;
+	    butterfatAdjustment: number;
+	    proteinAdjustment: number;
+	    sccAdjustment: number;
+	    adjustment: number;
+	    pricePerLiter: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new QualityPrice(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.basePrice = source["basePrice"];
+	        this.butterfatAdjustment = source["butterfatAdjustment"];
+	        this.proteinAdjustment = source["proteinAdjustment"];
+	        this.sccAdjustment = source["sccAdjustment"];
+	        this.adjustment = source["adjustment"];
+	        this.pricePerLiter = source["pricePerLiter"];
+	    }
+	}
 	export class RecentActivity {
 	    id: number;
 	    type: string;
@@ -677,6 +2900,26 @@ export namespace main {
 		    return a;
 		}
 	}
+	export class ReconciliationSummary {
+	    clearedCount: number;
+	    unclearedCount: number;
+	    unclearedIncome: number;
+	    unclearedExpenses: number;
+	    reviewLines: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new ReconciliationSummary(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.clearedCount = source["clearedCount"];
+	        this.unclearedCount = source["unclearedCount"];
+	        this.unclearedIncome = source["unclearedIncome"];
+	        this.unclearedExpenses = source["unclearedExpenses"];
+	        this.reviewLines = source["reviewLines"];
+	    }
+	}
 	export class Reminder {
 	    id: number;
 	    type: string;
@@ -707,6 +2950,7 @@ export namespace main {
 	        this.entityName = source["entityName"];
 	    }
 	}
+	
 	export class SearchResult {
 	    id: number;
 	    name: string;
@@ -729,34 +2973,114 @@ export namespace main {
 	        this.admin1 = source["admin1"];
 	    }
 	}
-	export class Transaction {
+	export class SpeciesProfile {
+	    species: string;
+	    label: string;
+	    types: string[];
+	    milkingTypes: string[];
+	    maleTypes: string[];
+	    gestationDays: number;
+	    metric: string;
+	    flockOnly: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new SpeciesProfile(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.species = source["species"];
+	        this.label = source["label"];
+	        this.types = source["types"];
+	        this.milkingTypes = source["milkingTypes"];
+	        this.maleTypes = source["maleTypes"];
+	        this.gestationDays = source["gestationDays"];
+	        this.metric = source["metric"];
+	        this.flockOnly = source["flockOnly"];
+	    }
+	}
+	export class SpeciesSummary {
+	    species: string;
+	    label: string;
+	    metric: string;
+	    headCount: number;
+	    producing: number;
+	    milkLiters: number;
+	    averageAdg: number;
+	    eggs: number;
+	    layingRate: number;
+	    mortality: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new SpeciesSummary(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.species = source["species"];
+	        this.label = source["label"];
+	        this.metric = source["metric"];
+	        this.headCount = source["headCount"];
+	        this.producing = source["producing"];
+	        this.milkLiters = source["milkLiters"];
+	        this.averageAdg = source["averageAdg"];
+	        this.eggs = source["eggs"];
+	        this.layingRate = source["layingRate"];
+	        this.mortality = source["mortality"];
+	    }
+	}
+	export class StatementImportResult {
+	    path: string;
+	    lines: number;
+	    duplicates: number;
+	    matched: number;
+	    review: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new StatementImportResult(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.path = source["path"];
+	        this.lines = source["lines"];
+	        this.duplicates = source["duplicates"];
+	        this.matched = source["matched"];
+	        this.review = source["review"];
+	    }
+	}
+	export class StatementLine {
 	    id: number;
+	    source: string;
+	    reference: string;
 	    date: string;
-	    type: string;
-	    category: string;
 	    description: string;
+	    counterparty: string;
 	    amount: number;
-	    paymentMethod: string;
-	    relatedEntity: string;
-	    notes: string;
+	    direction: string;
+	    status: string;
+	    transactionId?: number;
+	    milkSaleId?: number;
 	    // Go type: time
 	    createdAt: any;
 	
 	    static createFrom(source: any = {}) {
-	        return new Transaction(source);
+	        return new StatementLine(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
 	        this.id = source["id"];
+	        this.source = source["source"];
+	        this.reference = source["reference"];
 	        this.date = source["date"];
-	        this.type = source["type"];
-	        this.category = source["category"];
 	        this.description = source["description"];
+	        this.counterparty = source["counterparty"];
 	        this.amount = source["amount"];
-	        this.paymentMethod = source["paymentMethod"];
-	        this.relatedEntity = source["relatedEntity"];
-	        this.notes = source["notes"];
+	        this.direction = source["direction"];
+	        this.status = source["status"];
+	        this.transactionId = source["transactionId"];
+	        this.milkSaleId = source["milkSaleId"];
 	        this.createdAt = this.convertValues(source["createdAt"], null);
 	    }
 	
@@ -778,6 +3102,25 @@ export namespace main {
 		    return a;
 		}
 	}
+	export class StatementSplitPart {
+	    transactionId: number;
+	    category: string;
+	    description: string;
+	    amount: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new StatementSplitPart(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.transactionId = source["transactionId"];
+	        this.category = source["category"];
+	        this.description = source["description"];
+	        this.amount = source["amount"];
+	    }
+	}
+	
 	export class UpdateInfo {
 	    currentVersion: string;
 	    latestVersion: string;
@@ -804,65 +3147,7 @@ export namespace main {
 	        this.publishedAt = source["publishedAt"];
 	    }
 	}
-	export class VetRecord {
-	    id: number;
-	    animalId: number;
-	    animalName?: string;
-	    date: string;
-	    recordType: string;
-	    description: string;
-	    diagnosis: string;
-	    treatment: string;
-	    medicine: string;
-	    dosage: string;
-	    vetName: string;
-	    cost: number;
-	    nextDueDate: string;
-	    notes: string;
-	    // Go type: time
-	    createdAt: any;
-	
-	    static createFrom(source: any = {}) {
-	        return new VetRecord(source);
-	    }
-	
-	    constructor(source: any = {}) {
-	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.id = source["id"];
-	        this.animalId = source["animalId"];
-	        this.animalName = source["animalName"];
-	        this.date = source["date"];
-	        this.recordType = source["recordType"];
-	        this.description = source["description"];
-	        this.diagnosis = source["diagnosis"];
-	        this.treatment = source["treatment"];
-	        this.medicine = source["medicine"];
-	        this.dosage = source["dosage"];
-	        this.vetName = source["vetName"];
-	        this.cost = source["cost"];
-	        this.nextDueDate = source["nextDueDate"];
-	        this.notes = source["notes"];
-	        this.createdAt = this.convertValues(source["createdAt"], null);
-	    }
 	
-		convertValues(a: any, classs: any, asMap: boolean = false): any {
-		    if (!a) {
-		        return a;
-		    }
-		    if (a.slice && a.map) {
-		        return (a as any[]).map(elem => this.convertValues(elem, classs));
-		    } else if ("object" === typeof a) {
-		        if (asMap) {
-		            for (const key of Object.keys(a)) {
-		                a[key] = new classs(a[key]);
-		            }
-		            return a;
-		        }
-		        return new classs(a);
-		    }
-		    return a;
-		}
-	}
 	export class WeatherData {
 	    current: CurrentWeather;
 	    forecast: DailyForecast[];
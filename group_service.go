@@ -0,0 +1,401 @@
+package main
+
+import (
+	"database/sql"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+)
+
+// GroupService handles animal groups, pens and paddocks and the movement of
+// animals between them
+type GroupService struct {
+	livestock *LivestockService
+}
+
+// NewGroupService creates a new GroupService
+func NewGroupService(livestock *LivestockService) *GroupService {
+	return &GroupService{livestock: livestock}
+}
+
+// milkRecordGroupSQL resolves the group an animal was in on the date of milk
+// record mr: the last move on or before that date, else the group it was
+// moved out of first, else its current group when it has never been moved.
+const milkRecordGroupSQL = `
+	CASE
+		WHEN EXISTS (SELECT 1 FROM animal_movements m WHERE m.animal_id = mr.animal_id AND m.date <= mr.date)
+			THEN (SELECT m.to_group_id FROM animal_movements m
+				  WHERE m.animal_id = mr.animal_id AND m.date <= mr.date
+				  ORDER BY m.date DESC, m.id DESC LIMIT 1)
+		WHEN EXISTS (SELECT 1 FROM animal_movements m WHERE m.animal_id = mr.animal_id)
+			THEN (SELECT m.from_group_id FROM animal_movements m
+				  WHERE m.animal_id = mr.animal_id
+				  ORDER BY m.date, m.id LIMIT 1)
+		ELSE a.group_id
+	END`
+
+// GetGroups returns all groups with their current head count
+func (s *GroupService) GetGroups() ([]AnimalGroup, error) {
+	rows, err := db.Query(`
+		SELECT g.id, g.name, g.kind, g.description, g.active, g.created_at,
+			   (SELECT COUNT(*) FROM animals a WHERE a.group_id = g.id AND a.status = 'active')
+		FROM animal_groups g
+		ORDER BY g.active DESC, g.name
+	`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	groups := []AnimalGroup{}
+	for rows.Next() {
+		var g AnimalGroup
+		var kind, description sql.NullString
+		if err := rows.Scan(&g.ID, &g.Name, &kind, &description, &g.Active, &g.CreatedAt, &g.HeadCount); err != nil {
+			return nil, err
+		}
+		g.Kind = firstNonEmpty(kind.String, "group")
+		g.Description = description.String
+		groups = append(groups, g)
+	}
+	return groups, nil
+}
+
+// GetGroup returns a single group by ID
+func (s *GroupService) GetGroup(id int64) (*AnimalGroup, error) {
+	groups, err := s.GetGroups()
+	if err != nil {
+		return nil, err
+	}
+	for _, g := range groups {
+		if g.ID == id {
+			return &g, nil
+		}
+	}
+	return nil, fmt.Errorf("group not found")
+}
+
+// AddGroup adds a new group
+func (s *GroupService) AddGroup(group AnimalGroup) (int64, error) {
+	if err := normalizeGroup(&group); err != nil {
+		return 0, err
+	}
+	result, err := db.Exec(`
+		INSERT INTO animal_groups (name, kind, description, active) VALUES (?, ?, ?, ?)
+	`, group.Name, group.Kind, group.Description, group.Active)
+	if err != nil {
+		return 0, groupError(group.Name, err)
+	}
+	return result.LastInsertId()
+}
+
+// UpdateGroup updates an existing group. Deactivating a group keeps its
+// history but it can no longer receive animals.
+func (s *GroupService) UpdateGroup(group AnimalGroup) error {
+	if err := normalizeGroup(&group); err != nil {
+		return err
+	}
+	if !group.Active {
+		count, err := groupHeadCount(group.ID)
+		if err != nil {
+			return err
+		}
+		if count > 0 {
+			return fmt.Errorf("move the %d animals out of %s before deactivating it", count, group.Name)
+		}
+	}
+	_, err := db.Exec(`
+		UPDATE animal_groups SET name = ?, kind = ?, description = ?, active = ? WHERE id = ?
+	`, group.Name, group.Kind, group.Description, group.Active, group.ID)
+	return groupError(group.Name, err)
+}
+
+// DeleteGroup deletes a group that has never been used. Groups with
+// movements or feedings should be deactivated instead.
+func (s *GroupService) DeleteGroup(id int64) error {
+	var used int
+	err := db.QueryRow(`
+		SELECT (SELECT COUNT(*) FROM animals WHERE group_id = ?)
+			 + (SELECT COUNT(*) FROM animal_movements WHERE from_group_id = ? OR to_group_id = ?)
+			 + (SELECT COUNT(*) FROM feed_records WHERE group_id = ?)
+	`, id, id, id, id).Scan(&used)
+	if err != nil {
+		return err
+	}
+	if used > 0 {
+		return fmt.Errorf("this group has animals or history; deactivate it instead")
+	}
+	_, err = db.Exec(`DELETE FROM animal_groups WHERE id = ?`, id)
+	return err
+}
+
+// normalizeGroup trims and validates a group before it is saved
+func normalizeGroup(group *AnimalGroup) error {
+	group.Name = strings.TrimSpace(group.Name)
+	if group.Name == "" {
+		return fmt.Errorf("group name is required")
+	}
+	switch group.Kind {
+	case "group", "pen", "paddock":
+	case "":
+		group.Kind = "group"
+	default:
+		return fmt.Errorf("unknown group kind %q", group.Kind)
+	}
+	return nil
+}
+
+// groupError gives a friendly message for a duplicate group name
+func groupError(name string, err error) error {
+	if err != nil && strings.Contains(err.Error(), "UNIQUE constraint failed") {
+		return fmt.Errorf("a group named %q already exists", name)
+	}
+	return err
+}
+
+// groupHeadCount returns the number of active animals currently in a group
+func groupHeadCount(groupID int64) (int, error) {
+	var count int
+	err := db.QueryRow(`SELECT COUNT(*) FROM animals WHERE group_id = ? AND status = 'active'`, groupID).Scan(&count)
+	return count, err
+}
+
+// MoveAnimals moves animals into a group on the given date and records each
+// move. A groupID of 0 takes the animals out of their group. Animals already
+// in the group are skipped; the number of animals moved is returned.
+func (s *GroupService) MoveAnimals(animalIDs []int64, groupID int64, date, reason string) (int, error) {
+	if len(animalIDs) == 0 {
+		return 0, fmt.Errorf("select at least one animal to move")
+	}
+	if date == "" {
+		date = time.Now().Format("2006-01-02")
+	}
+	if _, err := time.Parse("2006-01-02", date); err != nil {
+		return 0, fmt.Errorf("invalid date %q", date)
+	}
+
+	var target *int64
+	if groupID != 0 {
+		var name string
+		var active bool
+		err := db.QueryRow(`SELECT name, active FROM animal_groups WHERE id = ?`, groupID).Scan(&name, &active)
+		if err == sql.ErrNoRows {
+			return 0, fmt.Errorf("group not found")
+		}
+		if err != nil {
+			return 0, err
+		}
+		if !active {
+			return 0, fmt.Errorf("%s is inactive", name)
+		}
+		target = &groupID
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		return 0, err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	moved := 0
+	for _, animalID := range animalIDs {
+		var name string
+		var current sql.NullInt64
+		var lastMove sql.NullString
+		err := tx.QueryRow(`
+			SELECT a.name, a.group_id, (SELECT MAX(date) FROM animal_movements WHERE animal_id = a.id)
+			FROM animals a WHERE a.id = ?
+		`, animalID).Scan(&name, &current, &lastMove)
+		if err == sql.ErrNoRows {
+			return 0, fmt.Errorf("animal %d not found", animalID)
+		}
+		if err != nil {
+			return 0, err
+		}
+		if (target == nil && !current.Valid) || (target != nil && current.Valid && current.Int64 == *target) {
+			continue
+		}
+		// Moves are kept in date order so the history always ends in the current group
+		if lastMove.Valid && date < lastMove.String {
+			return 0, fmt.Errorf("%s was last moved on %s; a move cannot be dated earlier", name, lastMove.String)
+		}
+
+		var from *int64
+		if current.Valid {
+			from = &current.Int64
+		}
+		if _, err := tx.Exec(`
+			INSERT INTO animal_movements (animal_id, date, from_group_id, to_group_id, reason) VALUES (?, ?, ?, ?, ?)
+		`, animalID, date, from, target, reason); err != nil {
+			return 0, err
+		}
+		if _, err := tx.Exec(`UPDATE animals SET group_id = ?, updated_at = CURRENT_TIMESTAMP WHERE id = ?`, target, animalID); err != nil {
+			return 0, err
+		}
+		moved++
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, err
+	}
+	return moved, nil
+}
+
+// GetAnimalMovements returns an animal's movement history, newest first
+func (s *GroupService) GetAnimalMovements(animalID int64) ([]AnimalMovement, error) {
+	return animalMovements(`m.animal_id = ?`, animalID)
+}
+
+// GetGroupMovements returns the moves into and out of a group within a date
+// range, newest first
+func (s *GroupService) GetGroupMovements(groupID int64, startDate, endDate string) ([]AnimalMovement, error) {
+	where := `(m.from_group_id = ? OR m.to_group_id = ?)`
+	args := []interface{}{groupID, groupID}
+	if startDate != "" {
+		where += " AND m.date >= ?"
+		args = append(args, startDate)
+	}
+	if endDate != "" {
+		where += " AND m.date <= ?"
+		args = append(args, endDate)
+	}
+	return animalMovements(where, args...)
+}
+
+func animalMovements(where string, args ...interface{}) ([]AnimalMovement, error) {
+	rows, err := db.Query(`
+		SELECT m.id, m.animal_id, a.name, m.date, m.from_group_id, fg.name, m.to_group_id, tg.name, m.reason, m.created_at
+		FROM animal_movements m
+		JOIN animals a ON m.animal_id = a.id
+		LEFT JOIN animal_groups fg ON m.from_group_id = fg.id
+		LEFT JOIN animal_groups tg ON m.to_group_id = tg.id
+		WHERE `+where+`
+		ORDER BY m.date DESC, m.id DESC
+	`, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	movements := []AnimalMovement{}
+	for rows.Next() {
+		var m AnimalMovement
+		var fromID, toID sql.NullInt64
+		var fromName, toName, reason sql.NullString
+		err := rows.Scan(&m.ID, &m.AnimalID, &m.AnimalName, &m.Date, &fromID, &fromName, &toID, &toName, &reason, &m.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+		if fromID.Valid {
+			m.FromGroupID = &fromID.Int64
+		}
+		if toID.Valid {
+			m.ToGroupID = &toID.Int64
+		}
+		m.FromGroupName = fromName.String
+		m.ToGroupName = toName.String
+		m.Reason = reason.String
+		movements = append(movements, m)
+	}
+	return movements, nil
+}
+
+// GetGroupAnimals returns the active animals currently in a group
+func (s *GroupService) GetGroupAnimals(groupID int64) ([]Animal, error) {
+	all, err := s.livestock.GetAllAnimals()
+	if err != nil {
+		return nil, err
+	}
+	animals := []Animal{}
+	for _, a := range all {
+		if a.Status == "active" && a.GroupID != nil && *a.GroupID == groupID {
+			animals = append(animals, a)
+		}
+	}
+	return animals, nil
+}
+
+// GetGroupMilkTotals returns the milk produced by each group within a date
+// range. Each milk record counts towards the group the animal was in on that
+// day, so totals stay correct after animals move.
+func (s *GroupService) GetGroupMilkTotals(startDate, endDate string) ([]GroupMilkTotal, error) {
+	startDate, endDate = groupPeriod(startDate, endDate)
+	rows, err := db.Query(`
+		SELECT COALESCE(t.group_id, 0), g.name, COALESCE(SUM(t.liters), 0), COUNT(DISTINCT t.animal_id), COUNT(*)
+		FROM (
+			SELECT mr.animal_id, mr.total_liters AS liters, `+milkRecordGroupSQL+` AS group_id
+			FROM milk_records mr
+			JOIN animals a ON mr.animal_id = a.id
+			WHERE mr.date >= ? AND mr.date <= ?
+		) t
+		LEFT JOIN animal_groups g ON t.group_id = g.id
+		GROUP BY COALESCE(t.group_id, 0)
+		ORDER BY g.name IS NULL, g.name
+	`, startDate, endDate)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	totals := []GroupMilkTotal{}
+	for rows.Next() {
+		var t GroupMilkTotal
+		var name sql.NullString
+		if err := rows.Scan(&t.GroupID, &name, &t.Liters, &t.Animals, &t.MilkRecords); err != nil {
+			return nil, err
+		}
+		t.GroupName = firstNonEmpty(name.String, "Unassigned")
+		t.Liters = math.Round(t.Liters*10) / 10
+		totals = append(totals, t)
+	}
+	return totals, nil
+}
+
+// GetGroupSummary returns a group's current animals with its milk and
+// feedings within a date range. The range defaults to the current month.
+func (s *GroupService) GetGroupSummary(groupID int64, startDate, endDate string) (*GroupSummary, error) {
+	group, err := s.GetGroup(groupID)
+	if err != nil {
+		return nil, err
+	}
+	startDate, endDate = groupPeriod(startDate, endDate)
+
+	summary := &GroupSummary{Group: *group, StartDate: startDate, EndDate: endDate}
+	if summary.Animals, err = s.GetGroupAnimals(groupID); err != nil {
+		return nil, err
+	}
+
+	err = db.QueryRow(`
+		SELECT COALESCE(SUM(mr.total_liters), 0), COUNT(*)
+		FROM milk_records mr
+		JOIN animals a ON mr.animal_id = a.id
+		WHERE mr.date >= ? AND mr.date <= ? AND `+milkRecordGroupSQL+` = ?
+	`, startDate, endDate, groupID).Scan(&summary.MilkLiters, &summary.MilkRecords)
+	if err != nil {
+		return nil, err
+	}
+	summary.MilkLiters = math.Round(summary.MilkLiters*10) / 10
+
+	records, err := feedRecords(startDate, endDate, groupID)
+	if err != nil {
+		return nil, err
+	}
+	summary.FeedRecords = records
+	if summary.FeedRecords == nil {
+		summary.FeedRecords = []FeedRecord{}
+	}
+	return summary, nil
+}
+
+// groupPeriod fills an empty date range with the current month
+func groupPeriod(startDate, endDate string) (string, string) {
+	now := time.Now()
+	if startDate == "" {
+		startDate = time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location()).Format("2006-01-02")
+	}
+	if endDate == "" {
+		endDate = now.Format("2006-01-02")
+	}
+	return startDate, endDate
+}
@@ -17,7 +17,8 @@ func NewHealthService() *HealthService {
 func (s *HealthService) GetVetRecords(animalId int64) ([]VetRecord, error) {
 	query := `
 		SELECT vr.id, vr.animal_id, a.name, vr.date, vr.record_type, vr.description, vr.diagnosis, 
-			   vr.treatment, vr.medicine, vr.dosage, vr.vet_name, vr.cost, vr.next_due_date, vr.notes, vr.created_at
+			   vr.treatment, vr.medicine, vr.dosage, vr.vet_name, vr.cost, vr.next_due_date, vr.notes, vr.created_at,
+			   vr.milk_withdrawal_days, vr.meat_withdrawal_days
 		FROM vet_records vr
 		JOIN animals a ON vr.animal_id = a.id
 	`
@@ -39,8 +40,10 @@ func (s *HealthService) GetVetRecords(animalId int64) ([]VetRecord, error) {
 	for rows.Next() {
 		var r VetRecord
 		var description, diagnosis, treatment, medicine, dosage, vetName, nextDueDate, notes sql.NullString
+		var milkDays, meatDays sql.NullInt64
 		err := rows.Scan(&r.ID, &r.AnimalID, &r.AnimalName, &r.Date, &r.RecordType, &description, &diagnosis,
-			&treatment, &medicine, &dosage, &vetName, &r.Cost, &nextDueDate, &notes, &r.CreatedAt)
+			&treatment, &medicine, &dosage, &vetName, &r.Cost, &nextDueDate, &notes, &r.CreatedAt,
+			&milkDays, &meatDays)
 		if err != nil {
 			return nil, err
 		}
@@ -52,18 +55,27 @@ func (s *HealthService) GetVetRecords(animalId int64) ([]VetRecord, error) {
 		r.VetName = vetName.String
 		r.NextDueDate = nextDueDate.String
 		r.Notes = notes.String
+		r.MilkWithdrawalDays = int(milkDays.Int64)
+		r.MeatWithdrawalDays = int(meatDays.Int64)
+		r.MilkWithheldUntil, r.MeatWithheldUntil = withheldUntil(r)
 		records = append(records, r)
 	}
 	return records, nil
 }
 
-// AddVetRecord adds a new vet record
+// AddVetRecord adds a new vet record. A medicine given without withdrawal
+// periods gets the periods saved for that medicine.
 func (s *HealthService) AddVetRecord(record VetRecord) (int64, error) {
+	if err := applyMedicineWithdrawal(&record); err != nil {
+		return 0, err
+	}
 	result, err := db.Exec(`
-		INSERT INTO vet_records (animal_id, date, record_type, description, diagnosis, treatment, medicine, dosage, vet_name, cost, next_due_date, notes)
-		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		INSERT INTO vet_records (animal_id, date, record_type, description, diagnosis, treatment, medicine, dosage, vet_name, cost, next_due_date, notes,
+			milk_withdrawal_days, meat_withdrawal_days)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 	`, record.AnimalID, record.Date, record.RecordType, record.Description, record.Diagnosis, record.Treatment,
-		record.Medicine, record.Dosage, record.VetName, record.Cost, record.NextDueDate, record.Notes)
+		record.Medicine, record.Dosage, record.VetName, record.Cost, record.NextDueDate, record.Notes,
+		record.MilkWithdrawalDays, record.MeatWithdrawalDays)
 	if err != nil {
 		return 0, err
 	}
@@ -84,17 +96,38 @@ func (s *HealthService) AddVetRecord(record VetRecord) (int64, error) {
 	return id, nil
 }
 
-// UpdateVetRecord updates an existing vet record
+// UpdateVetRecord updates an existing vet record. As when adding one, a
+// medicine given without withdrawal periods gets its saved periods.
 func (s *HealthService) UpdateVetRecord(record VetRecord) error {
+	if err := applyMedicineWithdrawal(&record); err != nil {
+		return err
+	}
 	_, err := db.Exec(`
 		UPDATE vet_records SET animal_id = ?, date = ?, record_type = ?, description = ?, diagnosis = ?, 
-			treatment = ?, medicine = ?, dosage = ?, vet_name = ?, cost = ?, next_due_date = ?, notes = ?
+			treatment = ?, medicine = ?, dosage = ?, vet_name = ?, cost = ?, next_due_date = ?, notes = ?,
+			milk_withdrawal_days = ?, meat_withdrawal_days = ?
 		WHERE id = ?
 	`, record.AnimalID, record.Date, record.RecordType, record.Description, record.Diagnosis, record.Treatment,
-		record.Medicine, record.Dosage, record.VetName, record.Cost, record.NextDueDate, record.Notes, record.ID)
+		record.Medicine, record.Dosage, record.VetName, record.Cost, record.NextDueDate, record.Notes,
+		record.MilkWithdrawalDays, record.MeatWithdrawalDays, record.ID)
 	return err
 }
 
+// applyMedicineWithdrawal checks a record's withdrawal periods and fills them
+// in from the saved periods of its medicine when none are given
+func applyMedicineWithdrawal(record *VetRecord) error {
+	if record.MilkWithdrawalDays < 0 || record.MeatWithdrawalDays < 0 {
+		return fmt.Errorf("withdrawal days cannot be negative")
+	}
+	if record.MilkWithdrawalDays == 0 && record.MeatWithdrawalDays == 0 {
+		if w, err := medicineWithdrawalFor(record.Medicine); err == nil && w != nil {
+			record.MilkWithdrawalDays = w.MilkDays
+			record.MeatWithdrawalDays = w.MeatDays
+		}
+	}
+	return nil
+}
+
 // DeleteVetRecord deletes a vet record
 func (s *HealthService) DeleteVetRecord(id int64) error {
 	_, err := db.Exec(`DELETE FROM vet_records WHERE id = ?`, id)
@@ -105,10 +138,12 @@ func (s *HealthService) DeleteVetRecord(id int64) error {
 func (s *HealthService) GetUpcomingVaccinations() ([]VetRecord, error) {
 	rows, err := db.Query(`
 		SELECT vr.id, vr.animal_id, a.name, vr.date, vr.record_type, vr.description, vr.diagnosis, 
-			   vr.treatment, vr.medicine, vr.dosage, vr.vet_name, vr.cost, vr.next_due_date, vr.notes, vr.created_at
+			   vr.treatment, vr.medicine, vr.dosage, vr.vet_name, vr.cost, vr.next_due_date, vr.notes, vr.created_at,
+			   vr.milk_withdrawal_days, vr.meat_withdrawal_days
 		FROM vet_records vr
 		JOIN animals a ON vr.animal_id = a.id
 		WHERE vr.next_due_date IS NOT NULL AND vr.next_due_date != '' AND vr.next_due_date >= date('now')
+		AND a.status = 'active'
 		ORDER BY vr.next_due_date ASC
 	`)
 	if err != nil {
@@ -120,8 +155,10 @@ func (s *HealthService) GetUpcomingVaccinations() ([]VetRecord, error) {
 	for rows.Next() {
 		var r VetRecord
 		var description, diagnosis, treatment, medicine, dosage, vetName, nextDueDate, notes sql.NullString
+		var milkDays, meatDays sql.NullInt64
 		err := rows.Scan(&r.ID, &r.AnimalID, &r.AnimalName, &r.Date, &r.RecordType, &description, &diagnosis,
-			&treatment, &medicine, &dosage, &vetName, &r.Cost, &nextDueDate, &notes, &r.CreatedAt)
+			&treatment, &medicine, &dosage, &vetName, &r.Cost, &nextDueDate, &notes, &r.CreatedAt,
+			&milkDays, &meatDays)
 		if err != nil {
 			return nil, err
 		}
@@ -133,6 +170,9 @@ func (s *HealthService) GetUpcomingVaccinations() ([]VetRecord, error) {
 		r.VetName = vetName.String
 		r.NextDueDate = nextDueDate.String
 		r.Notes = notes.String
+		r.MilkWithdrawalDays = int(milkDays.Int64)
+		r.MeatWithdrawalDays = int(meatDays.Int64)
+		r.MilkWithheldUntil, r.MeatWithheldUntil = withheldUntil(r)
 		records = append(records, r)
 	}
 	return records, nil
@@ -142,8 +182,10 @@ func (s *HealthService) GetUpcomingVaccinations() ([]VetRecord, error) {
 func (s *HealthService) GetPendingVetVisitsCount() (int, error) {
 	var count int
 	err := db.QueryRow(`
-		SELECT COUNT(*) FROM vet_records 
-		WHERE next_due_date IS NOT NULL AND next_due_date != '' AND next_due_date >= date('now') AND next_due_date <= date('now', '+30 days')
+		SELECT COUNT(*) FROM vet_records vr
+		JOIN animals a ON vr.animal_id = a.id
+		WHERE vr.next_due_date IS NOT NULL AND vr.next_due_date != '' AND vr.next_due_date >= date('now') AND vr.next_due_date <= date('now', '+30 days')
+		AND a.status = 'active'
 	`).Scan(&count)
 	return count, err
 }
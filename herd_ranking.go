@@ -0,0 +1,294 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+)
+
+// Herd ranking: milking cows are scored on yield, fertility, health cost and
+// age over the last year to suggest which to cull
+const (
+	herdRankingMonths = 12
+
+	yieldWeight     = 0.4
+	fertilityWeight = 0.3
+	healthWeight    = 0.15
+	ageWeight       = 0.15
+
+	// Cows scoring below cullScoreThreshold are culling candidates
+	cullScoreThreshold = 50.0
+	// A cow giving less than this share of her herd's average yield is a
+	// candidate whatever her other scores
+	cullYieldRatio = 0.5
+	// Services without a conception before a cow is treated as infertile
+	cullFailedServices = 3
+
+	targetServicesPerConception = 2.0
+	targetCalvingIntervalDays   = 400
+	primeAgeYears               = 6.0
+)
+
+// cowHistory collects the records a cow is ranked on
+type cowHistory struct {
+	liters      float64
+	days        int
+	services    int
+	conceptions int
+	calvings    []string
+	healthCost  float64
+}
+
+// GetHerdRanking ranks the active milking cows from the strongest culling
+// candidate to the cow most worth keeping, with the reasons for each ranking.
+// Cows are compared with the others of their species.
+func (s *LivestockService) GetHerdRanking() (*HerdRanking, error) {
+	now := time.Now()
+	endDate := now.Format("2006-01-02")
+	startDate := now.AddDate(0, -herdRankingMonths, 0).Format("2006-01-02")
+
+	cows, err := s.GetDairyCows()
+	if err != nil {
+		return nil, err
+	}
+	histories, err := cowHistories(startDate, endDate)
+	if err != nil {
+		return nil, err
+	}
+
+	// Herd averages per species, from cows with records in the period
+	type herdAverage struct {
+		liters, cost      float64
+		milking, recorded int
+	}
+	averages := map[string]*herdAverage{}
+	ranked := []Animal{}
+	for _, cow := range cows {
+		h := histories[cow.ID]
+		// Heifers still being reared, neither calved nor milked in the period,
+		// are listed as milking animals but are not yet in the milking herd
+		if h == nil || (h.days == 0 && len(h.calvings) == 0) {
+			continue
+		}
+		ranked = append(ranked, cow)
+		avg := averages[cow.Species]
+		if avg == nil {
+			avg = &herdAverage{}
+			averages[cow.Species] = avg
+		}
+		if h.days > 0 {
+			avg.liters += h.liters / float64(h.days)
+			avg.milking++
+		}
+		avg.cost += h.healthCost
+		avg.recorded++
+	}
+
+	report := &HerdRanking{StartDate: startDate, EndDate: endDate, Cows: []CowRanking{}}
+	for _, cow := range ranked {
+		avg := averages[cow.Species]
+		herdLiters, herdCost := 0.0, avg.cost/float64(avg.recorded)
+		if avg.milking > 0 {
+			herdLiters = avg.liters / float64(avg.milking)
+		}
+		r := rankCow(cow, histories[cow.ID], herdLiters, herdCost, startDate, endDate)
+		if r.CullCandidate {
+			report.Candidates++
+		}
+		report.Cows = append(report.Cows, r)
+	}
+
+	sort.SliceStable(report.Cows, func(i, j int) bool { return report.Cows[i].Score < report.Cows[j].Score })
+	for i := range report.Cows {
+		report.Cows[i].Rank = i + 1
+	}
+	return report, nil
+}
+
+// cowHistories loads milk and vet records in the period and the full breeding
+// history of every cow
+func cowHistories(startDate, endDate string) (map[int64]*cowHistory, error) {
+	histories := map[int64]*cowHistory{}
+	history := func(id int64) *cowHistory {
+		if histories[id] == nil {
+			histories[id] = &cowHistory{}
+		}
+		return histories[id]
+	}
+
+	rows, err := db.Query(`
+		SELECT animal_id, SUM(total_liters), COUNT(DISTINCT date) FROM milk_records
+		WHERE date >= ? AND date <= ?
+		GROUP BY animal_id
+	`, startDate, endDate)
+	if err != nil {
+		return nil, err
+	}
+	for rows.Next() {
+		var id int64
+		var liters float64
+		var days int
+		if err := rows.Scan(&id, &liters, &days); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		h := history(id)
+		h.liters, h.days = liters, days
+	}
+	rows.Close()
+
+	rows, err = db.Query(`
+		SELECT female_id, COALESCE(pregnancy_status, ''), COALESCE(actual_birth_date, '') FROM breeding_records
+		ORDER BY actual_birth_date
+	`)
+	if err != nil {
+		return nil, err
+	}
+	for rows.Next() {
+		var id int64
+		var status, birth string
+		if err := rows.Scan(&id, &status, &birth); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		h := history(id)
+		// Pending services have no outcome yet
+		switch {
+		case birth != "" || status == "confirmed" || status == "delivered":
+			h.services++
+			h.conceptions++
+		case status == "failed":
+			h.services++
+		}
+		if birth != "" && (len(h.calvings) == 0 || h.calvings[len(h.calvings)-1] != birth) {
+			h.calvings = append(h.calvings, birth)
+		}
+	}
+	rows.Close()
+
+	rows, err = db.Query(`
+		SELECT animal_id, SUM(cost) FROM vet_records
+		WHERE date >= ? AND date <= ?
+		GROUP BY animal_id
+	`, startDate, endDate)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var id int64
+		var cost float64
+		if err := rows.Scan(&id, &cost); err != nil {
+			return nil, err
+		}
+		history(id).healthCost = cost
+	}
+	return histories, rows.Err()
+}
+
+// rankCow scores a cow against her herd's average yield and vet costs and
+// explains the weak points behind her score
+func rankCow(cow Animal, h *cowHistory, herdLiters, herdCost float64, startDate, endDate string) CowRanking {
+	r := CowRanking{
+		AnimalID:    cow.ID,
+		AnimalName:  cow.Name,
+		TagNumber:   cow.TagNumber,
+		Species:     cow.Species,
+		Breed:       cow.Breed,
+		Services:    h.services,
+		Conceptions: h.conceptions,
+		Calvings:    len(h.calvings),
+		HealthCost:  h.healthCost,
+		Reasons:     []string{},
+	}
+
+	// Yield: a cow at the herd average scores 75
+	r.RecordedDays = h.days
+	if h.days == 0 {
+		r.Reasons = append(r.Reasons, fmt.Sprintf("No milk recorded since %s", startDate))
+	} else {
+		r.AvgDailyLiters = h.liters / float64(h.days)
+		ratio := 1.0
+		if herdLiters > 0 {
+			ratio = r.AvgDailyLiters / herdLiters
+		}
+		r.YieldScore = clampScore(ratio * 75)
+		if ratio < cullYieldRatio {
+			r.CullCandidate = true
+		}
+		if ratio < 0.8 {
+			r.Reasons = append(r.Reasons, fmt.Sprintf("Gives %.1f L/day, %.0f%% of the herd average of %.1f L/day", r.AvgDailyLiters, ratio*100, herdLiters))
+		}
+	}
+
+	// Fertility: repeat services and long calving intervals cost points
+	if h.conceptions > 0 {
+		r.ServicesPerConception = float64(h.services) / float64(h.conceptions)
+	}
+	if len(h.calvings) > 1 {
+		total := daysBetween(h.calvings[0], h.calvings[len(h.calvings)-1])
+		r.CalvingIntervalDays = total / (len(h.calvings) - 1)
+	}
+	switch {
+	case h.services == 0 && len(h.calvings) == 0:
+		r.FertilityScore = 50
+		r.Reasons = append(r.Reasons, "No breeding outcomes recorded")
+	case h.conceptions == 0:
+		r.FertilityScore = clampScore(100 - 30*float64(h.services))
+		if h.services >= cullFailedServices {
+			r.CullCandidate = true
+		}
+		services := fmt.Sprintf("%d services", h.services)
+		if h.services == 1 {
+			services = "1 service"
+		}
+		r.Reasons = append(r.Reasons, "No conception from "+services)
+	default:
+		score := 100.0
+		if r.ServicesPerConception > targetServicesPerConception {
+			score -= (r.ServicesPerConception - targetServicesPerConception) * 25
+			r.Reasons = append(r.Reasons, fmt.Sprintf("%.1f services per conception", r.ServicesPerConception))
+		}
+		if r.CalvingIntervalDays > targetCalvingIntervalDays {
+			score -= float64(r.CalvingIntervalDays-targetCalvingIntervalDays) / 3
+			r.Reasons = append(r.Reasons, fmt.Sprintf("Calving interval of %d days", r.CalvingIntervalDays))
+		}
+		r.FertilityScore = clampScore(score)
+	}
+
+	// Health: a cow costing the herd average scores 75
+	r.HealthScore = 100
+	if h.healthCost > 0 && herdCost > 0 {
+		ratio := h.healthCost / herdCost
+		r.HealthScore = clampScore(100 - ratio*25)
+		if ratio >= 2 {
+			r.Reasons = append(r.Reasons, fmt.Sprintf("Vet costs of %.0f, %.1f times the herd average", h.healthCost, ratio))
+		}
+	}
+
+	// Age: full marks up to prime age, then 20 points off a year
+	r.AgeScore = 75
+	if cow.DateOfBirth != "" {
+		r.AgeYears = float64(daysBetween(cow.DateOfBirth, endDate)) / 365.25
+		r.AgeScore = clampScore(100 - (r.AgeYears-primeAgeYears)*20)
+		if r.AgeYears >= primeAgeYears+2 {
+			r.Reasons = append(r.Reasons, fmt.Sprintf("%.0f years old", math.Floor(r.AgeYears)))
+		}
+	}
+
+	r.Score = math.Round((r.YieldScore*yieldWeight+r.FertilityScore*fertilityWeight+
+		r.HealthScore*healthWeight+r.AgeScore*ageWeight)*10) / 10
+	if r.Score < cullScoreThreshold {
+		r.CullCandidate = true
+	}
+	if len(r.Reasons) == 0 {
+		r.Reasons = append(r.Reasons, "In line with the herd on yield, fertility, health and age")
+	}
+	return r
+}
+
+// clampScore keeps a score between 0 and 100, to one decimal place
+func clampScore(score float64) float64 {
+	return math.Round(math.Max(0, math.Min(100, score))*10) / 10
+}
@@ -0,0 +1,311 @@
+package main
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"log"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Life-stage transitions: a background job moves animals to their next type
+// (calf to heifer, heifer to cow) using the rules saved in settings
+const (
+	lifeStageSettingsSetting = "life_stage_settings"
+	lifeStageCheckInterval   = 6 * time.Hour
+	daysPerMonth             = 30.4375
+	// An animal can pass through several stages in one run when it was
+	// entered late, e.g. a piglet that is already a sow
+	maxLifeStageSteps = 5
+)
+
+// defaultLifeStageRules are the usual stages for each species. Ages follow
+// common dairy and smallholder practice: a dairy calf is counted as a heifer
+// from about a year, when she is grown enough to be served.
+var defaultLifeStageRules = []LifeStageRule{
+	{Species: "cattle", Breed: "default", FromType: "calf", ToType: "heifer", Gender: "female", Trigger: "age", AgeMonths: 12},
+	{Species: "cattle", Breed: "default", FromType: "heifer", ToType: "cow", Gender: "female", Trigger: "first_birth"},
+	{Species: "cattle", Breed: "default", FromType: "calf", ToType: "bull", Gender: "male", Trigger: "age", AgeMonths: 12},
+	{Species: "goat", Breed: "default", FromType: "kid", ToType: "doeling", Gender: "female", Trigger: "age", AgeMonths: 3},
+	{Species: "goat", Breed: "default", FromType: "doeling", ToType: "doe", Gender: "female", Trigger: "first_birth"},
+	{Species: "goat", Breed: "default", FromType: "kid", ToType: "buck", Gender: "male", Trigger: "age", AgeMonths: 6},
+	{Species: "sheep", Breed: "default", FromType: "lamb", ToType: "ewe_lamb", Gender: "female", Trigger: "age", AgeMonths: 4},
+	{Species: "sheep", Breed: "default", FromType: "ewe_lamb", ToType: "ewe", Gender: "female", Trigger: "first_birth"},
+	{Species: "sheep", Breed: "default", FromType: "lamb", ToType: "ram", Gender: "male", Trigger: "age", AgeMonths: 8},
+	{Species: "pig", Breed: "default", FromType: "piglet", ToType: "weaner", Trigger: "age", AgeMonths: 1},
+	{Species: "pig", Breed: "default", FromType: "weaner", ToType: "gilt", Gender: "female", Trigger: "age", AgeMonths: 4},
+	{Species: "pig", Breed: "default", FromType: "gilt", ToType: "sow", Gender: "female", Trigger: "first_birth"},
+	{Species: "pig", Breed: "default", FromType: "weaner", ToType: "boar", Gender: "male", Trigger: "age", AgeMonths: 6},
+}
+
+// GetLifeStageSettings returns the saved life-stage rules, or the defaults
+func (s *LivestockService) GetLifeStageSettings() (*LifeStageSettings, error) {
+	return lifeStageSettings()
+}
+
+// SaveLifeStageSettings validates and saves the life-stage rules
+func (s *LivestockService) SaveLifeStageSettings(settings LifeStageSettings) (*LifeStageSettings, error) {
+	seen := map[string]bool{}
+	for i := range settings.Rules {
+		r := &settings.Rules[i]
+		r.Species = firstNonEmpty(r.Species, defaultSpecies)
+		r.Breed = firstNonEmpty(strings.TrimSpace(r.Breed), "default")
+		profile, ok := speciesProfile(r.Species)
+		if !ok || profile.FlockOnly {
+			return nil, fmt.Errorf("life stages cannot be set for %q", r.Species)
+		}
+		if !slices.Contains(profile.Types, r.FromType) || !slices.Contains(profile.Types, r.ToType) || r.FromType == r.ToType {
+			return nil, fmt.Errorf("%s to %s is not a valid %s life stage", r.FromType, r.ToType, r.Species)
+		}
+		if r.Gender != "" && r.Gender != "male" && r.Gender != "female" {
+			return nil, fmt.Errorf("unknown gender %q", r.Gender)
+		}
+		switch r.Trigger {
+		case "age":
+			if r.AgeMonths <= 0 {
+				return nil, fmt.Errorf("%s to %s needs an age in months", r.FromType, r.ToType)
+			}
+		case "first_birth":
+			r.Gender = "female"
+			r.AgeMonths = 0
+		default:
+			return nil, fmt.Errorf("unknown trigger %q", r.Trigger)
+		}
+		key := strings.ToLower(r.Species + "|" + r.Breed + "|" + r.FromType + "|" + r.Gender)
+		if seen[key] {
+			return nil, fmt.Errorf("%s %s already has a rule for %s", r.Breed, r.Species, r.FromType)
+		}
+		seen[key] = true
+	}
+
+	data, err := json.Marshal(settings)
+	if err != nil {
+		return nil, err
+	}
+	if err := setSetting(lifeStageSettingsSetting, string(data)); err != nil {
+		return nil, err
+	}
+	return &settings, nil
+}
+
+func lifeStageSettings() (*LifeStageSettings, error) {
+	settings := LifeStageSettings{Enabled: true, Rules: defaultLifeStageRules}
+	value := getSetting(lifeStageSettingsSetting, "")
+	if value == "" {
+		return &settings, nil
+	}
+	if err := json.Unmarshal([]byte(value), &settings); err != nil {
+		return nil, fmt.Errorf("invalid saved life stage settings: %w", err)
+	}
+	return &settings, nil
+}
+
+// StartLifeStageWorker applies the life-stage rules now and then every few
+// hours while the app runs. It must be started after the database is open.
+func (s *LivestockService) StartLifeStageWorker() {
+	go func() {
+		ticker := time.NewTicker(lifeStageCheckInterval)
+		defer ticker.Stop()
+
+		for {
+			settings, err := lifeStageSettings()
+			if err == nil && settings.Enabled {
+				if _, err := s.ApplyLifeStageTransitions(); err != nil {
+					log.Printf("Life stage transitions failed: %v", err)
+				}
+			}
+			<-ticker.C
+		}
+	}()
+}
+
+// ApplyLifeStageTransitions moves every active animal that has reached its
+// next life stage to the new type and records the change in its history.
+// It returns the changes made.
+func (s *LivestockService) ApplyLifeStageTransitions() ([]AnimalTypeChange, error) {
+	settings, err := lifeStageSettings()
+	if err != nil {
+		return nil, err
+	}
+	animals, err := s.GetAllAnimals()
+	if err != nil {
+		return nil, err
+	}
+	firstBirths, err := firstBirthDates()
+	if err != nil {
+		return nil, err
+	}
+	lastChanges := map[int64]string{}
+	rows, err := db.Query(`SELECT animal_id, MAX(date) FROM animal_type_changes GROUP BY animal_id`)
+	if err != nil {
+		return nil, err
+	}
+	for rows.Next() {
+		var id int64
+		var date string
+		if err := rows.Scan(&id, &date); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		lastChanges[id] = date
+	}
+	rows.Close()
+
+	today := time.Now().Format("2006-01-02")
+	tx, err := db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	changes := []AnimalTypeChange{}
+	for _, a := range animals {
+		if a.Status != "active" {
+			continue
+		}
+		from := a.Type
+		for step := 0; step < maxLifeStageSteps; step++ {
+			rule := lifeStageRuleFor(settings.Rules, a)
+			if rule == nil {
+				break
+			}
+			date, reason := lifeStageDue(*rule, a, firstBirths[a.ID])
+			if date == "" || date > today {
+				break
+			}
+			// Keep the history in order when the stage was due before an earlier change
+			if last := lastChanges[a.ID]; date < last {
+				date = last
+			}
+			if _, err := tx.Exec(`
+				INSERT INTO animal_type_changes (animal_id, date, from_type, to_type, reason, automatic) VALUES (?, ?, ?, ?, ?, 1)
+			`, a.ID, date, a.Type, rule.ToType, reason); err != nil {
+				return nil, err
+			}
+			changes = append(changes, AnimalTypeChange{
+				AnimalID: a.ID, AnimalName: a.Name, Date: date,
+				FromType: a.Type, ToType: rule.ToType, Reason: reason, Automatic: true,
+			})
+			a.Type = rule.ToType
+			lastChanges[a.ID] = date
+		}
+		if a.Type != from {
+			if _, err := tx.Exec(`UPDATE animals SET type = ?, updated_at = CURRENT_TIMESTAMP WHERE id = ?`, a.Type, a.ID); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return changes, nil
+}
+
+// lifeStageRuleFor finds the rule that moves an animal on from its current
+// type, preferring a rule for its breed over the species default
+func lifeStageRuleFor(rules []LifeStageRule, animal Animal) *LifeStageRule {
+	species := firstNonEmpty(animal.Species, defaultSpecies)
+	var fallback *LifeStageRule
+	for i := range rules {
+		r := &rules[i]
+		if firstNonEmpty(r.Species, defaultSpecies) != species || r.FromType != animal.Type {
+			continue
+		}
+		if r.Gender != "" && r.Gender != animal.Gender {
+			continue
+		}
+		if strings.EqualFold(r.Breed, animal.Breed) {
+			return r
+		}
+		if strings.EqualFold(r.Breed, "default") && fallback == nil {
+			fallback = r
+		}
+	}
+	return fallback
+}
+
+// lifeStageDue returns the date an animal reaches a rule's stage and the
+// reason recorded for it, or an empty date when it cannot be worked out
+func lifeStageDue(rule LifeStageRule, animal Animal, firstBirth string) (string, string) {
+	switch rule.Trigger {
+	case "age":
+		dob, err := time.Parse("2006-01-02", animal.DateOfBirth)
+		if err != nil {
+			return "", ""
+		}
+		days := int(math.Round(rule.AgeMonths * daysPerMonth))
+		months := strconv.FormatFloat(rule.AgeMonths, 'f', -1, 64) + " months"
+		if rule.AgeMonths == 1 {
+			months = "1 month"
+		}
+		return dob.AddDate(0, 0, days).Format("2006-01-02"), "Reached " + months
+	case "first_birth":
+		if firstBirth == "" {
+			return "", ""
+		}
+		return firstBirth, "First birth"
+	}
+	return "", ""
+}
+
+// firstBirthDates returns each female's first recorded birth, from breeding
+// records and from offspring entered with her as their mother
+func firstBirthDates() (map[int64]string, error) {
+	rows, err := db.Query(`
+		SELECT mother, MIN(date) FROM (
+			SELECT female_id AS mother, actual_birth_date AS date FROM breeding_records
+			WHERE actual_birth_date IS NOT NULL AND actual_birth_date != ''
+			UNION ALL
+			SELECT mother_id, date_of_birth FROM animals
+			WHERE mother_id IS NOT NULL AND date_of_birth IS NOT NULL AND date_of_birth != ''
+		)
+		GROUP BY mother
+	`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	births := map[int64]string{}
+	for rows.Next() {
+		var id int64
+		var date string
+		if err := rows.Scan(&id, &date); err != nil {
+			return nil, err
+		}
+		births[id] = date
+	}
+	return births, nil
+}
+
+// GetAnimalTypeHistory returns an animal's type changes, newest first
+func (s *LivestockService) GetAnimalTypeHistory(animalID int64) ([]AnimalTypeChange, error) {
+	rows, err := db.Query(`
+		SELECT c.id, c.animal_id, a.name, c.date, c.from_type, c.to_type, c.reason, c.automatic, c.created_at
+		FROM animal_type_changes c
+		JOIN animals a ON c.animal_id = a.id
+		WHERE c.animal_id = ?
+		ORDER BY c.date DESC, c.id DESC
+	`, animalID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	changes := []AnimalTypeChange{}
+	for rows.Next() {
+		var c AnimalTypeChange
+		var reason sql.NullString
+		err := rows.Scan(&c.ID, &c.AnimalID, &c.AnimalName, &c.Date, &c.FromType, &c.ToType, &reason, &c.Automatic, &c.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+		c.Reason = reason.String
+		changes = append(changes, c)
+	}
+	return changes, nil
+}
@@ -0,0 +1,45 @@
+package main
+
+import "testing"
+
+func TestLifeStageRules(t *testing.T) {
+	rules := append([]LifeStageRule{
+		{Species: "cattle", Breed: "Jersey", FromType: "calf", ToType: "heifer", Gender: "female", Trigger: "age", AgeMonths: 14},
+	}, defaultLifeStageRules...)
+
+	tests := []struct {
+		name       string
+		animal     Animal
+		firstBirth string
+		wantType   string
+		wantDate   string
+		wantReason string
+	}{
+		{"heifer calf", Animal{Type: "calf", Gender: "female", Breed: "Friesian", DateOfBirth: "2024-01-15"}, "", "heifer", "2025-01-14", "Reached 12 months"},
+		{"breed rule before the default", Animal{Type: "calf", Gender: "female", Breed: "jersey", DateOfBirth: "2024-01-15"}, "", "heifer", "2025-03-16", "Reached 14 months"},
+		{"bull calf", Animal{Type: "calf", Gender: "male", DateOfBirth: "2024-01-15"}, "", "bull", "2025-01-14", "Reached 12 months"},
+		{"calf without a birth date", Animal{Type: "calf", Gender: "female"}, "", "heifer", "", ""},
+		{"heifer that calved", Animal{Type: "heifer", Gender: "female", DateOfBirth: "2023-01-01"}, "2025-02-10", "cow", "2025-02-10", "First birth"},
+		{"heifer not yet calved", Animal{Type: "heifer", Gender: "female", DateOfBirth: "2023-01-01"}, "", "cow", "", ""},
+		{"steer has no next stage", Animal{Type: "steer", Gender: "male", DateOfBirth: "2023-01-01"}, "", "", "", ""},
+		{"goat kid", Animal{Species: "goat", Type: "kid", Gender: "female", DateOfBirth: "2024-03-01"}, "", "doeling", "2024-05-31", "Reached 3 months"},
+		{"piglet of either sex", Animal{Species: "pig", Type: "piglet", DateOfBirth: "2024-03-01"}, "", "weaner", "2024-03-31", "Reached 1 month"},
+		{"rule of another species", Animal{Species: "sheep", Type: "calf", Gender: "female", DateOfBirth: "2024-03-01"}, "", "", "", ""},
+	}
+	for _, tt := range tests {
+		rule := lifeStageRuleFor(rules, tt.animal)
+		if rule == nil {
+			if tt.wantType != "" {
+				t.Errorf("%s: no rule, want %s", tt.name, tt.wantType)
+			}
+			continue
+		}
+		if rule.ToType != tt.wantType {
+			t.Errorf("%s: moves to %q, want %q", tt.name, rule.ToType, tt.wantType)
+		}
+		date, reason := lifeStageDue(*rule, tt.animal, tt.firstBirth)
+		if date != tt.wantDate || reason != tt.wantReason {
+			t.Errorf("%s: due %q (%q), want %q (%q)", tt.name, date, reason, tt.wantDate, tt.wantReason)
+		}
+	}
+}
@@ -2,7 +2,12 @@ package main
 
 import (
 	"database/sql"
+	"encoding/json"
 	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
 	"time"
 )
 
@@ -17,12 +22,15 @@ func NewLivestockService() *LivestockService {
 // GetAllAnimals returns all animals
 func (s *LivestockService) GetAllAnimals() ([]Animal, error) {
 	rows, err := db.Query(`
-		SELECT a.id, a.tag_number, a.name, a.type, a.breed, a.date_of_birth, a.gender, 
+		SELECT a.id, a.tag_number, a.name, COALESCE(a.species, 'cattle'), a.type, a.breed, a.date_of_birth, a.gender, 
 			   a.mother_id, m.name, a.father_id, f.name,
-			   a.status, a.notes, a.created_at, a.updated_at
+			   a.status, a.notes, a.created_at, a.updated_at,
+			   a.acquisition_type, a.seller, a.purchase_date, a.purchase_price, a.transport_cost,
+			   a.quarantine_days, a.quarantine_end_date, a.group_id, g.name
 		FROM animals a
 		LEFT JOIN animals m ON a.mother_id = m.id
 		LEFT JOIN animals f ON a.father_id = f.id
+		LEFT JOIN animal_groups g ON a.group_id = g.id
 		ORDER BY a.date_of_birth ASC
 	`)
 	if err != nil {
@@ -32,29 +40,10 @@ func (s *LivestockService) GetAllAnimals() ([]Animal, error) {
 
 	var animals []Animal
 	for rows.Next() {
-		var a Animal
-		var tagNumber, breed, dateOfBirth, gender, notes sql.NullString
-		var motherID, fatherID sql.NullInt64
-		var motherName, fatherName sql.NullString
-		err := rows.Scan(&a.ID, &tagNumber, &a.Name, &a.Type, &breed, &dateOfBirth, &gender,
-			&motherID, &motherName, &fatherID, &fatherName,
-			&a.Status, &notes, &a.CreatedAt, &a.UpdatedAt)
+		a, err := scanAnimal(rows)
 		if err != nil {
 			return nil, err
 		}
-		a.TagNumber = tagNumber.String
-		a.Breed = breed.String
-		a.DateOfBirth = dateOfBirth.String
-		a.Gender = gender.String
-		a.Notes = notes.String
-		if motherID.Valid {
-			a.MotherID = &motherID.Int64
-		}
-		if fatherID.Valid {
-			a.FatherID = &fatherID.Int64
-		}
-		a.MotherName = motherName.String
-		a.FatherName = fatherName.String
 		animals = append(animals, a)
 	}
 	return animals, nil
@@ -62,24 +51,48 @@ func (s *LivestockService) GetAllAnimals() ([]Animal, error) {
 
 // GetAnimal returns a single animal by ID with parent info
 func (s *LivestockService) GetAnimal(id int64) (*Animal, error) {
-	var a Animal
-	var tagNumber, breed, dateOfBirth, gender, notes sql.NullString
-	var motherID, fatherID sql.NullInt64
-	var motherName, fatherName sql.NullString
-	err := db.QueryRow(`
-		SELECT a.id, a.tag_number, a.name, a.type, a.breed, a.date_of_birth, a.gender,
+	row := db.QueryRow(`
+		SELECT a.id, a.tag_number, a.name, COALESCE(a.species, 'cattle'), a.type, a.breed, a.date_of_birth, a.gender,
 			   a.mother_id, m.name, a.father_id, f.name,
-			   a.status, a.notes, a.created_at, a.updated_at
+			   a.status, a.notes, a.created_at, a.updated_at,
+			   a.acquisition_type, a.seller, a.purchase_date, a.purchase_price, a.transport_cost,
+			   a.quarantine_days, a.quarantine_end_date, a.group_id, g.name
 		FROM animals a
 		LEFT JOIN animals m ON a.mother_id = m.id
 		LEFT JOIN animals f ON a.father_id = f.id
+		LEFT JOIN animal_groups g ON a.group_id = g.id
 		WHERE a.id = ?
-	`, id).Scan(&a.ID, &tagNumber, &a.Name, &a.Type, &breed, &dateOfBirth, &gender,
-		&motherID, &motherName, &fatherID, &fatherName,
-		&a.Status, &notes, &a.CreatedAt, &a.UpdatedAt)
+	`, id)
+	a, err := scanAnimal(row)
 	if err != nil {
 		return nil, err
 	}
+	return &a, nil
+}
+
+// rowScanner is implemented by *sql.Row and *sql.Rows
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// scanAnimal scans a full animal row as selected by GetAnimal, GetAllAnimals and GetOffspring
+func scanAnimal(row rowScanner) (Animal, error) {
+	var a Animal
+	var tagNumber, breed, dateOfBirth, gender, notes sql.NullString
+	var motherID, fatherID sql.NullInt64
+	var motherName, fatherName sql.NullString
+	var acquisitionType, seller, purchaseDate, quarantineEnd sql.NullString
+	var purchasePrice, transportCost sql.NullFloat64
+	var quarantineDays, groupID sql.NullInt64
+	var groupName sql.NullString
+	err := row.Scan(&a.ID, &tagNumber, &a.Name, &a.Species, &a.Type, &breed, &dateOfBirth, &gender,
+		&motherID, &motherName, &fatherID, &fatherName,
+		&a.Status, &notes, &a.CreatedAt, &a.UpdatedAt,
+		&acquisitionType, &seller, &purchaseDate, &purchasePrice, &transportCost,
+		&quarantineDays, &quarantineEnd, &groupID, &groupName)
+	if err != nil {
+		return a, err
+	}
 	a.TagNumber = tagNumber.String
 	a.Breed = breed.String
 	a.DateOfBirth = dateOfBirth.String
@@ -93,31 +106,150 @@ func (s *LivestockService) GetAnimal(id int64) (*Animal, error) {
 	}
 	a.MotherName = motherName.String
 	a.FatherName = fatherName.String
-	return &a, nil
+	a.AcquisitionType = firstNonEmpty(acquisitionType.String, "born")
+	a.Seller = seller.String
+	a.PurchaseDate = purchaseDate.String
+	a.PurchasePrice = purchasePrice.Float64
+	a.TransportCost = transportCost.Float64
+	a.QuarantineDays = int(quarantineDays.Int64)
+	a.QuarantineEndDate = quarantineEnd.String
+	if groupID.Valid {
+		a.GroupID = &groupID.Int64
+	}
+	a.GroupName = groupName.String
+	return a, nil
 }
 
-// AddAnimal adds a new animal
+// AddAnimal adds a new animal. Purchased animals also post their purchase
+// price and transport cost as expenses, and an animal added straight into a
+// group gets its first movement record.
 func (s *LivestockService) AddAnimal(animal Animal) (int64, error) {
+	if err := validateSpecies(&animal); err != nil {
+		return 0, err
+	}
+	normalizeAcquisition(&animal)
+	if animal.GroupID != nil && *animal.GroupID == 0 {
+		animal.GroupID = nil
+	}
 	result, err := db.Exec(`
-		INSERT INTO animals (tag_number, name, type, breed, date_of_birth, gender, mother_id, father_id, status, notes)
-		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
-	`, animal.TagNumber, animal.Name, animal.Type, animal.Breed, animal.DateOfBirth, animal.Gender,
-		animal.MotherID, animal.FatherID, animal.Status, animal.Notes)
+		INSERT INTO animals (tag_number, name, species, type, breed, date_of_birth, gender, mother_id, father_id, status, notes,
+			acquisition_type, seller, purchase_date, purchase_price, transport_cost, quarantine_days, quarantine_end_date, group_id)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`, animal.TagNumber, animal.Name, animal.Species, animal.Type, animal.Breed, animal.DateOfBirth, animal.Gender,
+		animal.MotherID, animal.FatherID, animal.Status, animal.Notes,
+		animal.AcquisitionType, animal.Seller, animal.PurchaseDate, animal.PurchasePrice, animal.TransportCost,
+		animal.QuarantineDays, animal.QuarantineEndDate, animal.GroupID)
 	if err != nil {
 		return 0, err
 	}
-	return result.LastInsertId()
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get last insert id: %w", err)
+	}
+
+	if animal.GroupID != nil {
+		// Date the move from the animal's arrival so earlier moves can still be recorded
+		date := firstNonEmpty(animal.PurchaseDate, animal.DateOfBirth, time.Now().Format("2006-01-02"))
+		if _, err := db.Exec(`
+			INSERT INTO animal_movements (animal_id, date, to_group_id, reason) VALUES (?, ?, ?, ?)
+		`, id, date, *animal.GroupID, "Added to herd"); err != nil {
+			_ = err // Log error but continue
+		}
+	}
+
+	if animal.AcquisitionType == "purchased" {
+		// Automatically record in finances
+		description := fmt.Sprintf("Purchase of %s", animal.Name)
+		if animal.Seller != "" {
+			description += " from " + animal.Seller
+		}
+		if err := addTransactionInternal(animal.PurchaseDate, "expense", "livestock_purchase",
+			description, animal.PurchasePrice, fmt.Sprintf("animal:%d", id)); err != nil {
+			_ = err // Log error but continue
+		}
+		if err := addTransactionInternal(animal.PurchaseDate, "expense", "transport",
+			fmt.Sprintf("Transport of %s", animal.Name), animal.TransportCost, fmt.Sprintf("animal:%d", id)); err != nil {
+			_ = err // Log error but continue
+		}
+	}
+
+	return id, nil
 }
 
-// UpdateAnimal updates an existing animal
+// UpdateAnimal updates an existing animal. Changing acquisition details does
+// not touch the expenses already posted by AddAnimal, and callers that leave
+// AcquisitionType or Species empty keep the stored values. The group is not
+// changed here; use GroupService.MoveAnimals so the move is recorded. A
+// change of type is recorded in the animal's type history.
 func (s *LivestockService) UpdateAnimal(animal Animal) error {
+	if animal.AcquisitionType == "" {
+		if existing, err := s.GetAnimal(animal.ID); err == nil {
+			animal.AcquisitionType = existing.AcquisitionType
+			animal.Seller = existing.Seller
+			animal.PurchaseDate = existing.PurchaseDate
+			animal.PurchasePrice = existing.PurchasePrice
+			animal.TransportCost = existing.TransportCost
+			animal.QuarantineDays = existing.QuarantineDays
+		}
+	}
+	if animal.Species == "" {
+		if existing, err := s.GetAnimal(animal.ID); err == nil {
+			animal.Species = existing.Species
+		}
+	}
+	if err := validateSpecies(&animal); err != nil {
+		return err
+	}
+	normalizeAcquisition(&animal)
+
+	var previousType string
+	_ = db.QueryRow(`SELECT type FROM animals WHERE id = ?`, animal.ID).Scan(&previousType)
+
 	_, err := db.Exec(`
-		UPDATE animals SET tag_number = ?, name = ?, type = ?, breed = ?, date_of_birth = ?, 
-			gender = ?, mother_id = ?, father_id = ?, status = ?, notes = ?, updated_at = CURRENT_TIMESTAMP
+		UPDATE animals SET tag_number = ?, name = ?, species = ?, type = ?, breed = ?, date_of_birth = ?, 
+			gender = ?, mother_id = ?, father_id = ?, status = ?, notes = ?,
+			acquisition_type = ?, seller = ?, purchase_date = ?, purchase_price = ?, transport_cost = ?,
+			quarantine_days = ?, quarantine_end_date = ?, updated_at = CURRENT_TIMESTAMP
 		WHERE id = ?
-	`, animal.TagNumber, animal.Name, animal.Type, animal.Breed, animal.DateOfBirth,
-		animal.Gender, animal.MotherID, animal.FatherID, animal.Status, animal.Notes, animal.ID)
-	return err
+	`, animal.TagNumber, animal.Name, animal.Species, animal.Type, animal.Breed, animal.DateOfBirth,
+		animal.Gender, animal.MotherID, animal.FatherID, animal.Status, animal.Notes,
+		animal.AcquisitionType, animal.Seller, animal.PurchaseDate, animal.PurchasePrice, animal.TransportCost,
+		animal.QuarantineDays, animal.QuarantineEndDate, animal.ID)
+	if err != nil {
+		return err
+	}
+
+	if previousType != "" && previousType != animal.Type {
+		if _, err := db.Exec(`
+			INSERT INTO animal_type_changes (animal_id, date, from_type, to_type, reason) VALUES (?, ?, ?, ?, ?)
+		`, animal.ID, time.Now().Format("2006-01-02"), previousType, animal.Type, "Edited"); err != nil {
+			_ = err // Log error but continue
+		}
+	}
+	return nil
+}
+
+// normalizeAcquisition fills acquisition defaults and derives the quarantine end date
+func normalizeAcquisition(animal *Animal) {
+	if animal.AcquisitionType != "purchased" {
+		animal.AcquisitionType = "born"
+		animal.Seller = ""
+		animal.PurchasePrice = 0
+		animal.TransportCost = 0
+		animal.QuarantineDays = 0
+		animal.QuarantineEndDate = ""
+		return
+	}
+	if animal.PurchaseDate == "" {
+		animal.PurchaseDate = time.Now().Format("2006-01-02")
+	}
+	animal.QuarantineEndDate = ""
+	if animal.QuarantineDays > 0 {
+		if d, err := time.Parse("2006-01-02", animal.PurchaseDate); err == nil {
+			animal.QuarantineEndDate = d.AddDate(0, 0, animal.QuarantineDays).Format("2006-01-02")
+		}
+	}
 }
 
 // DeleteAnimal deletes an animal
@@ -129,12 +261,15 @@ func (s *LivestockService) DeleteAnimal(id int64) error {
 // GetOffspring returns all children of an animal
 func (s *LivestockService) GetOffspring(parentID int64) ([]Animal, error) {
 	rows, err := db.Query(`
-		SELECT a.id, a.tag_number, a.name, a.type, a.breed, a.date_of_birth, a.gender,
+		SELECT a.id, a.tag_number, a.name, COALESCE(a.species, 'cattle'), a.type, a.breed, a.date_of_birth, a.gender,
 			   a.mother_id, m.name, a.father_id, f.name,
-			   a.status, a.notes, a.created_at, a.updated_at
+			   a.status, a.notes, a.created_at, a.updated_at,
+			   a.acquisition_type, a.seller, a.purchase_date, a.purchase_price, a.transport_cost,
+			   a.quarantine_days, a.quarantine_end_date, a.group_id, g.name
 		FROM animals a
 		LEFT JOIN animals m ON a.mother_id = m.id
 		LEFT JOIN animals f ON a.father_id = f.id
+		LEFT JOIN animal_groups g ON a.group_id = g.id
 		WHERE a.mother_id = ? OR a.father_id = ?
 		ORDER BY a.date_of_birth DESC
 	`, parentID, parentID)
@@ -145,29 +280,10 @@ func (s *LivestockService) GetOffspring(parentID int64) ([]Animal, error) {
 
 	var animals []Animal
 	for rows.Next() {
-		var a Animal
-		var tagNumber, breed, dateOfBirth, gender, notes sql.NullString
-		var motherID, fatherID sql.NullInt64
-		var motherName, fatherName sql.NullString
-		err := rows.Scan(&a.ID, &tagNumber, &a.Name, &a.Type, &breed, &dateOfBirth, &gender,
-			&motherID, &motherName, &fatherID, &fatherName,
-			&a.Status, &notes, &a.CreatedAt, &a.UpdatedAt)
+		a, err := scanAnimal(rows)
 		if err != nil {
 			return nil, err
 		}
-		a.TagNumber = tagNumber.String
-		a.Breed = breed.String
-		a.DateOfBirth = dateOfBirth.String
-		a.Gender = gender.String
-		a.Notes = notes.String
-		if motherID.Valid {
-			a.MotherID = &motherID.Int64
-		}
-		if fatherID.Valid {
-			a.FatherID = &fatherID.Int64
-		}
-		a.MotherName = motherName.String
-		a.FatherName = fatherName.String
 		animals = append(animals, a)
 	}
 	return animals, nil
@@ -176,7 +292,7 @@ func (s *LivestockService) GetOffspring(parentID int64) ([]Animal, error) {
 // GetFemaleAnimals returns female animals for breeding selection
 func (s *LivestockService) GetFemaleAnimals() ([]Animal, error) {
 	rows, err := db.Query(`
-		SELECT id, tag_number, name, type, breed, date_of_birth, gender, status, notes, created_at, updated_at
+		SELECT id, tag_number, name, COALESCE(species, 'cattle'), type, breed, date_of_birth, gender, status, notes, created_at, updated_at
 		FROM animals WHERE gender = 'female' AND status = 'active'
 		ORDER BY date_of_birth ASC
 	`)
@@ -189,7 +305,7 @@ func (s *LivestockService) GetFemaleAnimals() ([]Animal, error) {
 	for rows.Next() {
 		var a Animal
 		var tagNumber, breed, dateOfBirth, gender, notes sql.NullString
-		err := rows.Scan(&a.ID, &tagNumber, &a.Name, &a.Type, &breed, &dateOfBirth, &gender, &a.Status, &notes, &a.CreatedAt, &a.UpdatedAt)
+		err := rows.Scan(&a.ID, &tagNumber, &a.Name, &a.Species, &a.Type, &breed, &dateOfBirth, &gender, &a.Status, &notes, &a.CreatedAt, &a.UpdatedAt)
 		if err != nil {
 			return nil, err
 		}
@@ -206,7 +322,7 @@ func (s *LivestockService) GetFemaleAnimals() ([]Animal, error) {
 // GetMaleAnimals returns male animals for breeding selection
 func (s *LivestockService) GetMaleAnimals() ([]Animal, error) {
 	rows, err := db.Query(`
-		SELECT id, tag_number, name, type, breed, date_of_birth, gender, status, notes, created_at, updated_at
+		SELECT id, tag_number, name, COALESCE(species, 'cattle'), type, breed, date_of_birth, gender, status, notes, created_at, updated_at
 		FROM animals WHERE gender = 'male' AND status = 'active'
 		ORDER BY date_of_birth ASC
 	`)
@@ -219,7 +335,7 @@ func (s *LivestockService) GetMaleAnimals() ([]Animal, error) {
 	for rows.Next() {
 		var a Animal
 		var tagNumber, breed, dateOfBirth, gender, notes sql.NullString
-		err := rows.Scan(&a.ID, &tagNumber, &a.Name, &a.Type, &breed, &dateOfBirth, &gender, &a.Status, &notes, &a.CreatedAt, &a.UpdatedAt)
+		err := rows.Scan(&a.ID, &tagNumber, &a.Name, &a.Species, &a.Type, &breed, &dateOfBirth, &gender, &a.Status, &notes, &a.CreatedAt, &a.UpdatedAt)
 		if err != nil {
 			return nil, err
 		}
@@ -235,29 +351,29 @@ func (s *LivestockService) GetMaleAnimals() ([]Animal, error) {
 
 // GetMilkRecords returns milk records for an animal or all if animalId is 0
 func (s *LivestockService) GetMilkRecords(animalId int64, startDate, endDate string) ([]MilkRecord, error) {
-	query := `
-		SELECT mr.id, mr.animal_id, a.name, mr.date, mr.morning_liters, mr.evening_liters, mr.total_liters, mr.notes, mr.created_at
-		FROM milk_records mr
-		JOIN animals a ON mr.animal_id = a.id
-		WHERE 1=1
-	`
+	filter := ""
 	args := []interface{}{}
 
 	if animalId > 0 {
-		query += " AND mr.animal_id = ?"
+		filter += " AND mr.animal_id = ?"
 		args = append(args, animalId)
 	}
 	if startDate != "" {
-		query += " AND mr.date >= ?"
+		filter += " AND mr.date >= ?"
 		args = append(args, startDate)
 	}
 	if endDate != "" {
-		query += " AND mr.date <= ?"
+		filter += " AND mr.date <= ?"
 		args = append(args, endDate)
 	}
-	query += " ORDER BY mr.date DESC, a.name"
 
-	rows, err := db.Query(query, args...)
+	rows, err := db.Query(`
+		SELECT mr.id, mr.animal_id, a.name, mr.date, mr.total_liters, COALESCE(mr.discarded, 0), mr.notes, mr.created_at
+		FROM milk_records mr
+		JOIN animals a ON mr.animal_id = a.id
+		WHERE 1=1`+filter+`
+		ORDER BY mr.date DESC, a.name
+	`, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -267,13 +383,24 @@ func (s *LivestockService) GetMilkRecords(animalId int64, startDate, endDate str
 	for rows.Next() {
 		var r MilkRecord
 		var notes sql.NullString
-		err := rows.Scan(&r.ID, &r.AnimalID, &r.AnimalName, &r.Date, &r.MorningLiters, &r.EveningLiters, &r.TotalLiters, &notes, &r.CreatedAt)
+		err := rows.Scan(&r.ID, &r.AnimalID, &r.AnimalName, &r.Date, &r.TotalLiters, &r.Discarded, &notes, &r.CreatedAt)
 		if err != nil {
 			return nil, err
 		}
 		r.Notes = notes.String
 		records = append(records, r)
 	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	yields, err := milkSessionYields(filter, args)
+	if err != nil {
+		return nil, err
+	}
+	for i := range records {
+		records[i].Sessions = yields[records[i].ID]
+	}
 	return records, nil
 }
 
@@ -282,11 +409,11 @@ func (s *LivestockService) GetMilkRecordByAnimalAndDate(animalId int64, date str
 	var r MilkRecord
 	var notes sql.NullString
 	err := db.QueryRow(`
-		SELECT mr.id, mr.animal_id, a.name, mr.date, mr.morning_liters, mr.evening_liters, mr.total_liters, mr.notes, mr.created_at
+		SELECT mr.id, mr.animal_id, a.name, mr.date, mr.total_liters, COALESCE(mr.discarded, 0), mr.notes, mr.created_at
 		FROM milk_records mr
 		JOIN animals a ON mr.animal_id = a.id
 		WHERE mr.animal_id = ? AND mr.date = ?
-	`, animalId, date).Scan(&r.ID, &r.AnimalID, &r.AnimalName, &r.Date, &r.MorningLiters, &r.EveningLiters, &r.TotalLiters, &notes, &r.CreatedAt)
+	`, animalId, date).Scan(&r.ID, &r.AnimalID, &r.AnimalName, &r.Date, &r.TotalLiters, &r.Discarded, &notes, &r.CreatedAt)
 
 	if err == sql.ErrNoRows {
 		return nil, nil // No record found
@@ -295,159 +422,814 @@ func (s *LivestockService) GetMilkRecordByAnimalAndDate(animalId int64, date str
 		return nil, err
 	}
 	r.Notes = notes.String
+
+	yields, err := milkSessionYields(" AND mr.id = ?", []interface{}{r.ID})
+	if err != nil {
+		return nil, err
+	}
+	r.Sessions = yields[r.ID]
 	return &r, nil
 }
 
+// milkSessionYields returns the session quantities of the milk records
+// matching a GetMilkRecords filter, keyed by record id in session order
+func milkSessionYields(filter string, args []interface{}) (map[int64][]MilkSessionYield, error) {
+	rows, err := db.Query(`
+		SELECT mrs.milk_record_id, mrs.session_id, ms.name, mrs.liters
+		FROM milk_record_sessions mrs
+		JOIN milking_sessions ms ON mrs.session_id = ms.id
+		JOIN milk_records mr ON mrs.milk_record_id = mr.id
+		WHERE 1=1`+filter+`
+		ORDER BY ms.sort_order, ms.id
+	`, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	yields := make(map[int64][]MilkSessionYield)
+	for rows.Next() {
+		var recordID int64
+		var y MilkSessionYield
+		if err := rows.Scan(&recordID, &y.SessionID, &y.SessionName, &y.Liters); err != nil {
+			return nil, err
+		}
+		yields[recordID] = append(yields[recordID], y)
+	}
+	return yields, rows.Err()
+}
+
 // AddMilkRecord adds a new milk record
 func (s *LivestockService) AddMilkRecord(record MilkRecord) (int64, error) {
-	total := record.MorningLiters + record.EveningLiters
-	result, err := db.Exec(`
-		INSERT INTO milk_records (animal_id, date, morning_liters, evening_liters, total_liters, notes)
-		VALUES (?, ?, ?, ?, ?, ?)
-	`, record.AnimalID, record.Date, record.MorningLiters, record.EveningLiters, total, record.Notes)
+	tx, err := db.Begin()
 	if err != nil {
 		return 0, err
 	}
-	return result.LastInsertId()
+	defer func() { _ = tx.Rollback() }()
+
+	result, err := tx.Exec(`
+		INSERT INTO milk_records (animal_id, date, discarded, notes)
+		VALUES (?, ?, ?, ?)
+	`, record.AnimalID, record.Date, record.Discarded, record.Notes)
+	if err != nil {
+		_ = tx.Rollback()
+		return 0, milkRecordError(err, record.AnimalID, record.Date)
+	}
+	id, err := result.LastInsertId()
+	if err != nil {
+		return 0, err
+	}
+	if err := saveMilkSessionYields(tx, id, record.Sessions); err != nil {
+		return 0, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, err
+	}
+	return id, nil
 }
 
 // UpdateMilkRecord updates an existing milk record
 func (s *LivestockService) UpdateMilkRecord(record MilkRecord) error {
-	total := record.MorningLiters + record.EveningLiters
-	_, err := db.Exec(`
-		UPDATE milk_records SET animal_id = ?, date = ?, morning_liters = ?, evening_liters = ?, total_liters = ?, notes = ?
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	_, err = tx.Exec(`
+		UPDATE milk_records SET animal_id = ?, date = ?, discarded = ?, notes = ?
 		WHERE id = ?
-	`, record.AnimalID, record.Date, record.MorningLiters, record.EveningLiters, total, record.Notes, record.ID)
-	return err
+	`, record.AnimalID, record.Date, record.Discarded, record.Notes, record.ID)
+	if err != nil {
+		_ = tx.Rollback()
+		return milkRecordError(err, record.AnimalID, record.Date)
+	}
+	if err := saveMilkSessionYields(tx, record.ID, record.Sessions); err != nil {
+		return err
+	}
+	return tx.Commit()
 }
 
 // DeleteMilkRecord deletes a milk record
 func (s *LivestockService) DeleteMilkRecord(id int64) error {
-	_, err := db.Exec(`DELETE FROM milk_records WHERE id = ?`, id)
-	return err
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	if _, err := tx.Exec(`DELETE FROM milk_record_sessions WHERE milk_record_id = ?`, id); err != nil {
+		return err
+	}
+	if _, err := tx.Exec(`DELETE FROM milk_records WHERE id = ?`, id); err != nil {
+		return err
+	}
+	return tx.Commit()
 }
 
-// GetMilkSales returns milk sales within a date range
-func (s *LivestockService) GetMilkSales(startDate, endDate string) ([]MilkSale, error) {
-	query := `
-		SELECT id, date, buyer_name, liters, price_per_liter, total_amount, is_paid, notes, created_at
-		FROM milk_sales WHERE 1=1
-	`
-	args := []interface{}{}
+// saveMilkSessionYields replaces the session quantities of a milk record and
+// updates its total
+func saveMilkSessionYields(tx *sql.Tx, recordID int64, yields []MilkSessionYield) error {
+	sessions, err := milkingSessionNames(tx)
+	if err != nil {
+		return err
+	}
+	if err := validateMilkSessionYields(yields, sessions); err != nil {
+		return err
+	}
 
-	if startDate != "" {
-		query += " AND date >= ?"
-		args = append(args, startDate)
+	if _, err := tx.Exec(`DELETE FROM milk_record_sessions WHERE milk_record_id = ?`, recordID); err != nil {
+		return err
 	}
-	if endDate != "" {
-		query += " AND date <= ?"
-		args = append(args, endDate)
+	total := 0.0
+	for _, y := range yields {
+		if y.Liters == 0 {
+			continue
+		}
+		if _, err := tx.Exec(`
+			INSERT INTO milk_record_sessions (milk_record_id, session_id, liters)
+			VALUES (?, ?, ?)
+		`, recordID, y.SessionID, y.Liters); err != nil {
+			return err
+		}
+		total += y.Liters
 	}
-	query += " ORDER BY date DESC"
+	_, err = tx.Exec(`UPDATE milk_records SET total_liters = ? WHERE id = ?`, total, recordID)
+	return err
+}
 
-	rows, err := db.Query(query, args...)
+// validateMilkSessionYields checks that every quantity is for a known session,
+// appears once and is not negative
+func validateMilkSessionYields(yields []MilkSessionYield, sessions map[int64]string) error {
+	seen := make(map[int64]bool, len(yields))
+	for _, y := range yields {
+		name, ok := sessions[y.SessionID]
+		if !ok {
+			return fmt.Errorf("unknown milking session %d", y.SessionID)
+		}
+		if seen[y.SessionID] {
+			return fmt.Errorf("%s milk is entered more than once", name)
+		}
+		if y.Liters < 0 {
+			return fmt.Errorf("%s liters cannot be negative", name)
+		}
+		seen[y.SessionID] = true
+	}
+	return nil
+}
+
+// milkingSessionNames returns the names of all milking sessions by id
+func milkingSessionNames(tx *sql.Tx) (map[int64]string, error) {
+	rows, err := tx.Query(`SELECT id, name FROM milking_sessions`)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
 
-	var sales []MilkSale
+	names := make(map[int64]string)
 	for rows.Next() {
-		var s MilkSale
-		var buyerName, notes sql.NullString
-		err := rows.Scan(&s.ID, &s.Date, &buyerName, &s.Liters, &s.PricePerLiter, &s.TotalAmount, &s.IsPaid, &notes, &s.CreatedAt)
-		if err != nil {
+		var id int64
+		var name string
+		if err := rows.Scan(&id, &name); err != nil {
 			return nil, err
 		}
-		s.BuyerName = buyerName.String
-		s.Notes = notes.String
-		sales = append(sales, s)
+		names[id] = name
 	}
-	return sales, nil
+	return names, rows.Err()
 }
 
-// AddMilkSale adds a new milk sale
-func (s *LivestockService) AddMilkSale(sale MilkSale) (int64, error) {
-	total := sale.Liters * sale.PricePerLiter
-	result, err := db.Exec(`
-		INSERT INTO milk_sales (date, buyer_name, liters, price_per_liter, total_amount, is_paid, notes)
-		VALUES (?, ?, ?, ?, ?, ?, ?)
-	`, sale.Date, sale.BuyerName, sale.Liters, sale.PricePerLiter, total, sale.IsPaid, sale.Notes)
+// milkRecordError turns a clash with the one-record-per-cow-per-day index
+// into a message the user can act on
+func milkRecordError(err error, animalId int64, date string) error {
+	if !strings.Contains(err.Error(), "UNIQUE constraint failed") {
+		return err
+	}
+	var name string
+	if db.QueryRow(`SELECT name FROM animals WHERE id = ?`, animalId).Scan(&name) != nil {
+		name = fmt.Sprintf("Animal %d", animalId)
+	}
+	return fmt.Errorf("%s already has a milk record for %s; edit that record instead", name, date)
+}
+
+// GetMilkingSessions returns the milking sessions in milking order
+func (s *LivestockService) GetMilkingSessions() ([]MilkingSession, error) {
+	rows, err := db.Query(`SELECT id, name, sort_order, active FROM milking_sessions ORDER BY sort_order, id`)
 	if err != nil {
-		return 0, err
+		return nil, err
+	}
+	defer rows.Close()
+
+	sessions := []MilkingSession{}
+	for rows.Next() {
+		var m MilkingSession
+		if err := rows.Scan(&m.ID, &m.Name, &m.SortOrder, &m.Active); err != nil {
+			return nil, err
+		}
+		sessions = append(sessions, m)
 	}
+	return sessions, nil
+}
 
-	id, err := result.LastInsertId()
+// SaveMilkingSessions adds, renames, reorders and (de)activates milking
+// sessions. Sessions are ordered as given; those with ID 0 are created.
+// Sessions left out are not deleted, use DeleteMilkingSession for that.
+func (s *LivestockService) SaveMilkingSessions(sessions []MilkingSession) ([]MilkingSession, error) {
+	names := make(map[string]bool, len(sessions))
+	active := 0
+	for _, m := range sessions {
+		name := strings.ToLower(strings.TrimSpace(m.Name))
+		if name == "" {
+			return nil, fmt.Errorf("every milking session needs a name")
+		}
+		if names[name] {
+			return nil, fmt.Errorf("milking session %q is listed twice", m.Name)
+		}
+		names[name] = true
+		if m.Active {
+			active++
+		}
+	}
+	if active == 0 {
+		return nil, fmt.Errorf("at least one milking session must be active")
+	}
+
+	tx, err := db.Begin()
 	if err != nil {
-		return 0, fmt.Errorf("failed to get last insert id: %w", err)
+		return nil, err
 	}
+	defer func() { _ = tx.Rollback() }()
 
-	// Automatically record in finances
-	if err := addTransactionInternal(sale.Date, "income", "milk_sales",
-		fmt.Sprintf("Milk Sale: %.1fL to %s", sale.Liters, sale.BuyerName),
-		total, fmt.Sprintf("milk_sale:%d", id)); err != nil {
-		_ = err // Log error but continue
+	// Clear the names first so sessions can swap names without hitting the unique index
+	for _, m := range sessions {
+		if m.ID > 0 {
+			if _, err := tx.Exec(`UPDATE milking_sessions SET name = '#' || id WHERE id = ?`, m.ID); err != nil {
+				return nil, err
+			}
+		}
+	}
+	for i, m := range sessions {
+		if m.ID > 0 {
+			_, err = tx.Exec(`UPDATE milking_sessions SET name = ?, sort_order = ?, active = ? WHERE id = ?`,
+				strings.TrimSpace(m.Name), i+1, m.Active, m.ID)
+		} else {
+			_, err = tx.Exec(`INSERT INTO milking_sessions (name, sort_order, active) VALUES (?, ?, ?)`,
+				strings.TrimSpace(m.Name), i+1, m.Active)
+		}
+		if err != nil {
+			if strings.Contains(err.Error(), "UNIQUE constraint failed") {
+				return nil, fmt.Errorf("a milking session named %q already exists", m.Name)
+			}
+			return nil, err
+		}
 	}
 
-	return id, nil
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return s.GetMilkingSessions()
 }
 
-// UpdateMilkSale updates an existing milk sale
-func (s *LivestockService) UpdateMilkSale(sale MilkSale) error {
-	total := sale.Liters * sale.PricePerLiter
-	_, err := db.Exec(`
-		UPDATE milk_sales SET date = ?, buyer_name = ?, liters = ?, price_per_liter = ?, total_amount = ?, is_paid = ?, notes = ?
-		WHERE id = ?
-	`, sale.Date, sale.BuyerName, sale.Liters, sale.PricePerLiter, total, sale.IsPaid, sale.Notes, sale.ID)
+// DeleteMilkingSession deletes a milking session that has no milk recorded.
+// Sessions with history should be deactivated instead.
+func (s *LivestockService) DeleteMilkingSession(id int64) error {
+	var used int
+	if err := db.QueryRow(`SELECT COUNT(*) FROM milk_record_sessions WHERE session_id = ?`, id).Scan(&used); err != nil {
+		return err
+	}
+	if used > 0 {
+		return fmt.Errorf("this session has %d milk records; deactivate it instead", used)
+	}
+	var others int
+	if err := db.QueryRow(`SELECT COUNT(*) FROM milking_sessions WHERE active = 1 AND id != ?`, id).Scan(&others); err != nil {
+		return err
+	}
+	if others == 0 {
+		return fmt.Errorf("at least one milking session must be active")
+	}
+	_, err := db.Exec(`DELETE FROM milking_sessions WHERE id = ?`, id)
 	return err
 }
 
-// DeleteMilkSale deletes a milk sale
-func (s *LivestockService) DeleteMilkSale(id int64) error {
-	_, err := db.Exec(`DELETE FROM milk_sales WHERE id = ?`, id)
-	return err
+// GetMilkEntrySheet returns a row for every milking cow for the given date,
+// filled with the day's existing records, for entering a whole milking at once.
+// Cows under a milk withdrawal are flagged, and new rows for them start discarded.
+func (s *LivestockService) GetMilkEntrySheet(date string) ([]MilkEntry, error) {
+	cows, err := s.GetDairyCows()
+	if err != nil {
+		return nil, err
+	}
+	records, err := s.GetMilkRecords(0, date, date)
+	if err != nil {
+		return nil, err
+	}
+	withdrawals, err := withdrawalsOn(date)
+	if err != nil {
+		return nil, err
+	}
+	byAnimal := make(map[int64]MilkRecord, len(records))
+	for _, r := range records {
+		byAnimal[r.AnimalID] = r
+	}
+
+	sheet := make([]MilkEntry, 0, len(cows))
+	for _, cow := range cows {
+		entry := MilkEntry{AnimalID: cow.ID, AnimalName: cow.Name, TagNumber: cow.TagNumber, Sessions: []MilkSessionYield{}}
+		entry.MilkWithheldUntil = withdrawals[cow.ID].MilkWithheldUntil
+		entry.Discarded = entry.MilkWithheldUntil != ""
+		if r, ok := byAnimal[cow.ID]; ok {
+			entry.RecordID = r.ID
+			entry.Sessions = r.Sessions
+			entry.Discarded = r.Discarded
+			entry.Notes = r.Notes
+		}
+		sheet = append(sheet, entry)
+	}
+	return sheet, nil
 }
 
-// GetTodayMilkTotal returns total milk produced today
-func (s *LivestockService) GetTodayMilkTotal() (float64, error) {
-	today := time.Now().Format("2006-01-02")
-	var total sql.NullFloat64
-	err := db.QueryRow(`SELECT SUM(total_liters) FROM milk_records WHERE date = ?`, today).Scan(&total)
+// SaveMilkEntries creates or updates the milk records of many cows for one day
+// in a single transaction. Invalid rows are reported and left out; empty rows
+// without an existing record are skipped. A database error saves nothing.
+// Milk kept from a cow under a drug withdrawal is saved with a warning.
+func (s *LivestockService) SaveMilkEntries(date string, entries []MilkEntry) ([]MilkEntryResult, error) {
+	if _, err := time.Parse("2006-01-02", date); err != nil {
+		return nil, fmt.Errorf("invalid date %q", date)
+	}
+	withdrawals, err := withdrawalsOn(date)
 	if err != nil {
-		return 0, err
+		return nil, err
 	}
-	return total.Float64, nil
-}
 
-// GetMonthMilkTotal returns total milk produced this month
-func (s *LivestockService) GetMonthMilkTotal() (float64, error) {
-	startOfMonth := time.Now().Format("2006-01") + "-01"
-	var total sql.NullFloat64
-	err := db.QueryRow(`SELECT SUM(total_liters) FROM milk_records WHERE date >= ?`, startOfMonth).Scan(&total)
+	tx, err := db.Begin()
 	if err != nil {
-		return 0, err
+		return nil, err
 	}
-	return total.Float64, nil
-}
+	defer func() { _ = tx.Rollback() }()
 
-// GetDailyCows returns cows that can be milked (female, active, not calves)
-func (s *LivestockService) GetDairyCows() ([]Animal, error) {
-	rows, err := db.Query(`
-		SELECT id, tag_number, name, type, breed, date_of_birth, gender, status, notes, created_at, updated_at
-		FROM animals 
-		WHERE gender = 'female' AND status = 'active' AND type IN ('cow', 'heifer')
-		ORDER BY date_of_birth ASC
-	`)
+	sessions, err := milkingSessionNames(tx)
 	if err != nil {
 		return nil, err
 	}
-	defer rows.Close()
 
-	var animals []Animal
-	for rows.Next() {
-		var a Animal
+	results := make([]MilkEntryResult, 0, len(entries))
+	seen := make(map[int64]bool, len(entries))
+	for _, e := range entries {
+		result := MilkEntryResult{AnimalID: e.AnimalID, AnimalName: e.AnimalName}
+
+		var name string
+		err := tx.QueryRow(`SELECT name FROM animals WHERE id = ?`, e.AnimalID).Scan(&name)
+		if err != nil && err != sql.ErrNoRows {
+			return nil, err
+		}
+		if err == sql.ErrNoRows {
+			result.Status, result.Error = "failed", "animal not found"
+			results = append(results, result)
+			continue
+		}
+		result.AnimalName = name
+		if seen[e.AnimalID] {
+			result.Status, result.Error = "failed", "animal appears more than once"
+			results = append(results, result)
+			continue
+		}
+		if err := validateMilkSessionYields(e.Sessions, sessions); err != nil {
+			result.Status, result.Error = "failed", err.Error()
+			results = append(results, result)
+			continue
+		}
+		seen[e.AnimalID] = true
+
+		var existingID int64
+		err = tx.QueryRow(`SELECT id FROM milk_records WHERE animal_id = ? AND date = ?`, e.AnimalID, date).Scan(&existingID)
+		if err != nil && err != sql.ErrNoRows {
+			return nil, err
+		}
+
+		total := 0.0
+		for _, y := range e.Sessions {
+			total += y.Liters
+		}
+		switch {
+		case existingID > 0:
+			if _, err := tx.Exec(`UPDATE milk_records SET discarded = ?, notes = ? WHERE id = ?`, e.Discarded, e.Notes, existingID); err != nil {
+				return nil, err
+			}
+			result.RecordID, result.Status = existingID, "updated"
+		case total == 0 && e.Notes == "":
+			result.Status = "skipped"
+			results = append(results, result)
+			continue
+		default:
+			res, err := tx.Exec(`INSERT INTO milk_records (animal_id, date, discarded, notes) VALUES (?, ?, ?, ?)`, e.AnimalID, date, e.Discarded, e.Notes)
+			if err != nil {
+				return nil, err
+			}
+			result.RecordID, _ = res.LastInsertId()
+			result.Status = "created"
+		}
+		if err := saveMilkSessionYields(tx, result.RecordID, e.Sessions); err != nil {
+			return nil, err
+		}
+		if w, ok := withdrawals[e.AnimalID]; ok && w.MilkWithheldUntil != "" && !e.Discarded && total > 0 {
+			result.Warning = withdrawalWarning(w)
+		}
+		results = append(results, result)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// GetMilkSales returns milk sales within a date range
+func (s *LivestockService) GetMilkSales(startDate, endDate string) ([]MilkSale, error) {
+	query := `
+		SELECT id, date, buyer_id, buyer_name, liters, price_per_liter, total_amount, is_paid, notes, created_at,
+			   butterfat_pct, protein_pct, scc, quality_adjustment,
+			   COALESCE((SELECT SUM(amount) FROM payment_allocations WHERE milk_sale_id = milk_sales.id), 0)
+		FROM milk_sales WHERE 1=1
+	`
+	args := []interface{}{}
+
+	if startDate != "" {
+		query += " AND date >= ?"
+		args = append(args, startDate)
+	}
+	if endDate != "" {
+		query += " AND date <= ?"
+		args = append(args, endDate)
+	}
+	query += " ORDER BY date DESC"
+
+	rows, err := db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var sales []MilkSale
+	for rows.Next() {
+		var s MilkSale
+		var buyerName, notes sql.NullString
+		var butterfat, protein, adjustment sql.NullFloat64
+		var buyerID, scc sql.NullInt64
+		err := rows.Scan(&s.ID, &s.Date, &buyerID, &buyerName, &s.Liters, &s.PricePerLiter, &s.TotalAmount, &s.IsPaid, &notes, &s.CreatedAt,
+			&butterfat, &protein, &scc, &adjustment, &s.AmountPaid)
+		if err != nil {
+			return nil, err
+		}
+		if buyerID.Valid {
+			s.BuyerID = &buyerID.Int64
+		} else if s.IsPaid {
+			s.AmountPaid = s.TotalAmount
+		}
+		s.BuyerName = buyerName.String
+		s.Notes = notes.String
+		s.ButterfatPct = butterfat.Float64
+		s.ProteinPct = protein.Float64
+		s.SCC = scc.Int64
+		s.QualityAdjustment = adjustment.Float64
+		sales = append(sales, s)
+	}
+	return sales, nil
+}
+
+// AddMilkSale adds a new milk sale, applying quality pricing when test results are given.
+// A sale to a buyer account marked paid records a payment on delivery for it.
+func (s *LivestockService) AddMilkSale(sale MilkSale) (int64, error) {
+	if err := resolveSaleBuyer(&sale); err != nil {
+		return 0, err
+	}
+	if err := s.applyQualityPricing(&sale); err != nil {
+		return 0, err
+	}
+	total := sale.Liters * (sale.PricePerLiter + sale.QualityAdjustment)
+	tx, err := db.Begin()
+	if err != nil {
+		return 0, err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	result, err := tx.Exec(`
+		INSERT INTO milk_sales (date, buyer_id, buyer_name, liters, price_per_liter, total_amount, is_paid, notes,
+			butterfat_pct, protein_pct, scc, quality_adjustment)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`, sale.Date, sale.BuyerID, sale.BuyerName, sale.Liters, sale.PricePerLiter, total, sale.IsPaid, sale.Notes,
+		sale.ButterfatPct, sale.ProteinPct, sale.SCC, sale.QualityAdjustment)
+	if err != nil {
+		return 0, err
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get last insert id: %w", err)
+	}
+
+	if sale.BuyerID != nil {
+		if err := paySaleOnDelivery(tx, *sale.BuyerID, id, sale.Date, total, sale.IsPaid); err != nil {
+			return 0, err
+		}
+	}
+
+	// Automatically record in finances
+	if total > 0 {
+		if _, err := tx.Exec(`
+			INSERT INTO transactions (date, type, category, description, amount, payment_method, related_entity)
+			VALUES (?, 'income', 'milk_sales', ?, ?, 'automatic', ?)
+		`, sale.Date, milkSaleDescription(sale), total, fmt.Sprintf("milk_sale:%d", id)); err != nil {
+			return 0, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, err
+	}
+	return id, nil
+}
+
+// UpdateMilkSale updates an existing milk sale. Whether a sale to a buyer
+// account is paid follows from the buyer's payments, so IsPaid is ignored for
+// them. The stored quality results are kept and repriced at the new base
+// price; change them with SetMilkSaleQuality. The sale's income transaction
+// follows the new total.
+func (s *LivestockService) UpdateMilkSale(sale MilkSale) error {
+	if err := resolveSaleBuyer(&sale); err != nil {
+		return err
+	}
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	var previousBuyer sql.NullInt64
+	var butterfat, protein sql.NullFloat64
+	var scc sql.NullInt64
+	if err := tx.QueryRow(`SELECT buyer_id, butterfat_pct, protein_pct, scc FROM milk_sales WHERE id = ?`, sale.ID).Scan(
+		&previousBuyer, &butterfat, &protein, &scc); err != nil {
+		return fmt.Errorf("sale not found: %w", err)
+	}
+	sale.ButterfatPct = butterfat.Float64
+	sale.ProteinPct = protein.Float64
+	sale.SCC = scc.Int64
+	if err := s.applyQualityPricing(&sale); err != nil {
+		return err
+	}
+
+	total := sale.Liters * (sale.PricePerLiter + sale.QualityAdjustment)
+	if _, err := tx.Exec(`
+		UPDATE milk_sales SET date = ?, buyer_id = ?, buyer_name = ?, liters = ?, price_per_liter = ?, total_amount = ?, is_paid = ?, notes = ?,
+			butterfat_pct = ?, protein_pct = ?, scc = ?, quality_adjustment = ?
+		WHERE id = ?
+	`, sale.Date, sale.BuyerID, sale.BuyerName, sale.Liters, sale.PricePerLiter, total, sale.IsPaid, sale.Notes,
+		sale.ButterfatPct, sale.ProteinPct, sale.SCC, sale.QualityAdjustment, sale.ID); err != nil {
+		return err
+	}
+	if err := updateMilkSaleTransaction(tx, sale.ID, sale.Date, milkSaleDescription(sale), total); err != nil {
+		return err
+	}
+
+	if previousBuyer.Valid && (sale.BuyerID == nil || *sale.BuyerID != previousBuyer.Int64) {
+		if err := detachSalePayments(tx, sale.ID); err != nil {
+			return err
+		}
+		if err := applyBuyerPayments(tx, previousBuyer.Int64); err != nil {
+			return err
+		}
+	}
+	if sale.BuyerID != nil {
+		if err := applyBuyerPayments(tx, *sale.BuyerID); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+// SetMilkSaleQuality records the buyer's test results for a sale and reprices
+// it and its income transaction. Zero values clear a result.
+func (s *LivestockService) SetMilkSaleQuality(id int64, butterfatPct, proteinPct float64, scc int64) error {
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	var sale MilkSale
+	var buyerID sql.NullInt64
+	var buyerName sql.NullString
+	if err := tx.QueryRow(`SELECT date, liters, price_per_liter, buyer_id, buyer_name FROM milk_sales WHERE id = ?`, id).Scan(
+		&sale.Date, &sale.Liters, &sale.PricePerLiter, &buyerID, &buyerName); err != nil {
+		return fmt.Errorf("sale not found: %w", err)
+	}
+	sale.BuyerName = buyerName.String
+	sale.ButterfatPct = butterfatPct
+	sale.ProteinPct = proteinPct
+	sale.SCC = scc
+	if err := s.applyQualityPricing(&sale); err != nil {
+		return err
+	}
+
+	total := sale.Liters * (sale.PricePerLiter + sale.QualityAdjustment)
+	if _, err := tx.Exec(`
+		UPDATE milk_sales SET butterfat_pct = ?, protein_pct = ?, scc = ?, quality_adjustment = ?, total_amount = ?
+		WHERE id = ?
+	`, sale.ButterfatPct, sale.ProteinPct, sale.SCC, sale.QualityAdjustment, total, id); err != nil {
+		return err
+	}
+	if err := updateMilkSaleTransaction(tx, id, sale.Date, milkSaleDescription(sale), total); err != nil {
+		return err
+	}
+	if buyerID.Valid {
+		if err := applyBuyerPayments(tx, buyerID.Int64); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+// milkSaleDescription describes a sale on its income transaction
+func milkSaleDescription(sale MilkSale) string {
+	return fmt.Sprintf("Milk Sale: %.1fL to %s", sale.Liters, sale.BuyerName)
+}
+
+// updateMilkSaleTransaction keeps the income transaction posted for a sale in
+// step with the sale. The amount and date of a transaction that has cleared or
+// been reconciled against a statement cannot change, so the edit is refused.
+func updateMilkSaleTransaction(tx *sql.Tx, saleID int64, date, description string, total float64) error {
+	var transactionID int64
+	var postedDate string
+	var amount float64
+	err := tx.QueryRow(`SELECT id, date, amount FROM transactions WHERE related_entity = ?`, fmt.Sprintf("milk_sale:%d", saleID)).Scan(
+		&transactionID, &postedDate, &amount)
+	if err == sql.ErrNoRows {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if postedDate != date || roundMoney(amount) != roundMoney(total) {
+		if err := checkTransactionRemovable(tx, transactionID); err != nil {
+			return fmt.Errorf("cannot reprice the sale: %w", err)
+		}
+	}
+	_, err = tx.Exec(`UPDATE transactions SET date = ?, description = ?, amount = ? WHERE id = ?`, date, description, total, transactionID)
+	return err
+}
+
+// applyQualityPricing sets the sale's per-liter quality adjustment from its test results
+func (s *LivestockService) applyQualityPricing(sale *MilkSale) error {
+	sale.QualityAdjustment = 0
+	if err := validateMilkComponents(sale.ButterfatPct, sale.ProteinPct, sale.SCC); err != nil {
+		return err
+	}
+	if sale.ButterfatPct <= 0 && sale.ProteinPct <= 0 && sale.SCC <= 0 {
+		return nil
+	}
+	price, err := s.GetQualityAdjustedPrice(sale.PricePerLiter, sale.ButterfatPct, sale.ProteinPct, sale.SCC)
+	if err != nil {
+		return err
+	}
+	sale.QualityAdjustment = price.Adjustment
+	return nil
+}
+
+// DeleteMilkSale deletes a milk sale. Payments made against a buyer sale stay
+// on the buyer's account and are applied to their other sales.
+func (s *LivestockService) DeleteMilkSale(id int64) error {
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	var buyerID sql.NullInt64
+	if err := tx.QueryRow(`SELECT buyer_id FROM milk_sales WHERE id = ?`, id).Scan(&buyerID); err != nil {
+		return fmt.Errorf("sale not found: %w", err)
+	}
+	if err := detachSalePayments(tx, id); err != nil {
+		return err
+	}
+	if _, err := tx.Exec(`DELETE FROM milk_sales WHERE id = ?`, id); err != nil {
+		return err
+	}
+	if buyerID.Valid {
+		if err := applyBuyerPayments(tx, buyerID.Int64); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+// GetTodayMilkTotal returns total milk produced today across all sessions
+func (s *LivestockService) GetTodayMilkTotal() (float64, error) {
+	today := time.Now().Format("2006-01-02")
+	return milkTotal(today, today)
+}
+
+// GetMonthMilkTotal returns total milk produced this month
+func (s *LivestockService) GetMonthMilkTotal() (float64, error) {
+	startOfMonth := time.Now().Format("2006-01") + "-01"
+	return milkTotal(startOfMonth, "")
+}
+
+// GetTodayMilkBySession returns today's milk per milking session
+func (s *LivestockService) GetTodayMilkBySession() ([]MilkSessionYield, error) {
+	today := time.Now().Format("2006-01-02")
+	return s.GetMilkTotalsBySession(today, today)
+}
+
+// GetMilkTotalsBySession returns the milk of every session within a date
+// range. Active sessions are always listed; inactive ones only when they have milk.
+func (s *LivestockService) GetMilkTotalsBySession(startDate, endDate string) ([]MilkSessionYield, error) {
+	filter := ""
+	args := []interface{}{}
+	if startDate != "" {
+		filter += " AND mr.date >= ?"
+		args = append(args, startDate)
+	}
+	if endDate != "" {
+		filter += " AND mr.date <= ?"
+		args = append(args, endDate)
+	}
+
+	rows, err := db.Query(`
+		SELECT ms.id, ms.name, ms.active, COALESCE(SUM(t.liters), 0)
+		FROM milking_sessions ms
+		LEFT JOIN (
+			SELECT mrs.session_id, mrs.liters
+			FROM milk_record_sessions mrs
+			JOIN milk_records mr ON mrs.milk_record_id = mr.id
+			WHERE 1=1`+filter+`
+		) t ON t.session_id = ms.id
+		GROUP BY ms.id
+		ORDER BY ms.sort_order, ms.id
+	`, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	totals := []MilkSessionYield{}
+	for rows.Next() {
+		var y MilkSessionYield
+		var active bool
+		if err := rows.Scan(&y.SessionID, &y.SessionName, &active, &y.Liters); err != nil {
+			return nil, err
+		}
+		if active || y.Liters > 0 {
+			totals = append(totals, y)
+		}
+	}
+	return totals, nil
+}
+
+// milkTotal sums the session quantities of milk records within a date range
+func milkTotal(startDate, endDate string) (float64, error) {
+	query := `
+		SELECT SUM(mrs.liters)
+		FROM milk_record_sessions mrs
+		JOIN milk_records mr ON mrs.milk_record_id = mr.id
+		WHERE 1=1
+	`
+	args := []interface{}{}
+	if startDate != "" {
+		query += " AND mr.date >= ?"
+		args = append(args, startDate)
+	}
+	if endDate != "" {
+		query += " AND mr.date <= ?"
+		args = append(args, endDate)
+	}
+
+	var total sql.NullFloat64
+	if err := db.QueryRow(query, args...).Scan(&total); err != nil {
+		return 0, err
+	}
+	return total.Float64, nil
+}
+
+// GetDairyCows returns animals that can be milked: active females of a milked
+// type in their species, such as cows and heifers or dairy goat does
+func (s *LivestockService) GetDairyCows() ([]Animal, error) {
+	milking, args := milkingAnimalsClause("")
+	rows, err := db.Query(`
+		SELECT id, tag_number, name, COALESCE(species, 'cattle'), type, breed, date_of_birth, gender, status, notes, created_at, updated_at
+		FROM animals 
+		WHERE gender = 'female' AND status = 'active' AND `+milking+`
+		ORDER BY date_of_birth ASC
+	`, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var animals []Animal
+	for rows.Next() {
+		var a Animal
 		var tagNumber, breed, dateOfBirth, gender, notes sql.NullString
-		err := rows.Scan(&a.ID, &tagNumber, &a.Name, &a.Type, &breed, &dateOfBirth, &gender, &a.Status, &notes, &a.CreatedAt, &a.UpdatedAt)
+		err := rows.Scan(&a.ID, &tagNumber, &a.Name, &a.Species, &a.Type, &breed, &dateOfBirth, &gender, &a.Status, &notes, &a.CreatedAt, &a.UpdatedAt)
 		if err != nil {
 			return nil, err
 		}
@@ -460,3 +1242,1202 @@ func (s *LivestockService) GetDairyCows() ([]Animal, error) {
 	}
 	return animals, nil
 }
+
+// disposalStatus maps a disposal type to the animal status it sets
+var disposalStatus = map[string]string{
+	"sale":  "sold",
+	"death": "deceased",
+	"cull":  "culled",
+}
+
+// DisposeAnimal records an animal leaving the herd and marks it inactive, which
+// also stops milk, breeding and vet reminders for it. Sales, and culls sold for
+// slaughter, post a livestock_sales income transaction. Culls cannot be sold
+// for slaughter while the animal's meat is withheld after a treatment.
+func (s *LivestockService) DisposeAnimal(disposal AnimalDisposal) (int64, error) {
+	status, ok := disposalStatus[disposal.DisposalType]
+	if !ok {
+		return 0, fmt.Errorf("invalid disposal type %q: expected sale, death or cull", disposal.DisposalType)
+	}
+	if disposal.Date == "" {
+		disposal.Date = time.Now().Format("2006-01-02")
+	}
+	if disposal.DisposalType == "death" {
+		disposal.Price = 0
+	}
+
+	animal, err := s.GetAnimal(disposal.AnimalID)
+	if err != nil {
+		return 0, fmt.Errorf("animal not found: %w", err)
+	}
+	if animal.Status != "active" {
+		return 0, fmt.Errorf("%s is already %s", animal.Name, animal.Status)
+	}
+	if disposal.DisposalType == "cull" && disposal.Price > 0 {
+		withdrawals, err := withdrawalsOn(disposal.Date)
+		if err != nil {
+			return 0, err
+		}
+		if until := withdrawals[animal.ID].MeatWithheldUntil; until != "" {
+			return 0, fmt.Errorf("%s cannot be sold for slaughter: meat is withheld until %s after %s",
+				animal.Name, until, firstNonEmpty(withdrawals[animal.ID].MeatMedicine, "treatment"))
+		}
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		return 0, err
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	result, err := tx.Exec(`
+		INSERT INTO animal_disposals (animal_id, disposal_type, date, buyer_name, price, cause_of_death, cull_reason, notes)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+	`, disposal.AnimalID, disposal.DisposalType, disposal.Date, disposal.BuyerName, disposal.Price,
+		disposal.CauseOfDeath, disposal.CullReason, disposal.Notes)
+	if err != nil {
+		return 0, err
+	}
+	id, err := result.LastInsertId()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get last insert id: %w", err)
+	}
+
+	if _, err := tx.Exec(`UPDATE animals SET status = ?, updated_at = CURRENT_TIMESTAMP WHERE id = ?`, status, disposal.AnimalID); err != nil {
+		return 0, err
+	}
+
+	if disposal.Price > 0 {
+		description := fmt.Sprintf("Sale of %s", animal.Name)
+		if disposal.DisposalType == "cull" {
+			description = fmt.Sprintf("Cull sale of %s", animal.Name)
+		}
+		if disposal.BuyerName != "" {
+			description += " to " + disposal.BuyerName
+		}
+		txResult, err := tx.Exec(`
+			INSERT INTO transactions (date, type, category, description, amount, payment_method, related_entity)
+			VALUES (?, 'income', 'livestock_sales', ?, ?, 'automatic', ?)
+		`, disposal.Date, description, disposal.Price, fmt.Sprintf("animal_disposal:%d", id))
+		if err != nil {
+			return 0, err
+		}
+		transactionID, err := txResult.LastInsertId()
+		if err != nil {
+			return 0, fmt.Errorf("failed to get last insert id: %w", err)
+		}
+		if _, err := tx.Exec(`UPDATE animal_disposals SET transaction_id = ? WHERE id = ?`, transactionID, id); err != nil {
+			return 0, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, err
+	}
+	return id, nil
+}
+
+// ReverseDisposal undoes a disposal entered by mistake: the animal becomes
+// active again and any sale transaction posted for it is removed. A sale that
+// has already been reconciled against a statement cannot be reversed.
+func (s *LivestockService) ReverseDisposal(id int64) error {
+	var animalID int64
+	var transactionID sql.NullInt64
+	if err := db.QueryRow(`SELECT animal_id, transaction_id FROM animal_disposals WHERE id = ?`, id).Scan(&animalID, &transactionID); err != nil {
+		return fmt.Errorf("disposal not found: %w", err)
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	if transactionID.Valid {
+		if err := checkTransactionRemovable(tx, transactionID.Int64); err != nil {
+			return fmt.Errorf("cannot reverse disposal: %w", err)
+		}
+		if _, err := tx.Exec(`DELETE FROM transactions WHERE id = ?`, transactionID.Int64); err != nil {
+			return err
+		}
+	}
+	if _, err := tx.Exec(`DELETE FROM animal_disposals WHERE id = ?`, id); err != nil {
+		return err
+	}
+	if _, err := tx.Exec(`UPDATE animals SET status = 'active', updated_at = CURRENT_TIMESTAMP WHERE id = ?`, animalID); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// GetDisposals returns disposals in a date range, optionally of one type
+func (s *LivestockService) GetDisposals(startDate, endDate, disposalType string) ([]AnimalDisposal, error) {
+	query := `
+		SELECT d.id, d.animal_id, a.name, a.tag_number, d.disposal_type, d.date, d.buyer_name, d.price,
+			   d.cause_of_death, d.cull_reason, d.transaction_id, d.notes, d.created_at
+		FROM animal_disposals d
+		JOIN animals a ON d.animal_id = a.id
+		WHERE 1=1
+	`
+	args := []interface{}{}
+
+	if startDate != "" {
+		query += " AND d.date >= ?"
+		args = append(args, startDate)
+	}
+	if endDate != "" {
+		query += " AND d.date <= ?"
+		args = append(args, endDate)
+	}
+	if disposalType != "" {
+		query += " AND d.disposal_type = ?"
+		args = append(args, disposalType)
+	}
+	query += " ORDER BY d.date DESC"
+
+	rows, err := db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	disposals := []AnimalDisposal{}
+	for rows.Next() {
+		var d AnimalDisposal
+		var tagNumber, buyerName, cause, reason, notes sql.NullString
+		var transactionID sql.NullInt64
+		err := rows.Scan(&d.ID, &d.AnimalID, &d.AnimalName, &tagNumber, &d.DisposalType, &d.Date, &buyerName, &d.Price,
+			&cause, &reason, &transactionID, &notes, &d.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+		d.TagNumber = tagNumber.String
+		d.BuyerName = buyerName.String
+		d.CauseOfDeath = cause.String
+		d.CullReason = reason.String
+		d.Notes = notes.String
+		if transactionID.Valid {
+			d.TransactionID = &transactionID.Int64
+		}
+		disposals = append(disposals, d)
+	}
+	return disposals, nil
+}
+
+// GetDisposalReport returns the mortality and cull report for a date range
+func (s *LivestockService) GetDisposalReport(startDate, endDate string) (*DisposalReport, error) {
+	disposals, err := s.GetDisposals(startDate, endDate, "")
+	if err != nil {
+		return nil, err
+	}
+
+	report := &DisposalReport{
+		StartDate:     startDate,
+		EndDate:       endDate,
+		DeathsByCause: make(map[string]int),
+		CullsByReason: make(map[string]int),
+		Disposals:     disposals,
+	}
+	for _, d := range disposals {
+		switch d.DisposalType {
+		case "sale":
+			report.Sales++
+		case "death":
+			report.Deaths++
+			report.DeathsByCause[firstNonEmpty(d.CauseOfDeath, "unknown")]++
+		case "cull":
+			report.Culls++
+			report.CullsByReason[firstNonEmpty(d.CullReason, "unspecified")]++
+		}
+		report.SalesIncome += d.Price
+	}
+
+	// Animals at risk are those still active plus those that left during or after the period
+	query := `
+		SELECT COUNT(*) FROM animals a
+		WHERE a.status = 'active'
+		OR a.id IN (SELECT animal_id FROM animal_disposals WHERE 1=1`
+	args := []interface{}{}
+	if startDate != "" {
+		query += " AND date >= ?"
+		args = append(args, startDate)
+	}
+	query += ")"
+	if err := db.QueryRow(query, args...).Scan(&report.HerdAtRisk); err != nil {
+		return nil, err
+	}
+	if report.HerdAtRisk > 0 {
+		report.MortalityRate = float64(report.Deaths) / float64(report.HerdAtRisk) * 100
+		report.CullRate = float64(report.Culls) / float64(report.HerdAtRisk) * 100
+	}
+
+	return report, nil
+}
+
+// GetDeathCauses returns common causes of death for disposal records
+func (s *LivestockService) GetDeathCauses() []string {
+	return []string{"disease", "calving_complications", "accident", "predator", "poisoning", "old_age", "unknown", "other"}
+}
+
+// GetCullReasons returns common reasons for culling
+func (s *LivestockService) GetCullReasons() []string {
+	return []string{"low_production", "infertility", "mastitis", "lameness", "old_age", "temperament", "disease", "other"}
+}
+
+// AddWeightRecord adds a body weight measurement
+func (s *LivestockService) AddWeightRecord(record WeightRecord) (int64, error) {
+	if record.WeightKg <= 0 {
+		return 0, fmt.Errorf("weight must be greater than zero")
+	}
+	if record.Method == "" {
+		record.Method = "weigh_band"
+	}
+	result, err := db.Exec(`
+		INSERT INTO weight_records (animal_id, date, weight_kg, method, notes)
+		VALUES (?, ?, ?, ?, ?)
+	`, record.AnimalID, record.Date, record.WeightKg, record.Method, record.Notes)
+	if err != nil {
+		return 0, err
+	}
+	return result.LastInsertId()
+}
+
+// UpdateWeightRecord updates an existing weight record
+func (s *LivestockService) UpdateWeightRecord(record WeightRecord) error {
+	if record.WeightKg <= 0 {
+		return fmt.Errorf("weight must be greater than zero")
+	}
+	_, err := db.Exec(`
+		UPDATE weight_records SET animal_id = ?, date = ?, weight_kg = ?, method = ?, notes = ?
+		WHERE id = ?
+	`, record.AnimalID, record.Date, record.WeightKg, record.Method, record.Notes, record.ID)
+	return err
+}
+
+// DeleteWeightRecord deletes a weight record
+func (s *LivestockService) DeleteWeightRecord(id int64) error {
+	_, err := db.Exec(`DELETE FROM weight_records WHERE id = ?`, id)
+	return err
+}
+
+// GetWeightRecords returns weight records, oldest first, with the average
+// daily gain since the animal's previous weighing
+func (s *LivestockService) GetWeightRecords(animalId int64, startDate, endDate string) ([]WeightRecord, error) {
+	query := `
+		SELECT w.id, w.animal_id, a.name, w.date, w.weight_kg, w.method, w.notes, w.created_at
+		FROM weight_records w
+		JOIN animals a ON w.animal_id = a.id
+		WHERE 1=1
+	`
+	args := []interface{}{}
+
+	if animalId > 0 {
+		query += " AND w.animal_id = ?"
+		args = append(args, animalId)
+	}
+	if endDate != "" {
+		query += " AND w.date <= ?"
+		args = append(args, endDate)
+	}
+	// The start date is applied after computing ADG so the first record in
+	// range still gets a gain from the weighing before it
+	query += " ORDER BY w.animal_id, w.date, w.id"
+
+	rows, err := db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	records := []WeightRecord{}
+	previous := map[int64]WeightRecord{}
+	for rows.Next() {
+		var r WeightRecord
+		var method, notes sql.NullString
+		if err := rows.Scan(&r.ID, &r.AnimalID, &r.AnimalName, &r.Date, &r.WeightKg, &method, &notes, &r.CreatedAt); err != nil {
+			return nil, err
+		}
+		r.Method = method.String
+		r.Notes = notes.String
+		if prev, ok := previous[r.AnimalID]; ok {
+			if days := daysBetween(prev.Date, r.Date); days > 0 {
+				adg := (r.WeightKg - prev.WeightKg) / float64(days)
+				r.ADG = &adg
+			}
+		}
+		previous[r.AnimalID] = r
+		if startDate == "" || r.Date >= startDate {
+			records = append(records, r)
+		}
+	}
+	return records, nil
+}
+
+// GetGrowthAnalysis compares an animal's weights with its breed target curve
+func (s *LivestockService) GetGrowthAnalysis(animalId int64) (*GrowthAnalysis, error) {
+	animal, err := s.GetAnimal(animalId)
+	if err != nil {
+		return nil, fmt.Errorf("animal not found: %w", err)
+	}
+	records, err := s.GetWeightRecords(animalId, "", "")
+	if err != nil {
+		return nil, err
+	}
+	curves, err := s.GetGrowthTargetCurves()
+	if err != nil {
+		return nil, err
+	}
+	return analyseGrowth(*animal, records, curves), nil
+}
+
+// GetAnimalsBelowTarget returns growing animals whose latest weight is below
+// the configured share of their breed target
+func (s *LivestockService) GetAnimalsBelowTarget() ([]GrowthAnalysis, error) {
+	return animalsBelowGrowthTarget()
+}
+
+// animalsBelowGrowthTarget is shared with NotificationService
+func animalsBelowGrowthTarget() ([]GrowthAnalysis, error) {
+	s := &LivestockService{}
+	animals, err := s.GetAllAnimals()
+	if err != nil {
+		return nil, err
+	}
+	curves, err := s.GetGrowthTargetCurves()
+	if err != nil {
+		return nil, err
+	}
+	records, err := s.GetWeightRecords(0, "", "")
+	if err != nil {
+		return nil, err
+	}
+
+	byAnimal := map[int64][]WeightRecord{}
+	for _, r := range records {
+		byAnimal[r.AnimalID] = append(byAnimal[r.AnimalID], r)
+	}
+
+	// Only recent weighings count; an old calf weight says little about the animal today
+	cutoff := time.Now().AddDate(0, 0, -growthAlertMaxAgeDays).Format("2006-01-02")
+
+	below := []GrowthAnalysis{}
+	for _, a := range animals {
+		if a.Status != "active" || len(byAnimal[a.ID]) == 0 {
+			continue
+		}
+		analysis := analyseGrowth(a, byAnimal[a.ID], curves)
+		if analysis.BelowTarget && analysis.LatestDate >= cutoff {
+			below = append(below, *analysis)
+		}
+	}
+	return below, nil
+}
+
+// analyseGrowth builds the growth analysis from weight records sorted oldest first
+func analyseGrowth(animal Animal, records []WeightRecord, curves []GrowthTargetCurve) *GrowthAnalysis {
+	analysis := &GrowthAnalysis{
+		AnimalID:   animal.ID,
+		AnimalName: animal.Name,
+		Breed:      animal.Breed,
+		Records:    records,
+	}
+	if len(records) == 0 {
+		return analysis
+	}
+
+	first, latest := records[0], records[len(records)-1]
+	analysis.LatestWeight = latest.WeightKg
+	analysis.LatestDate = latest.Date
+	if days := daysBetween(first.Date, latest.Date); days > 0 {
+		analysis.OverallADG = (latest.WeightKg - first.WeightKg) / float64(days)
+	}
+
+	if animal.DateOfBirth == "" {
+		return analysis
+	}
+	analysis.AgeDays = daysBetween(animal.DateOfBirth, latest.Date)
+	target := targetWeightAt(curveForBreed(curves, animal.Species, animal.Breed), float64(analysis.AgeDays)/30.44)
+	if target <= 0 {
+		return analysis
+	}
+
+	threshold, err := strconv.ParseFloat(getSetting(growthTargetThresholdSetting, ""), 64)
+	if err != nil || threshold <= 0 {
+		threshold = defaultGrowthTargetThreshold
+	}
+	analysis.TargetWeight = target
+	analysis.PercentOfTarget = latest.WeightKg / target * 100
+	analysis.BelowTarget = analysis.PercentOfTarget < threshold
+	return analysis
+}
+
+// Growth targets: an animal is flagged when its weight is below this
+// percentage of the breed target, configurable in settings
+const (
+	growthTargetCurvesSetting    = "growth_target_curves"
+	growthTargetThresholdSetting = "growth_target_threshold"
+	defaultGrowthTargetThreshold = 90.0
+	growthAlertMaxAgeDays        = 90
+)
+
+// defaultGrowthTargetCurves are typical dairy heifer rearing targets from
+// birth to first calving, plus dairy goat doeling targets to first kidding.
+// "default" is used for breeds of the species without a curve.
+var defaultGrowthTargetCurves = []GrowthTargetCurve{
+	{Breed: "Friesian", Points: []GrowthTargetPoint{{0, 40}, {3, 100}, {6, 180}, {12, 320}, {15, 380}, {24, 560}}},
+	{Breed: "Holstein", Points: []GrowthTargetPoint{{0, 40}, {3, 100}, {6, 180}, {12, 320}, {15, 380}, {24, 560}}},
+	{Breed: "Ayrshire", Points: []GrowthTargetPoint{{0, 35}, {3, 85}, {6, 150}, {12, 270}, {15, 320}, {24, 470}}},
+	{Breed: "Guernsey", Points: []GrowthTargetPoint{{0, 32}, {3, 80}, {6, 140}, {12, 250}, {15, 290}, {24, 420}}},
+	{Breed: "Jersey", Points: []GrowthTargetPoint{{0, 25}, {3, 65}, {6, 120}, {12, 210}, {15, 250}, {24, 360}}},
+	{Breed: "default", Points: []GrowthTargetPoint{{0, 35}, {3, 85}, {6, 150}, {12, 270}, {15, 320}, {24, 470}}},
+	{Species: "goat", Breed: "Saanen", Points: []GrowthTargetPoint{{0, 3.5}, {3, 18}, {6, 28}, {9, 36}, {12, 42}, {18, 55}}},
+	{Species: "goat", Breed: "Toggenburg", Points: []GrowthTargetPoint{{0, 3.2}, {3, 16}, {6, 25}, {9, 32}, {12, 38}, {18, 50}}},
+	{Species: "goat", Breed: "default", Points: []GrowthTargetPoint{{0, 3}, {3, 15}, {6, 23}, {9, 30}, {12, 35}, {18, 45}}},
+}
+
+// GetGrowthTargetCurves returns the saved breed target curves, or the defaults
+func (s *LivestockService) GetGrowthTargetCurves() ([]GrowthTargetCurve, error) {
+	value := getSetting(growthTargetCurvesSetting, "")
+	if value == "" {
+		return defaultGrowthTargetCurves, nil
+	}
+	var curves []GrowthTargetCurve
+	if err := json.Unmarshal([]byte(value), &curves); err != nil {
+		return nil, fmt.Errorf("invalid saved growth target curves: %w", err)
+	}
+	return curves, nil
+}
+
+// SaveGrowthTargetCurves replaces the breed target curves
+func (s *LivestockService) SaveGrowthTargetCurves(curves []GrowthTargetCurve) error {
+	for i := range curves {
+		if curves[i].Breed == "" || len(curves[i].Points) < 2 {
+			return fmt.Errorf("each curve needs a breed and at least two points")
+		}
+		sort.Slice(curves[i].Points, func(a, b int) bool { return curves[i].Points[a].AgeMonths < curves[i].Points[b].AgeMonths })
+	}
+	data, err := json.Marshal(curves)
+	if err != nil {
+		return err
+	}
+	return setSetting(growthTargetCurvesSetting, string(data))
+}
+
+// curveForBreed finds the curve for a breed of a species, falling back to
+// the species' default curve. Curves without a species are cattle curves.
+func curveForBreed(curves []GrowthTargetCurve, species, breed string) *GrowthTargetCurve {
+	species = firstNonEmpty(species, defaultSpecies)
+	var fallback *GrowthTargetCurve
+	for i := range curves {
+		if firstNonEmpty(curves[i].Species, defaultSpecies) != species {
+			continue
+		}
+		if strings.EqualFold(curves[i].Breed, breed) {
+			return &curves[i]
+		}
+		if strings.EqualFold(curves[i].Breed, "default") {
+			fallback = &curves[i]
+		}
+	}
+	return fallback
+}
+
+// targetWeightAt interpolates the target weight at an age, or returns 0
+// outside the curve
+func targetWeightAt(curve *GrowthTargetCurve, ageMonths float64) float64 {
+	if curve == nil || len(curve.Points) < 2 {
+		return 0
+	}
+	points := curve.Points
+	if ageMonths < points[0].AgeMonths || ageMonths > points[len(points)-1].AgeMonths {
+		return 0
+	}
+	for i := 1; i < len(points); i++ {
+		if ageMonths <= points[i].AgeMonths {
+			a, b := points[i-1], points[i]
+			span := b.AgeMonths - a.AgeMonths
+			if span <= 0 {
+				return b.WeightKg
+			}
+			return a.WeightKg + (b.WeightKg-a.WeightKg)*(ageMonths-a.AgeMonths)/span
+		}
+	}
+	return 0
+}
+
+// daysBetween returns the whole days from one YYYY-MM-DD date to another
+func daysBetween(from, to string) int {
+	start, err := time.Parse("2006-01-02", from)
+	if err != nil {
+		return 0
+	}
+	end, err := time.Parse("2006-01-02", to)
+	if err != nil {
+		return 0
+	}
+	return int(end.Sub(start).Hours() / 24)
+}
+
+// Lactations shorter than lactationMinProjectionDays are too early to project
+// a 305-day yield from. A lactation without a milk record for
+// lactationEndGapDays is treated as over.
+const (
+	lactationStandardDays      = 305
+	lactationMinProjectionDays = 30
+	lactationEndGapDays        = 30
+)
+
+// GetLactations returns a cow's lactations, oldest first. Each calving date
+// recorded in breeding_records starts a lactation that runs until the next
+// one. The latest lactation also ends when the cow is due to be dried off
+// before her next expected calving, or when milk recording for her stops.
+func (s *LivestockService) GetLactations(animalId int64) ([]Lactation, error) {
+	animal, err := s.GetAnimal(animalId)
+	if err != nil {
+		return nil, fmt.Errorf("animal not found: %w", err)
+	}
+
+	rows, err := db.Query(`
+		SELECT DISTINCT actual_birth_date FROM breeding_records
+		WHERE female_id = ? AND actual_birth_date IS NOT NULL AND actual_birth_date != ''
+		ORDER BY actual_birth_date
+	`, animalId)
+	if err != nil {
+		return nil, err
+	}
+	var calvings []string
+	for rows.Next() {
+		var date string
+		if err := rows.Scan(&date); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		calvings = append(calvings, date)
+	}
+	rows.Close()
+
+	lactations := []Lactation{}
+	if len(calvings) == 0 {
+		return lactations, nil
+	}
+
+	records, err := s.GetMilkRecords(animalId, calvings[0], "")
+	if err != nil {
+		return nil, err
+	}
+	// GetMilkRecords returns newest first
+	sort.Slice(records, func(i, j int) bool { return records[i].Date < records[j].Date })
+
+	// The cow is dried off dryOffDays before the next calving she is expected to have
+	var nextDue sql.NullString
+	if err := db.QueryRow(`
+		SELECT MIN(expected_due_date) FROM breeding_records
+		WHERE female_id = ? AND pregnancy_status IN ('pending', 'confirmed')
+		AND expected_due_date IS NOT NULL AND expected_due_date > ?
+		AND (actual_birth_date IS NULL OR actual_birth_date = '')
+	`, animalId, calvings[len(calvings)-1]).Scan(&nextDue); err != nil {
+		return nil, err
+	}
+	dryOff := ""
+	if due, err := time.Parse("2006-01-02", nextDue.String); err == nil {
+		dryOff = due.AddDate(0, 0, -dryOffDays).Format("2006-01-02")
+	}
+
+	today := time.Now().Format("2006-01-02")
+	for i, calving := range calvings {
+		next := ""
+		if i+1 < len(calvings) {
+			next = calvings[i+1]
+		}
+
+		var inLactation []MilkRecord
+		for _, r := range records {
+			if r.Date >= calving && (next == "" || r.Date < next) {
+				inLactation = append(inLactation, r)
+			}
+		}
+
+		current := next == "" && animal.Status == "active" && lactationOpen(calving, inLactation, dryOff, today)
+		l := buildLactation(calving, inLactation, current, today)
+		l.AnimalID = animal.ID
+		l.AnimalName = animal.Name
+		l.Number = i + 1
+		lactations = append(lactations, l)
+	}
+	return lactations, nil
+}
+
+// GetCurrentLactations returns the current lactation of every milking cow,
+// highest 305-day yield first, for comparing cows
+func (s *LivestockService) GetCurrentLactations() ([]Lactation, error) {
+	cows, err := s.GetDairyCows()
+	if err != nil {
+		return nil, err
+	}
+
+	current := []Lactation{}
+	for _, cow := range cows {
+		lactations, err := s.GetLactations(cow.ID)
+		if err != nil {
+			return nil, err
+		}
+		if n := len(lactations); n > 0 && lactations[n-1].Current {
+			current = append(current, lactations[n-1])
+		}
+	}
+	sort.Slice(current, func(i, j int) bool { return current[i].Yield305 > current[j].Yield305 })
+	return current, nil
+}
+
+// lactationOpen reports whether a cow's latest lactation is still running:
+// she has not reached her dry-off date with no milk recorded after it, and has
+// been recorded within lactationEndGapDays. Records are sorted by date.
+func lactationOpen(calving string, records []MilkRecord, dryOff, today string) bool {
+	lastSeen := calving
+	if len(records) > 0 {
+		lastSeen = records[len(records)-1].Date
+	}
+	if dryOff != "" && dryOff <= today && lastSeen <= dryOff {
+		return false
+	}
+	return daysBetween(lastSeen, today) <= lactationEndGapDays
+}
+
+// buildLactation computes lactation figures from its milk records, sorted by
+// date. A finished lactation ends at its last milk record and its days in milk
+// run to that date; a current one runs to today.
+func buildLactation(calving string, records []MilkRecord, current bool, today string) Lactation {
+	l := Lactation{CalvingDate: calving, Current: current}
+
+	for _, r := range records {
+		dim := daysBetween(calving, r.Date)
+		l.RecordedDays++
+		l.CumulativeYield += r.TotalLiters
+		if dim <= lactationStandardDays {
+			l.Yield305 += r.TotalLiters
+		}
+		if r.TotalLiters > l.PeakYield {
+			l.PeakYield = r.TotalLiters
+			l.PeakDate = r.Date
+			l.PeakDIM = dim
+		}
+	}
+	if l.RecordedDays > 0 {
+		l.AverageYield = l.CumulativeYield / float64(l.RecordedDays)
+	}
+
+	if current {
+		l.DaysInMilk = daysBetween(calving, today)
+	} else if len(records) > 0 {
+		l.EndDate = records[len(records)-1].Date
+		l.DaysInMilk = daysBetween(calving, l.EndDate)
+	}
+
+	// A current lactation that has not reached 305 days is projected at its
+	// average daily yield so far, so cows at different stages can be compared
+	if current && l.DaysInMilk < lactationStandardDays && l.DaysInMilk >= lactationMinProjectionDays {
+		l.Yield305 = l.CumulativeYield + l.AverageYield*float64(lactationStandardDays-l.DaysInMilk)
+		l.Yield305Projected = true
+	}
+	return l
+}
+
+// Milk quality: the latest test of a cow above the SCC threshold raises an
+// alert. Pricing follows the buyer's scheme saved in settings.
+const (
+	milkQualityPricingSetting = "milk_quality_pricing"
+	sccAlertThresholdSetting  = "scc_alert_threshold"
+	defaultSCCAlertThreshold  = 200000
+	sccAlertMaxAgeDays        = 60
+)
+
+// defaultMilkQualityPricing is a typical processor scheme: 3.5% butterfat and
+// 3.2% protein base, with bonuses for low cell counts and penalties for high ones
+var defaultMilkQualityPricing = MilkQualityPricing{
+	BaseButterfat: 3.5,
+	ButterfatRate: 0.20,
+	BaseProtein:   3.2,
+	ProteinRate:   0.30,
+	SCCBands: []SCCBand{
+		{MaxSCC: 200000, Adjustment: 1},
+		{MaxSCC: 400000, Adjustment: 0},
+		{MaxSCC: 750000, Adjustment: -1},
+		{MaxSCC: 0, Adjustment: -3},
+	},
+}
+
+// AddMilkQualityTest records a milk quality test
+func (s *LivestockService) AddMilkQualityTest(test MilkQualityTest) (int64, error) {
+	if err := validateMilkQualityTest(test); err != nil {
+		return 0, err
+	}
+	result, err := db.Exec(`
+		INSERT INTO milk_quality_tests (animal_id, date, butterfat_pct, protein_pct, scc, lactometer, notes)
+		VALUES (?, ?, ?, ?, ?, ?, ?)
+	`, test.AnimalID, test.Date, test.ButterfatPct, test.ProteinPct, test.SCC, test.Lactometer, test.Notes)
+	if err != nil {
+		return 0, err
+	}
+	return result.LastInsertId()
+}
+
+// UpdateMilkQualityTest updates a milk quality test
+func (s *LivestockService) UpdateMilkQualityTest(test MilkQualityTest) error {
+	if err := validateMilkQualityTest(test); err != nil {
+		return err
+	}
+	_, err := db.Exec(`
+		UPDATE milk_quality_tests SET animal_id = ?, date = ?, butterfat_pct = ?, protein_pct = ?, scc = ?, lactometer = ?, notes = ?
+		WHERE id = ?
+	`, test.AnimalID, test.Date, test.ButterfatPct, test.ProteinPct, test.SCC, test.Lactometer, test.Notes, test.ID)
+	return err
+}
+
+// DeleteMilkQualityTest deletes a milk quality test
+func (s *LivestockService) DeleteMilkQualityTest(id int64) error {
+	_, err := db.Exec("DELETE FROM milk_quality_tests WHERE id = ?", id)
+	return err
+}
+
+func validateMilkQualityTest(test MilkQualityTest) error {
+	if test.Date == "" {
+		return fmt.Errorf("test date is required")
+	}
+	if test.Lactometer < 0 {
+		return fmt.Errorf("lactometer reading cannot be negative")
+	}
+	return validateMilkComponents(test.ButterfatPct, test.ProteinPct, test.SCC)
+}
+
+func validateMilkComponents(butterfatPct, proteinPct float64, scc int64) error {
+	if butterfatPct < 0 || butterfatPct > 15 || proteinPct < 0 || proteinPct > 10 {
+		return fmt.Errorf("butterfat and protein must be percentages of the milk")
+	}
+	if scc < 0 {
+		return fmt.Errorf("cell count cannot be negative")
+	}
+	return nil
+}
+
+// GetMilkQualityTests returns quality tests, newest first. animalId 0 returns all tests.
+func (s *LivestockService) GetMilkQualityTests(animalId int64, startDate, endDate string) ([]MilkQualityTest, error) {
+	query := `
+		SELECT q.id, q.animal_id, a.name, q.date, q.butterfat_pct, q.protein_pct, q.scc, q.lactometer, q.notes, q.created_at
+		FROM milk_quality_tests q
+		LEFT JOIN animals a ON q.animal_id = a.id
+		WHERE 1=1
+	`
+	args := []interface{}{}
+
+	if animalId > 0 {
+		query += " AND q.animal_id = ?"
+		args = append(args, animalId)
+	}
+	if startDate != "" {
+		query += " AND q.date >= ?"
+		args = append(args, startDate)
+	}
+	if endDate != "" {
+		query += " AND q.date <= ?"
+		args = append(args, endDate)
+	}
+	query += " ORDER BY q.date DESC, q.id DESC"
+
+	rows, err := db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	tests := []MilkQualityTest{}
+	for rows.Next() {
+		var t MilkQualityTest
+		var animalID sql.NullInt64
+		var animalName, notes sql.NullString
+		if err := rows.Scan(&t.ID, &animalID, &animalName, &t.Date, &t.ButterfatPct, &t.ProteinPct, &t.SCC, &t.Lactometer, &notes, &t.CreatedAt); err != nil {
+			return nil, err
+		}
+		if animalID.Valid {
+			t.AnimalID = &animalID.Int64
+			t.AnimalName = animalName.String
+		} else {
+			t.AnimalName = "Bulk tank"
+		}
+		t.Notes = notes.String
+		tests = append(tests, t)
+	}
+	return tests, nil
+}
+
+// GetMilkQualityTrend returns monthly average quality for a cow over the last
+// given number of months, oldest first. Components that were not measured in a
+// test are left out of that month's average.
+func (s *LivestockService) GetMilkQualityTrend(animalId int64, months int) ([]MilkQualityTrendPoint, error) {
+	if months <= 0 {
+		months = 12
+	}
+	start := time.Now().AddDate(0, -months+1, 0).Format("2006-01") + "-01"
+
+	rows, err := db.Query(`
+		SELECT substr(date, 1, 7) AS month, COUNT(*),
+			   COALESCE(AVG(NULLIF(butterfat_pct, 0)), 0),
+			   COALESCE(AVG(NULLIF(protein_pct, 0)), 0),
+			   COALESCE(AVG(NULLIF(scc, 0)), 0),
+			   COALESCE(AVG(NULLIF(lactometer, 0)), 0)
+		FROM milk_quality_tests
+		WHERE animal_id = ? AND date >= ?
+		GROUP BY month
+		ORDER BY month
+	`, animalId, start)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	trend := []MilkQualityTrendPoint{}
+	for rows.Next() {
+		var p MilkQualityTrendPoint
+		if err := rows.Scan(&p.Month, &p.Tests, &p.ButterfatPct, &p.ProteinPct, &p.SCC, &p.Lactometer); err != nil {
+			return nil, err
+		}
+		trend = append(trend, p)
+	}
+	return trend, nil
+}
+
+// GetSCCAlertThreshold returns the somatic cell count above which a cow is flagged
+func (s *LivestockService) GetSCCAlertThreshold() int64 {
+	return sccAlertThreshold()
+}
+
+// SaveSCCAlertThreshold sets the somatic cell count alert threshold
+func (s *LivestockService) SaveSCCAlertThreshold(threshold int64) error {
+	if threshold <= 0 {
+		return fmt.Errorf("threshold must be greater than zero")
+	}
+	return setSetting(sccAlertThresholdSetting, strconv.FormatInt(threshold, 10))
+}
+
+func sccAlertThreshold() int64 {
+	threshold, err := strconv.ParseInt(getSetting(sccAlertThresholdSetting, ""), 10, 64)
+	if err != nil || threshold <= 0 {
+		return defaultSCCAlertThreshold
+	}
+	return threshold
+}
+
+// cowsAboveSCCThreshold returns the latest recent test of each active cow
+// whose somatic cell count is above the threshold
+func cowsAboveSCCThreshold(threshold int64) ([]MilkQualityTest, error) {
+	since := time.Now().AddDate(0, 0, -sccAlertMaxAgeDays).Format("2006-01-02")
+	rows, err := db.Query(`
+		SELECT q.id, q.animal_id, a.name, q.date, q.scc
+		FROM milk_quality_tests q
+		JOIN animals a ON q.animal_id = a.id
+		WHERE a.status = 'active' AND q.scc > 0 AND q.date >= ?
+		AND q.id = (
+			SELECT q2.id FROM milk_quality_tests q2
+			WHERE q2.animal_id = q.animal_id AND q2.scc > 0
+			ORDER BY q2.date DESC, q2.id DESC LIMIT 1
+		)
+		AND q.scc > ?
+		ORDER BY q.scc DESC
+	`, since, threshold)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	tests := []MilkQualityTest{}
+	for rows.Next() {
+		var t MilkQualityTest
+		var animalID int64
+		if err := rows.Scan(&t.ID, &animalID, &t.AnimalName, &t.Date, &t.SCC); err != nil {
+			return nil, err
+		}
+		t.AnimalID = &animalID
+		tests = append(tests, t)
+	}
+	return tests, nil
+}
+
+// GetMilkQualityPricing returns the saved quality pricing scheme, or the default
+func (s *LivestockService) GetMilkQualityPricing() (*MilkQualityPricing, error) {
+	value := getSetting(milkQualityPricingSetting, "")
+	if value == "" {
+		pricing := defaultMilkQualityPricing
+		return &pricing, nil
+	}
+	var pricing MilkQualityPricing
+	if err := json.Unmarshal([]byte(value), &pricing); err != nil {
+		return nil, fmt.Errorf("invalid saved milk quality pricing: %w", err)
+	}
+	return &pricing, nil
+}
+
+// SaveMilkQualityPricing replaces the quality pricing scheme
+func (s *LivestockService) SaveMilkQualityPricing(pricing MilkQualityPricing) error {
+	if pricing.BaseButterfat < 0 || pricing.BaseProtein < 0 {
+		return fmt.Errorf("base butterfat and protein cannot be negative")
+	}
+	// Bands with a limit are checked in ascending order; the open band goes last
+	sort.SliceStable(pricing.SCCBands, func(i, j int) bool {
+		a, b := pricing.SCCBands[i].MaxSCC, pricing.SCCBands[j].MaxSCC
+		return a != 0 && (b == 0 || a < b)
+	})
+	data, err := json.Marshal(pricing)
+	if err != nil {
+		return err
+	}
+	return setSetting(milkQualityPricingSetting, string(data))
+}
+
+// GetQualityAdjustedPrice applies the quality pricing scheme to a base price
+// per liter. Components given as zero were not tested and are not adjusted.
+func (s *LivestockService) GetQualityAdjustedPrice(basePrice, butterfatPct, proteinPct float64, scc int64) (*QualityPrice, error) {
+	pricing, err := s.GetMilkQualityPricing()
+	if err != nil {
+		return nil, err
+	}
+
+	price := &QualityPrice{BasePrice: basePrice}
+	if butterfatPct > 0 {
+		price.ButterfatAdjustment = (butterfatPct - pricing.BaseButterfat) * 10 * pricing.ButterfatRate
+	}
+	if proteinPct > 0 {
+		price.ProteinAdjustment = (proteinPct - pricing.BaseProtein) * 10 * pricing.ProteinRate
+	}
+	if scc > 0 {
+		for _, band := range pricing.SCCBands {
+			if band.MaxSCC == 0 || scc <= band.MaxSCC {
+				price.SCCAdjustment = band.Adjustment
+				break
+			}
+		}
+	}
+
+	price.Adjustment = math.Round((price.ButterfatAdjustment+price.ProteinAdjustment+price.SCCAdjustment)*100) / 100
+	price.PricePerLiter = basePrice + price.Adjustment
+	return price, nil
+}
+
+// Milk anomaly detection compares each cow's latest yield with her own
+// rolling baseline and flags cows that have stopped being recorded
+const milkAnomalySettingsSetting = "milk_anomaly_settings"
+
+// Cows within this many days of calving are expected to be dry
+const dryOffDays = 60
+
+var milkAnomalyPresets = map[string]struct {
+	dropPercent float64
+	missedDays  int
+}{
+	"low":    {35, 3},
+	"medium": {25, 2},
+	"high":   {15, 1},
+}
+
+var defaultMilkAnomalySettings = MilkAnomalySettings{
+	Enabled:            true,
+	Sensitivity:        "medium",
+	BaselineDays:       7,
+	MinBaselineRecords: 4,
+	DropPercent:        25,
+	MissedDays:         2,
+}
+
+// GetMilkAnomalySettings returns the saved anomaly detector settings, or the defaults
+func (s *LivestockService) GetMilkAnomalySettings() (*MilkAnomalySettings, error) {
+	return milkAnomalySettings()
+}
+
+// SaveMilkAnomalySettings saves the anomaly detector settings. Presets fill in
+// their thresholds so the saved values always describe what is applied.
+func (s *LivestockService) SaveMilkAnomalySettings(settings MilkAnomalySettings) (*MilkAnomalySettings, error) {
+	if preset, ok := milkAnomalyPresets[settings.Sensitivity]; ok {
+		settings.DropPercent = preset.dropPercent
+		settings.MissedDays = preset.missedDays
+	} else if settings.Sensitivity != "custom" {
+		return nil, fmt.Errorf("unknown sensitivity %q", settings.Sensitivity)
+	}
+	if settings.DropPercent <= 0 || settings.DropPercent >= 100 {
+		return nil, fmt.Errorf("drop percentage must be between 0 and 100")
+	}
+	if settings.BaselineDays < 3 || settings.MinBaselineRecords < 2 || settings.MinBaselineRecords > settings.BaselineDays {
+		return nil, fmt.Errorf("the baseline needs at least 3 days and 2 records, and no more records than days")
+	}
+	if settings.MissedDays < 1 {
+		return nil, fmt.Errorf("missed days must be at least 1")
+	}
+
+	data, err := json.Marshal(settings)
+	if err != nil {
+		return nil, err
+	}
+	if err := setSetting(milkAnomalySettingsSetting, string(data)); err != nil {
+		return nil, err
+	}
+	return &settings, nil
+}
+
+func milkAnomalySettings() (*MilkAnomalySettings, error) {
+	settings := defaultMilkAnomalySettings
+	value := getSetting(milkAnomalySettingsSetting, "")
+	if value == "" {
+		return &settings, nil
+	}
+	if err := json.Unmarshal([]byte(value), &settings); err != nil {
+		return nil, fmt.Errorf("invalid saved milk anomaly settings: %w", err)
+	}
+	return &settings, nil
+}
+
+// GetMilkAnomalies returns the milking cows whose latest yield dropped below
+// their baseline or that have not been recorded recently
+func (s *LivestockService) GetMilkAnomalies() ([]MilkAnomaly, error) {
+	return detectMilkAnomalies()
+}
+
+// detectMilkAnomalies is shared with NotificationService
+func detectMilkAnomalies() ([]MilkAnomaly, error) {
+	s := &LivestockService{}
+	settings, err := milkAnomalySettings()
+	if err != nil {
+		return nil, err
+	}
+	if !settings.Enabled {
+		return []MilkAnomaly{}, nil
+	}
+
+	cows, err := s.GetDairyCows()
+	if err != nil {
+		return nil, err
+	}
+	dry, err := cowsDueToCalve(dryOffDays)
+	if err != nil {
+		return nil, err
+	}
+
+	today := time.Now().Format("2006-01-02")
+	since := time.Now().AddDate(0, 0, -(settings.BaselineDays + settings.MissedDays + 1)).Format("2006-01-02")
+
+	anomalies := []MilkAnomaly{}
+	for _, cow := range cows {
+		if dry[cow.ID] {
+			continue
+		}
+		records, err := s.GetMilkRecords(cow.ID, since, today)
+		if err != nil {
+			return nil, err
+		}
+		if a := checkMilkAnomaly(cow, records, settings, today); a != nil {
+			anomalies = append(anomalies, *a)
+		}
+	}
+	return anomalies, nil
+}
+
+// checkMilkAnomaly checks one cow's recent records, newest first as
+// GetMilkRecords returns them
+func checkMilkAnomaly(cow Animal, records []MilkRecord, settings *MilkAnomalySettings, today string) *MilkAnomaly {
+	if len(records) == 0 {
+		return nil
+	}
+	latest := records[0]
+
+	// The baseline is the records in the window before the latest one
+	var baseline []MilkRecord
+	for _, r := range records[1:] {
+		if daysBetween(r.Date, latest.Date) <= settings.BaselineDays {
+			baseline = append(baseline, r)
+		}
+	}
+	if len(baseline) < settings.MinBaselineRecords-1 {
+		return nil // not milked regularly enough to judge
+	}
+
+	// A cow that was being milked regularly has stopped being recorded. The
+	// alert lapses once her last record falls out of the lookback window.
+	if gap := daysBetween(latest.Date, today); gap > settings.MissedDays {
+		average := latest.TotalLiters
+		for _, r := range baseline {
+			average += r.TotalLiters
+		}
+		average /= float64(len(baseline) + 1)
+		return &MilkAnomaly{
+			AnimalID:       cow.ID,
+			AnimalName:     cow.Name,
+			Type:           "missed",
+			Date:           latest.Date,
+			Liters:         latest.TotalLiters,
+			BaselineLiters: math.Round(average*10) / 10,
+			DaysMissed:     gap - 1, // today may not be recorded yet
+			Explanation: fmt.Sprintf("No milk recorded for %s since %s (%.1f L). Check whether she was milked or is unwell.",
+				cow.Name, latest.Date, latest.TotalLiters),
+		}
+	}
+	if len(baseline) < settings.MinBaselineRecords {
+		return nil
+	}
+
+	// Compare only the sessions recorded on the latest day, so a day with just
+	// the morning milking entered so far is not mistaken for a drop
+	perSession := make(map[int64]float64)
+	for _, r := range baseline {
+		for _, y := range r.Sessions {
+			perSession[y.SessionID] += y.Liters
+		}
+	}
+	expected := 0.0
+	for _, y := range latest.Sessions {
+		expected += perSession[y.SessionID] / float64(len(baseline))
+	}
+	if expected <= 0 {
+		return nil
+	}
+
+	drop := (expected - latest.TotalLiters) / expected * 100
+	if drop < settings.DropPercent {
+		return nil
+	}
+	return &MilkAnomaly{
+		AnimalID:       cow.ID,
+		AnimalName:     cow.Name,
+		Type:           "drop",
+		Date:           latest.Date,
+		Liters:         latest.TotalLiters,
+		BaselineLiters: math.Round(expected*10) / 10,
+		DropPercent:    math.Round(drop),
+		Explanation: fmt.Sprintf("%s gave %.1f L on %s, %.0f%% below her %d-day average of %.1f L. A sudden drop can be an early sign of mastitis or illness.",
+			cow.Name, latest.TotalLiters, latest.Date, drop, settings.BaselineDays, expected),
+	}
+}
+
+// cowsDueToCalve returns the cows expected to calve within the given days,
+// which are normally dried off
+func cowsDueToCalve(days int) (map[int64]bool, error) {
+	rows, err := db.Query(`
+		SELECT female_id FROM breeding_records
+		WHERE pregnancy_status IN ('pending', 'confirmed')
+		AND expected_due_date IS NOT NULL AND expected_due_date != ''
+		AND expected_due_date <= ?
+	`, time.Now().AddDate(0, 0, days).Format("2006-01-02"))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	due := make(map[int64]bool)
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		due[id] = true
+	}
+	return due, nil
+}
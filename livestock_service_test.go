@@ -0,0 +1,219 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestReverseDisposalGuard(t *testing.T) {
+	setupTestDatabase(t)
+	livestock := NewLivestockService()
+
+	dispose := func(name string) (int64, int64) {
+		animalID, err := livestock.AddAnimal(Animal{TagNumber: name, Name: name, Type: "cow", Gender: "female", Status: "active"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		disposalID, err := livestock.DisposeAnimal(AnimalDisposal{AnimalID: animalID, DisposalType: "sale", Date: "2024-03-01", Price: 60000})
+		if err != nil {
+			t.Fatalf("dispose %s: %v", name, err)
+		}
+		var transactionID int64
+		if err := db.QueryRow(`SELECT transaction_id FROM animal_disposals WHERE id = ?`, disposalID).Scan(&transactionID); err != nil {
+			t.Fatal(err)
+		}
+		return disposalID, transactionID
+	}
+
+	plain, _ := dispose("Daisy")
+	cleared, clearedTxn := dispose("Bella")
+	if _, err := db.Exec(`UPDATE transactions SET reconciliation_status = 'cleared', cleared_date = '2024-03-02' WHERE id = ?`, clearedTxn); err != nil {
+		t.Fatal(err)
+	}
+	linked, linkedTxn := dispose("Molly")
+	if _, err := db.Exec(`
+		INSERT INTO statement_lines (source, reference, date, amount, direction, status, transaction_id)
+		VALUES ('bank', 'R1', '2024-03-01', 60000, 'in', 'matched', ?)
+	`, linkedTxn); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name       string
+		disposalID int64
+		wantErr    string
+	}{
+		{"uncleared sale", plain, ""},
+		{"cleared sale", cleared, "has cleared the bank"},
+		{"sale on a statement line", linked, "reconciled against a bank statement"},
+	}
+	for _, tt := range tests {
+		err := livestock.ReverseDisposal(tt.disposalID)
+		if tt.wantErr == "" {
+			if err != nil {
+				t.Errorf("%s: unexpected error %v", tt.name, err)
+			}
+			continue
+		}
+		if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+			t.Errorf("%s: error = %v, want %q", tt.name, err, tt.wantErr)
+		}
+		var remaining int
+		if err := db.QueryRow(`SELECT COUNT(*) FROM animal_disposals WHERE id = ?`, tt.disposalID).Scan(&remaining); err != nil {
+			t.Fatal(err)
+		}
+		if remaining != 1 {
+			t.Errorf("%s: disposal was removed despite the error", tt.name)
+		}
+	}
+}
+
+func TestLactationEnd(t *testing.T) {
+	records := func(dates ...string) []MilkRecord {
+		list := []MilkRecord{}
+		for _, d := range dates {
+			list = append(list, MilkRecord{Date: d, TotalLiters: 10})
+		}
+		return list
+	}
+	const today = "2024-06-30"
+
+	tests := []struct {
+		name        string
+		records     []MilkRecord
+		dryOff      string
+		wantOpen    bool
+		wantEndDate string
+		wantDIM     int
+	}{
+		{"recorded this week", records("2024-02-01", "2024-06-28"), "", true, "", 150},
+		{"recorded within the gap", records("2024-02-01", "2024-06-01"), "", true, "", 150},
+		{"recording stopped", records("2024-02-01", "2024-04-30"), "", false, "2024-04-30", 89},
+		{"no records long after calving", nil, "", false, "", 0},
+		{"dried off", records("2024-02-01", "2024-06-20"), "2024-06-25", false, "2024-06-20", 140},
+		{"dry-off date ahead", records("2024-02-01", "2024-06-28"), "2024-07-10", true, "", 150},
+		{"still milked after the dry-off date", records("2024-02-01", "2024-06-29"), "2024-06-25", true, "", 150},
+	}
+	for _, tt := range tests {
+		open := lactationOpen("2024-02-01", tt.records, tt.dryOff, today)
+		if open != tt.wantOpen {
+			t.Errorf("%s: open = %v, want %v", tt.name, open, tt.wantOpen)
+		}
+		l := buildLactation("2024-02-01", tt.records, open, today)
+		if l.EndDate != tt.wantEndDate || l.DaysInMilk != tt.wantDIM {
+			t.Errorf("%s: end %q at %d DIM, want %q at %d DIM", tt.name, l.EndDate, l.DaysInMilk, tt.wantEndDate, tt.wantDIM)
+		}
+	}
+}
+
+func TestApplyQualityPricing(t *testing.T) {
+	setupTestDatabase(t)
+	livestock := NewLivestockService()
+
+	tests := []struct {
+		name      string
+		butterfat float64
+		protein   float64
+		scc       int64
+		want      float64
+		wantErr   string
+	}{
+		{"no results", 0, 0, 0, 0, ""},
+		{"butterfat above base", 4.0, 0, 0, 1.0, ""},
+		{"protein below base", 0, 3.0, 0, -0.6, ""},
+		{"low cell count bonus", 0, 0, 150000, 1, ""},
+		{"cell count on a band limit", 0, 0, 200000, 1, ""},
+		{"middle band", 0, 0, 300000, 0, ""},
+		{"high cell count", 0, 0, 500000, -1, ""},
+		{"open band", 0, 0, 900000, -3, ""},
+		{"all components", 4.0, 3.4, 150000, 2.6, ""},
+		{"penalties add up", 3.2, 0, 800000, -3.6, ""},
+		{"negative butterfat", -1, 0, 0, 0, "percentages"},
+		{"protein out of range", 0, 12, 0, 0, "percentages"},
+		{"negative cell count", 0, 0, -5, 0, "cannot be negative"},
+	}
+	for _, tt := range tests {
+		sale := MilkSale{Liters: 100, PricePerLiter: 50, ButterfatPct: tt.butterfat, ProteinPct: tt.protein, SCC: tt.scc, QualityAdjustment: 9}
+		err := livestock.applyQualityPricing(&sale)
+		if tt.wantErr != "" {
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("%s: error = %v, want %q", tt.name, err, tt.wantErr)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: unexpected error %v", tt.name, err)
+			continue
+		}
+		if math.Abs(sale.QualityAdjustment-tt.want) > 1e-9 {
+			t.Errorf("%s: adjustment = %v, want %v", tt.name, sale.QualityAdjustment, tt.want)
+		}
+	}
+}
+
+func TestUpdateMilkSaleKeepsQuality(t *testing.T) {
+	setupTestDatabase(t)
+	livestock := NewLivestockService()
+
+	id, err := livestock.AddMilkSale(MilkSale{Date: "2024-05-01", BuyerName: "Dairy", Liters: 100, PricePerLiter: 50, ButterfatPct: 4.0, SCC: 150000})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The sales form does not send the quality results when editing
+	if err := livestock.UpdateMilkSale(MilkSale{ID: id, Date: "2024-05-01", BuyerName: "Dairy", Liters: 100, PricePerLiter: 55}); err != nil {
+		t.Fatal(err)
+	}
+
+	storedQuality := func() (float64, float64, int64, float64) {
+		var butterfat, adjustment, total float64
+		var scc int64
+		if err := db.QueryRow(`SELECT butterfat_pct, scc, quality_adjustment, total_amount FROM milk_sales WHERE id = ?`, id).Scan(
+			&butterfat, &scc, &adjustment, &total); err != nil {
+			t.Fatal(err)
+		}
+		return butterfat, adjustment, scc, total
+	}
+	postedAmount := func() float64 {
+		var amount float64
+		if err := db.QueryRow(`SELECT amount FROM transactions WHERE related_entity = ?`, fmt.Sprintf("milk_sale:%d", id)).Scan(&amount); err != nil {
+			t.Fatal(err)
+		}
+		return amount
+	}
+	if butterfat, adjustment, scc, total := storedQuality(); butterfat != 4.0 || scc != 150000 || adjustment != 2 || total != 5700 {
+		t.Errorf("after update: butterfat %v, scc %d, adjustment %v, total %v; want 4, 150000, 2, 5700", butterfat, scc, adjustment, total)
+	}
+	if amount := postedAmount(); amount != 5700 {
+		t.Errorf("after update: transaction amount %v, want 5700", amount)
+	}
+
+	if err := livestock.SetMilkSaleQuality(id, 0, 0, 0); err != nil {
+		t.Fatal(err)
+	}
+	if butterfat, adjustment, scc, total := storedQuality(); butterfat != 0 || scc != 0 || adjustment != 0 || total != 5500 {
+		t.Errorf("after clearing: butterfat %v, scc %d, adjustment %v, total %v; want 0, 0, 0, 5500", butterfat, scc, adjustment, total)
+	}
+	if amount := postedAmount(); amount != 5500 {
+		t.Errorf("after clearing: transaction amount %v, want 5500", amount)
+	}
+
+	// Once the income has cleared the bank the sale cannot be repriced
+	if _, err := db.Exec(`UPDATE transactions SET reconciliation_status = 'cleared', cleared_date = '2024-05-03' WHERE related_entity = ?`,
+		fmt.Sprintf("milk_sale:%d", id)); err != nil {
+		t.Fatal(err)
+	}
+	if err := livestock.SetMilkSaleQuality(id, 4.0, 0, 0); err == nil || !strings.Contains(err.Error(), "has cleared the bank") {
+		t.Errorf("repricing a cleared sale: error = %v, want it refused", err)
+	}
+	if err := livestock.UpdateMilkSale(MilkSale{ID: id, Date: "2024-05-01", BuyerName: "Dairy", Liters: 90, PricePerLiter: 55}); err == nil {
+		t.Error("changing the liters of a cleared sale: want it refused")
+	}
+	if err := livestock.UpdateMilkSale(MilkSale{ID: id, Date: "2024-05-01", BuyerName: "Dairy", Liters: 100, PricePerLiter: 55, Notes: "Evening milk"}); err != nil {
+		t.Errorf("editing the notes of a cleared sale: %v", err)
+	}
+	if _, _, _, total := storedQuality(); total != 5500 || postedAmount() != 5500 {
+		t.Errorf("after refused edits: sale total %v, transaction %v; want both 5500", total, postedAmount())
+	}
+}
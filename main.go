@@ -45,6 +45,12 @@ func main() {
 			app.Notification,
 			app.Export,
 			app.Photo,
+			app.Statement,
+			app.Calendar,
+			app.Group,
+			app.Flock,
+			app.Buyer,
+			app.Cooperative,
 		},
 	})
 
@@ -0,0 +1,212 @@
+package main
+
+import (
+	"database/sql"
+	"fmt"
+	"math"
+	"slices"
+	"sort"
+	"time"
+)
+
+// Milk utilization: every liter produced should be sold, delivered to a
+// cooperative, used on the farm or discarded. What is left over is
+// unaccounted for and may point to theft or a recording error.
+const (
+	// A balance is flagged when the unaccounted milk is more than
+	// milkBalanceTolerancePct of production, and at least milkBalanceToleranceLiters
+	milkBalanceTolerancePct    = 2.0
+	milkBalanceToleranceLiters = 1.0
+)
+
+// milkUseTypes are the ways milk is used on the farm
+var milkUseTypes = []string{"calf_feeding", "household", "spoilage", "other"}
+
+// GetMilkUseTypes returns the ways milk can be used on the farm
+func (s *LivestockService) GetMilkUseTypes() []string {
+	return milkUseTypes
+}
+
+// GetMilkUsages returns milk used on the farm within a date range, newest first
+func (s *LivestockService) GetMilkUsages(startDate, endDate string) ([]MilkUsage, error) {
+	query, args := appendDateRange(`SELECT id, date, use_type, liters, notes, created_at FROM milk_usages WHERE 1=1`, "date", startDate, endDate)
+	query += " ORDER BY date DESC, id DESC"
+
+	rows, err := db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	usages := []MilkUsage{}
+	for rows.Next() {
+		var u MilkUsage
+		var notes sql.NullString
+		if err := rows.Scan(&u.ID, &u.Date, &u.UseType, &u.Liters, &notes, &u.CreatedAt); err != nil {
+			return nil, err
+		}
+		u.Notes = notes.String
+		usages = append(usages, u)
+	}
+	return usages, nil
+}
+
+// AddMilkUsage records milk used on the farm
+func (s *LivestockService) AddMilkUsage(usage MilkUsage) (int64, error) {
+	if err := validateMilkUsage(&usage); err != nil {
+		return 0, err
+	}
+	result, err := db.Exec(`INSERT INTO milk_usages (date, use_type, liters, notes) VALUES (?, ?, ?, ?)`,
+		usage.Date, usage.UseType, usage.Liters, usage.Notes)
+	if err != nil {
+		return 0, err
+	}
+	return result.LastInsertId()
+}
+
+// UpdateMilkUsage updates a milk usage record
+func (s *LivestockService) UpdateMilkUsage(usage MilkUsage) error {
+	if err := validateMilkUsage(&usage); err != nil {
+		return err
+	}
+	_, err := db.Exec(`UPDATE milk_usages SET date = ?, use_type = ?, liters = ?, notes = ? WHERE id = ?`,
+		usage.Date, usage.UseType, usage.Liters, usage.Notes, usage.ID)
+	return err
+}
+
+// DeleteMilkUsage deletes a milk usage record
+func (s *LivestockService) DeleteMilkUsage(id int64) error {
+	_, err := db.Exec(`DELETE FROM milk_usages WHERE id = ?`, id)
+	return err
+}
+
+func validateMilkUsage(usage *MilkUsage) error {
+	if usage.Date == "" {
+		usage.Date = time.Now().Format("2006-01-02")
+	}
+	if !slices.Contains(milkUseTypes, usage.UseType) {
+		return fmt.Errorf("unknown milk use %q", usage.UseType)
+	}
+	if usage.Liters <= 0 {
+		return fmt.Errorf("liters must be greater than zero")
+	}
+	return nil
+}
+
+// GetMilkBalance accounts for the milk produced in a date range by day or by
+// month. Without dates a daily balance covers this month and a monthly one
+// the last twelve months.
+func (s *LivestockService) GetMilkBalance(startDate, endDate, period string) (*MilkBalanceReport, error) {
+	if period != "month" {
+		period = "day"
+	}
+	now := time.Now()
+	if endDate == "" {
+		endDate = now.Format("2006-01-02")
+	}
+	if startDate == "" {
+		startDate = now.Format("2006-01") + "-01"
+		if period == "month" {
+			startDate = now.AddDate(0, -11, 1-now.Day()).Format("2006-01-02")
+		}
+	}
+
+	report := &MilkBalanceReport{StartDate: startDate, EndDate: endDate, Period: period, Rows: []MilkBalanceRow{}}
+	byPeriod := map[string]*MilkBalanceRow{}
+	row := func(date string) *MilkBalanceRow {
+		key := date
+		if period == "month" && len(date) >= 7 {
+			key = date[:7]
+		}
+		if byPeriod[key] == nil {
+			byPeriod[key] = &MilkBalanceRow{Period: key}
+		}
+		return byPeriod[key]
+	}
+
+	// Each source gives liters per date, and optionally per use
+	sources := []struct {
+		query, dateColumn string
+		add               func(r *MilkBalanceRow, use string, liters float64)
+	}{
+		{`SELECT mr.date, '', SUM(mrs.liters) FROM milk_record_sessions mrs JOIN milk_records mr ON mrs.milk_record_id = mr.id WHERE 1=1`, "mr.date",
+			func(r *MilkBalanceRow, _ string, liters float64) { r.Produced += liters }},
+		{`SELECT date, '', SUM(total_liters) FROM milk_records WHERE discarded = 1`, "date",
+			func(r *MilkBalanceRow, _ string, liters float64) { r.Discarded += liters }},
+		{`SELECT date, '', SUM(liters) FROM milk_sales WHERE 1=1`, "date",
+			func(r *MilkBalanceRow, _ string, liters float64) { r.Sold += liters }},
+		{`SELECT date, '', SUM(liters) FROM coop_deliveries WHERE 1=1`, "date",
+			func(r *MilkBalanceRow, _ string, liters float64) { r.Cooperative += liters }},
+		{`SELECT date, use_type, SUM(liters) FROM milk_usages WHERE 1=1`, "date",
+			func(r *MilkBalanceRow, use string, liters float64) {
+				switch use {
+				case "calf_feeding":
+					r.CalfFeeding += liters
+				case "household":
+					r.Household += liters
+				case "spoilage":
+					r.Spoilage += liters
+				default:
+					r.Other += liters
+				}
+			}},
+	}
+	for _, source := range sources {
+		query, args := appendDateRange(source.query, source.dateColumn, startDate, endDate)
+		rows, err := db.Query(query+" GROUP BY 1, 2", args...)
+		if err != nil {
+			return nil, err
+		}
+		for rows.Next() {
+			var date, use string
+			var liters sql.NullFloat64
+			if err := rows.Scan(&date, &use, &liters); err != nil {
+				rows.Close()
+				return nil, err
+			}
+			source.add(row(date), use, liters.Float64)
+		}
+		err = rows.Err()
+		rows.Close()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	t := &report.Totals
+	t.Period = "total"
+	for _, r := range byPeriod {
+		settleMilkBalance(r)
+		if r.Flagged {
+			report.FlaggedRows++
+		}
+		t.Produced += r.Produced
+		t.Sold += r.Sold
+		t.Cooperative += r.Cooperative
+		t.CalfFeeding += r.CalfFeeding
+		t.Household += r.Household
+		t.Spoilage += r.Spoilage
+		t.Other += r.Other
+		t.Discarded += r.Discarded
+		report.Rows = append(report.Rows, *r)
+	}
+	settleMilkBalance(t)
+	sort.Slice(report.Rows, func(i, j int) bool { return report.Rows[i].Period < report.Rows[j].Period })
+	return report, nil
+}
+
+// settleMilkBalance rounds a balance row, works out the unaccounted milk and
+// flags it when it is beyond the tolerance
+func settleMilkBalance(r *MilkBalanceRow) {
+	round := func(v float64) float64 { return math.Round(v*10) / 10 }
+	r.Produced, r.Sold, r.Cooperative = round(r.Produced), round(r.Sold), round(r.Cooperative)
+	r.CalfFeeding, r.Household, r.Spoilage = round(r.CalfFeeding), round(r.Household), round(r.Spoilage)
+	r.Other, r.Discarded = round(r.Other), round(r.Discarded)
+	r.Accounted = round(r.Sold + r.Cooperative + r.CalfFeeding + r.Household + r.Spoilage + r.Other + r.Discarded)
+	r.Unaccounted = round(r.Produced - r.Accounted)
+	if r.Produced > 0 {
+		r.UnaccountedPct = round(r.Unaccounted / r.Produced * 100)
+	}
+	tolerance := math.Max(milkBalanceToleranceLiters, r.Produced*milkBalanceTolerancePct/100)
+	r.Flagged = math.Abs(r.Unaccounted) > tolerance
+}
@@ -0,0 +1,121 @@
+package main
+
+import "testing"
+
+func TestSettleMilkBalance(t *testing.T) {
+	tests := []struct {
+		name            string
+		row             MilkBalanceRow
+		wantUnaccounted float64
+		wantPct         float64
+		wantFlagged     bool
+	}{
+		{"fully accounted", MilkBalanceRow{Produced: 100, Sold: 60, Cooperative: 30, CalfFeeding: 10}, 0, 0, false},
+		{"discarded milk counts", MilkBalanceRow{Produced: 40, Sold: 30, Discarded: 10}, 0, 0, false},
+		{"on the percentage tolerance", MilkBalanceRow{Produced: 100, Sold: 98}, 2, 2, false},
+		{"beyond the percentage tolerance", MilkBalanceRow{Produced: 100, Sold: 97}, 3, 3, true},
+		{"small day within a liter", MilkBalanceRow{Produced: 20, Sold: 19.2}, 0.8, 4, false},
+		{"small day beyond a liter", MilkBalanceRow{Produced: 20, Sold: 18.5}, 1.5, 7.5, true},
+		{"more used than produced", MilkBalanceRow{Produced: 50, Sold: 45, Household: 5, Spoilage: 5}, -5, -10, true},
+		{"use without production", MilkBalanceRow{Other: 0.5}, -0.5, 0, false},
+		{"rounded to a tenth", MilkBalanceRow{Produced: 10.04, Sold: 9.96}, 0, 0, false},
+	}
+	for _, tt := range tests {
+		r := tt.row
+		settleMilkBalance(&r)
+		if r.Unaccounted != tt.wantUnaccounted || r.UnaccountedPct != tt.wantPct || r.Flagged != tt.wantFlagged {
+			t.Errorf("%s: unaccounted %v (%v%%) flagged %v, want %v (%v%%) flagged %v",
+				tt.name, r.Unaccounted, r.UnaccountedPct, r.Flagged, tt.wantUnaccounted, tt.wantPct, tt.wantFlagged)
+		}
+	}
+}
+
+func TestGetMilkBalance(t *testing.T) {
+	setupTestDatabase(t)
+	livestock := NewLivestockService()
+	coops := NewCooperativeService()
+
+	cowID, err := livestock.AddAnimal(Animal{TagNumber: "K1", Name: "Kamau", Type: "cow", Gender: "female", Status: "active"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	sessions, err := livestock.GetMilkingSessions()
+	if err != nil || len(sessions) < 2 {
+		t.Fatalf("sessions: %v %v", sessions, err)
+	}
+	coopID, err := coops.AddCooperative(Cooperative{Name: "Githunguri Dairy", PricePerLiter: 50, Active: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	milk := func(date string, morning, evening float64, discarded bool) MilkRecord {
+		return MilkRecord{AnimalID: cowID, Date: date, Discarded: discarded, Sessions: []MilkSessionYield{
+			{SessionID: sessions[0].ID, Liters: morning}, {SessionID: sessions[1].ID, Liters: evening},
+		}}
+	}
+	must := func(_ int64, err error) {
+		t.Helper()
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	must(livestock.AddMilkRecord(milk("2024-05-01", 12, 8, false)))
+	must(livestock.AddMilkRecord(milk("2024-05-02", 10, 10, false)))
+	must(livestock.AddMilkRecord(milk("2024-05-03", 6, 4, true)))
+	must(livestock.AddMilkRecord(milk("2024-06-01", 15, 10, false)))
+	must(livestock.AddMilkSale(MilkSale{Date: "2024-05-01", BuyerName: "Hotel", Liters: 15, PricePerLiter: 60}))
+	must(livestock.AddMilkUsage(MilkUsage{Date: "2024-05-01", UseType: "calf_feeding", Liters: 5}))
+	must(coops.AddCoopDelivery(CoopDelivery{CooperativeID: coopID, Date: "2024-05-02", Liters: 12}))
+	must(livestock.AddMilkUsage(MilkUsage{Date: "2024-05-02", UseType: "household", Liters: 2}))
+	must(coops.AddCoopDelivery(CoopDelivery{CooperativeID: coopID, Date: "2024-06-01", Liters: 24}))
+	must(livestock.AddMilkUsage(MilkUsage{Date: "2024-06-01", UseType: "other", Liters: 1}))
+
+	tests := []struct {
+		name        string
+		start, end  string
+		period      string
+		want        []MilkBalanceRow
+		wantTotal   MilkBalanceRow
+		wantFlagged int
+	}{
+		{
+			name: "by day", start: "2024-05-01", end: "2024-05-31", period: "day",
+			want: []MilkBalanceRow{
+				{Period: "2024-05-01", Produced: 20, Sold: 15, CalfFeeding: 5, Accounted: 20},
+				{Period: "2024-05-02", Produced: 20, Cooperative: 12, Household: 2, Accounted: 14, Unaccounted: 6, UnaccountedPct: 30, Flagged: true},
+				{Period: "2024-05-03", Produced: 10, Discarded: 10, Accounted: 10},
+			},
+			wantTotal:   MilkBalanceRow{Period: "total", Produced: 50, Sold: 15, Cooperative: 12, CalfFeeding: 5, Household: 2, Discarded: 10, Accounted: 44, Unaccounted: 6, UnaccountedPct: 12, Flagged: true},
+			wantFlagged: 1,
+		},
+		{
+			name: "by month", start: "2024-05-01", end: "2024-06-30", period: "month",
+			want: []MilkBalanceRow{
+				{Period: "2024-05", Produced: 50, Sold: 15, Cooperative: 12, CalfFeeding: 5, Household: 2, Discarded: 10, Accounted: 44, Unaccounted: 6, UnaccountedPct: 12, Flagged: true},
+				{Period: "2024-06", Produced: 25, Cooperative: 24, Other: 1, Accounted: 25},
+			},
+			wantTotal:   MilkBalanceRow{Period: "total", Produced: 75, Sold: 15, Cooperative: 36, CalfFeeding: 5, Household: 2, Other: 1, Discarded: 10, Accounted: 69, Unaccounted: 6, UnaccountedPct: 8, Flagged: true},
+			wantFlagged: 1,
+		},
+	}
+	for _, tt := range tests {
+		report, err := livestock.GetMilkBalance(tt.start, tt.end, tt.period)
+		if err != nil {
+			t.Fatalf("%s: %v", tt.name, err)
+		}
+		if len(report.Rows) != len(tt.want) {
+			t.Fatalf("%s: %d rows, want %d: %+v", tt.name, len(report.Rows), len(tt.want), report.Rows)
+		}
+		for i, want := range tt.want {
+			if report.Rows[i] != want {
+				t.Errorf("%s: row %d = %+v, want %+v", tt.name, i, report.Rows[i], want)
+			}
+		}
+		if report.Totals != tt.wantTotal {
+			t.Errorf("%s: totals = %+v, want %+v", tt.name, report.Totals, tt.wantTotal)
+		}
+		if report.FlaggedRows != tt.wantFlagged {
+			t.Errorf("%s: %d flagged rows, want %d", tt.name, report.FlaggedRows, tt.wantFlagged)
+		}
+	}
+}
@@ -7,7 +7,8 @@ type Animal struct {
 	ID          int64     `json:"id"`
 	TagNumber   string    `json:"tagNumber"`
 	Name        string    `json:"name"`
-	Type        string    `json:"type"`                 // cow, bull, calf, heifer
+	Species     string    `json:"species"`              // cattle, goat, sheep, pig; see speciesProfiles
+	Type        string    `json:"type"`                 // a type of the species, e.g. cow, bull, calf, heifer for cattle
 	Breed       string    `json:"breed"`                // Friesian, Ayrshire, Jersey, etc.
 	DateOfBirth string    `json:"dateOfBirth"`          // YYYY-MM-DD format
 	Gender      string    `json:"gender"`               // male, female
@@ -15,38 +16,300 @@ type Animal struct {
 	FatherID    *int64    `json:"fatherId"`             // Optional reference to father
 	MotherName  string    `json:"motherName,omitempty"` // Joined field
 	FatherName  string    `json:"fatherName,omitempty"` // Joined field
-	Status      string    `json:"status"`               // active, sold, deceased
+	Status      string    `json:"status"`               // active, sold, deceased, culled
 	Notes       string    `json:"notes"`
 	CreatedAt   time.Time `json:"createdAt"`
 	UpdatedAt   time.Time `json:"updatedAt"`
+
+	AcquisitionType   string  `json:"acquisitionType"`   // born, purchased
+	Seller            string  `json:"seller"`            // purchased only
+	PurchaseDate      string  `json:"purchaseDate"`      // YYYY-MM-DD
+	PurchasePrice     float64 `json:"purchasePrice"`     // posted as a livestock_purchase expense
+	TransportCost     float64 `json:"transportCost"`     // posted as a transport expense
+	QuarantineDays    int     `json:"quarantineDays"`    // isolation period after arrival
+	QuarantineEndDate string  `json:"quarantineEndDate"` // purchase date + quarantine days
+
+	GroupID   *int64 `json:"groupId"`             // current group or pen, changed through MoveAnimals
+	GroupName string `json:"groupName,omitempty"` // Joined field
 }
 
 // MilkRecord represents daily milk production for an animal
 type MilkRecord struct {
-	ID            int64     `json:"id"`
-	AnimalID      int64     `json:"animalId"`
-	AnimalName    string    `json:"animalName,omitempty"` // Joined field
-	Date          string    `json:"date"`                 // YYYY-MM-DD format
-	MorningLiters float64   `json:"morningLiters"`
-	EveningLiters float64   `json:"eveningLiters"`
-	TotalLiters   float64   `json:"totalLiters"`
-	Notes         string    `json:"notes"`
-	CreatedAt     time.Time `json:"createdAt"`
+	ID          int64              `json:"id"`
+	AnimalID    int64              `json:"animalId"`
+	AnimalName  string             `json:"animalName,omitempty"` // Joined field
+	Date        string             `json:"date"`                 // YYYY-MM-DD format
+	Sessions    []MilkSessionYield `json:"sessions"`             // ordered by session
+	TotalLiters float64            `json:"totalLiters"`          // sum of the sessions
+	Discarded   bool               `json:"discarded"`            // milk thrown away, e.g. during a drug withdrawal period
+	Notes       string             `json:"notes"`
+	CreatedAt   time.Time          `json:"createdAt"`
+}
+
+// MilkingSession is one of the day's milkings, e.g. Morning, Midday, Evening
+type MilkingSession struct {
+	ID        int64  `json:"id"`
+	Name      string `json:"name"`
+	SortOrder int    `json:"sortOrder"`
+	Active    bool   `json:"active"` // inactive sessions keep their history but are not offered for entry
+}
+
+// MilkSessionYield is the milk from one session of a milk record
+type MilkSessionYield struct {
+	SessionID   int64   `json:"sessionId"`
+	SessionName string  `json:"sessionName,omitempty"` // Joined field
+	Liters      float64 `json:"liters"`
+}
+
+// MilkEntry is one cow's row in a bulk milk entry for a day
+type MilkEntry struct {
+	AnimalID   int64              `json:"animalId"`
+	AnimalName string             `json:"animalName,omitempty"`
+	TagNumber  string             `json:"tagNumber,omitempty"`
+	RecordID   int64              `json:"recordId"` // existing record for the day, 0 if none
+	Sessions   []MilkSessionYield `json:"sessions"`
+	Discarded  bool               `json:"discarded"`
+	Notes      string             `json:"notes"`
+
+	MilkWithheldUntil string `json:"milkWithheldUntil,omitempty"` // set when the cow is under a drug withdrawal on the day
+}
+
+// MilkEntryResult reports what happened to one row of a bulk milk entry
+type MilkEntryResult struct {
+	AnimalID   int64  `json:"animalId"`
+	AnimalName string `json:"animalName"`
+	RecordID   int64  `json:"recordId"`
+	Status     string `json:"status"` // created, updated, skipped, failed
+	Error      string `json:"error,omitempty"`
+	Warning    string `json:"warning,omitempty"` // saved, but needs attention
 }
 
 // MilkSale represents a sale of milk
 type MilkSale struct {
 	ID            int64     `json:"id"`
-	Date          string    `json:"date"` // YYYY-MM-DD format
+	Date          string    `json:"date"`    // YYYY-MM-DD format
+	BuyerID       *int64    `json:"buyerId"` // nil for one-off buyers known only by name
 	BuyerName     string    `json:"buyerName"`
 	Liters        float64   `json:"liters"`
-	PricePerLiter float64   `json:"pricePerLiter"`
+	PricePerLiter float64   `json:"pricePerLiter"` // base price before quality adjustment
 	TotalAmount   float64   `json:"totalAmount"`
 	IsPaid        bool      `json:"isPaid"`
+	AmountPaid    float64   `json:"amountPaid"` // computed from payment allocations for buyer sales
+	Notes         string    `json:"notes"`
+	CreatedAt     time.Time `json:"createdAt"`
+
+	// Optional quality results from the buyer's test. When set, the total is
+	// Liters * (PricePerLiter + QualityAdjustment) under the saved pricing scheme.
+	ButterfatPct      float64 `json:"butterfatPct"`
+	ProteinPct        float64 `json:"proteinPct"`
+	SCC               int64   `json:"scc"`               // somatic cells per mL
+	QualityAdjustment float64 `json:"qualityAdjustment"` // KES per liter, computed
+}
+
+// MilkUsage is milk used on the farm rather than sold. Milk discarded during
+// a drug withdrawal is recorded on the milk record instead.
+type MilkUsage struct {
+	ID        int64     `json:"id"`
+	Date      string    `json:"date"`    // YYYY-MM-DD
+	UseType   string    `json:"useType"` // calf_feeding, household, spoilage, other
+	Liters    float64   `json:"liters"`
+	Notes     string    `json:"notes"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// MilkBalanceRow accounts for the milk produced on a day or in a month
+type MilkBalanceRow struct {
+	Period         string  `json:"period"` // YYYY-MM-DD or YYYY-MM
+	Produced       float64 `json:"produced"`
+	Sold           float64 `json:"sold"`
+	Cooperative    float64 `json:"cooperative"` // delivered to cooperatives
+	CalfFeeding    float64 `json:"calfFeeding"`
+	Household      float64 `json:"household"`
+	Spoilage       float64 `json:"spoilage"`
+	Other          float64 `json:"other"`
+	Discarded      float64 `json:"discarded"` // withdrawal milk from the milk records
+	Accounted      float64 `json:"accounted"`
+	Unaccounted    float64 `json:"unaccounted"` // negative when more was used than produced
+	UnaccountedPct float64 `json:"unaccountedPct"`
+	Flagged        bool    `json:"flagged"`
+}
+
+// MilkBalanceReport is the milk utilization balance over a date range
+type MilkBalanceReport struct {
+	StartDate   string           `json:"startDate"`
+	EndDate     string           `json:"endDate"`
+	Period      string           `json:"period"` // day, month
+	Rows        []MilkBalanceRow `json:"rows"`
+	Totals      MilkBalanceRow   `json:"totals"`
+	FlaggedRows int              `json:"flaggedRows"`
+}
+
+// Buyer is a regular milk customer with an account. Sales to a buyer are
+// paid by payments, which are applied to the oldest unpaid sales first.
+type Buyer struct {
+	ID          int64     `json:"id"`
+	Name        string    `json:"name"`
+	Phone       string    `json:"phone"`
+	Email       string    `json:"email"`
+	Address     string    `json:"address"`
+	AgreedPrice float64   `json:"agreedPrice"` // KES per liter; used when a sale gives no price
+	Active      bool      `json:"active"`
+	Notes       string    `json:"notes"`
+	CreatedAt   time.Time `json:"createdAt"`
+
+	TotalSales    float64 `json:"totalSales"`    // computed
+	TotalPayments float64 `json:"totalPayments"` // computed
+	Balance       float64 `json:"balance"`       // computed; owed to the farm, negative when in credit
+}
+
+// BuyerPayment is money received from a buyer. A payment for a particular
+// sale is applied to it first; the rest goes to the oldest unpaid sales and
+// any remainder stays on the account as credit.
+type BuyerPayment struct {
+	ID         int64     `json:"id"`
+	BuyerID    int64     `json:"buyerId"`
+	BuyerName  string    `json:"buyerName,omitempty"` // Joined field
+	Date       string    `json:"date"`                // YYYY-MM-DD
+	Amount     float64   `json:"amount"`
+	Method     string    `json:"method"` // cash, mpesa, bank, cheque
+	Reference  string    `json:"reference"`
+	MilkSaleID *int64    `json:"milkSaleId"`
+	Notes      string    `json:"notes"`
+	CreatedAt  time.Time `json:"createdAt"`
+	Allocated  float64   `json:"allocated"` // computed; applied to sales
+}
+
+// BuyerStatementLine is a sale or payment on a buyer statement
+type BuyerStatementLine struct {
+	Date        string  `json:"date"`
+	Type        string  `json:"type"` // sale, payment
+	Description string  `json:"description"`
+	Reference   string  `json:"reference"`
+	Debit       float64 `json:"debit"`   // sales
+	Credit      float64 `json:"credit"`  // payments
+	Balance     float64 `json:"balance"` // running balance after the line
+}
+
+// BuyerStatement is a buyer's account for one month
+type BuyerStatement struct {
+	Buyer          Buyer                `json:"buyer"`
+	Month          string               `json:"month"` // YYYY-MM
+	StartDate      string               `json:"startDate"`
+	EndDate        string               `json:"endDate"`
+	OpeningBalance float64              `json:"openingBalance"`
+	Lines          []BuyerStatementLine `json:"lines"`
+	TotalSales     float64              `json:"totalSales"`
+	TotalLiters    float64              `json:"totalLiters"`
+	TotalPayments  float64              `json:"totalPayments"`
+	ClosingBalance float64              `json:"closingBalance"`
+}
+
+// BuyerAgeing splits what a buyer owes by the age of the unpaid sales
+type BuyerAgeing struct {
+	BuyerID     int64   `json:"buyerId"`
+	BuyerName   string  `json:"buyerName"`
+	Current     float64 `json:"current"` // 0-30 days
+	Days31To60  float64 `json:"days31To60"`
+	Days61To90  float64 `json:"days61To90"`
+	Over90      float64 `json:"over90"`
+	Credit      float64 `json:"credit"` // unapplied payments
+	Outstanding float64 `json:"outstanding"`
+	OldestSale  string  `json:"oldestSale,omitempty"` // date of the oldest unpaid sale
+}
+
+// AgeingReport lists outstanding receivables from buyers as of a date
+type AgeingReport struct {
+	AsOf   string        `json:"asOf"`
+	Buyers []BuyerAgeing `json:"buyers"`
+	Totals BuyerAgeing   `json:"totals"`
+}
+
+// Cooperative is a dairy cooperative the farm delivers milk to and is paid by monthly
+type Cooperative struct {
+	ID            int64     `json:"id"`
+	Name          string    `json:"name"`
+	MemberNumber  string    `json:"memberNumber"`
+	PricePerLiter float64   `json:"pricePerLiter"` // usual price; a payout may state its own
+	Active        bool      `json:"active"`
 	Notes         string    `json:"notes"`
 	CreatedAt     time.Time `json:"createdAt"`
 }
 
+// CoopDelivery is milk delivered to a cooperative, with the liters the
+// cooperative measured once its figure is known
+type CoopDelivery struct {
+	ID              int64     `json:"id"`
+	CooperativeID   int64     `json:"cooperativeId"`
+	CooperativeName string    `json:"cooperativeName,omitempty"` // Joined field
+	Date            string    `json:"date"`                      // YYYY-MM-DD
+	Liters          float64   `json:"liters"`                    // as measured on the farm
+	CoopLiters      *float64  `json:"coopLiters"`                // as measured by the cooperative; nil until known
+	DeliveryNote    string    `json:"deliveryNote"`
+	Notes           string    `json:"notes"`
+	CreatedAt       time.Time `json:"createdAt"`
+}
+
+// CoopDeduction is an item deducted from a cooperative payout
+type CoopDeduction struct {
+	ID          int64   `json:"id"`
+	PayoutID    int64   `json:"payoutId"`
+	Category    string  `json:"category"` // transport, shares, loan, inputs, other
+	Description string  `json:"description"`
+	Amount      float64 `json:"amount"`
+}
+
+// CoopPayout is a cooperative's monthly payout statement. The net payout is
+// posted to finances as milk sales income.
+type CoopPayout struct {
+	ID              int64           `json:"id"`
+	CooperativeID   int64           `json:"cooperativeId"`
+	CooperativeName string          `json:"cooperativeName,omitempty"` // Joined field
+	Month           string          `json:"month"`                     // YYYY-MM the milk was delivered
+	PayoutDate      string          `json:"payoutDate"`                // YYYY-MM-DD
+	Liters          float64         `json:"liters"`                    // liters the cooperative paid for
+	PricePerLiter   float64         `json:"pricePerLiter"`
+	GrossAmount     float64         `json:"grossAmount"`
+	Deductions      []CoopDeduction `json:"deductions"`
+	Reference       string          `json:"reference"`
+	TransactionID   *int64          `json:"transactionId"`
+	Notes           string          `json:"notes"`
+	CreatedAt       time.Time       `json:"createdAt"`
+
+	TotalDeductions float64 `json:"totalDeductions"` // computed
+	NetAmount       float64 `json:"netAmount"`       // computed
+}
+
+// CoopReconciliationDay compares one day's deliveries with the cooperative's figures
+type CoopReconciliationDay struct {
+	Date          string  `json:"date"`
+	Liters        float64 `json:"liters"`
+	CoopLiters    float64 `json:"coopLiters"`
+	Shortfall     float64 `json:"shortfall"` // liters the cooperative recorded short
+	Measured      bool    `json:"measured"`  // the cooperative's figure is known for every delivery
+	Flagged       bool    `json:"flagged"`
+	DeliveryNotes string  `json:"deliveryNotes"`
+}
+
+// CoopReconciliation checks a month of deliveries against what the
+// cooperative measured and paid for
+type CoopReconciliation struct {
+	CooperativeID   int64                   `json:"cooperativeId"`
+	CooperativeName string                  `json:"cooperativeName"`
+	Month           string                  `json:"month"`
+	StartDate       string                  `json:"startDate"`
+	EndDate         string                  `json:"endDate"`
+	Days            []CoopReconciliationDay `json:"days"`
+	DeliveredLiters float64                 `json:"deliveredLiters"`
+	CoopLiters      float64                 `json:"coopLiters"`
+	ShortfallLiters float64                 `json:"shortfallLiters"` // on flagged days
+	FlaggedDays     int                     `json:"flaggedDays"`
+	UnmeasuredDays  int                     `json:"unmeasuredDays"`
+	Payout          *CoopPayout             `json:"payout"`
+	UnpaidLiters    float64                 `json:"unpaidLiters"`   // measured by the cooperative but not paid for
+	ShortfallValue  float64                 `json:"shortfallValue"` // shortfall and unpaid liters at the payout price
+	Issues          []string                `json:"issues"`
+}
+
 // Field represents a farm field/plot
 type Field struct {
 	ID          int64     `json:"id"`
@@ -113,13 +376,160 @@ type FeedRecord struct {
 	FeedTypeID   int64     `json:"feedTypeId"`
 	FeedTypeName string    `json:"feedTypeName,omitempty"` // Joined field
 	QuantityKg   float64   `json:"quantityKg"`
-	Unit         string    `json:"unit"`         // kg, liters, bags, pieces, bottles, packets
-	AnimalCount  int       `json:"animalCount"`  // number of animals fed
-	FeedingTime  string    `json:"feedingTime"`  // morning, afternoon, evening
+	Unit         string    `json:"unit"`                // kg, liters, bags, pieces, bottles, packets
+	AnimalCount  int       `json:"animalCount"`         // number of animals fed
+	GroupID      *int64    `json:"groupId"`             // group fed, if the feeding targeted a group
+	GroupName    string    `json:"groupName,omitempty"` // Joined field
+	FeedingTime  string    `json:"feedingTime"`         // morning, afternoon, evening
 	Notes        string    `json:"notes"`
 	CreatedAt    time.Time `json:"createdAt"`
 }
 
+// AnimalGroup is a management group or physical location that animals are
+// assigned to, such as the milking group, a calf pen or a paddock
+type AnimalGroup struct {
+	ID          int64     `json:"id"`
+	Name        string    `json:"name"`
+	Kind        string    `json:"kind"` // group, pen, paddock
+	Description string    `json:"description"`
+	Active      bool      `json:"active"`
+	HeadCount   int       `json:"headCount"` // active animals currently assigned
+	CreatedAt   time.Time `json:"createdAt"`
+}
+
+// AnimalMovement records an animal moving into a group. FromGroupID is nil
+// when the animal was unassigned and ToGroupID is nil when it was removed
+// from its group.
+type AnimalMovement struct {
+	ID            int64     `json:"id"`
+	AnimalID      int64     `json:"animalId"`
+	AnimalName    string    `json:"animalName,omitempty"` // Joined field
+	Date          string    `json:"date"`                 // YYYY-MM-DD
+	FromGroupID   *int64    `json:"fromGroupId"`
+	FromGroupName string    `json:"fromGroupName,omitempty"` // Joined field
+	ToGroupID     *int64    `json:"toGroupId"`
+	ToGroupName   string    `json:"toGroupName,omitempty"` // Joined field
+	Reason        string    `json:"reason"`
+	CreatedAt     time.Time `json:"createdAt"`
+}
+
+// AnimalTypeChange records an animal moving from one type to another, such
+// as a heifer becoming a cow at her first calving
+type AnimalTypeChange struct {
+	ID         int64     `json:"id"`
+	AnimalID   int64     `json:"animalId"`
+	AnimalName string    `json:"animalName,omitempty"` // Joined field
+	Date       string    `json:"date"`                 // YYYY-MM-DD
+	FromType   string    `json:"fromType"`
+	ToType     string    `json:"toType"`
+	Reason     string    `json:"reason"`
+	Automatic  bool      `json:"automatic"` // made by the life-stage job rather than an edit
+	CreatedAt  time.Time `json:"createdAt"`
+}
+
+// LifeStageRule moves animals of a species from one type to the next once
+// they reach an age or give birth for the first time. Rules for the breed
+// "default" apply to breeds without a rule of their own.
+type LifeStageRule struct {
+	Species   string  `json:"species"`
+	Breed     string  `json:"breed"`
+	FromType  string  `json:"fromType"`
+	ToType    string  `json:"toType"`
+	Gender    string  `json:"gender"`    // male, female, or empty for both
+	Trigger   string  `json:"trigger"`   // age, first_birth
+	AgeMonths float64 `json:"ageMonths"` // for the age trigger
+}
+
+// LifeStageSettings configures the automatic life-stage transitions
+type LifeStageSettings struct {
+	Enabled bool            `json:"enabled"`
+	Rules   []LifeStageRule `json:"rules"`
+}
+
+// GroupSummary gathers a group's animals, milk and feeding over a period
+type GroupSummary struct {
+	Group       AnimalGroup  `json:"group"`
+	StartDate   string       `json:"startDate"`
+	EndDate     string       `json:"endDate"`
+	Animals     []Animal     `json:"animals"`
+	MilkLiters  float64      `json:"milkLiters"` // milk from animals while they were in the group
+	MilkRecords int          `json:"milkRecords"`
+	FeedRecords []FeedRecord `json:"feedRecords"` // feedings that targeted the group
+}
+
+// GroupMilkTotal is the milk produced by one group over a period. GroupID is
+// 0 for animals that were not in any group.
+type GroupMilkTotal struct {
+	GroupID     int64   `json:"groupId"`
+	GroupName   string  `json:"groupName"`
+	Liters      float64 `json:"liters"`
+	Animals     int     `json:"animals"`
+	MilkRecords int     `json:"milkRecords"`
+}
+
+// SpeciesProfile describes a kept species: the animal types it has, its
+// gestation length and the production metric it is measured by
+type SpeciesProfile struct {
+	Species       string   `json:"species"`
+	Label         string   `json:"label"`
+	Types         []string `json:"types"`         // animal types, adult female first
+	MilkingTypes  []string `json:"milkingTypes"`  // female types that are milked, empty if not milked
+	MaleTypes     []string `json:"maleTypes"`     // breeding males
+	GestationDays int      `json:"gestationDays"` // incubation days for poultry
+	Metric        string   `json:"metric"`        // milk, weight_gain, eggs
+	FlockOnly     bool     `json:"flockOnly"`     // kept as flocks rather than tagged animals
+}
+
+// SpeciesSummary gives the head count and main production figures of a
+// species for the current month
+type SpeciesSummary struct {
+	Species    string  `json:"species"`
+	Label      string  `json:"label"`
+	Metric     string  `json:"metric"`
+	HeadCount  int     `json:"headCount"`  // active animals, or live birds for poultry
+	Producing  int     `json:"producing"`  // milking females, or birds in laying flocks
+	MilkLiters float64 `json:"milkLiters"` // this month
+	AverageADG float64 `json:"averageAdg"` // kg/day between the last two weighings
+	Eggs       int     `json:"eggs"`       // this month
+	LayingRate float64 `json:"layingRate"` // eggs per 100 hen-days this month
+	Mortality  int     `json:"mortality"`  // deaths this month
+}
+
+// Flock is a group of birds managed as a unit, e.g. a batch of layers
+type Flock struct {
+	ID           int64     `json:"id"`
+	Name         string    `json:"name"`
+	Species      string    `json:"species"` // poultry
+	Purpose      string    `json:"purpose"` // layers, broilers, breeders
+	Breed        string    `json:"breed"`
+	PlacedDate   string    `json:"placedDate"` // YYYY-MM-DD the birds arrived
+	InitialCount int       `json:"initialCount"`
+	CurrentCount int       `json:"currentCount"` // initial count less mortality, culls and sales
+	Status       string    `json:"status"`       // active, closed
+	Notes        string    `json:"notes"`
+	CreatedAt    time.Time `json:"createdAt"`
+
+	EggsLast7Days int     `json:"eggsLast7Days"`
+	LayingRate    float64 `json:"layingRate"` // eggs per 100 hen-days over the last 7 days
+	Mortality     int     `json:"mortality"`  // total deaths
+}
+
+// FlockRecord is one day's counts for a flock
+type FlockRecord struct {
+	ID         int64     `json:"id"`
+	FlockID    int64     `json:"flockId"`
+	FlockName  string    `json:"flockName,omitempty"` // Joined field
+	Date       string    `json:"date"`                // YYYY-MM-DD
+	Eggs       int       `json:"eggs"`                // eggs collected
+	BrokenEggs int       `json:"brokenEggs"`          // included in Eggs
+	Mortality  int       `json:"mortality"`
+	Culled     int       `json:"culled"`
+	Sold       int       `json:"sold"`
+	FeedKg     float64   `json:"feedKg"`
+	Notes      string    `json:"notes"`
+	CreatedAt  time.Time `json:"createdAt"`
+}
+
 // VetRecord represents health/veterinary records
 type VetRecord struct {
 	ID          int64     `json:"id"`
@@ -137,20 +547,54 @@ type VetRecord struct {
 	NextDueDate string    `json:"nextDueDate"` // for follow-ups or vaccinations
 	Notes       string    `json:"notes"`
 	CreatedAt   time.Time `json:"createdAt"`
+
+	// Drug withdrawal: milk and meat from the animal must not be sold until
+	// the withheld-until dates, counted from the treatment date
+	MilkWithdrawalDays int    `json:"milkWithdrawalDays"`
+	MeatWithdrawalDays int    `json:"meatWithdrawalDays"`
+	MilkWithheldUntil  string `json:"milkWithheldUntil,omitempty"` // computed
+	MeatWithheldUntil  string `json:"meatWithheldUntil,omitempty"` // computed
+}
+
+// MedicineWithdrawal gives the usual withdrawal periods of a medicine, used
+// to fill them in when a treatment is recorded
+type MedicineWithdrawal struct {
+	Medicine string `json:"medicine"`
+	MilkDays int    `json:"milkDays"`
+	MeatDays int    `json:"meatDays"`
+}
+
+// AnimalWithdrawal is an animal whose milk or meat is withheld after a
+// treatment. The dates are the last days the product must not be sold; each
+// names the treatment that withholds it longest.
+type AnimalWithdrawal struct {
+	AnimalID          int64  `json:"animalId"`
+	AnimalName        string `json:"animalName"`
+	TagNumber         string `json:"tagNumber"`
+	MilkWithheldUntil string `json:"milkWithheldUntil,omitempty"`
+	MilkMedicine      string `json:"milkMedicine,omitempty"`
+	MilkVetRecordID   int64  `json:"milkVetRecordId,omitempty"`
+	MilkTreatmentDate string `json:"milkTreatmentDate,omitempty"`
+	MeatWithheldUntil string `json:"meatWithheldUntil,omitempty"`
+	MeatMedicine      string `json:"meatMedicine,omitempty"`
+	MeatVetRecordID   int64  `json:"meatVetRecordId,omitempty"`
+	MeatTreatmentDate string `json:"meatTreatmentDate,omitempty"`
 }
 
 // Transaction represents financial transactions
 type Transaction struct {
-	ID            int64     `json:"id"`
-	Date          string    `json:"date"`     // YYYY-MM-DD
-	Type          string    `json:"type"`     // income, expense
-	Category      string    `json:"category"` // milk_sales, crop_sales, feed, veterinary, labor, equipment, etc.
-	Description   string    `json:"description"`
-	Amount        float64   `json:"amount"`
-	PaymentMethod string    `json:"paymentMethod"` // cash, mpesa, bank
-	RelatedEntity string    `json:"relatedEntity"` // e.g., "Cow: Daisy" or "Field: North Plot"
-	Notes         string    `json:"notes"`
-	CreatedAt     time.Time `json:"createdAt"`
+	ID                   int64     `json:"id"`
+	Date                 string    `json:"date"`     // YYYY-MM-DD
+	Type                 string    `json:"type"`     // income, expense
+	Category             string    `json:"category"` // milk_sales, crop_sales, feed, veterinary, labor, equipment, etc.
+	Description          string    `json:"description"`
+	Amount               float64   `json:"amount"`
+	PaymentMethod        string    `json:"paymentMethod"` // cash, mpesa, bank
+	RelatedEntity        string    `json:"relatedEntity"` // e.g., "Cow: Daisy" or "Field: North Plot"
+	Notes                string    `json:"notes"`
+	ReconciliationStatus string    `json:"reconciliationStatus"` // uncleared, cleared (matched to a bank or M-Pesa statement)
+	ClearedDate          string    `json:"clearedDate"`          // YYYY-MM-DD
+	CreatedAt            time.Time `json:"createdAt"`
 }
 
 // DashboardStats represents overview statistics
@@ -166,6 +610,11 @@ type DashboardStats struct {
 	MonthExpenses    float64 `json:"monthExpenses"`
 	LowStockItems    int     `json:"lowStockItems"`
 	PendingVetVisits int     `json:"pendingVetVisits"`
+
+	MonthDiscardedMilkLiters float64 `json:"monthDiscardedMilkLiters"` // withheld after treatment or otherwise thrown away
+	AnimalsUnderWithdrawal   int     `json:"animalsUnderWithdrawal"`
+
+	TodayMilkBySession []MilkSessionYield `json:"todayMilkBySession"`
 }
 
 // RecentActivity represents recent activity items for dashboard
@@ -205,6 +654,251 @@ type BreedingRecord struct {
 	CreatedAt       time.Time `json:"createdAt"`
 }
 
+// PedigreeNode is an animal in an ancestor or descendant tree. Ancestor
+// trees fill Mother and Father; descendant trees fill Children.
+type PedigreeNode struct {
+	AnimalID    int64          `json:"animalId"`
+	Name        string         `json:"name"`
+	TagNumber   string         `json:"tagNumber,omitempty"`
+	Gender      string         `json:"gender"`
+	Breed       string         `json:"breed,omitempty"`
+	DateOfBirth string         `json:"dateOfBirth,omitempty"`
+	Generation  int            `json:"generation"` // 0 for the animal itself
+	Mother      *PedigreeNode  `json:"mother,omitempty"`
+	Father      *PedigreeNode  `json:"father,omitempty"`
+	Children    []PedigreeNode `json:"children,omitempty"`
+}
+
+// InbreedingResult is Wright's coefficient of inbreeding for an animal, or
+// for the calf of a proposed pairing
+type InbreedingResult struct {
+	AnimalID        int64            `json:"animalId,omitempty"` // 0 for a proposed pairing
+	DamID           int64            `json:"damId"`
+	SireID          int64            `json:"sireId"`
+	Coefficient     float64          `json:"coefficient"` // 0 to 1
+	Percent         float64          `json:"percent"`
+	CommonAncestors []CommonAncestor `json:"commonAncestors"`
+	Assessment      string           `json:"assessment"` // acceptable, caution, avoid
+}
+
+// CommonAncestor is an ancestor shared by the sire and dam, with its share
+// of the inbreeding coefficient
+type CommonAncestor struct {
+	AnimalID     int64   `json:"animalId"`
+	Name         string  `json:"name"`
+	Paths        int     `json:"paths"`
+	Contribution float64 `json:"contribution"`
+}
+
+// AnimalDisposal records an animal leaving the herd through sale, death or culling
+type AnimalDisposal struct {
+	ID            int64     `json:"id"`
+	AnimalID      int64     `json:"animalId"`
+	AnimalName    string    `json:"animalName,omitempty"` // Joined field
+	TagNumber     string    `json:"tagNumber,omitempty"`  // Joined field
+	DisposalType  string    `json:"disposalType"`         // sale, death, cull
+	Date          string    `json:"date"`                 // YYYY-MM-DD
+	BuyerName     string    `json:"buyerName"`            // sale, or cull sold for slaughter
+	Price         float64   `json:"price"`
+	CauseOfDeath  string    `json:"causeOfDeath"` // death only
+	CullReason    string    `json:"cullReason"`   // cull only
+	TransactionID *int64    `json:"transactionId"`
+	Notes         string    `json:"notes"`
+	CreatedAt     time.Time `json:"createdAt"`
+}
+
+// DisposalReport summarises sales, deaths and culls over a period
+type DisposalReport struct {
+	StartDate     string           `json:"startDate"`
+	EndDate       string           `json:"endDate"`
+	Sales         int              `json:"sales"`
+	Deaths        int              `json:"deaths"`
+	Culls         int              `json:"culls"`
+	SalesIncome   float64          `json:"salesIncome"`   // sales and cull sales
+	HerdAtRisk    int              `json:"herdAtRisk"`    // animals active at any point in the period
+	MortalityRate float64          `json:"mortalityRate"` // deaths per 100 animals at risk
+	CullRate      float64          `json:"cullRate"`      // culls per 100 animals at risk
+	DeathsByCause map[string]int   `json:"deathsByCause"`
+	CullsByReason map[string]int   `json:"cullsByReason"`
+	Disposals     []AnimalDisposal `json:"disposals"`
+}
+
+// MilkAnomalySettings tunes the milk yield anomaly detector. Sensitivity
+// "low", "medium" or "high" selects preset thresholds; "custom" uses the
+// DropPercent and MissedDays given.
+type MilkAnomalySettings struct {
+	Enabled            bool    `json:"enabled"`
+	Sensitivity        string  `json:"sensitivity"`        // low, medium, high, custom
+	BaselineDays       int     `json:"baselineDays"`       // rolling window the latest yield is compared with
+	MinBaselineRecords int     `json:"minBaselineRecords"` // records needed in the window before judging
+	DropPercent        float64 `json:"dropPercent"`        // flag yields this far below the baseline
+	MissedDays         int     `json:"missedDays"`         // flag cows not recorded for this many days
+}
+
+// MilkAnomaly is an unusual milk yield or a gap in recording for a cow
+type MilkAnomaly struct {
+	AnimalID       int64   `json:"animalId"`
+	AnimalName     string  `json:"animalName"`
+	Type           string  `json:"type"` // drop, missed
+	Date           string  `json:"date"` // date of the low record, or of the last record when missed
+	Liters         float64 `json:"liters"`
+	BaselineLiters float64 `json:"baselineLiters"`
+	DropPercent    float64 `json:"dropPercent,omitempty"`
+	DaysMissed     int     `json:"daysMissed,omitempty"`
+	Explanation    string  `json:"explanation"`
+}
+
+// MilkQualityTest holds a test-day quality result for a cow, or for the bulk
+// tank when AnimalID is nil
+type MilkQualityTest struct {
+	ID           int64     `json:"id"`
+	AnimalID     *int64    `json:"animalId"`
+	AnimalName   string    `json:"animalName,omitempty"` // Joined field
+	Date         string    `json:"date"`                 // YYYY-MM-DD
+	ButterfatPct float64   `json:"butterfatPct"`
+	ProteinPct   float64   `json:"proteinPct"`
+	SCC          int64     `json:"scc"`        // somatic cells per mL
+	Lactometer   float64   `json:"lactometer"` // lactometer reading, e.g. 28 for 1.028 g/mL
+	Notes        string    `json:"notes"`
+	CreatedAt    time.Time `json:"createdAt"`
+}
+
+// MilkQualityTrendPoint averages quality tests over one month
+type MilkQualityTrendPoint struct {
+	Month        string  `json:"month"` // YYYY-MM
+	Tests        int     `json:"tests"`
+	ButterfatPct float64 `json:"butterfatPct"`
+	ProteinPct   float64 `json:"proteinPct"`
+	SCC          float64 `json:"scc"`
+	Lactometer   float64 `json:"lactometer"`
+}
+
+// MilkQualityPricing is the buyer's quality payment scheme. Butterfat and
+// protein earn Rate KES per liter for every 0.1 percentage point above the
+// base (and lose it below); the first SCC band the count fits adds its adjustment.
+type MilkQualityPricing struct {
+	BaseButterfat float64   `json:"baseButterfat"`
+	ButterfatRate float64   `json:"butterfatRate"`
+	BaseProtein   float64   `json:"baseProtein"`
+	ProteinRate   float64   `json:"proteinRate"`
+	SCCBands      []SCCBand `json:"sccBands"` // ordered by MaxSCC; MaxSCC 0 means no upper limit
+}
+
+// SCCBand is a somatic cell count price band
+type SCCBand struct {
+	MaxSCC     int64   `json:"maxScc"`
+	Adjustment float64 `json:"adjustment"` // KES per liter
+}
+
+// QualityPrice is the breakdown of a quality-adjusted milk price
+type QualityPrice struct {
+	BasePrice           float64 `json:"basePrice"`
+	ButterfatAdjustment float64 `json:"butterfatAdjustment"`
+	ProteinAdjustment   float64 `json:"proteinAdjustment"`
+	SCCAdjustment       float64 `json:"sccAdjustment"`
+	Adjustment          float64 `json:"adjustment"` // total per liter
+	PricePerLiter       float64 `json:"pricePerLiter"`
+}
+
+// Lactation is one lactation of a cow, derived from her calving dates in
+// breeding_records and her milk records
+type Lactation struct {
+	AnimalID          int64   `json:"animalId"`
+	AnimalName        string  `json:"animalName"`
+	Number            int     `json:"number"`      // 1 for the first calving
+	CalvingDate       string  `json:"calvingDate"` // YYYY-MM-DD
+	EndDate           string  `json:"endDate"`     // last milk record; empty while current
+	Current           bool    `json:"current"`
+	DaysInMilk        int     `json:"daysInMilk"`
+	RecordedDays      int     `json:"recordedDays"`
+	PeakYield         float64 `json:"peakYield"` // liters/day
+	PeakDate          string  `json:"peakDate"`
+	PeakDIM           int     `json:"peakDim"`
+	CumulativeYield   float64 `json:"cumulativeYield"`
+	AverageYield      float64 `json:"averageYield"` // liters per recorded day
+	Yield305          float64 `json:"yield305"`
+	Yield305Projected bool    `json:"yield305Projected"` // true when extrapolated from a lactation under 305 days
+}
+
+// CowRanking scores a cow on yield, fertility, health cost and age against
+// the rest of her species in the herd. Scores run from 0 to 100; higher
+// means more worth keeping.
+type CowRanking struct {
+	AnimalID              int64    `json:"animalId"`
+	AnimalName            string   `json:"animalName"`
+	TagNumber             string   `json:"tagNumber,omitempty"`
+	Species               string   `json:"species"`
+	Breed                 string   `json:"breed,omitempty"`
+	Rank                  int      `json:"rank"` // 1 is the strongest culling candidate
+	Score                 float64  `json:"score"`
+	YieldScore            float64  `json:"yieldScore"`
+	FertilityScore        float64  `json:"fertilityScore"`
+	HealthScore           float64  `json:"healthScore"`
+	AgeScore              float64  `json:"ageScore"`
+	AvgDailyLiters        float64  `json:"avgDailyLiters"` // per recorded day in the period
+	RecordedDays          int      `json:"recordedDays"`
+	Services              int      `json:"services"` // services with a known outcome
+	Conceptions           int      `json:"conceptions"`
+	ServicesPerConception float64  `json:"servicesPerConception"` // 0 before any conception
+	Calvings              int      `json:"calvings"`
+	CalvingIntervalDays   int      `json:"calvingIntervalDays"` // average; 0 before a second calving
+	HealthCost            float64  `json:"healthCost"`          // vet costs in the period
+	AgeYears              float64  `json:"ageYears"`            // 0 when the date of birth is unknown
+	CullCandidate         bool     `json:"cullCandidate"`
+	Reasons               []string `json:"reasons"`
+}
+
+// HerdRanking ranks the milking herd from the strongest culling candidate
+// to the cow most worth keeping
+type HerdRanking struct {
+	StartDate  string       `json:"startDate"`
+	EndDate    string       `json:"endDate"`
+	Candidates int          `json:"candidates"`
+	Cows       []CowRanking `json:"cows"`
+}
+
+// WeightRecord represents a body weight measurement
+type WeightRecord struct {
+	ID         int64     `json:"id"`
+	AnimalID   int64     `json:"animalId"`
+	AnimalName string    `json:"animalName,omitempty"` // Joined field
+	Date       string    `json:"date"`                 // YYYY-MM-DD
+	WeightKg   float64   `json:"weightKg"`
+	Method     string    `json:"method"` // scale, weigh_band, estimate
+	Notes      string    `json:"notes"`
+	ADG        *float64  `json:"adg"` // kg/day since the previous weighing, computed
+	CreatedAt  time.Time `json:"createdAt"`
+}
+
+// GrowthTargetCurve gives target weights by age for a breed. Curves without
+// a species are cattle curves.
+type GrowthTargetCurve struct {
+	Species string              `json:"species,omitempty"`
+	Breed   string              `json:"breed"`
+	Points  []GrowthTargetPoint `json:"points"` // ordered by age
+}
+
+// GrowthTargetPoint is the target weight at an age
+type GrowthTargetPoint struct {
+	AgeMonths float64 `json:"ageMonths"`
+	WeightKg  float64 `json:"weightKg"`
+}
+
+// GrowthAnalysis compares an animal's weights with its breed target curve
+type GrowthAnalysis struct {
+	AnimalID        int64          `json:"animalId"`
+	AnimalName      string         `json:"animalName"`
+	Breed           string         `json:"breed"`
+	Records         []WeightRecord `json:"records"` // oldest first, with ADG
+	LatestWeight    float64        `json:"latestWeight"`
+	LatestDate      string         `json:"latestDate"`
+	AgeDays         int            `json:"ageDays"`      // at the latest weighing
+	OverallADG      float64        `json:"overallAdg"`   // kg/day from first to latest weighing
+	TargetWeight    float64        `json:"targetWeight"` // 0 when no birth date or beyond the curve
+	PercentOfTarget float64        `json:"percentOfTarget"`
+	BelowTarget     bool           `json:"belowTarget"`
+}
+
 // Photo represents a photo attachment for an entity
 type Photo struct {
 	ID         int64     `json:"id"`
@@ -215,3 +909,19 @@ type Photo struct {
 	Notes      string    `json:"notes"`
 	CreatedAt  time.Time `json:"createdAt"`
 }
+
+// StatementLine represents a line imported from an M-Pesa or bank statement
+type StatementLine struct {
+	ID            int64     `json:"id"`
+	Source        string    `json:"source"`    // mpesa, bank
+	Reference     string    `json:"reference"` // receipt number or bank reference
+	Date          string    `json:"date"`      // YYYY-MM-DD
+	Description   string    `json:"description"`
+	Counterparty  string    `json:"counterparty"` // sender or recipient name parsed from the description
+	Amount        float64   `json:"amount"`       // always positive
+	Direction     string    `json:"direction"`    // in, out
+	Status        string    `json:"status"`       // review, matched, created, split, ignored
+	TransactionID *int64    `json:"transactionId"`
+	MilkSaleID    *int64    `json:"milkSaleId"`
+	CreatedAt     time.Time `json:"createdAt"`
+}
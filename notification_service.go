@@ -2,6 +2,7 @@ package main
 
 import (
 	"context"
+	"database/sql"
 	"fmt"
 	"time"
 
@@ -100,7 +101,7 @@ func (s *NotificationService) TestNotification() string {
 // Reminder represents an upcoming task or event
 type Reminder struct {
 	ID          int64  `json:"id"`
-	Type        string `json:"type"` // vaccination, vet_followup, breeding, low_stock
+	Type        string `json:"type"` // vaccination, vet_followup, breeding, quarantine, low_stock, growth, high_scc, milk_drop, milk_missing
 	Title       string `json:"title"`
 	Description string `json:"description"`
 	DueDate     string `json:"dueDate"`
@@ -121,8 +122,8 @@ func (s *NotificationService) GetUpcomingReminders() ([]Reminder, error) {
 		reminders = append(reminders, vaccinations...)
 	}
 
-	// Get vet follow-ups
-	followups, err := s.getVetFollowups()
+	// Get vet follow-ups (next 7 days)
+	followups, err := s.getVetFollowups(7)
 	if err == nil {
 		reminders = append(reminders, followups...)
 	}
@@ -133,6 +134,43 @@ func (s *NotificationService) GetUpcomingReminders() ([]Reminder, error) {
 		reminders = append(reminders, pregnancies...)
 	}
 
+	// Get purchased animals leaving quarantine (next 14 days)
+	quarantines, err := s.getQuarantineEnds(14)
+	if err == nil {
+		reminders = append(reminders, quarantines...)
+	}
+
+	return reminders, nil
+}
+
+// GetRemindersWithin returns dated reminders due in the next given number of days
+func (s *NotificationService) GetRemindersWithin(days int) ([]Reminder, error) {
+	reminders := []Reminder{}
+
+	vaccinations, err := s.getUpcomingVaccinations(days)
+	if err != nil {
+		return nil, err
+	}
+	reminders = append(reminders, vaccinations...)
+
+	followups, err := s.getVetFollowups(days)
+	if err != nil {
+		return nil, err
+	}
+	reminders = append(reminders, followups...)
+
+	pregnancies, err := s.getUpcomingBirths(days)
+	if err != nil {
+		return nil, err
+	}
+	reminders = append(reminders, pregnancies...)
+
+	quarantines, err := s.getQuarantineEnds(days)
+	if err != nil {
+		return nil, err
+	}
+	reminders = append(reminders, quarantines...)
+
 	return reminders, nil
 }
 
@@ -142,7 +180,7 @@ func (s *NotificationService) GetLowStockAlerts() ([]Reminder, error) {
 
 	rows, err := db.Query(`
 		SELECT id, name, quantity, unit, minimum_stock 
-		FROM inventory 
+		FROM inventory_items
 		WHERE quantity <= minimum_stock AND minimum_stock > 0
 	`)
 	if err != nil {
@@ -152,7 +190,8 @@ func (s *NotificationService) GetLowStockAlerts() ([]Reminder, error) {
 
 	for rows.Next() {
 		var id int64
-		var name, unit string
+		var name string
+		var unit sql.NullString
 		var quantity, minStock float64
 		if err := rows.Scan(&id, &name, &quantity, &unit, &minStock); err != nil {
 			continue
@@ -172,6 +211,105 @@ func (s *NotificationService) GetLowStockAlerts() ([]Reminder, error) {
 	return reminders, nil
 }
 
+// GetGrowthAlerts returns growing animals whose latest weight is below their breed target
+func (s *NotificationService) GetGrowthAlerts() ([]Reminder, error) {
+	reminders := []Reminder{}
+
+	below, err := animalsBelowGrowthTarget()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, g := range below {
+		// Animals far behind target need attention first
+		priority := "medium"
+		if g.PercentOfTarget < 80 {
+			priority = "high"
+		}
+		reminders = append(reminders, Reminder{
+			ID:    g.AnimalID,
+			Type:  "growth",
+			Title: "Below Growth Target",
+			Description: fmt.Sprintf("%s weighed %.0f kg on %s, %.0f%% of the %.0f kg target",
+				g.AnimalName, g.LatestWeight, g.LatestDate, g.PercentOfTarget, g.TargetWeight),
+			Priority:   priority,
+			EntityType: "animal",
+			EntityID:   g.AnimalID,
+			EntityName: g.AnimalName,
+		})
+	}
+
+	return reminders, nil
+}
+
+// GetSCCAlerts returns cows whose latest milk test has a somatic cell count
+// above the configured threshold, a sign of subclinical mastitis
+func (s *NotificationService) GetSCCAlerts() ([]Reminder, error) {
+	reminders := []Reminder{}
+
+	threshold := sccAlertThreshold()
+	tests, err := cowsAboveSCCThreshold(threshold)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, t := range tests {
+		priority := "medium"
+		if t.SCC > 2*threshold {
+			priority = "high"
+		}
+		reminders = append(reminders, Reminder{
+			ID:          t.ID,
+			Type:        "high_scc",
+			Title:       "High Somatic Cell Count",
+			Description: fmt.Sprintf("%s tested %d cells/mL on %s (threshold %d)", t.AnimalName, t.SCC, t.Date, threshold),
+			DueDate:     t.Date,
+			Priority:    priority,
+			EntityType:  "animal",
+			EntityID:    *t.AnimalID,
+			EntityName:  t.AnimalName,
+		})
+	}
+
+	return reminders, nil
+}
+
+// GetMilkAnomalyAlerts returns cows whose milk suddenly dropped or stopped
+// being recorded, often the first sign of mastitis or illness
+func (s *NotificationService) GetMilkAnomalyAlerts() ([]Reminder, error) {
+	reminders := []Reminder{}
+
+	anomalies, err := detectMilkAnomalies()
+	if err != nil {
+		return nil, err
+	}
+
+	today := time.Now().Format("2006-01-02")
+	for _, a := range anomalies {
+		r := Reminder{
+			ID:          a.AnimalID,
+			Type:        "milk_drop",
+			Title:       "Milk Yield Drop",
+			Description: a.Explanation,
+			DueDate:     a.Date,
+			DaysUntil:   -daysBetween(a.Date, today),
+			Priority:    "high",
+			EntityType:  "animal",
+			EntityID:    a.AnimalID,
+			EntityName:  a.AnimalName,
+		}
+		if a.Type == "missed" {
+			r.Type = "milk_missing"
+			r.Title = "Milk Not Recorded"
+			r.DueDate = today
+			r.DaysUntil = 0
+		}
+		reminders = append(reminders, r)
+	}
+
+	return reminders, nil
+}
+
 // getUpcomingVaccinations returns animals needing vaccination soon
 func (s *NotificationService) getUpcomingVaccinations(days int) ([]Reminder, error) {
 	reminders := []Reminder{}
@@ -180,13 +318,14 @@ func (s *NotificationService) getUpcomingVaccinations(days int) ([]Reminder, err
 	todayStr := today.Format("2006-01-02")
 
 	rows, err := db.Query(`
-		SELECT hr.id, hr.animal_id, a.name, hr.next_date, hr.treatment_type, hr.notes
-		FROM health_records hr
-		JOIN animals a ON hr.animal_id = a.id
-		WHERE hr.next_date IS NOT NULL 
-		AND hr.next_date BETWEEN ? AND ?
+		SELECT vr.id, vr.animal_id, a.name, vr.next_due_date, vr.record_type
+		FROM vet_records vr
+		JOIN animals a ON vr.animal_id = a.id
+		WHERE vr.next_due_date IS NOT NULL AND vr.next_due_date != ''
+		AND vr.next_due_date BETWEEN ? AND ?
+		AND vr.record_type != 'checkup'
 		AND a.status = 'active'
-		ORDER BY hr.next_date
+		ORDER BY vr.next_due_date
 	`, todayStr, futureDate)
 	if err != nil {
 		return nil, err
@@ -195,8 +334,8 @@ func (s *NotificationService) getUpcomingVaccinations(days int) ([]Reminder, err
 
 	for rows.Next() {
 		var id, animalID int64
-		var animalName, nextDate, treatmentType, notes string
-		if err := rows.Scan(&id, &animalID, &animalName, &nextDate, &treatmentType, &notes); err != nil {
+		var animalName, nextDate, treatmentType string
+		if err := rows.Scan(&id, &animalID, &animalName, &nextDate, &treatmentType); err != nil {
 			continue
 		}
 
@@ -226,21 +365,21 @@ func (s *NotificationService) getUpcomingVaccinations(days int) ([]Reminder, err
 }
 
 // getVetFollowups returns scheduled vet follow-ups
-func (s *NotificationService) getVetFollowups() ([]Reminder, error) {
+func (s *NotificationService) getVetFollowups(days int) ([]Reminder, error) {
 	reminders := []Reminder{}
 	today := time.Now()
-	futureDate := today.AddDate(0, 0, 7).Format("2006-01-02")
+	futureDate := today.AddDate(0, 0, days).Format("2006-01-02")
 	todayStr := today.Format("2006-01-02")
 
 	rows, err := db.Query(`
-		SELECT hr.id, hr.animal_id, a.name, hr.next_date, hr.treatment_type
-		FROM health_records hr
-		JOIN animals a ON hr.animal_id = a.id
-		WHERE hr.next_date IS NOT NULL 
-		AND hr.next_date BETWEEN ? AND ?
-		AND hr.treatment_type = 'checkup'
+		SELECT vr.id, vr.animal_id, a.name, vr.next_due_date, vr.record_type
+		FROM vet_records vr
+		JOIN animals a ON vr.animal_id = a.id
+		WHERE vr.next_due_date IS NOT NULL AND vr.next_due_date != ''
+		AND vr.next_due_date BETWEEN ? AND ?
+		AND vr.record_type = 'checkup'
 		AND a.status = 'active'
-		ORDER BY hr.next_date
+		ORDER BY vr.next_due_date
 	`, todayStr, futureDate)
 	if err != nil {
 		return nil, err
@@ -288,6 +427,7 @@ func (s *NotificationService) getUpcomingBirths(days int) ([]Reminder, error) {
 		WHERE br.pregnancy_status IN ('pending', 'confirmed')
 		AND br.expected_due_date IS NOT NULL
 		AND br.expected_due_date BETWEEN ? AND ?
+		AND a.status = 'active'
 		ORDER BY br.expected_due_date
 	`, todayStr, futureDate)
 	if err != nil {
@@ -327,6 +467,58 @@ func (s *NotificationService) getUpcomingBirths(days int) ([]Reminder, error) {
 	return reminders, nil
 }
 
+// getQuarantineEnds returns purchased animals whose quarantine ends soon
+func (s *NotificationService) getQuarantineEnds(days int) ([]Reminder, error) {
+	reminders := []Reminder{}
+	today := time.Now()
+	futureDate := today.AddDate(0, 0, days).Format("2006-01-02")
+	todayStr := today.Format("2006-01-02")
+
+	rows, err := db.Query(`
+		SELECT id, name, quarantine_end_date
+		FROM animals
+		WHERE quarantine_end_date IS NOT NULL AND quarantine_end_date != ''
+		AND quarantine_end_date BETWEEN ? AND ?
+		AND status = 'active'
+		ORDER BY quarantine_end_date
+	`, todayStr, futureDate)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var id int64
+		var animalName, endDate string
+		if err := rows.Scan(&id, &animalName, &endDate); err != nil {
+			continue
+		}
+
+		endDateParsed, _ := time.Parse("2006-01-02", endDate)
+		daysUntil := int(endDateParsed.Sub(today).Hours() / 24)
+
+		priority := "medium"
+		if daysUntil <= 2 {
+			priority = "high"
+		}
+
+		reminders = append(reminders, Reminder{
+			ID:          id,
+			Type:        "quarantine",
+			Title:       "Quarantine Ends",
+			Description: animalName + " can join the herd after a final health check",
+			DueDate:     endDate,
+			DaysUntil:   daysUntil,
+			Priority:    priority,
+			EntityType:  "animal",
+			EntityID:    id,
+			EntityName:  animalName,
+		})
+	}
+
+	return reminders, nil
+}
+
 // GetAllNotifications returns all reminders and alerts combined
 func (s *NotificationService) GetAllNotifications() ([]Reminder, error) {
 	allNotifs := []Reminder{}
@@ -337,5 +529,14 @@ func (s *NotificationService) GetAllNotifications() ([]Reminder, error) {
 	lowStock, _ := s.GetLowStockAlerts()
 	allNotifs = append(allNotifs, lowStock...)
 
+	growth, _ := s.GetGrowthAlerts()
+	allNotifs = append(allNotifs, growth...)
+
+	scc, _ := s.GetSCCAlerts()
+	allNotifs = append(allNotifs, scc...)
+
+	milk, _ := s.GetMilkAnomalyAlerts()
+	allNotifs = append(allNotifs, milk...)
+
 	return allNotifs, nil
 }
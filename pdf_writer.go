@@ -0,0 +1,441 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/color"
+	_ "image/gif" // register GIF decoding for embedded photos
+	"image/jpeg"
+	_ "image/png" // register PNG decoding for embedded photos
+	"os"
+	"strings"
+	"time"
+)
+
+// pdfDocument is a minimal PDF writer for printable farm reports. It lays out
+// headings, paragraphs, tables, simple bar charts and photos top-to-bottom on
+// A4 pages using the built-in Helvetica fonts, so no font files need to be embedded.
+type pdfDocument struct {
+	title  string
+	pages  []*bytes.Buffer
+	page   *bytes.Buffer
+	y      float64
+	images []*pdfImage
+}
+
+// pdfImage is a JPEG stream embedded as an image XObject
+type pdfImage struct {
+	data       []byte
+	width      int
+	height     int
+	colorSpace string
+}
+
+// A4 page geometry in PDF points
+const (
+	pdfPageWidth    = 595.28
+	pdfPageHeight   = 841.89
+	pdfMargin       = 50.0
+	pdfContentWidth = pdfPageWidth - 2*pdfMargin
+)
+
+func newPDFDocument(title string) *pdfDocument {
+	d := &pdfDocument{title: title}
+	d.addPage()
+	return d
+}
+
+func (d *pdfDocument) addPage() {
+	d.page = &bytes.Buffer{}
+	d.pages = append(d.pages, d.page)
+	d.y = pdfPageHeight - pdfMargin
+}
+
+// ensureSpace starts a new page when less than h points remain
+func (d *pdfDocument) ensureSpace(h float64) {
+	if d.y-h < pdfMargin {
+		d.addPage()
+	}
+}
+
+// Title writes the large document title with a generated-on line
+func (d *pdfDocument) Title(text, subtitle string) {
+	d.y -= 20
+	d.text(pdfMargin, d.y, 20, true, text)
+	if subtitle != "" {
+		d.y -= 18
+		d.setGray(0.4)
+		d.text(pdfMargin, d.y, 10, false, subtitle)
+		d.setGray(0)
+	}
+	d.y -= 10
+	d.Rule()
+}
+
+// Heading writes a section heading
+func (d *pdfDocument) Heading(text string) {
+	d.ensureSpace(50)
+	d.y -= 24
+	d.text(pdfMargin, d.y, 14, true, text)
+	d.y -= 8
+}
+
+// Subheading writes a smaller bold heading
+func (d *pdfDocument) Subheading(text string) {
+	d.ensureSpace(36)
+	d.y -= 16
+	d.text(pdfMargin, d.y, 11, true, text)
+	d.y -= 4
+}
+
+// Paragraph writes word-wrapped body text
+func (d *pdfDocument) Paragraph(text string) {
+	const size, leading = 10.0, 14.0
+	for _, line := range pdfWrap(text, size, pdfContentWidth) {
+		d.ensureSpace(leading)
+		d.y -= leading
+		d.text(pdfMargin, d.y, size, false, line)
+	}
+	d.y -= 4
+}
+
+// KeyValues writes label/value pairs in two aligned columns
+func (d *pdfDocument) KeyValues(pairs [][2]string) {
+	const size, leading = 10.0, 15.0
+	for _, p := range pairs {
+		d.ensureSpace(leading)
+		d.y -= leading
+		d.text(pdfMargin, d.y, size, true, p[0])
+		d.text(pdfMargin+160, d.y, size, false, pdfTruncate(p[1], size, pdfContentWidth-160))
+	}
+	d.y -= 4
+}
+
+// Rule draws a horizontal line across the content width
+func (d *pdfDocument) Rule() {
+	d.ensureSpace(8)
+	d.y -= 4
+	fmt.Fprintf(d.page, "0.8 G 0.5 w %.2f %.2f m %.2f %.2f l S 0 G\n", pdfMargin, d.y, pdfPageWidth-pdfMargin, d.y)
+	d.y -= 4
+}
+
+// Table writes a table with a shaded header row. Widths are relative column
+// weights; long cell values are truncated to fit their column.
+func (d *pdfDocument) Table(headers []string, widths []float64, rows [][]string) {
+	const size, rowHeight = 9.0, 15.0
+
+	total := 0.0
+	for _, w := range widths {
+		total += w
+	}
+	cols := make([]float64, len(widths))
+	for i, w := range widths {
+		cols[i] = w / total * pdfContentWidth
+	}
+
+	drawHeader := func() {
+		d.y -= rowHeight
+		fmt.Fprintf(d.page, "0.92 g %.2f %.2f %.2f %.2f re f 0 g\n", pdfMargin, d.y-4, pdfContentWidth, rowHeight)
+		x := pdfMargin + 3
+		for i, h := range headers {
+			d.text(x, d.y, size, true, pdfTruncate(h, size, cols[i]-6))
+			x += cols[i]
+		}
+	}
+
+	d.ensureSpace(rowHeight * 3)
+	drawHeader()
+	if len(rows) == 0 {
+		d.y -= rowHeight
+		d.setGray(0.4)
+		d.text(pdfMargin+3, d.y, size, false, "No records")
+		d.setGray(0)
+	}
+	for _, row := range rows {
+		if d.y-rowHeight < pdfMargin {
+			d.addPage()
+			drawHeader()
+		}
+		d.y -= rowHeight
+		x := pdfMargin + 3
+		for i, cell := range row {
+			if i >= len(cols) {
+				break
+			}
+			d.text(x, d.y, size, false, pdfTruncate(cell, size, cols[i]-6))
+			x += cols[i]
+		}
+	}
+	d.y -= 8
+}
+
+// BarChart draws a simple vertical bar chart with labels under every few bars
+func (d *pdfDocument) BarChart(labels []string, values []float64, unit string) {
+	const chartHeight, labelSpace = 120.0, 28.0
+	if len(values) == 0 {
+		return
+	}
+	d.ensureSpace(chartHeight + labelSpace + 10)
+
+	maxValue := 0.0
+	for _, v := range values {
+		if v > maxValue {
+			maxValue = v
+		}
+	}
+	if maxValue == 0 {
+		maxValue = 1
+	}
+
+	base := d.y - chartHeight - 6
+	slot := pdfContentWidth / float64(len(values))
+	barWidth := slot * 0.7
+
+	// Axis and maximum label
+	fmt.Fprintf(d.page, "0.6 G 0.5 w %.2f %.2f m %.2f %.2f l S 0 G\n", pdfMargin, base, pdfPageWidth-pdfMargin, base)
+	d.setGray(0.4)
+	d.text(pdfMargin, d.y-4, 8, false, fmt.Sprintf("max %.1f %s", maxValue, unit))
+	d.setGray(0)
+
+	labelEvery := 1
+	if len(values) > 12 {
+		labelEvery = (len(values) + 11) / 12
+	}
+	for i, v := range values {
+		x := pdfMargin + float64(i)*slot + (slot-barWidth)/2
+		h := v / maxValue * (chartHeight - 12)
+		fmt.Fprintf(d.page, "0.30 0.55 0.35 rg %.2f %.2f %.2f %.2f re f 0 g\n", x, base, barWidth, h)
+		if i%labelEvery == 0 && i < len(labels) {
+			d.text(x, base-11, 7, false, pdfTruncate(labels[i], 7, slot*float64(labelEvery)))
+		}
+	}
+	d.y = base - labelSpace
+}
+
+// Images draws photos in rows of the given height, wrapping to new rows and
+// pages as needed. JPEG data is embedded as-is; PNG and GIF images are
+// re-encoded as JPEG. Images that cannot be decoded are skipped. It returns
+// the number of images drawn.
+func (d *pdfDocument) Images(images [][]byte, height float64) int {
+	const gap = 8.0
+	x := pdfMargin
+	placed := 0
+	for _, data := range images {
+		img, err := newPDFImage(data)
+		if err != nil {
+			continue
+		}
+		d.images = append(d.images, img)
+
+		w, h := height*float64(img.width)/float64(img.height), height
+		if w > pdfContentWidth {
+			h = h * pdfContentWidth / w
+			w = pdfContentWidth
+		}
+		if placed == 0 || x+w > pdfPageWidth-pdfMargin {
+			if placed > 0 {
+				d.y -= gap
+			}
+			d.ensureSpace(height)
+			d.y -= height
+			x = pdfMargin
+		}
+		fmt.Fprintf(d.page, "q %.2f 0 0 %.2f %.2f %.2f cm /Im%d Do Q\n", w, h, x, d.y+height-h, len(d.images))
+		x += w + gap
+		placed++
+	}
+	if placed > 0 {
+		d.y -= gap
+	}
+	return placed
+}
+
+// newPDFImage prepares image data for embedding with the DCTDecode filter
+func newPDFImage(data []byte) (*pdfImage, error) {
+	cfg, format, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	if cfg.Width == 0 || cfg.Height == 0 {
+		return nil, fmt.Errorf("empty image")
+	}
+
+	if format == "jpeg" {
+		switch cfg.ColorModel {
+		case color.YCbCrModel, color.RGBAModel:
+			return &pdfImage{data: data, width: cfg.Width, height: cfg.Height, colorSpace: "DeviceRGB"}, nil
+		case color.GrayModel:
+			return &pdfImage{data: data, width: cfg.Width, height: cfg.Height, colorSpace: "DeviceGray"}, nil
+		}
+	}
+
+	// Anything else (PNG, GIF, CMYK JPEG) is decoded and re-encoded as RGB JPEG
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, img, &jpeg.Options{Quality: 85}); err != nil {
+		return nil, err
+	}
+	bounds := img.Bounds()
+	colorSpace := "DeviceRGB"
+	if img.ColorModel() == color.GrayModel {
+		colorSpace = "DeviceGray"
+	}
+	return &pdfImage{data: buf.Bytes(), width: bounds.Dx(), height: bounds.Dy(), colorSpace: colorSpace}, nil
+}
+
+// Save writes the document to the given path
+func (d *pdfDocument) Save(path string) error {
+	return os.WriteFile(path, d.Bytes(), 0644)
+}
+
+// Bytes renders the complete PDF file
+func (d *pdfDocument) Bytes() []byte {
+	var out bytes.Buffer
+	var offsets []int
+
+	startObj := func() int {
+		offsets = append(offsets, out.Len())
+		n := len(offsets)
+		fmt.Fprintf(&out, "%d 0 obj\n", n)
+		return n
+	}
+
+	out.WriteString("%PDF-1.4\n%\xe2\xe3\xcf\xd3\n")
+
+	// Objects 1-4 are fixed: catalog, page tree, regular and bold fonts.
+	// Each page then takes two objects: the page and its content stream,
+	// and embedded images follow the pages.
+	pageCount := len(d.pages)
+	firstImage := 5 + pageCount*2
+	resources := "/Font << /F1 3 0 R /F2 4 0 R >>"
+	if len(d.images) > 0 {
+		var xobjects strings.Builder
+		for i := range d.images {
+			fmt.Fprintf(&xobjects, "/Im%d %d 0 R ", i+1, firstImage+i)
+		}
+		resources += " /XObject << " + xobjects.String() + ">>"
+	}
+	startObj()
+	out.WriteString("<< /Type /Catalog /Pages 2 0 R >>\nendobj\n")
+	startObj()
+	out.WriteString("<< /Type /Pages /Kids [")
+	for i := 0; i < pageCount; i++ {
+		fmt.Fprintf(&out, "%d 0 R ", 5+i*2)
+	}
+	fmt.Fprintf(&out, "] /Count %d >>\nendobj\n", pageCount)
+	startObj()
+	out.WriteString("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica /Encoding /WinAnsiEncoding >>\nendobj\n")
+	startObj()
+	out.WriteString("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica-Bold /Encoding /WinAnsiEncoding >>\nendobj\n")
+
+	for i, page := range d.pages {
+		content := page.Bytes()
+		footer := &pdfDocument{page: &bytes.Buffer{}}
+		footer.setGray(0.5)
+		footer.text(pdfMargin, pdfMargin/2, 8, false, d.title)
+		label := fmt.Sprintf("Page %d of %d", i+1, pageCount)
+		footer.text(pdfPageWidth-pdfMargin-pdfTextWidth(label, 8), pdfMargin/2, 8, false, label)
+		content = append(content, footer.page.Bytes()...)
+
+		startObj()
+		fmt.Fprintf(&out, "<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %.2f %.2f] /Resources << %s >> /Contents %d 0 R >>\nendobj\n",
+			pdfPageWidth, pdfPageHeight, resources, 6+i*2)
+		startObj()
+		fmt.Fprintf(&out, "<< /Length %d >>\nstream\n", len(content))
+		out.Write(content)
+		out.WriteString("\nendstream\nendobj\n")
+	}
+
+	for _, img := range d.images {
+		startObj()
+		fmt.Fprintf(&out, "<< /Type /XObject /Subtype /Image /Width %d /Height %d /ColorSpace /%s /BitsPerComponent 8 /Filter /DCTDecode /Length %d >>\nstream\n",
+			img.width, img.height, img.colorSpace, len(img.data))
+		out.Write(img.data)
+		out.WriteString("\nendstream\nendobj\n")
+	}
+
+	xref := out.Len()
+	fmt.Fprintf(&out, "xref\n0 %d\n0000000000 65535 f \n", len(offsets)+1)
+	for _, off := range offsets {
+		fmt.Fprintf(&out, "%010d 00000 n \n", off)
+	}
+	fmt.Fprintf(&out, "trailer\n<< /Size %d /Root 1 0 R /Info << /Title (%s) /Producer (Farmland) /CreationDate (D:%s) >> >>\nstartxref\n%d\n%%%%EOF\n",
+		len(offsets)+1, pdfEscape(d.title), time.Now().Format("20060102150405"), xref)
+	return out.Bytes()
+}
+
+func (d *pdfDocument) text(x, y, size float64, bold bool, s string) {
+	font := "F1"
+	if bold {
+		font = "F2"
+	}
+	fmt.Fprintf(d.page, "BT /%s %.1f Tf %.2f %.2f Td (%s) Tj ET\n", font, size, x, y, pdfEscape(s))
+}
+
+func (d *pdfDocument) setGray(level float64) {
+	fmt.Fprintf(d.page, "%.2f g\n", level)
+}
+
+// pdfEscape converts text to a WinAnsi PDF string literal body
+func pdfEscape(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		switch {
+		case r == '(' || r == ')' || r == '\\':
+			b.WriteByte('\\')
+			b.WriteRune(r)
+		case r == '\n' || r == '\r' || r == '\t':
+			b.WriteByte(' ')
+		case r < 32:
+			continue
+		case r < 256:
+			b.WriteByte(byte(r))
+		default:
+			b.WriteByte('?')
+		}
+	}
+	return b.String()
+}
+
+// pdfTextWidth estimates the rendered width of Helvetica text
+func pdfTextWidth(s string, size float64) float64 {
+	return float64(len([]rune(s))) * size * 0.52
+}
+
+// pdfTruncate shortens text with an ellipsis so it fits within width
+func pdfTruncate(s string, size, width float64) string {
+	if pdfTextWidth(s, size) <= width {
+		return s
+	}
+	runes := []rune(s)
+	for len(runes) > 0 && pdfTextWidth(string(runes)+"...", size) > width {
+		runes = runes[:len(runes)-1]
+	}
+	return string(runes) + "..."
+}
+
+// pdfWrap splits text into lines that fit within width
+func pdfWrap(text string, size, width float64) []string {
+	var lines []string
+	for _, para := range strings.Split(text, "\n") {
+		line := ""
+		for _, word := range strings.Fields(para) {
+			candidate := word
+			if line != "" {
+				candidate = line + " " + word
+			}
+			if line != "" && pdfTextWidth(candidate, size) > width {
+				lines = append(lines, line)
+				line = word
+			} else {
+				line = candidate
+			}
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
@@ -0,0 +1,234 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+	"time"
+)
+
+// defaultSpecies is the species of animals recorded before species existed
+const defaultSpecies = "cattle"
+
+// speciesProfiles lists the species the farm can keep. Types are the values
+// allowed in Animal.Type for the species.
+var speciesProfiles = []SpeciesProfile{
+	{
+		Species: "cattle", Label: "Cattle",
+		Types:         []string{"cow", "heifer", "calf", "bull", "steer"},
+		MilkingTypes:  []string{"cow", "heifer"},
+		MaleTypes:     []string{"bull"},
+		GestationDays: 280,
+		Metric:        "milk",
+	},
+	{
+		Species: "goat", Label: "Goats",
+		Types:         []string{"doe", "doeling", "kid", "buck", "wether"},
+		MilkingTypes:  []string{"doe"},
+		MaleTypes:     []string{"buck"},
+		GestationDays: 150,
+		Metric:        "milk",
+	},
+	{
+		Species: "sheep", Label: "Sheep",
+		Types:         []string{"ewe", "ewe_lamb", "lamb", "ram", "wether"},
+		MaleTypes:     []string{"ram"},
+		GestationDays: 147,
+		Metric:        "weight_gain",
+	},
+	{
+		Species: "pig", Label: "Pigs",
+		Types:         []string{"sow", "gilt", "piglet", "weaner", "boar", "barrow"},
+		MaleTypes:     []string{"boar"},
+		GestationDays: 114,
+		Metric:        "weight_gain",
+	},
+	{
+		Species: "poultry", Label: "Poultry",
+		GestationDays: 21,
+		Metric:        "eggs",
+		FlockOnly:     true,
+	},
+}
+
+// GetSpeciesProfiles returns the species the farm can keep with their types
+func (s *LivestockService) GetSpeciesProfiles() []SpeciesProfile {
+	return speciesProfiles
+}
+
+// speciesProfile returns the profile of a species, treating an empty species
+// as cattle
+func speciesProfile(species string) (*SpeciesProfile, bool) {
+	if species == "" {
+		species = defaultSpecies
+	}
+	for i := range speciesProfiles {
+		if speciesProfiles[i].Species == species {
+			return &speciesProfiles[i], true
+		}
+	}
+	return nil, false
+}
+
+// gestationDays returns the gestation length of a species, falling back to cattle
+func gestationDays(species string) int {
+	if p, ok := speciesProfile(species); ok && p.GestationDays > 0 {
+		return p.GestationDays
+	}
+	p, _ := speciesProfile(defaultSpecies)
+	return p.GestationDays
+}
+
+// validateSpecies fills the default species and checks that the animal type
+// belongs to it. Flock-only species are recorded as flocks, not animals.
+func validateSpecies(animal *Animal) error {
+	if animal.Species == "" {
+		animal.Species = defaultSpecies
+	}
+	profile, ok := speciesProfile(animal.Species)
+	if !ok {
+		return fmt.Errorf("unknown species %q", animal.Species)
+	}
+	if profile.FlockOnly {
+		return fmt.Errorf("%s are recorded as flocks rather than individual animals", strings.ToLower(profile.Label))
+	}
+	if !slices.Contains(profile.Types, animal.Type) {
+		return fmt.Errorf("%q is not a %s type; use one of %s", animal.Type, profile.Species, strings.Join(profile.Types, ", "))
+	}
+	return nil
+}
+
+// milkingAnimalsClause returns a WHERE condition, for an animals table with
+// the given alias prefix, that matches the types milked in each species
+func milkingAnimalsClause(prefix string) (string, []interface{}) {
+	var conditions []string
+	var args []interface{}
+	for _, p := range speciesProfiles {
+		if len(p.MilkingTypes) == 0 {
+			continue
+		}
+		placeholders := strings.TrimSuffix(strings.Repeat("?, ", len(p.MilkingTypes)), ", ")
+		conditions = append(conditions, fmt.Sprintf("(%sspecies = ? AND %stype IN (%s))", prefix, prefix, placeholders))
+		args = append(args, p.Species)
+		for _, t := range p.MilkingTypes {
+			args = append(args, t)
+		}
+	}
+	return "(" + strings.Join(conditions, " OR ") + ")", args
+}
+
+// GetSpeciesSummaries returns head counts and this month's production for
+// every species the farm keeps: milk for dairy species, daily weight gain
+// for meat species and eggs and laying rate for poultry flocks
+func (s *LivestockService) GetSpeciesSummaries() ([]SpeciesSummary, error) {
+	now := time.Now()
+	monthStart := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location()).Format("2006-01-02")
+	today := now.Format("2006-01-02")
+
+	animals, err := s.GetAllAnimals()
+	if err != nil {
+		return nil, err
+	}
+	milkers, err := s.GetDairyCows()
+	if err != nil {
+		return nil, err
+	}
+	weights, err := s.GetWeightRecords(0, "", "")
+	if err != nil {
+		return nil, err
+	}
+	flocks, err := NewFlockService().GetFlocks()
+	if err != nil {
+		return nil, err
+	}
+
+	milkBySpecies := map[string]float64{}
+	rows, err := db.Query(`
+		SELECT COALESCE(a.species, 'cattle'), COALESCE(SUM(mr.total_liters), 0)
+		FROM milk_records mr
+		JOIN animals a ON mr.animal_id = a.id
+		WHERE mr.date >= ? AND mr.date <= ?
+		GROUP BY COALESCE(a.species, 'cattle')
+	`, monthStart, today)
+	if err != nil {
+		return nil, err
+	}
+	for rows.Next() {
+		var species string
+		var liters float64
+		if err := rows.Scan(&species, &liters); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		milkBySpecies[species] = liters
+	}
+	rows.Close()
+
+	// Weight records come oldest first, so the last two per animal give its recent gain
+	byAnimal := map[int64][]WeightRecord{}
+	for _, w := range weights {
+		byAnimal[w.AnimalID] = append(byAnimal[w.AnimalID], w)
+	}
+
+	summaries := []SpeciesSummary{}
+	for _, p := range speciesProfiles {
+		summary := SpeciesSummary{Species: p.Species, Label: p.Label, Metric: p.Metric}
+
+		if p.FlockOnly {
+			henDays := 0
+			for _, f := range flocks {
+				if f.Species != p.Species || f.Status != "active" {
+					continue
+				}
+				records, err := NewFlockService().GetFlockRecords(f.ID, "", "")
+				if err != nil {
+					return nil, err
+				}
+				stats := flockPeriodStats(f, records, monthStart, today)
+				summary.HeadCount += f.CurrentCount
+				if f.Purpose == "layers" {
+					summary.Producing += f.CurrentCount
+					summary.Eggs += stats.eggs
+					henDays += stats.henDays
+				}
+				summary.Mortality += stats.mortality
+			}
+			if henDays > 0 {
+				summary.LayingRate = math.Round(float64(summary.Eggs)/float64(henDays)*1000) / 10
+			}
+		} else {
+			var gains []float64
+			for _, a := range animals {
+				if firstNonEmpty(a.Species, defaultSpecies) != p.Species || a.Status != "active" {
+					continue
+				}
+				summary.HeadCount++
+				if w := byAnimal[a.ID]; len(w) >= 2 {
+					prev, last := w[len(w)-2], w[len(w)-1]
+					if days := daysBetween(prev.Date, last.Date); days > 0 {
+						gains = append(gains, (last.WeightKg-prev.WeightKg)/float64(days))
+					}
+				}
+			}
+			for _, m := range milkers {
+				if m.Species == p.Species {
+					summary.Producing++
+				}
+			}
+			summary.MilkLiters = math.Round(milkBySpecies[p.Species]*10) / 10
+			if len(gains) > 0 {
+				total := 0.0
+				for _, g := range gains {
+					total += g
+				}
+				summary.AverageADG = math.Round(total/float64(len(gains))*1000) / 1000
+			}
+		}
+
+		if summary.HeadCount > 0 || summary.MilkLiters > 0 || summary.Eggs > 0 {
+			summaries = append(summaries, summary)
+		}
+	}
+	return summaries, nil
+}
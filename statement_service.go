@@ -0,0 +1,1247 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha1"
+	"database/sql"
+	"encoding/csv"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"html"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+// StatementService imports M-Pesa and bank statements and reconciles them against farm records
+type StatementService struct {
+	ctx context.Context
+}
+
+// NewStatementService creates a new StatementService
+func NewStatementService() *StatementService {
+	return &StatementService{}
+}
+
+// SetContext sets the Wails runtime context
+func (s *StatementService) SetContext(ctx context.Context) {
+	s.ctx = ctx
+}
+
+// StatementImportResult summarises a statement import
+type StatementImportResult struct {
+	Path       string `json:"path"`
+	Lines      int    `json:"lines"`      // lines read from the statement
+	Duplicates int    `json:"duplicates"` // lines already imported earlier
+	Matched    int    `json:"matched"`    // lines matched to existing records
+	Review     int    `json:"review"`     // lines left in the review queue
+}
+
+// A payment matches a milk sale when the amounts agree to within
+// mpesaMatchTolerance shillings and the payment was made between
+// mpesaMatchDaysBefore days before and mpesaMatchDaysAfter days after the sale.
+const (
+	mpesaMatchDaysBefore = 3
+	mpesaMatchDaysAfter  = 30
+	mpesaMatchTolerance  = 1.0
+)
+
+// ImportMpesaStatement asks for an M-Pesa statement CSV and imports it
+func (s *StatementService) ImportMpesaStatement() (*StatementImportResult, error) {
+	if s.ctx == nil {
+		return nil, fmt.Errorf("context not set")
+	}
+
+	openPath, err := runtime.OpenFileDialog(s.ctx, runtime.OpenDialogOptions{
+		Title: "Select M-Pesa Statement",
+		Filters: []runtime.FileFilter{
+			{DisplayName: "CSV Files", Pattern: "*.csv"},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if openPath == "" {
+		return nil, nil
+	}
+
+	return s.ImportMpesaStatementFile(openPath)
+}
+
+// ImportMpesaStatementFile imports an M-Pesa statement CSV from disk.
+// Incoming payments that match an unpaid milk sale mark the sale as paid and
+// outgoing payments that match a single uncleared expense clear it; anything
+// else is left in the review queue. Lines imported before are skipped by
+// receipt number. The import is all or nothing.
+func (s *StatementService) ImportMpesaStatementFile(path string) (*StatementImportResult, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	lines, err := parseMpesaStatement(file)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	result := &StatementImportResult{Path: path, Lines: len(lines)}
+	for _, line := range lines {
+		id, err := insertStatementLine(tx, line)
+		if err != nil {
+			return nil, err
+		}
+		if id == 0 {
+			result.Duplicates++
+			continue
+		}
+		line.ID = id
+
+		if line.Direction == "in" {
+			saleID, err := findMatchingMilkSale(tx, line)
+			if err != nil {
+				return nil, err
+			}
+			if saleID > 0 {
+				if err := matchStatementLineToSale(tx, &line, saleID); err != nil {
+					return nil, err
+				}
+				result.Matched++
+				continue
+			}
+		} else {
+			transactionID, err := findMatchingTransaction(tx, line)
+			if err != nil {
+				return nil, err
+			}
+			if transactionID > 0 {
+				if err := confirmStatementMatch(tx, &line, transactionID); err != nil {
+					return nil, err
+				}
+				result.Matched++
+				continue
+			}
+		}
+		result.Review++
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// GetStatementLines returns imported statement lines, optionally filtered by source and status
+func (s *StatementService) GetStatementLines(source, status string) ([]StatementLine, error) {
+	query := `SELECT ` + statementLineColumns + ` FROM statement_lines WHERE 1=1`
+	args := []interface{}{}
+	if source != "" {
+		query += " AND source = ?"
+		args = append(args, source)
+	}
+	if status != "" {
+		query += " AND status = ?"
+		args = append(args, status)
+	}
+	query += " ORDER BY date DESC, id DESC"
+
+	rows, err := db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var lines []StatementLine
+	for rows.Next() {
+		l, err := scanStatementLine(rows)
+		if err != nil {
+			return nil, err
+		}
+		lines = append(lines, *l)
+	}
+	return lines, nil
+}
+
+// GetReviewQueue returns statement lines that still need a decision
+func (s *StatementService) GetReviewQueue() ([]StatementLine, error) {
+	return s.GetStatementLines("", "review")
+}
+
+// MatchStatementLineToSale marks a milk sale as paid by an incoming statement line
+// from the review queue. The amounts must agree to within mpesaMatchTolerance.
+func (s *StatementService) MatchStatementLineToSale(lineID, saleID int64) error {
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	line, err := reviewStatementLine(tx, lineID)
+	if err != nil {
+		return err
+	}
+	if err := matchStatementLineToSale(tx, line, saleID); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// matchStatementLineToSale links a line to a milk sale. The sale already posted
+// its income when it was recorded, so no new transaction is created; the sale's
+// transaction is cleared and linked to the line instead. Sales to a buyer
+// account get a payment for the line's amount on the buyer's account.
+func matchStatementLineToSale(tx *sql.Tx, line *StatementLine, saleID int64) error {
+	if line.Direction != "in" {
+		return fmt.Errorf("only incoming payments can be matched to milk sales")
+	}
+
+	var buyerID sql.NullInt64
+	var total float64
+	if err := tx.QueryRow(`SELECT buyer_id, total_amount FROM milk_sales WHERE id = ?`, saleID).Scan(&buyerID, &total); err != nil {
+		return fmt.Errorf("sale not found: %w", err)
+	}
+	if math.Abs(total-line.Amount) > mpesaMatchTolerance {
+		return fmt.Errorf("the payment of %.2f does not match the sale total of %.2f", line.Amount, total)
+	}
+	var matched int
+	if err := tx.QueryRow(`SELECT COUNT(*) FROM statement_lines WHERE milk_sale_id = ? AND id != ?`, saleID, line.ID).Scan(&matched); err != nil {
+		return err
+	}
+	if matched > 0 {
+		return fmt.Errorf("this sale is already matched to another statement line")
+	}
+
+	if buyerID.Valid {
+		payment := BuyerPayment{
+			BuyerID: buyerID.Int64, Date: line.Date, Amount: line.Amount, Method: line.Source,
+			Reference: line.Reference, MilkSaleID: &saleID, Notes: line.Description,
+		}
+		if _, err := insertBuyerPayment(tx, payment); err != nil {
+			return err
+		}
+		if err := applyBuyerPayments(tx, buyerID.Int64); err != nil {
+			return err
+		}
+	} else if _, err := tx.Exec(`UPDATE milk_sales SET is_paid = 1 WHERE id = ?`, saleID); err != nil {
+		return err
+	}
+
+	var transactionID sql.NullInt64
+	err := tx.QueryRow(`SELECT id FROM transactions WHERE related_entity = ?`, fmt.Sprintf("milk_sale:%d", saleID)).Scan(&transactionID)
+	if err != nil && err != sql.ErrNoRows {
+		return err
+	}
+	if transactionID.Valid {
+		if _, err := tx.Exec(`UPDATE transactions SET payment_method = ?, reconciliation_status = 'cleared', cleared_date = ? WHERE id = ?`,
+			line.Source, line.Date, transactionID.Int64); err != nil {
+			return err
+		}
+	}
+
+	_, err = tx.Exec(`UPDATE statement_lines SET status = 'matched', milk_sale_id = ?, transaction_id = ? WHERE id = ?`,
+		saleID, transactionID, line.ID)
+	return err
+}
+
+// CreateTransactionFromStatementLine records a line from the review queue as a new income or expense transaction
+func (s *StatementService) CreateTransactionFromStatementLine(lineID int64, category string) (int64, error) {
+	tx, err := db.Begin()
+	if err != nil {
+		return 0, err
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	line, err := reviewStatementLine(tx, lineID)
+	if err != nil {
+		return 0, err
+	}
+
+	txType := "income"
+	if line.Direction == "out" {
+		txType = "expense"
+	}
+	if category == "" {
+		category = "other_" + txType
+	}
+
+	description := line.Description
+	if line.Counterparty != "" {
+		description = line.Counterparty
+	}
+
+	result, err := tx.Exec(`
+		INSERT INTO transactions (date, type, category, description, amount, payment_method, related_entity, notes, reconciliation_status, cleared_date)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, 'cleared', ?)
+	`, line.Date, txType, category, description, line.Amount, line.Source,
+		fmt.Sprintf("%s:%s", line.Source, line.Reference), line.Description, line.Date)
+	if err != nil {
+		return 0, err
+	}
+
+	transactionID, err := result.LastInsertId()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get last insert id: %w", err)
+	}
+
+	if _, err := tx.Exec(`UPDATE statement_lines SET status = 'created', transaction_id = ? WHERE id = ?`, transactionID, lineID); err != nil {
+		return 0, err
+	}
+	if err := tx.Commit(); err != nil {
+		return 0, err
+	}
+	return transactionID, nil
+}
+
+// IgnoreStatementLine removes a line from the review queue without recording it
+func (s *StatementService) IgnoreStatementLine(lineID int64) error {
+	result, err := db.Exec(`UPDATE statement_lines SET status = 'ignored' WHERE id = ? AND status = 'review'`, lineID)
+	if err != nil {
+		return err
+	}
+	if affected, err := result.RowsAffected(); err != nil {
+		return err
+	} else if affected == 0 {
+		return fmt.Errorf("statement line is not in the review queue")
+	}
+	return nil
+}
+
+const statementLineColumns = `id, source, reference, date, description, counterparty, amount, direction, status,
+			   transaction_id, milk_sale_id, created_at`
+
+// getStatementLine loads a single statement line
+func getStatementLine(id int64) (*StatementLine, error) {
+	return scanStatementLine(db.QueryRow(`SELECT `+statementLineColumns+` FROM statement_lines WHERE id = ?`, id))
+}
+
+// reviewStatementLine loads a statement line within a transaction and checks
+// that it is still waiting in the review queue
+func reviewStatementLine(tx *sql.Tx, id int64) (*StatementLine, error) {
+	line, err := scanStatementLine(tx.QueryRow(`SELECT `+statementLineColumns+` FROM statement_lines WHERE id = ?`, id))
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("statement line not found")
+	}
+	if err != nil {
+		return nil, err
+	}
+	if line.Status != "review" {
+		return nil, fmt.Errorf("statement line is already %s", line.Status)
+	}
+	return line, nil
+}
+
+// scanStatementLine scans a row selected with statementLineColumns
+func scanStatementLine(row rowScanner) (*StatementLine, error) {
+	var l StatementLine
+	var description, counterparty sql.NullString
+	var transactionID, milkSaleID sql.NullInt64
+	err := row.Scan(&l.ID, &l.Source, &l.Reference, &l.Date, &description, &counterparty, &l.Amount, &l.Direction,
+		&l.Status, &transactionID, &milkSaleID, &l.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+	l.Description = description.String
+	l.Counterparty = counterparty.String
+	if transactionID.Valid {
+		l.TransactionID = &transactionID.Int64
+	}
+	if milkSaleID.Valid {
+		l.MilkSaleID = &milkSaleID.Int64
+	}
+	return &l, nil
+}
+
+// insertStatementLine stores a parsed line and returns its ID, or 0 if the
+// same reference was already imported from this source
+func insertStatementLine(tx *sql.Tx, line StatementLine) (int64, error) {
+	result, err := tx.Exec(`
+		INSERT OR IGNORE INTO statement_lines (source, reference, date, description, counterparty, amount, direction, status)
+		VALUES (?, ?, ?, ?, ?, ?, ?, 'review')
+	`, line.Source, line.Reference, line.Date, line.Description, line.Counterparty, line.Amount, line.Direction)
+	if err != nil {
+		return 0, err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil || affected == 0 {
+		return 0, err
+	}
+	return result.LastInsertId()
+}
+
+// findMatchingTransaction looks for the single uncleared transaction of the
+// same direction and amount, dated within bankMatchWindowDays of the line and
+// not yet linked to another statement line. It returns 0 when there is no
+// candidate or more than one.
+func findMatchingTransaction(tx *sql.Tx, line StatementLine) (int64, error) {
+	lineDate, err := time.Parse("2006-01-02", line.Date)
+	if err != nil {
+		return 0, nil
+	}
+	txType := "income"
+	if line.Direction == "out" {
+		txType = "expense"
+	}
+
+	rows, err := tx.Query(`
+		SELECT id FROM transactions
+		WHERE type = ? AND ABS(amount - ?) <= 0.01 AND date >= ? AND date <= ?
+		  AND COALESCE(reconciliation_status, 'uncleared') != 'cleared'
+		  AND id NOT IN (SELECT transaction_id FROM statement_lines WHERE transaction_id IS NOT NULL)
+		  AND id NOT IN (SELECT transaction_id FROM statement_splits)
+		LIMIT 2
+	`, txType, line.Amount,
+		lineDate.AddDate(0, 0, -bankMatchWindowDays).Format("2006-01-02"), lineDate.AddDate(0, 0, bankMatchWindowDays).Format("2006-01-02"))
+	if err != nil {
+		return 0, err
+	}
+	defer rows.Close()
+
+	var ids []int64
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return 0, err
+		}
+		ids = append(ids, id)
+	}
+	if err := rows.Err(); err != nil {
+		return 0, err
+	}
+	if len(ids) != 1 {
+		return 0, nil
+	}
+	return ids[0], nil
+}
+
+// findMatchingMilkSale looks for a single unpaid milk sale paid by an incoming
+// line: same amount, sold shortly before the payment, and bought by someone
+// whose name appears in the payment details. Sales without a buyer name only
+// match when they are the only candidate.
+func findMatchingMilkSale(tx *sql.Tx, line StatementLine) (int64, error) {
+	paidOn, err := time.Parse("2006-01-02", line.Date)
+	if err != nil {
+		return 0, nil
+	}
+
+	rows, err := tx.Query(`
+		SELECT id, date, buyer_name, total_amount FROM milk_sales
+		WHERE is_paid = 0 AND date >= ? AND date <= ?
+		  AND id NOT IN (SELECT milk_sale_id FROM statement_lines WHERE milk_sale_id IS NOT NULL)
+		ORDER BY date DESC
+	`, paidOn.AddDate(0, 0, -mpesaMatchDaysAfter).Format("2006-01-02"), paidOn.AddDate(0, 0, mpesaMatchDaysBefore).Format("2006-01-02"))
+	if err != nil {
+		return 0, err
+	}
+	defer rows.Close()
+
+	var named, unnamed []int64
+	for rows.Next() {
+		var id int64
+		var date string
+		var buyerName sql.NullString
+		var total float64
+		if err := rows.Scan(&id, &date, &buyerName, &total); err != nil {
+			return 0, err
+		}
+		if math.Abs(total-line.Amount) > mpesaMatchTolerance {
+			continue
+		}
+		if buyerName.String == "" {
+			unnamed = append(unnamed, id)
+		} else if namesMatch(buyerName.String, line.Counterparty+" "+line.Description) {
+			named = append(named, id)
+		}
+	}
+
+	// Rows are newest first, so the closest sale wins when a buyer has several
+	if len(named) > 0 {
+		return named[0], nil
+	}
+	if len(unnamed) == 1 {
+		return unnamed[0], nil
+	}
+	return 0, nil
+}
+
+// namesMatch reports whether any word of a buyer's name appears in text
+func namesMatch(name, text string) bool {
+	text = strings.ToLower(text)
+	for _, word := range strings.Fields(strings.ToLower(name)) {
+		if len(word) >= 3 && strings.Contains(text, word) {
+			return true
+		}
+	}
+	return false
+}
+
+// parseMpesaStatement reads the transaction table of an M-Pesa statement CSV.
+// Statements start with account details before the header row, so the parser
+// looks for the row containing "Receipt No" and reads from there.
+func parseMpesaStatement(r io.Reader) ([]StatementLine, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.LazyQuotes = true
+	reader.TrimLeadingSpace = true
+
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read statement: %w", err)
+	}
+
+	col := map[string]int{}
+	start := -1
+	for i, record := range records {
+		for j, cell := range record {
+			name := strings.ToLower(strings.TrimSpace(cell))
+			name = strings.TrimSuffix(name, ".")
+			col[name] = j
+		}
+		if _, ok := col["receipt no"]; ok {
+			start = i + 1
+			break
+		}
+		col = map[string]int{}
+	}
+	if start < 0 {
+		return nil, fmt.Errorf("not an M-Pesa statement: no \"Receipt No.\" column found")
+	}
+	for _, required := range []string{"completion time", "details", "paid in", "withdrawn"} {
+		if _, ok := col[required]; !ok {
+			return nil, fmt.Errorf("M-Pesa statement is missing the %q column", required)
+		}
+	}
+
+	field := func(record []string, name string) string {
+		i, ok := col[name]
+		if !ok || i >= len(record) {
+			return ""
+		}
+		return strings.TrimSpace(record[i])
+	}
+
+	var lines []StatementLine
+	for _, record := range records[start:] {
+		receipt := field(record, "receipt no")
+		if receipt == "" {
+			continue
+		}
+		if status := field(record, "transaction status"); status != "" && !strings.EqualFold(status, "completed") {
+			continue
+		}
+
+		date, err := parseStatementDate(field(record, "completion time"))
+		if err != nil {
+			return nil, fmt.Errorf("receipt %s: %w", receipt, err)
+		}
+
+		paidIn := parseStatementAmount(field(record, "paid in"))
+		withdrawn := parseStatementAmount(field(record, "withdrawn"))
+		details := field(record, "details")
+
+		line := StatementLine{
+			Source:       "mpesa",
+			Reference:    receipt,
+			Date:         date,
+			Description:  details,
+			Counterparty: mpesaCounterparty(details),
+		}
+		switch {
+		case paidIn > 0:
+			line.Direction = "in"
+			line.Amount = paidIn
+		case withdrawn != 0:
+			line.Direction = "out"
+			line.Amount = math.Abs(withdrawn)
+		default:
+			continue
+		}
+		lines = append(lines, line)
+	}
+	return lines, nil
+}
+
+// mpesaCounterparty extracts the other party from M-Pesa details such as
+// "Customer Transfer from 2547XXXXXXXX - JOHN DOE" or "Pay Bill to 888880 - KPLC Acc. 123"
+func mpesaCounterparty(details string) string {
+	i := strings.LastIndex(details, " - ")
+	if i < 0 {
+		return ""
+	}
+	name := strings.TrimSpace(details[i+3:])
+	if j := strings.Index(name, " Acc."); j >= 0 {
+		name = strings.TrimSpace(name[:j])
+	}
+	return name
+}
+
+// statementDateFormats lists the date layouts seen in M-Pesa and bank exports
+var statementDateFormats = []string{
+	"2006-01-02 15:04:05",
+	"2006-01-02 15:04",
+	"2006-01-02",
+	"02/01/2006 15:04:05",
+	"02/01/2006 15:04",
+	"02/01/2006",
+	"2/1/2006",
+	"02-01-2006",
+	"02 Jan 2006",
+	"2 Jan 2006",
+}
+
+// parseStatementDate converts a statement date to YYYY-MM-DD
+func parseStatementDate(value string) (string, error) {
+	for _, layout := range statementDateFormats {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t.Format("2006-01-02"), nil
+		}
+	}
+	return "", fmt.Errorf("unrecognised date %q", value)
+}
+
+// parseStatementAmount parses amounts like "1,250.00" or "-300"; blanks are zero
+func parseStatementAmount(value string) float64 {
+	value = strings.ReplaceAll(strings.TrimSpace(value), ",", "")
+	value = strings.TrimPrefix(value, "KES")
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0
+	}
+	amount, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return 0
+	}
+	return amount
+}
+
+// BankCSVLayout describes the columns of a bank's CSV statement export.
+// Column values are header names, or 1-based column numbers when the file has no header.
+type BankCSVLayout struct {
+	Delimiter         string `json:"delimiter"` // ",", ";" or "tab"
+	SkipRows          int    `json:"skipRows"`  // lines to skip before the header or first data row
+	HasHeader         bool   `json:"hasHeader"`
+	DateColumn        string `json:"dateColumn"`
+	DescriptionColumn string `json:"descriptionColumn"`
+	ReferenceColumn   string `json:"referenceColumn"` // optional
+	AmountColumn      string `json:"amountColumn"`    // signed amount; leave empty to use debit/credit columns
+	DebitColumn       string `json:"debitColumn"`
+	CreditColumn      string `json:"creditColumn"`
+	DateFormat        string `json:"dateFormat"` // e.g. DD/MM/YYYY, YYYY-MM-DD, DD-MMM-YYYY
+}
+
+// MatchProposal is an existing transaction that may correspond to a statement line
+type MatchProposal struct {
+	Transaction Transaction `json:"transaction"`
+	DaysApart   int         `json:"daysApart"`
+}
+
+// StatementSplitPart is one part of a statement line split across transactions.
+// Set TransactionID to link an existing transaction, or leave it zero to create one.
+type StatementSplitPart struct {
+	TransactionID int64   `json:"transactionId"`
+	Category      string  `json:"category"`
+	Description   string  `json:"description"`
+	Amount        float64 `json:"amount"`
+}
+
+// ReconciliationSummary gives an overview of cleared and uncleared transactions
+type ReconciliationSummary struct {
+	ClearedCount      int     `json:"clearedCount"`
+	UnclearedCount    int     `json:"unclearedCount"`
+	UnclearedIncome   float64 `json:"unclearedIncome"`
+	UnclearedExpenses float64 `json:"unclearedExpenses"`
+	ReviewLines       int     `json:"reviewLines"`
+}
+
+const bankCSVLayoutSetting = "bank_csv_layout"
+
+// Bank lines are proposed against transactions of the same amount dated
+// within bankMatchWindowDays days of the statement date.
+const bankMatchWindowDays = 7
+
+// defaultBankCSVLayout matches the plain Date/Description/Amount exports most banks offer
+func defaultBankCSVLayout() BankCSVLayout {
+	return BankCSVLayout{
+		Delimiter:         ",",
+		HasHeader:         true,
+		DateColumn:        "Date",
+		DescriptionColumn: "Description",
+		ReferenceColumn:   "Reference",
+		AmountColumn:      "Amount",
+		DateFormat:        "DD/MM/YYYY",
+	}
+}
+
+// GetBankCSVLayout returns the saved bank CSV layout, or the default layout
+func (s *StatementService) GetBankCSVLayout() (*BankCSVLayout, error) {
+	layout := defaultBankCSVLayout()
+	value := getSetting(bankCSVLayoutSetting, "")
+	if value == "" {
+		return &layout, nil
+	}
+	if err := json.Unmarshal([]byte(value), &layout); err != nil {
+		return nil, fmt.Errorf("invalid saved bank CSV layout: %w", err)
+	}
+	return &layout, nil
+}
+
+// SaveBankCSVLayout stores the bank CSV layout used for future imports
+func (s *StatementService) SaveBankCSVLayout(layout BankCSVLayout) error {
+	if layout.DateColumn == "" || layout.DescriptionColumn == "" {
+		return fmt.Errorf("date and description columns are required")
+	}
+	if layout.AmountColumn == "" && layout.DebitColumn == "" && layout.CreditColumn == "" {
+		return fmt.Errorf("an amount column or debit/credit columns are required")
+	}
+	data, err := json.Marshal(layout)
+	if err != nil {
+		return err
+	}
+	return setSetting(bankCSVLayoutSetting, string(data))
+}
+
+// ImportBankStatement asks for a bank statement (OFX/QFX or CSV) and imports it
+func (s *StatementService) ImportBankStatement() (*StatementImportResult, error) {
+	if s.ctx == nil {
+		return nil, fmt.Errorf("context not set")
+	}
+
+	openPath, err := runtime.OpenFileDialog(s.ctx, runtime.OpenDialogOptions{
+		Title: "Select Bank Statement",
+		Filters: []runtime.FileFilter{
+			{DisplayName: "Bank Statements", Pattern: "*.ofx;*.qfx;*.csv"},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if openPath == "" {
+		return nil, nil
+	}
+
+	return s.ImportBankStatementFile(openPath)
+}
+
+// ImportBankStatementFile imports an OFX/QFX file, or a CSV file using the saved
+// bank CSV layout. New lines go to the review queue, where GetMatchProposals
+// suggests existing transactions to confirm against.
+func (s *StatementService) ImportBankStatementFile(path string) (*StatementImportResult, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var lines []StatementLine
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".ofx", ".qfx":
+		lines, err = parseOFXStatement(string(data))
+	default:
+		layout, layoutErr := s.GetBankCSVLayout()
+		if layoutErr != nil {
+			return nil, layoutErr
+		}
+		lines, err = parseBankCSV(bytes.NewReader(data), *layout)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	result := &StatementImportResult{Path: path, Lines: len(lines)}
+	for _, line := range lines {
+		id, err := insertStatementLine(tx, line)
+		if err != nil {
+			return nil, err
+		}
+		if id == 0 {
+			result.Duplicates++
+			continue
+		}
+		result.Review++
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// GetMatchProposals returns uncleared transactions that could correspond to a
+// statement line: same direction and amount, closest dates first
+func (s *StatementService) GetMatchProposals(lineID int64) ([]MatchProposal, error) {
+	line, err := getStatementLine(lineID)
+	if err != nil {
+		return nil, err
+	}
+	lineDate, err := time.Parse("2006-01-02", line.Date)
+	if err != nil {
+		return nil, err
+	}
+
+	txType := "income"
+	if line.Direction == "out" {
+		txType = "expense"
+	}
+
+	transactions, err := NewFinancialService().GetTransactions(
+		lineDate.AddDate(0, 0, -bankMatchWindowDays).Format("2006-01-02"),
+		lineDate.AddDate(0, 0, bankMatchWindowDays).Format("2006-01-02"),
+		txType, "")
+	if err != nil {
+		return nil, err
+	}
+
+	proposals := []MatchProposal{}
+	for _, t := range transactions {
+		if t.ReconciliationStatus == "cleared" || math.Abs(t.Amount-line.Amount) > 0.01 {
+			continue
+		}
+		var linked int
+		if err := db.QueryRow(`
+			SELECT (SELECT COUNT(*) FROM statement_lines WHERE transaction_id = ?) + (SELECT COUNT(*) FROM statement_splits WHERE transaction_id = ?)
+		`, t.ID, t.ID).Scan(&linked); err != nil {
+			return nil, err
+		}
+		if linked > 0 {
+			continue
+		}
+		txDate, err := time.Parse("2006-01-02", t.Date)
+		if err != nil {
+			continue
+		}
+		days := int(math.Abs(txDate.Sub(lineDate).Hours() / 24))
+		proposals = append(proposals, MatchProposal{Transaction: t, DaysApart: days})
+	}
+	sort.SliceStable(proposals, func(i, j int) bool { return proposals[i].DaysApart < proposals[j].DaysApart })
+	return proposals, nil
+}
+
+// ConfirmStatementMatch links a line from the review queue to an existing transaction and marks it cleared
+func (s *StatementService) ConfirmStatementMatch(lineID, transactionID int64) error {
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	line, err := reviewStatementLine(tx, lineID)
+	if err != nil {
+		return err
+	}
+	if err := confirmStatementMatch(tx, line, transactionID); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// confirmStatementMatch clears a transaction against a statement line. The
+// transaction must be of the line's direction and amount and not already
+// reconciled against another line.
+func confirmStatementMatch(tx *sql.Tx, line *StatementLine, transactionID int64) error {
+	if err := checkStatementTransaction(tx, line, transactionID, line.Amount); err != nil {
+		return err
+	}
+	if _, err := tx.Exec(`UPDATE transactions SET reconciliation_status = 'cleared', cleared_date = ? WHERE id = ?`, line.Date, transactionID); err != nil {
+		return err
+	}
+	_, err := tx.Exec(`UPDATE statement_lines SET status = 'matched', transaction_id = ? WHERE id = ?`, transactionID, line.ID)
+	return err
+}
+
+// checkStatementTransaction verifies that a transaction can be reconciled
+// against a statement line for the given amount
+func checkStatementTransaction(tx *sql.Tx, line *StatementLine, transactionID int64, amount float64) error {
+	var txType string
+	var txAmount float64
+	err := tx.QueryRow(`SELECT type, amount FROM transactions WHERE id = ?`, transactionID).Scan(&txType, &txAmount)
+	if err == sql.ErrNoRows {
+		return fmt.Errorf("transaction %d not found", transactionID)
+	}
+	if err != nil {
+		return err
+	}
+
+	wantType := "income"
+	if line.Direction == "out" {
+		wantType = "expense"
+	}
+	if txType != wantType {
+		return fmt.Errorf("transaction %d is %s but the statement line is money %s", transactionID, txType, line.Direction)
+	}
+	if math.Abs(txAmount-amount) > 0.01 {
+		return fmt.Errorf("transaction %d is for %.2f, not %.2f", transactionID, txAmount, amount)
+	}
+
+	var linked int
+	err = tx.QueryRow(`
+		SELECT (SELECT COUNT(*) FROM statement_lines WHERE transaction_id = ? AND id != ?)
+		     + (SELECT COUNT(*) FROM statement_splits WHERE transaction_id = ?)
+	`, transactionID, line.ID, transactionID).Scan(&linked)
+	if err != nil {
+		return err
+	}
+	if linked > 0 {
+		return fmt.Errorf("transaction %d is already reconciled against another statement line", transactionID)
+	}
+	return nil
+}
+
+// checkTransactionRemovable refuses to let a transaction be deleted or have its
+// amount or date rewritten once it has been cleared or reconciled against a
+// statement, since the reconciled statement line would no longer match it
+func checkTransactionRemovable(tx *sql.Tx, transactionID int64) error {
+	var status sql.NullString
+	var linked int
+	err := tx.QueryRow(`
+		SELECT reconciliation_status,
+		       (SELECT COUNT(*) FROM statement_lines WHERE transaction_id = t.id)
+		     + (SELECT COUNT(*) FROM statement_splits WHERE transaction_id = t.id)
+		FROM transactions t WHERE t.id = ?
+	`, transactionID).Scan(&status, &linked)
+	if err == sql.ErrNoRows {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if linked > 0 {
+		return fmt.Errorf("transaction %d is reconciled against a bank statement; unlink it before changing or removing it", transactionID)
+	}
+	if status.String == "cleared" {
+		return fmt.Errorf("transaction %d has cleared the bank; mark it uncleared before changing or removing it", transactionID)
+	}
+	return nil
+}
+
+// SplitStatementLine reconciles a line from the review queue against several
+// transactions, linking existing ones and creating the rest. The parts must add
+// up to the line amount, and a linked transaction must be for its part's amount.
+func (s *StatementService) SplitStatementLine(lineID int64, parts []StatementSplitPart) error {
+	if len(parts) < 2 {
+		return fmt.Errorf("a split needs at least two parts")
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	line, err := reviewStatementLine(tx, lineID)
+	if err != nil {
+		return err
+	}
+
+	total := 0.0
+	linked := map[int64]bool{}
+	for _, p := range parts {
+		if p.Amount <= 0 {
+			return fmt.Errorf("split amounts must be positive")
+		}
+		if p.TransactionID > 0 {
+			if linked[p.TransactionID] {
+				return fmt.Errorf("transaction %d is used in more than one part", p.TransactionID)
+			}
+			linked[p.TransactionID] = true
+		}
+		total += p.Amount
+	}
+	if math.Abs(total-line.Amount) > 0.01 {
+		return fmt.Errorf("split parts add up to %.2f but the statement line is %.2f", total, line.Amount)
+	}
+
+	txType := "income"
+	if line.Direction == "out" {
+		txType = "expense"
+	}
+
+	for _, p := range parts {
+		transactionID := p.TransactionID
+		if transactionID > 0 {
+			if err := checkStatementTransaction(tx, line, transactionID, p.Amount); err != nil {
+				return err
+			}
+			if _, err := tx.Exec(`UPDATE transactions SET reconciliation_status = 'cleared', cleared_date = ? WHERE id = ?`, line.Date, transactionID); err != nil {
+				return err
+			}
+		} else {
+			category := p.Category
+			if category == "" {
+				category = "other_" + txType
+			}
+			description := firstNonEmpty(p.Description, line.Counterparty, line.Description)
+			result, err := tx.Exec(`
+				INSERT INTO transactions (date, type, category, description, amount, payment_method, related_entity, notes, reconciliation_status, cleared_date)
+				VALUES (?, ?, ?, ?, ?, ?, ?, ?, 'cleared', ?)
+			`, line.Date, txType, category, description, p.Amount, line.Source,
+				fmt.Sprintf("%s:%s", line.Source, line.Reference), line.Description, line.Date)
+			if err != nil {
+				return err
+			}
+			transactionID, err = result.LastInsertId()
+			if err != nil {
+				return fmt.Errorf("failed to get last insert id: %w", err)
+			}
+		}
+		if _, err := tx.Exec(`INSERT INTO statement_splits (line_id, transaction_id, amount) VALUES (?, ?, ?)`, lineID, transactionID, p.Amount); err != nil {
+			return err
+		}
+	}
+
+	if _, err := tx.Exec(`UPDATE statement_lines SET status = 'split' WHERE id = ?`, lineID); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// GetReconciliationSummary returns counts and totals of cleared and uncleared transactions
+func (s *StatementService) GetReconciliationSummary() (*ReconciliationSummary, error) {
+	summary := &ReconciliationSummary{}
+	err := db.QueryRow(`
+		SELECT
+			COALESCE(SUM(CASE WHEN reconciliation_status = 'cleared' THEN 1 ELSE 0 END), 0),
+			COALESCE(SUM(CASE WHEN COALESCE(reconciliation_status, 'uncleared') != 'cleared' THEN 1 ELSE 0 END), 0),
+			COALESCE(SUM(CASE WHEN COALESCE(reconciliation_status, 'uncleared') != 'cleared' AND type = 'income' THEN amount ELSE 0 END), 0),
+			COALESCE(SUM(CASE WHEN COALESCE(reconciliation_status, 'uncleared') != 'cleared' AND type = 'expense' THEN amount ELSE 0 END), 0)
+		FROM transactions
+	`).Scan(&summary.ClearedCount, &summary.UnclearedCount, &summary.UnclearedIncome, &summary.UnclearedExpenses)
+	if err != nil {
+		return nil, err
+	}
+	if err := db.QueryRow(`SELECT COUNT(*) FROM statement_lines WHERE status = 'review'`).Scan(&summary.ReviewLines); err != nil {
+		return nil, err
+	}
+	return summary, nil
+}
+
+// parseOFXStatement reads STMTTRN entries from an OFX/QFX file. Both the SGML
+// (OFX 1.x, unclosed leaf tags) and XML (OFX 2.x) variants are accepted.
+func parseOFXStatement(data string) ([]StatementLine, error) {
+	blocks := ofxTransactionPattern.FindAllStringSubmatch(data, -1)
+	if len(blocks) == 0 {
+		return nil, fmt.Errorf("no transactions found in OFX file")
+	}
+
+	seen := map[string]int{}
+	var lines []StatementLine
+	for _, block := range blocks {
+		body := block[1]
+		posted := ofxField(body, "DTPOSTED")
+		if len(posted) < 8 {
+			return nil, fmt.Errorf("OFX transaction without a valid DTPOSTED")
+		}
+		date, err := time.Parse("20060102", posted[:8])
+		if err != nil {
+			return nil, fmt.Errorf("invalid OFX date %q", posted)
+		}
+		amount := parseStatementAmount(ofxField(body, "TRNAMT"))
+		if amount == 0 {
+			continue
+		}
+
+		name := ofxField(body, "NAME")
+		description := strings.TrimSpace(name + " " + ofxField(body, "MEMO"))
+		line := StatementLine{
+			Source:       "bank",
+			Reference:    ofxField(body, "FITID"),
+			Date:         date.Format("2006-01-02"),
+			Description:  description,
+			Counterparty: name,
+			Amount:       math.Abs(amount),
+			Direction:    "in",
+		}
+		if amount < 0 {
+			line.Direction = "out"
+		}
+		if line.Reference == "" {
+			line.Reference = syntheticReference(line, seen)
+		}
+		lines = append(lines, line)
+	}
+	return lines, nil
+}
+
+var ofxTransactionPattern = regexp.MustCompile(`(?is)<STMTTRN>(.*?)</STMTTRN>`)
+
+// ofxField returns the value of a leaf element within an OFX block
+func ofxField(block, tag string) string {
+	re := regexp.MustCompile(`(?i)<` + tag + `>([^<\r\n]*)`)
+	m := re.FindStringSubmatch(block)
+	if m == nil {
+		return ""
+	}
+	return strings.TrimSpace(html.UnescapeString(m[1]))
+}
+
+// parseBankCSV reads a bank CSV export using the given column layout
+func parseBankCSV(r io.Reader, layout BankCSVLayout) ([]StatementLine, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.LazyQuotes = true
+	reader.TrimLeadingSpace = true
+	switch layout.Delimiter {
+	case "", ",":
+	case "tab", "\t":
+		reader.Comma = '\t'
+	default:
+		reader.Comma = []rune(layout.Delimiter)[0]
+	}
+
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read statement: %w", err)
+	}
+	if layout.SkipRows > 0 {
+		if layout.SkipRows >= len(records) {
+			return nil, nil
+		}
+		records = records[layout.SkipRows:]
+	}
+
+	var header []string
+	if layout.HasHeader && len(records) > 0 {
+		header = records[0]
+		records = records[1:]
+	}
+
+	column := func(name string) (int, error) {
+		if name == "" {
+			return -1, nil
+		}
+		if n, err := strconv.Atoi(name); err == nil && n > 0 {
+			return n - 1, nil
+		}
+		for i, h := range header {
+			if strings.EqualFold(strings.TrimSpace(h), strings.TrimSpace(name)) {
+				return i, nil
+			}
+		}
+		return -1, fmt.Errorf("column %q not found in bank statement", name)
+	}
+
+	dateCol, err := column(layout.DateColumn)
+	if err != nil {
+		return nil, err
+	}
+	descCol, err := column(layout.DescriptionColumn)
+	if err != nil {
+		return nil, err
+	}
+	amountCol, err := column(layout.AmountColumn)
+	if err != nil {
+		return nil, err
+	}
+	debitCol, err := column(layout.DebitColumn)
+	if err != nil {
+		return nil, err
+	}
+	creditCol, err := column(layout.CreditColumn)
+	if err != nil {
+		return nil, err
+	}
+	// The reference column is optional, so a missing one is not an error
+	refCol, _ := column(layout.ReferenceColumn)
+
+	dateLayout := goDateLayout(layout.DateFormat)
+	cell := func(record []string, i int) string {
+		if i < 0 || i >= len(record) {
+			return ""
+		}
+		return strings.TrimSpace(record[i])
+	}
+
+	seen := map[string]int{}
+	var lines []StatementLine
+	for n, record := range records {
+		rawDate := cell(record, dateCol)
+		if rawDate == "" {
+			continue
+		}
+		date, err := time.Parse(dateLayout, rawDate)
+		if err != nil {
+			formatted, fallbackErr := parseStatementDate(rawDate)
+			if fallbackErr != nil {
+				return nil, fmt.Errorf("row %d: unrecognised date %q", n+1, rawDate)
+			}
+			date, _ = time.Parse("2006-01-02", formatted)
+		}
+
+		amount := parseStatementAmount(cell(record, amountCol))
+		if amountCol < 0 {
+			amount = parseStatementAmount(cell(record, creditCol)) - math.Abs(parseStatementAmount(cell(record, debitCol)))
+		}
+		if amount == 0 {
+			continue
+		}
+
+		description := cell(record, descCol)
+		line := StatementLine{
+			Source:       "bank",
+			Reference:    cell(record, refCol),
+			Date:         date.Format("2006-01-02"),
+			Description:  description,
+			Counterparty: description,
+			Amount:       math.Abs(amount),
+			Direction:    "in",
+		}
+		if amount < 0 {
+			line.Direction = "out"
+		}
+		if line.Reference == "" {
+			line.Reference = syntheticReference(line, seen)
+		}
+		lines = append(lines, line)
+	}
+	return lines, nil
+}
+
+// syntheticReference builds a stable reference for statements without one, so
+// that importing the same file twice does not duplicate lines. Identical lines
+// within one file are told apart by their occurrence count.
+func syntheticReference(line StatementLine, seen map[string]int) string {
+	key := fmt.Sprintf("%s|%s|%.2f|%s", line.Date, line.Direction, line.Amount, line.Description)
+	seen[key]++
+	sum := sha1.Sum([]byte(fmt.Sprintf("%s|%d", key, seen[key])))
+	return "auto-" + hex.EncodeToString(sum[:6])
+}
+
+// goDateLayout converts a user-friendly date format such as DD/MM/YYYY to a Go time layout
+func goDateLayout(format string) string {
+	if format == "" {
+		return "02/01/2006"
+	}
+	replacer := strings.NewReplacer("YYYY", "2006", "MMM", "Jan", "MM", "01", "DD", "02", "YY", "06")
+	return replacer.Replace(strings.ToUpper(format))
+}
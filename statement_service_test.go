@@ -0,0 +1,463 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// setupTestDatabase points the app at a fresh database in a temporary home directory
+func setupTestDatabase(t *testing.T) {
+	t.Helper()
+	t.Setenv("HOME", t.TempDir())
+	if err := InitDatabase(); err != nil {
+		t.Fatalf("InitDatabase: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+}
+
+const mpesaHeader = "Receipt No.,Completion Time,Details,Transaction Status,Paid In,Withdrawn,Balance\n"
+
+func TestParseMpesaStatement(t *testing.T) {
+	tests := []struct {
+		name    string
+		csv     string
+		want    []StatementLine
+		wantErr string
+	}{
+		{
+			name: "incoming and outgoing after account details",
+			csv: "Customer Name:,JANE FARMER\nStatement Period:,01 Jan 2024 - 31 Jan 2024\n\n" + mpesaHeader +
+				`QAB1,2024-01-05 08:15:00,Customer Transfer from 254711000000 - JOHN DOE,Completed,"1,250.00",,5000` + "\n" +
+				`QAB2,2024-01-06 17:40:12,Pay Bill to 888880 - KPLC Acc. 1234,Completed,,-300.00,4700` + "\n",
+			want: []StatementLine{
+				{Source: "mpesa", Reference: "QAB1", Date: "2024-01-05", Counterparty: "JOHN DOE", Amount: 1250, Direction: "in"},
+				{Source: "mpesa", Reference: "QAB2", Date: "2024-01-06", Counterparty: "KPLC", Amount: 300, Direction: "out"},
+			},
+		},
+		{
+			name: "failed and blank lines are skipped",
+			csv: mpesaHeader +
+				`QAB3,2024-01-07 09:00:00,Customer Transfer from 254722000000 - MARY,Failed,500.00,,` + "\n" +
+				`,,,,,,` + "\n" +
+				`QAB4,07/01/2024 10:00,Customer Transfer from 254722000000 - MARY,Completed,500.00,,` + "\n" +
+				`QAB5,2024-01-07 11:00:00,Balance enquiry,Completed,,,` + "\n",
+			want: []StatementLine{
+				{Source: "mpesa", Reference: "QAB4", Date: "2024-01-07", Counterparty: "MARY", Amount: 500, Direction: "in"},
+			},
+		},
+		{
+			name:    "no receipt column",
+			csv:     "Date,Description,Amount\n2024-01-05,Milk,100\n",
+			wantErr: "no \"Receipt No.\" column",
+		},
+		{
+			name:    "missing required column",
+			csv:     "Receipt No.,Completion Time,Details,Paid In\nQAB1,2024-01-05,x,100\n",
+			wantErr: `missing the "withdrawn" column`,
+		},
+		{
+			name:    "bad date",
+			csv:     mpesaHeader + "QAB6,yesterday,x,Completed,100,,\n",
+			wantErr: "receipt QAB6",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lines, err := parseMpesaStatement(strings.NewReader(tt.csv))
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(lines) != len(tt.want) {
+				t.Fatalf("got %d lines, want %d", len(lines), len(tt.want))
+			}
+			for i, want := range tt.want {
+				got := lines[i]
+				got.Description = ""
+				if got != want {
+					t.Errorf("line %d = %+v, want %+v", i, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestMpesaCounterparty(t *testing.T) {
+	tests := []struct {
+		details, want string
+	}{
+		{"Customer Transfer from 2547XXXXXXXX - JOHN DOE", "JOHN DOE"},
+		{"Pay Bill to 888880 - KPLC Acc. 123", "KPLC"},
+		{"Merchant Payment to 12345 - AGROVET - KITALE", "KITALE"},
+		{"Airtime Purchase", ""},
+	}
+	for _, tt := range tests {
+		if got := mpesaCounterparty(tt.details); got != tt.want {
+			t.Errorf("mpesaCounterparty(%q) = %q, want %q", tt.details, got, tt.want)
+		}
+	}
+}
+
+func TestParseStatementAmountAndDate(t *testing.T) {
+	amounts := []struct {
+		value string
+		want  float64
+	}{
+		{"1,250.00", 1250},
+		{"-300", -300},
+		{" KES 75.50 ", 75.5},
+		{"", 0},
+		{"n/a", 0},
+	}
+	for _, tt := range amounts {
+		if got := parseStatementAmount(tt.value); got != tt.want {
+			t.Errorf("parseStatementAmount(%q) = %v, want %v", tt.value, got, tt.want)
+		}
+	}
+
+	dates := []struct {
+		value, want string
+		wantErr     bool
+	}{
+		{"2024-03-09 14:05:59", "2024-03-09", false},
+		{"09/03/2024", "2024-03-09", false},
+		{"9/3/2024", "2024-03-09", false},
+		{"09 Mar 2024", "2024-03-09", false},
+		{"March 9", "", true},
+	}
+	for _, tt := range dates {
+		got, err := parseStatementDate(tt.value)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("parseStatementDate(%q) = %q, %v; want %q", tt.value, got, err, tt.want)
+		}
+	}
+}
+
+func TestImportMpesaStatementMatching(t *testing.T) {
+	setupTestDatabase(t)
+	livestock := NewLivestockService()
+	financial := NewFinancialService()
+	statements := NewStatementService()
+
+	buyerID, err := NewBuyerService().AddBuyer(Buyer{Name: "John Doe", Active: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	saleID, err := livestock.AddMilkSale(MilkSale{Date: "2024-01-04", BuyerID: &buyerID, Liters: 25, PricePerLiter: 50})
+	if err != nil {
+		t.Fatal(err)
+	}
+	otherSaleID, err := livestock.AddMilkSale(MilkSale{Date: "2024-01-04", BuyerName: "Walk-in", Liters: 10, PricePerLiter: 50})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := financial.AddTransaction(Transaction{Date: "2024-01-06", Type: "expense", Category: "utilities", Description: "Electricity", Amount: 300}); err != nil {
+		t.Fatal(err)
+	}
+
+	path := filepath.Join(t.TempDir(), "statement.csv")
+	csv := mpesaHeader +
+		"QAB1,2024-01-05 08:15:00,Customer Transfer from 254711000000 - JOHN DOE,Completed,1250.00,,\n" +
+		"QAB2,2024-01-06 17:40:12,Pay Bill to 888880 - KPLC Acc. 1234,Completed,,-300.00,\n" +
+		"QAB3,2024-01-06 18:00:00,Pay Bill to 400200 - AGROVET,Completed,,-900.00,\n" +
+		"QAB4,2024-01-07 09:00:00,Customer Transfer from 254722000000 - SOMEONE,Completed,480.00,,\n" +
+		"QAB5,2024-01-08 09:00:00,Customer Transfer from 254733000000 - STRANGER,Completed,1250.00,,\n"
+	if err := os.WriteFile(path, []byte(csv), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := statements.ImportMpesaStatementFile(path)
+	if err != nil {
+		t.Fatalf("import: %v", err)
+	}
+	if result.Lines != 5 || result.Matched != 2 || result.Review != 3 || result.Duplicates != 0 {
+		t.Fatalf("import result = %+v, want 5 lines, 2 matched, 3 to review", result)
+	}
+
+	// Unmatched expenses are left for review rather than posted
+	var expenses int
+	if err := db.QueryRow(`SELECT COUNT(*) FROM transactions WHERE type = 'expense'`).Scan(&expenses); err != nil {
+		t.Fatal(err)
+	}
+	if expenses != 1 {
+		t.Errorf("got %d expense transactions, want only the existing one", expenses)
+	}
+
+	again, err := statements.ImportMpesaStatementFile(path)
+	if err != nil {
+		t.Fatalf("second import: %v", err)
+	}
+	if again.Duplicates != 5 || again.Matched != 0 || again.Review != 0 {
+		t.Errorf("second import = %+v, want every line a duplicate", again)
+	}
+
+	lineIDs := map[string]int64{}
+	for _, ref := range []string{"QAB1", "QAB4", "QAB5"} {
+		var id int64
+		if err := db.QueryRow(`SELECT id FROM statement_lines WHERE reference = ?`, ref).Scan(&id); err != nil {
+			t.Fatal(err)
+		}
+		lineIDs[ref] = id
+	}
+
+	tests := []struct {
+		name    string
+		lineID  int64
+		saleID  int64
+		wantErr string
+	}{
+		{"line already matched", lineIDs["QAB1"], saleID, "already matched"},
+		{"amount does not agree", lineIDs["QAB4"], otherSaleID, "does not match"},
+		{"sale matched to another line", lineIDs["QAB5"], saleID, "already matched to another statement line"},
+	}
+	for _, tt := range tests {
+		err := statements.MatchStatementLineToSale(tt.lineID, tt.saleID)
+		if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+			t.Errorf("%s: error = %v, want %q", tt.name, err, tt.wantErr)
+		}
+	}
+
+	var payments int
+	if err := db.QueryRow(`SELECT COUNT(*) FROM buyer_payments WHERE buyer_id = ?`, buyerID).Scan(&payments); err != nil {
+		t.Fatal(err)
+	}
+	if payments != 1 {
+		t.Errorf("buyer has %d payments, want 1", payments)
+	}
+}
+
+func TestParseBankCSV(t *testing.T) {
+	tests := []struct {
+		name    string
+		layout  BankCSVLayout
+		csv     string
+		want    []StatementLine
+		wantErr string
+	}{
+		{
+			name:   "signed amount with header",
+			layout: defaultBankCSVLayout(),
+			csv: "Date,Description,Reference,Amount\n" +
+				"05/01/2024,Milk cheque KCC,CHQ001,\"12,500.00\"\n" +
+				"06/01/2024,Agrovet supplies,POS77,-2300\n" +
+				"07/01/2024,Zero line,,0\n",
+			want: []StatementLine{
+				{Source: "bank", Reference: "CHQ001", Date: "2024-01-05", Description: "Milk cheque KCC", Counterparty: "Milk cheque KCC", Amount: 12500, Direction: "in"},
+				{Source: "bank", Reference: "POS77", Date: "2024-01-06", Description: "Agrovet supplies", Counterparty: "Agrovet supplies", Amount: 2300, Direction: "out"},
+			},
+		},
+		{
+			name: "debit and credit columns by number, semicolons, skipped rows",
+			layout: BankCSVLayout{Delimiter: ";", SkipRows: 2, DateColumn: "1", DescriptionColumn: "2",
+				DebitColumn: "3", CreditColumn: "4", DateFormat: "YYYY-MM-DD"},
+			csv: "Account;12345\nPeriod;Jan\n" +
+				"2024-01-05;Salary;;800\n" +
+				"2024-01-06;Diesel;150;\n",
+			want: []StatementLine{
+				{Source: "bank", Date: "2024-01-05", Description: "Salary", Counterparty: "Salary", Amount: 800, Direction: "in"},
+				{Source: "bank", Date: "2024-01-06", Description: "Diesel", Counterparty: "Diesel", Amount: 150, Direction: "out"},
+			},
+		},
+		{
+			name:    "missing column",
+			layout:  defaultBankCSVLayout(),
+			csv:     "When,What,Amount\n05/01/2024,x,1\n",
+			wantErr: `column "Date" not found`,
+		},
+		{
+			name:    "bad date",
+			layout:  defaultBankCSVLayout(),
+			csv:     "Date,Description,Amount\nsoon,x,1\n",
+			wantErr: "row 1",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lines, err := parseBankCSV(strings.NewReader(tt.csv), tt.layout)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(lines) != len(tt.want) {
+				t.Fatalf("got %d lines, want %d", len(lines), len(tt.want))
+			}
+			for i, want := range tt.want {
+				got := lines[i]
+				if want.Reference == "" {
+					if !strings.HasPrefix(got.Reference, "auto-") {
+						t.Errorf("line %d reference = %q, want a synthetic one", i, got.Reference)
+					}
+					got.Reference = ""
+				}
+				if got != want {
+					t.Errorf("line %d = %+v, want %+v", i, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestParseOFXStatement(t *testing.T) {
+	sgml := `OFXHEADER:100
+<OFX><BANKMSGSRSV1><STMTTRNRS><STMTRS><BANKTRANLIST>
+<STMTTRN><TRNTYPE>CREDIT<DTPOSTED>20240105120000<TRNAMT>4500.00<FITID>A1<NAME>KCC Payout<MEMO>Jan milk</STMTTRN>
+<STMTTRN><TRNTYPE>DEBIT<DTPOSTED>20240106<TRNAMT>-1200.50<NAME>Feeds &amp; More</STMTTRN>
+<STMTTRN><TRNTYPE>DEBIT<DTPOSTED>20240106<TRNAMT>-1200.50<NAME>Feeds &amp; More</STMTTRN>
+</BANKTRANLIST></STMTRS></STMTTRNRS></BANKMSGSRSV1></OFX>`
+
+	lines, err := parseOFXStatement(sgml)
+	if err != nil {
+		t.Fatalf("parseOFXStatement: %v", err)
+	}
+	if len(lines) != 3 {
+		t.Fatalf("got %d lines, want 3", len(lines))
+	}
+	first := lines[0]
+	if first.Reference != "A1" || first.Date != "2024-01-05" || first.Amount != 4500 || first.Direction != "in" ||
+		first.Counterparty != "KCC Payout" || first.Description != "KCC Payout Jan milk" {
+		t.Errorf("first line = %+v", first)
+	}
+	if lines[1].Direction != "out" || lines[1].Amount != 1200.5 || lines[1].Counterparty != "Feeds & More" {
+		t.Errorf("second line = %+v", lines[1])
+	}
+	// Identical lines without a FITID get distinct, stable references
+	if lines[1].Reference == lines[2].Reference || !strings.HasPrefix(lines[1].Reference, "auto-") {
+		t.Errorf("synthetic references = %q, %q", lines[1].Reference, lines[2].Reference)
+	}
+	again, _ := parseOFXStatement(sgml)
+	if again[1].Reference != lines[1].Reference || again[2].Reference != lines[2].Reference {
+		t.Errorf("synthetic references are not stable between imports")
+	}
+
+	if _, err := parseOFXStatement("<OFX></OFX>"); err == nil {
+		t.Errorf("expected an error for a file without transactions")
+	}
+}
+
+func TestGoDateLayout(t *testing.T) {
+	tests := []struct {
+		format, want string
+	}{
+		{"", "02/01/2006"},
+		{"DD/MM/YYYY", "02/01/2006"},
+		{"yyyy-mm-dd", "2006-01-02"},
+		{"DD-MMM-YYYY", "02-Jan-2006"},
+		{"DD.MM.YY", "02.01.06"},
+	}
+	for _, tt := range tests {
+		if got := goDateLayout(tt.format); got != tt.want {
+			t.Errorf("goDateLayout(%q) = %q, want %q", tt.format, got, tt.want)
+		}
+	}
+}
+
+func TestStatementReconciliationGuards(t *testing.T) {
+	setupTestDatabase(t)
+	financial := NewFinancialService()
+	statements := NewStatementService()
+
+	addTransaction := func(txType string, amount float64) int64 {
+		id, err := financial.AddTransaction(Transaction{Date: "2024-01-05", Type: txType, Category: "other_" + txType, Amount: amount})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return id
+	}
+	feed := addTransaction("expense", 1000)
+	fuel := addTransaction("expense", 500)
+	income := addTransaction("income", 1000)
+	other := addTransaction("expense", 700)
+
+	path := filepath.Join(t.TempDir(), "bank.csv")
+	csv := "Date,Description,Reference,Amount\n" +
+		"05/01/2024,Agrovet,R1,-1000\n" +
+		"05/01/2024,Agrovet again,R2,-1000\n" +
+		"06/01/2024,Shop,R3,-1500\n"
+	if err := os.WriteFile(path, []byte(csv), 0644); err != nil {
+		t.Fatal(err)
+	}
+	result, err := statements.ImportBankStatementFile(path)
+	if err != nil {
+		t.Fatalf("import: %v", err)
+	}
+	if result.Review != 3 {
+		t.Fatalf("import result = %+v, want 3 lines to review", result)
+	}
+	line := map[string]int64{}
+	for _, ref := range []string{"R1", "R2", "R3"} {
+		var id int64
+		if err := db.QueryRow(`SELECT id FROM statement_lines WHERE reference = ?`, ref).Scan(&id); err != nil {
+			t.Fatal(err)
+		}
+		line[ref] = id
+	}
+
+	confirms := []struct {
+		name          string
+		lineID, txnID int64
+		wantErr       string
+	}{
+		{"wrong direction", line["R1"], income, "is income"},
+		{"wrong amount", line["R1"], fuel, "not 1000.00"},
+		{"ok", line["R1"], feed, ""},
+		{"line no longer in review", line["R1"], feed, "already matched"},
+		{"transaction cleared against another line", line["R2"], feed, "another statement line"},
+	}
+	for _, tt := range confirms {
+		err := statements.ConfirmStatementMatch(tt.lineID, tt.txnID)
+		if tt.wantErr == "" {
+			if err != nil {
+				t.Errorf("%s: unexpected error %v", tt.name, err)
+			}
+		} else if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+			t.Errorf("%s: error = %v, want %q", tt.name, err, tt.wantErr)
+		}
+	}
+
+	splits := []struct {
+		name    string
+		lineID  int64
+		parts   []StatementSplitPart
+		wantErr string
+	}{
+		{"parts do not add up", line["R3"], []StatementSplitPart{{Amount: 500}, {Amount: 500}}, "add up to 1000.00"},
+		{"linked amount differs", line["R3"], []StatementSplitPart{{TransactionID: fuel, Amount: 800}, {Amount: 700}}, "not 800.00"},
+		{"transaction already reconciled", line["R3"], []StatementSplitPart{{TransactionID: feed, Amount: 1000}, {Amount: 500}}, "another statement line"},
+		{"same transaction twice", line["R3"], []StatementSplitPart{{TransactionID: fuel, Amount: 500}, {TransactionID: fuel, Amount: 500}, {Amount: 500}}, "more than one part"},
+		{"ok", line["R3"], []StatementSplitPart{{TransactionID: fuel, Amount: 500}, {TransactionID: other, Amount: 700}, {Category: "transport", Amount: 300}}, ""},
+		{"already split", line["R3"], []StatementSplitPart{{Amount: 1000}, {Amount: 500}}, "already split"},
+		{"already matched", line["R1"], []StatementSplitPart{{Amount: 500}, {Amount: 500}}, "already matched"},
+	}
+	for _, tt := range splits {
+		err := statements.SplitStatementLine(tt.lineID, tt.parts)
+		if tt.wantErr == "" {
+			if err != nil {
+				t.Errorf("%s: unexpected error %v", tt.name, err)
+			}
+		} else if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+			t.Errorf("%s: error = %v, want %q", tt.name, err, tt.wantErr)
+		}
+	}
+
+	var splitRows int
+	if err := db.QueryRow(`SELECT COUNT(*) FROM statement_splits WHERE line_id = ?`, line["R3"]).Scan(&splitRows); err != nil {
+		t.Fatal(err)
+	}
+	if splitRows != 3 {
+		t.Errorf("got %d split rows, want 3", splitRows)
+	}
+}
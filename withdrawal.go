@@ -0,0 +1,235 @@
+package main
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Drug withdrawal periods: after a treatment, milk and meat from the animal
+// must not be sold until the medicine has cleared
+const medicineWithdrawalsSetting = "medicine_withdrawals"
+
+// defaultMedicineWithdrawals are typical label periods for the common
+// medicines offered in the vet record form. Always check the product label.
+var defaultMedicineWithdrawals = []MedicineWithdrawal{
+	{Medicine: "Oxytetracycline", MilkDays: 7, MeatDays: 28},
+	{Medicine: "Penicillin", MilkDays: 4, MeatDays: 14},
+	{Medicine: "Ivermectin", MilkDays: 28, MeatDays: 35},
+	{Medicine: "Albendazole (Dewormer)", MilkDays: 3, MeatDays: 14},
+}
+
+// GetMedicineWithdrawals returns the saved withdrawal periods per medicine, or the defaults
+func (s *HealthService) GetMedicineWithdrawals() ([]MedicineWithdrawal, error) {
+	return medicineWithdrawals()
+}
+
+// SaveMedicineWithdrawals replaces the withdrawal periods per medicine
+func (s *HealthService) SaveMedicineWithdrawals(withdrawals []MedicineWithdrawal) ([]MedicineWithdrawal, error) {
+	seen := map[string]bool{}
+	for i := range withdrawals {
+		w := &withdrawals[i]
+		w.Medicine = strings.TrimSpace(w.Medicine)
+		if w.Medicine == "" {
+			return nil, fmt.Errorf("each withdrawal period needs a medicine")
+		}
+		if w.MilkDays < 0 || w.MeatDays < 0 {
+			return nil, fmt.Errorf("withdrawal days for %s cannot be negative", w.Medicine)
+		}
+		key := strings.ToLower(w.Medicine)
+		if seen[key] {
+			return nil, fmt.Errorf("%s is listed more than once", w.Medicine)
+		}
+		seen[key] = true
+	}
+	sort.Slice(withdrawals, func(a, b int) bool {
+		return strings.ToLower(withdrawals[a].Medicine) < strings.ToLower(withdrawals[b].Medicine)
+	})
+
+	data, err := json.Marshal(withdrawals)
+	if err != nil {
+		return nil, err
+	}
+	if err := setSetting(medicineWithdrawalsSetting, string(data)); err != nil {
+		return nil, err
+	}
+	return withdrawals, nil
+}
+
+func medicineWithdrawals() ([]MedicineWithdrawal, error) {
+	value := getSetting(medicineWithdrawalsSetting, "")
+	if value == "" {
+		return defaultMedicineWithdrawals, nil
+	}
+	var withdrawals []MedicineWithdrawal
+	if err := json.Unmarshal([]byte(value), &withdrawals); err != nil {
+		return nil, fmt.Errorf("invalid saved medicine withdrawals: %w", err)
+	}
+	return withdrawals, nil
+}
+
+// medicineWithdrawalFor returns the saved periods of a medicine, or nil
+func medicineWithdrawalFor(medicine string) (*MedicineWithdrawal, error) {
+	withdrawals, err := medicineWithdrawals()
+	if err != nil {
+		return nil, err
+	}
+	for i := range withdrawals {
+		if strings.EqualFold(withdrawals[i].Medicine, strings.TrimSpace(medicine)) {
+			return &withdrawals[i], nil
+		}
+	}
+	return nil, nil
+}
+
+// withheldUntil returns the last days milk and meat from a treated animal
+// must be withheld, or empty strings when the record has no withdrawal
+func withheldUntil(record VetRecord) (string, string) {
+	date, err := time.Parse("2006-01-02", record.Date)
+	if err != nil {
+		return "", ""
+	}
+	var milk, meat string
+	if record.MilkWithdrawalDays > 0 {
+		milk = date.AddDate(0, 0, record.MilkWithdrawalDays).Format("2006-01-02")
+	}
+	if record.MeatWithdrawalDays > 0 {
+		meat = date.AddDate(0, 0, record.MeatWithdrawalDays).Format("2006-01-02")
+	}
+	return milk, meat
+}
+
+// GetActiveWithdrawals returns the active animals whose milk or meat is
+// withheld today
+func (s *HealthService) GetActiveWithdrawals() ([]AnimalWithdrawal, error) {
+	byAnimal, err := withdrawalsOn(time.Now().Format("2006-01-02"))
+	if err != nil {
+		return nil, err
+	}
+	withdrawals := make([]AnimalWithdrawal, 0, len(byAnimal))
+	for _, w := range byAnimal {
+		withdrawals = append(withdrawals, w)
+	}
+	sort.Slice(withdrawals, func(a, b int) bool {
+		return firstNonEmpty(withdrawals[a].MilkWithheldUntil, withdrawals[a].MeatWithheldUntil) <
+			firstNonEmpty(withdrawals[b].MilkWithheldUntil, withdrawals[b].MeatWithheldUntil)
+	})
+	return withdrawals, nil
+}
+
+// GetMilkWithdrawal returns the withdrawal withholding an animal's milk on a
+// date, or nil when its milk can be sold
+func (s *HealthService) GetMilkWithdrawal(animalID int64, date string) (*AnimalWithdrawal, error) {
+	withdrawals, err := withdrawalsOn(date)
+	if err != nil {
+		return nil, err
+	}
+	if w, ok := withdrawals[animalID]; ok && w.MilkWithheldUntil != "" {
+		return &w, nil
+	}
+	return nil, nil
+}
+
+// withdrawalsOn returns, by animal, the withdrawals covering a date. When
+// treatments overlap the latest withheld-until date of each product wins,
+// together with the treatment that set it.
+func withdrawalsOn(date string) (map[int64]AnimalWithdrawal, error) {
+	rows, err := db.Query(`
+		SELECT vr.id, vr.animal_id, a.name, a.tag_number, vr.date, vr.medicine, vr.milk_withdrawal_days, vr.meat_withdrawal_days
+		FROM vet_records vr
+		JOIN animals a ON vr.animal_id = a.id
+		WHERE a.status = 'active' AND vr.date <= ?
+		AND (vr.milk_withdrawal_days > 0 OR vr.meat_withdrawal_days > 0)
+		ORDER BY vr.date, vr.id
+	`, date)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	byAnimal := map[int64]AnimalWithdrawal{}
+	for rows.Next() {
+		var r VetRecord
+		var name, tag, medicine sql.NullString
+		if err := rows.Scan(&r.ID, &r.AnimalID, &name, &tag, &r.Date, &medicine, &r.MilkWithdrawalDays, &r.MeatWithdrawalDays); err != nil {
+			return nil, err
+		}
+		milk, meat := withheldUntil(r)
+		if milk < date {
+			milk = ""
+		}
+		if meat < date {
+			meat = ""
+		}
+		if milk == "" && meat == "" {
+			continue
+		}
+
+		w := byAnimal[r.AnimalID]
+		w.AnimalID = r.AnimalID
+		w.AnimalName = name.String
+		w.TagNumber = tag.String
+		if milk != "" && milk >= w.MilkWithheldUntil {
+			w.MilkWithheldUntil = milk
+			w.MilkMedicine = medicine.String
+			w.MilkVetRecordID = r.ID
+			w.MilkTreatmentDate = r.Date
+		}
+		if meat != "" && meat >= w.MeatWithheldUntil {
+			w.MeatWithheldUntil = meat
+			w.MeatMedicine = medicine.String
+			w.MeatVetRecordID = r.ID
+			w.MeatTreatmentDate = r.Date
+		}
+		byAnimal[r.AnimalID] = w
+	}
+	return byAnimal, rows.Err()
+}
+
+// withdrawalWarning describes milk recorded for sale from a cow under withdrawal
+func withdrawalWarning(w AnimalWithdrawal) string {
+	medicine := firstNonEmpty(w.MilkMedicine, "treatment")
+	return fmt.Sprintf("%s's milk is withheld until %s after %s; mark it discarded", w.AnimalName, w.MilkWithheldUntil, medicine)
+}
+
+// GetMilkSaleWarnings lists the cows under a milk withdrawal whose milk on
+// the date was recorded without being discarded, and so went into the milk
+// available for sale
+func (s *LivestockService) GetMilkSaleWarnings(date string) ([]string, error) {
+	withdrawals, err := withdrawalsOn(date)
+	if err != nil {
+		return nil, err
+	}
+	records, err := s.GetMilkRecords(0, date, date)
+	if err != nil {
+		return nil, err
+	}
+	warnings := []string{}
+	for _, r := range records {
+		w, ok := withdrawals[r.AnimalID]
+		if ok && w.MilkWithheldUntil != "" && !r.Discarded && r.TotalLiters > 0 {
+			warnings = append(warnings, withdrawalWarning(w))
+		}
+	}
+	return warnings, nil
+}
+
+// discardedMilkTotal returns the liters of milk discarded within a date range
+func discardedMilkTotal(startDate, endDate string) (float64, error) {
+	query := `SELECT COALESCE(SUM(total_liters), 0) FROM milk_records WHERE discarded = 1`
+	args := []interface{}{}
+	if startDate != "" {
+		query += " AND date >= ?"
+		args = append(args, startDate)
+	}
+	if endDate != "" {
+		query += " AND date <= ?"
+		args = append(args, endDate)
+	}
+	var total float64
+	err := db.QueryRow(query, args...).Scan(&total)
+	return total, err
+}
@@ -0,0 +1,44 @@
+package main
+
+import "testing"
+
+func TestWithdrawalsNameEachTreatment(t *testing.T) {
+	setupTestDatabase(t)
+	livestock := NewLivestockService()
+	health := NewHealthService()
+
+	cowID, err := livestock.AddAnimal(Animal{TagNumber: "W1", Name: "Wanjiru", Type: "cow", Gender: "female", Status: "active"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Both treatments take their withdrawal periods from the medicine table
+	if _, err := health.AddVetRecord(VetRecord{AnimalID: cowID, Date: "2024-05-01", RecordType: "treatment", Medicine: "Oxytetracycline"}); err != nil {
+		t.Fatal(err)
+	}
+	penicillinID, err := health.AddVetRecord(VetRecord{AnimalID: cowID, Date: "2024-05-06", RecordType: "treatment", Medicine: "Penicillin"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	check := func(step, wantMilk, wantMilkMedicine, wantMeat, wantMeatMedicine string) {
+		t.Helper()
+		withdrawals, err := withdrawalsOn("2024-05-09")
+		if err != nil {
+			t.Fatal(err)
+		}
+		w := withdrawals[cowID]
+		if w.MilkWithheldUntil != wantMilk || w.MilkMedicine != wantMilkMedicine {
+			t.Errorf("%s: milk withheld until %q for %q, want %q for %q", step, w.MilkWithheldUntil, w.MilkMedicine, wantMilk, wantMilkMedicine)
+		}
+		if w.MeatWithheldUntil != wantMeat || w.MeatMedicine != wantMeatMedicine {
+			t.Errorf("%s: meat withheld until %q for %q, want %q for %q", step, w.MeatWithheldUntil, w.MeatMedicine, wantMeat, wantMeatMedicine)
+		}
+	}
+	check("added", "2024-05-10", "Penicillin", "2024-05-29", "Oxytetracycline")
+
+	// Editing the later treatment to another medicine takes that medicine's periods
+	if err := health.UpdateVetRecord(VetRecord{ID: penicillinID, AnimalID: cowID, Date: "2024-05-06", RecordType: "treatment", Medicine: "Ivermectin"}); err != nil {
+		t.Fatal(err)
+	}
+	check("edited", "2024-06-03", "Ivermectin", "2024-06-10", "Ivermectin")
+}
@@ -0,0 +1,289 @@
+package main
+
+import (
+	"archive/zip"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+)
+
+// xlsxWorkbook is a minimal Office Open XML spreadsheet writer. It supports
+// typed string, number and date cells, a bold frozen header row per sheet and
+// nothing else, which is all the farm exports need.
+type xlsxWorkbook struct {
+	sheets []*xlsxSheet
+}
+
+// xlsxSheet is a single worksheet in an xlsxWorkbook
+type xlsxSheet struct {
+	name    string
+	headers []string
+	rows    [][]xlsxCell
+	widths  []int
+}
+
+// xlsxCell is a single typed cell value
+type xlsxCell struct {
+	kind  string // string, number, integer, date
+	text  string
+	value float64
+}
+
+// Cell style indexes into the cellXfs table written by writeStyles
+const (
+	xlsxStyleDefault = 0
+	xlsxStyleHeader  = 1
+	xlsxStyleDate    = 2
+	xlsxStyleNumber  = 3
+	xlsxStyleInteger = 4
+)
+
+// xlsxEpoch is the zero date used by Excel serial date numbers
+var xlsxEpoch = time.Date(1899, 12, 30, 0, 0, 0, 0, time.UTC)
+
+func newXLSXWorkbook() *xlsxWorkbook {
+	return &xlsxWorkbook{}
+}
+
+// AddSheet appends a worksheet with the given header row
+func (w *xlsxWorkbook) AddSheet(name string, headers ...string) *xlsxSheet {
+	sheet := &xlsxSheet{name: name, headers: headers, widths: make([]int, len(headers))}
+	for i, h := range headers {
+		sheet.widths[i] = len(h) + 2
+	}
+	w.sheets = append(w.sheets, sheet)
+	return sheet
+}
+
+// AddRow appends a row of cells to the sheet
+func (s *xlsxSheet) AddRow(cells ...xlsxCell) {
+	for i, c := range cells {
+		width := len(c.text) + 2
+		if c.kind == "date" {
+			width = 12
+		} else if c.kind != "string" {
+			width = len(fmt.Sprintf("%.2f", c.value)) + 3
+		}
+		if i >= len(s.widths) {
+			s.widths = append(s.widths, width)
+		} else if width > s.widths[i] {
+			s.widths[i] = width
+		}
+	}
+	s.rows = append(s.rows, cells)
+}
+
+// Len returns the number of data rows (excluding the header)
+func (s *xlsxSheet) Len() int {
+	return len(s.rows)
+}
+
+// xlsxString returns a text cell
+func xlsxString(v string) xlsxCell {
+	return xlsxCell{kind: "string", text: v}
+}
+
+// xlsxNumber returns a numeric cell formatted with two decimals
+func xlsxNumber(v float64) xlsxCell {
+	return xlsxCell{kind: "number", value: v}
+}
+
+// xlsxInt returns a numeric cell formatted as a whole number
+func xlsxInt(v int64) xlsxCell {
+	return xlsxCell{kind: "integer", value: float64(v)}
+}
+
+// xlsxDate returns a date cell from a YYYY-MM-DD string, falling back to a
+// text cell when the value is empty or not a valid date
+func xlsxDate(v string) xlsxCell {
+	if len(v) > 10 {
+		v = v[:10]
+	}
+	t, err := time.Parse("2006-01-02", v)
+	if err != nil {
+		return xlsxString(v)
+	}
+	return xlsxCell{kind: "date", text: v, value: t.Sub(xlsxEpoch).Hours() / 24}
+}
+
+// Save writes the workbook to the given path
+func (w *xlsxWorkbook) Save(path string) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	zw := zip.NewWriter(file)
+	if err := w.write(zw); err != nil {
+		return err
+	}
+	return zw.Close()
+}
+
+func (w *xlsxWorkbook) write(zw *zip.Writer) error {
+	var contentTypes, workbook, workbookRels strings.Builder
+
+	contentTypes.WriteString(xml.Header)
+	contentTypes.WriteString(`<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">`)
+	contentTypes.WriteString(`<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>`)
+	contentTypes.WriteString(`<Default Extension="xml" ContentType="application/xml"/>`)
+	contentTypes.WriteString(`<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>`)
+	contentTypes.WriteString(`<Override PartName="/xl/styles.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.styles+xml"/>`)
+
+	workbook.WriteString(xml.Header)
+	workbook.WriteString(`<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships"><sheets>`)
+
+	workbookRels.WriteString(xml.Header)
+	workbookRels.WriteString(`<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">`)
+
+	for i, sheet := range w.sheets {
+		n := i + 1
+		fmt.Fprintf(&contentTypes, `<Override PartName="/xl/worksheets/sheet%d.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>`, n)
+		fmt.Fprintf(&workbook, `<sheet name="%s" sheetId="%d" r:id="rId%d"/>`, xlsxEscape(xlsxSheetName(sheet.name)), n, n)
+		fmt.Fprintf(&workbookRels, `<Relationship Id="rId%d" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet%d.xml"/>`, n, n)
+
+		part, err := zw.Create(fmt.Sprintf("xl/worksheets/sheet%d.xml", n))
+		if err != nil {
+			return err
+		}
+		if err := sheet.write(part); err != nil {
+			return err
+		}
+	}
+
+	contentTypes.WriteString(`</Types>`)
+	workbook.WriteString(`</sheets></workbook>`)
+	fmt.Fprintf(&workbookRels, `<Relationship Id="rId%d" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/styles" Target="styles.xml"/>`, len(w.sheets)+1)
+	workbookRels.WriteString(`</Relationships>`)
+
+	parts := []struct {
+		name    string
+		content string
+	}{
+		{"[Content_Types].xml", contentTypes.String()},
+		{"_rels/.rels", xml.Header + `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships"><Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/></Relationships>`},
+		{"xl/workbook.xml", workbook.String()},
+		{"xl/_rels/workbook.xml.rels", workbookRels.String()},
+		{"xl/styles.xml", xlsxStyles},
+	}
+	for _, p := range parts {
+		part, err := zw.Create(p.name)
+		if err != nil {
+			return err
+		}
+		if _, err := io.WriteString(part, p.content); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *xlsxSheet) write(out io.Writer) error {
+	var b strings.Builder
+	b.WriteString(xml.Header)
+	b.WriteString(`<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">`)
+
+	// Freeze the header row so it stays visible while scrolling
+	b.WriteString(`<sheetViews><sheetView workbookViewId="0"><pane ySplit="1" topLeftCell="A2" activePane="bottomLeft" state="frozen"/></sheetView></sheetViews>`)
+
+	if len(s.widths) > 0 {
+		b.WriteString(`<cols>`)
+		for i, width := range s.widths {
+			if width > 60 {
+				width = 60
+			}
+			fmt.Fprintf(&b, `<col min="%d" max="%d" width="%d" customWidth="1"/>`, i+1, i+1, width)
+		}
+		b.WriteString(`</cols>`)
+	}
+
+	b.WriteString(`<sheetData>`)
+	b.WriteString(`<row r="1">`)
+	for i, h := range s.headers {
+		fmt.Fprintf(&b, `<c r="%s1" t="inlineStr" s="%d"><is><t>%s</t></is></c>`, xlsxColumn(i), xlsxStyleHeader, xlsxEscape(h))
+	}
+	b.WriteString(`</row>`)
+
+	for r, row := range s.rows {
+		rowNum := r + 2
+		fmt.Fprintf(&b, `<row r="%d">`, rowNum)
+		for i, c := range row {
+			ref := fmt.Sprintf("%s%d", xlsxColumn(i), rowNum)
+			switch c.kind {
+			case "number":
+				fmt.Fprintf(&b, `<c r="%s" s="%d"><v>%s</v></c>`, ref, xlsxStyleNumber, xlsxFloat(c.value))
+			case "integer":
+				fmt.Fprintf(&b, `<c r="%s" s="%d"><v>%s</v></c>`, ref, xlsxStyleInteger, xlsxFloat(c.value))
+			case "date":
+				fmt.Fprintf(&b, `<c r="%s" s="%d"><v>%s</v></c>`, ref, xlsxStyleDate, xlsxFloat(c.value))
+			default:
+				if c.text == "" {
+					continue
+				}
+				fmt.Fprintf(&b, `<c r="%s" t="inlineStr" s="%d"><is><t xml:space="preserve">%s</t></is></c>`, ref, xlsxStyleDefault, xlsxEscape(c.text))
+			}
+		}
+		b.WriteString(`</row>`)
+	}
+	b.WriteString(`</sheetData></worksheet>`)
+
+	_, err := io.WriteString(out, b.String())
+	return err
+}
+
+// xlsxStyles defines the fonts and number formats referenced by the xlsxStyle constants
+const xlsxStyles = xml.Header + `<styleSheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">` +
+	`<numFmts count="1"><numFmt numFmtId="164" formatCode="yyyy-mm-dd"/></numFmts>` +
+	`<fonts count="2"><font><sz val="11"/><name val="Calibri"/></font><font><b/><sz val="11"/><name val="Calibri"/></font></fonts>` +
+	`<fills count="2"><fill><patternFill patternType="none"/></fill><fill><patternFill patternType="gray125"/></fill></fills>` +
+	`<borders count="1"><border><left/><right/><top/><bottom/><diagonal/></border></borders>` +
+	`<cellStyleXfs count="1"><xf numFmtId="0" fontId="0" fillId="0" borderId="0"/></cellStyleXfs>` +
+	`<cellXfs count="5">` +
+	`<xf numFmtId="0" fontId="0" fillId="0" borderId="0" xfId="0"/>` +
+	`<xf numFmtId="0" fontId="1" fillId="0" borderId="0" xfId="0" applyFont="1"/>` +
+	`<xf numFmtId="164" fontId="0" fillId="0" borderId="0" xfId="0" applyNumberFormat="1"/>` +
+	`<xf numFmtId="4" fontId="0" fillId="0" borderId="0" xfId="0" applyNumberFormat="1"/>` +
+	`<xf numFmtId="1" fontId="0" fillId="0" borderId="0" xfId="0" applyNumberFormat="1"/>` +
+	`</cellXfs>` +
+	`<cellStyles count="1"><cellStyle name="Normal" xfId="0" builtinId="0"/></cellStyles>` +
+	`</styleSheet>`
+
+// xlsxColumn converts a zero-based column index to its letter reference (0 -> A, 26 -> AA)
+func xlsxColumn(i int) string {
+	name := ""
+	for i >= 0 {
+		name = string(rune('A'+i%26)) + name
+		i = i/26 - 1
+	}
+	return name
+}
+
+// xlsxSheetName trims characters Excel does not allow in sheet names
+func xlsxSheetName(name string) string {
+	name = strings.Map(func(r rune) rune {
+		if strings.ContainsRune(`[]:*?/\`, r) {
+			return -1
+		}
+		return r
+	}, name)
+	if len(name) > 31 {
+		name = name[:31]
+	}
+	return name
+}
+
+func xlsxFloat(v float64) string {
+	return fmt.Sprintf("%g", v)
+}
+
+func xlsxEscape(s string) string {
+	var b strings.Builder
+	if err := xml.EscapeText(&b, []byte(s)); err != nil {
+		return ""
+	}
+	return b.String()
+}
@@ -0,0 +1,150 @@
+package main
+
+import (
+	"archive/zip"
+	"encoding/xml"
+	"io"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestXLSXColumn(t *testing.T) {
+	tests := []struct {
+		index int
+		want  string
+	}{
+		{0, "A"},
+		{1, "B"},
+		{25, "Z"},
+		{26, "AA"},
+		{27, "AB"},
+		{51, "AZ"},
+		{52, "BA"},
+		{701, "ZZ"},
+		{702, "AAA"},
+	}
+	for _, tt := range tests {
+		if got := xlsxColumn(tt.index); got != tt.want {
+			t.Errorf("xlsxColumn(%d) = %q, want %q", tt.index, got, tt.want)
+		}
+	}
+}
+
+func TestXLSXSheetName(t *testing.T) {
+	tests := []struct {
+		name, want string
+	}{
+		{"Milk Records", "Milk Records"},
+		{"Feed/Inventory", "FeedInventory"},
+		{`[Vet]: *notes?\`, "Vet notes"},
+		{"A sheet name that is far too long for Excel", "A sheet name that is far too lo"},
+	}
+	for _, tt := range tests {
+		if got := xlsxSheetName(tt.name); got != tt.want {
+			t.Errorf("xlsxSheetName(%q) = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestXLSXDate(t *testing.T) {
+	tests := []struct {
+		value    string
+		kind     string
+		text     string
+		serial   float64
+		checkNum bool
+	}{
+		{"1900-01-01", "date", "1900-01-01", 2, true},
+		{"2024-01-15", "date", "2024-01-15", 45306, true},
+		{"2024-01-15 08:30:00", "date", "2024-01-15", 45306, true},
+		{"", "string", "", 0, false},
+		{"not a date", "string", "not a date", 0, false},
+	}
+	for _, tt := range tests {
+		c := xlsxDate(tt.value)
+		if c.kind != tt.kind || c.text != tt.text {
+			t.Errorf("xlsxDate(%q) = {%s %q}, want {%s %q}", tt.value, c.kind, c.text, tt.kind, tt.text)
+		}
+		if tt.checkNum && c.value != tt.serial {
+			t.Errorf("xlsxDate(%q) serial = %v, want %v", tt.value, c.value, tt.serial)
+		}
+	}
+}
+
+func TestXLSXWorkbookSave(t *testing.T) {
+	wb := newXLSXWorkbook()
+	animals := wb.AddSheet("Animals", "Tag", "Born", "Weight", "Calves")
+	animals.AddRow(xlsxString("K-001"), xlsxDate("2021-03-04"), xlsxNumber(412.5), xlsxInt(3))
+	animals.AddRow(xlsxString("Tom & <Jerry>"), xlsxDate(""), xlsxNumber(0), xlsxInt(0))
+	wb.AddSheet("Milk/Sales", "Date", "Liters")
+
+	path := filepath.Join(t.TempDir(), "farm.xlsx")
+	if err := wb.Save(path); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+
+	zr, err := zip.OpenReader(path)
+	if err != nil {
+		t.Fatalf("open workbook: %v", err)
+	}
+	defer zr.Close()
+
+	parts := map[string]string{}
+	for _, f := range zr.File {
+		rc, err := f.Open()
+		if err != nil {
+			t.Fatalf("open %s: %v", f.Name, err)
+		}
+		data, err := io.ReadAll(rc)
+		rc.Close()
+		if err != nil {
+			t.Fatalf("read %s: %v", f.Name, err)
+		}
+		parts[f.Name] = string(data)
+	}
+
+	for _, name := range []string{
+		"[Content_Types].xml", "_rels/.rels", "xl/workbook.xml", "xl/_rels/workbook.xml.rels",
+		"xl/styles.xml", "xl/worksheets/sheet1.xml", "xl/worksheets/sheet2.xml",
+	} {
+		content, ok := parts[name]
+		if !ok {
+			t.Errorf("missing part %s", name)
+			continue
+		}
+		// Every part must be well-formed XML
+		dec := xml.NewDecoder(strings.NewReader(content))
+		for {
+			if _, err := dec.Token(); err == io.EOF {
+				break
+			} else if err != nil {
+				t.Errorf("part %s is not well-formed: %v", name, err)
+				break
+			}
+		}
+	}
+
+	sheet := parts["xl/worksheets/sheet1.xml"]
+	for _, want := range []string{
+		`<c r="A1" t="inlineStr" s="1"><is><t>Tag</t></is></c>`,
+		`<c r="A2" t="inlineStr" s="0"><is><t xml:space="preserve">K-001</t></is></c>`,
+		`<c r="B2" s="2"><v>44259</v></c>`,
+		`<c r="C2" s="3"><v>412.5</v></c>`,
+		`<c r="D2" s="4"><v>3</v></c>`,
+		`Tom &amp; &lt;Jerry&gt;`,
+	} {
+		if !strings.Contains(sheet, want) {
+			t.Errorf("sheet1 missing %s", want)
+		}
+	}
+	if strings.Contains(sheet, `r="B3"`) {
+		t.Errorf("empty date should not write a cell")
+	}
+	if !strings.Contains(parts["xl/workbook.xml"], `<sheet name="MilkSales" sheetId="2" r:id="rId2"/>`) {
+		t.Errorf("workbook.xml missing cleaned second sheet name")
+	}
+	if !strings.Contains(parts["xl/_rels/workbook.xml.rels"], `Id="rId3"`) || !strings.Contains(parts["xl/_rels/workbook.xml.rels"], `styles.xml`) {
+		t.Errorf("styles relationship should follow the sheets")
+	}
+}